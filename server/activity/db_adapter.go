@@ -0,0 +1,70 @@
+package activity
+
+import (
+	"encoding/json"
+
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+func ActivityEventDBToEventModel(eventDB db.ActivityEvent) (Event, error) {
+	projectID, err := internal.EncodeUUID(eventDB.ProjectID.Bytes)
+	if err != nil {
+		return Event{}, err
+	}
+
+	event := Event{
+		ID:        eventDB.ID,
+		CreatedAt: eventDB.CreatedAt.Time,
+		ProjectID: projectID,
+		Action:    Action(eventDB.Action),
+		Actor:     eventDB.Actor,
+		RequestID: eventDB.RequestID,
+	}
+
+	if eventDB.TaskID.Valid {
+		taskID, err := internal.EncodeUUID(eventDB.TaskID.Bytes)
+		if err != nil {
+			return Event{}, err
+		}
+		event.TaskID = &taskID
+	}
+
+	if eventDB.UserID.Valid {
+		userID, err := internal.EncodeUUID(eventDB.UserID.Bytes)
+		if err != nil {
+			return Event{}, err
+		}
+		event.UserID = &userID
+	}
+
+	if eventDB.Before != nil {
+		err = json.Unmarshal(eventDB.Before, &event.Before)
+		if err != nil {
+			return Event{}, err
+		}
+	}
+
+	if eventDB.After != nil {
+		err = json.Unmarshal(eventDB.After, &event.After)
+		if err != nil {
+			return Event{}, err
+		}
+	}
+
+	return event, nil
+}
+
+func eventsDBToEventsModel(eventsDB []db.ActivityEvent) ([]Event, error) {
+	events := []Event{}
+	for _, eventDB := range eventsDB {
+		event, err := ActivityEventDBToEventModel(eventDB)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,94 @@
+package activity
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Name of the actor of changes made by anonymous clients.
+const AnonymousActor = "anonymous"
+
+// Action is the kind of change recorded by an Event.
+type Action string
+
+const (
+	ActionCreated       Action = "created"
+	ActionRenamed       Action = "renamed"
+	ActionReordered     Action = "reordered"
+	ActionMoved         Action = "moved"
+	ActionStatusChanged Action = "status_changed"
+	ActionDueAtChanged  Action = "due_at_changed"
+	ActionEstimated     Action = "estimated"
+	ActionPlanned       Action = "planned"
+	ActionDeleted       Action = "deleted"
+	ActionRestored      Action = "restored"
+	ActionArchived      Action = "archived"
+	ActionUnarchived    Action = "unarchived"
+	ActionUpdated       Action = "updated"
+)
+
+// An Event is an entry of the activity history. Events are append-only: once recorded, they are
+// never changed nor deleted, not even when the project or task they refer to is purged.
+type Event struct {
+	CreatedAt time.Time
+	// The values of the changed fields before the change, nil for created items
+	Before map[string]any
+	// The values of the changed fields after the change, nil for deleted items
+	After map[string]any
+	// The task that was changed, nil for changes of the project itself
+	TaskID *uuid.UUID
+	Action Action
+	// Who made the change
+	Actor string
+	// The authenticated user who made the change, nil for changes made before users existed or by
+	// the server itself
+	UserID *uuid.UUID
+	// The ID of the request that made the change. Changes cascaded from another change, such as
+	// completing the subtasks of a completed task, share its request ID.
+	RequestID string
+	// The project that was changed, or the project of the task that was changed
+	ProjectID uuid.UUID
+	// Events are numbered in the order they were recorded
+	ID int64
+}
+
+// Origin tells who made a change and through which request.
+type Origin struct {
+	// The authenticated user, if any
+	UserID    *uuid.UUID
+	Actor     string
+	RequestID string
+}
+
+// NewEvent returns an event for a change made by origin. The event is not recorded.
+func NewEvent(origin Origin, action Action, projectID uuid.UUID, taskID *uuid.UUID, before, after map[string]any) Event {
+	actor := origin.Actor
+	if actor == "" {
+		actor = AnonymousActor
+	}
+
+	return Event{
+		CreatedAt: time.Now().UTC(),
+		ProjectID: projectID,
+		TaskID:    taskID,
+		Action:    action,
+		Actor:     actor,
+		UserID:    origin.UserID,
+		RequestID: origin.RequestID,
+		Before:    before,
+		After:     after,
+	}
+}
+
+func (e Event) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("ID", e.ID),
+		slog.String("Action", string(e.Action)),
+		slog.String("ProjectID", e.ProjectID.String()),
+		slog.Any("TaskID", e.TaskID),
+		slog.String("Actor", e.Actor),
+		slog.String("RequestID", e.RequestID),
+	)
+}
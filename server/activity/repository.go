@@ -0,0 +1,16 @@
+package activity
+
+import "github.com/google/uuid"
+
+type ActivityRepository interface {
+	// Append an event to the history, returning it with its ID
+	Create(event Event) (Event, error)
+
+	// List at most limit events of a project and of its tasks, newest first, starting after the
+	// event with ID beforeID
+	ListByProject(projectID uuid.UUID, beforeID int64, limit int) ([]Event, error)
+
+	// List at most limit events of a task, newest first, starting after the event with ID
+	// beforeID
+	ListByTask(taskID uuid.UUID, beforeID int64, limit int) ([]Event, error)
+}
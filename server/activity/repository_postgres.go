@@ -0,0 +1,131 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+type ActivityRepositoryPostgres struct {
+	Queries *db.Queries
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewActivityRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *ActivityRepositoryPostgres {
+	return &ActivityRepositoryPostgres{
+		Queries: db.New(pool),
+		ctx:     ctx,
+		logger:  *internal.NewLogger("ActivityRepositoryPostgres"),
+	}
+}
+
+func (r *ActivityRepositoryPostgres) Create(event Event) (Event, error) {
+	pgProjectID, err := internal.ScanUUID(event.ProjectID)
+	if err != nil {
+		return Event{}, err
+	}
+
+	pgTaskID := pgtype.UUID{}
+	if event.TaskID != nil {
+		pgTaskID, err = internal.ScanUUID(*event.TaskID)
+		if err != nil {
+			return Event{}, err
+		}
+	}
+
+	pgUserID := pgtype.UUID{}
+	if event.UserID != nil {
+		pgUserID, err = internal.ScanUUID(*event.UserID)
+		if err != nil {
+			return Event{}, err
+		}
+	}
+
+	pgCreatedAt := pgtype.Timestamptz{}
+	err = pgCreatedAt.Scan(event.CreatedAt)
+	if err != nil {
+		return Event{}, err
+	}
+
+	before, err := marshalValues(event.Before)
+	if err != nil {
+		return Event{}, err
+	}
+
+	after, err := marshalValues(event.After)
+	if err != nil {
+		return Event{}, err
+	}
+
+	eventDB, err := r.Queries.CreateActivityEvent(r.ctx, db.CreateActivityEventParams{
+		CreatedAt: pgCreatedAt,
+		ProjectID: pgProjectID,
+		TaskID:    pgTaskID,
+		Action:    string(event.Action),
+		Actor:     event.Actor,
+		RequestID: event.RequestID,
+		Before:    before,
+		After:     after,
+		UserID:    pgUserID,
+	})
+	if err != nil {
+		r.logger.Error("failed to insert activity event in the database", slog.String("err", err.Error()))
+		return Event{}, err
+	}
+
+	return ActivityEventDBToEventModel(eventDB)
+}
+
+func (r *ActivityRepositoryPostgres) ListByProject(projectID uuid.UUID, beforeID int64, limit int) ([]Event, error) {
+	pgUUID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsDB, err := r.Queries.ListProjectActivity(r.ctx, db.ListProjectActivityParams{
+		ProjectID: pgUUID,
+		BeforeID:  beforeID,
+		MaxEvents: int32(limit),
+	})
+	if err != nil {
+		r.logger.Error("failed to list project activity", slog.String("projectID", projectID.String()), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return eventsDBToEventsModel(eventsDB)
+}
+
+func (r *ActivityRepositoryPostgres) ListByTask(taskID uuid.UUID, beforeID int64, limit int) ([]Event, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsDB, err := r.Queries.ListTaskActivity(r.ctx, db.ListTaskActivityParams{
+		TaskID:    pgUUID,
+		BeforeID:  beforeID,
+		MaxEvents: int32(limit),
+	})
+	if err != nil {
+		r.logger.Error("failed to list task activity", slog.String("taskID", taskID.String()), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return eventsDBToEventsModel(eventsDB)
+}
+
+// A nil map is stored as NULL rather than as the JSON null.
+func marshalValues(values map[string]any) ([]byte, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(values)
+}
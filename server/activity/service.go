@@ -0,0 +1,120 @@
+package activity
+
+import (
+	"encoding/base64"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+const (
+	// Number of events in a page when no limit is given
+	DefaultPageSize = 50
+	// Maximum number of events in a page
+	MaxPageSize = 100
+)
+
+var ErrInvalidCursor = internal.NewValidationError("invalid pagination cursor")
+
+// Recorder is implemented by anything that can append events to the activity history. Services
+// that record their changes depend on this interface rather than on ActivityService.
+type Recorder interface {
+	Record(event Event) (Event, error)
+}
+
+// A Page is a slice of the activity history, newest first.
+type Page struct {
+	Events []Event
+	// Cursor of the next (older) page, empty on the last page
+	NextCursor string
+}
+
+type ActivityService struct {
+	repository ActivityRepository
+	logger     slog.Logger
+}
+
+func NewActivityService(repository ActivityRepository) *ActivityService {
+	return &ActivityService{repository: repository, logger: *internal.NewLogger("ActivityService")}
+}
+
+// Record appends an event to the history.
+func (s *ActivityService) Record(event Event) (Event, error) {
+	recorded, err := s.repository.Create(event)
+	if err != nil {
+		s.logger.Error("failed to record activity event", slog.Any("event", event), slog.String("err", err.Error()))
+		return Event{}, err
+	}
+
+	return recorded, nil
+}
+
+// ListProjectActivity lists the events of a project and of its tasks, newest first. An empty
+// cursor starts from the most recent event. The events of unknown projects are an empty list, so
+// that the history of purged projects can still be read.
+func (s *ActivityService) ListProjectActivity(projectID uuid.UUID, cursor string, limit int) (Page, error) {
+	return s.listPage(cursor, limit, func(beforeID int64, limit int) ([]Event, error) {
+		return s.repository.ListByProject(projectID, beforeID, limit)
+	})
+}
+
+// ListTaskActivity lists the events of a task, newest first. An empty cursor starts from the most
+// recent event.
+func (s *ActivityService) ListTaskActivity(taskID uuid.UUID, cursor string, limit int) (Page, error) {
+	return s.listPage(cursor, limit, func(beforeID int64, limit int) ([]Event, error) {
+		return s.repository.ListByTask(taskID, beforeID, limit)
+	})
+}
+
+func (s *ActivityService) listPage(cursor string, limit int, list func(beforeID int64, limit int) ([]Event, error)) (Page, error) {
+	beforeID, err := decodeCursor(cursor)
+	if err != nil {
+		return Page{}, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultPageSize
+	} else if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	// One more event than requested is fetched to know whether there is a next page
+	events, err := list(beforeID, limit+1)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor = encodeCursor(page.Events[limit-1].ID)
+	}
+
+	return page, nil
+}
+
+// Cursors are opaque to clients, but simply hold the ID of the last event of the previous page.
+func encodeCursor(eventID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(eventID, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return math.MaxInt64, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	eventID, err := strconv.ParseInt(string(decoded), 10, 64)
+	if err != nil || eventID <= 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return eventID, nil
+}
@@ -0,0 +1,142 @@
+package activity
+
+import (
+	"context"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ActivityServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	service     *ActivityService
+}
+
+func (suite *ActivityServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.service = NewActivityService(NewActivityRepositoryPostgres(suite.ctx, pgPool))
+}
+
+func (suite *ActivityServiceTestSuite) SetupTest() {
+	testhelpers.CleanupActivityTable(suite.ctx, suite.T(), suite.pgContainer.ConnectionString)
+}
+
+func (suite *ActivityServiceTestSuite) TestRecord() {
+	t := suite.T()
+	projectID := uuid.New()
+	taskID := uuid.New()
+	origin := Origin{Actor: "alice", RequestID: "request-1"}
+
+	event, err := suite.service.Record(NewEvent(
+		origin,
+		ActionRenamed,
+		projectID,
+		&taskID,
+		map[string]any{"name": "Old name"},
+		map[string]any{"name": "New name"},
+	))
+	require.NoError(t, err)
+	assert.NotZero(t, event.ID)
+
+	page, err := suite.service.ListTaskActivity(taskID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	assert.Empty(t, page.NextCursor)
+
+	listedEvent := page.Events[0]
+	assert.Equal(t, event.ID, listedEvent.ID)
+	assert.Equal(t, ActionRenamed, listedEvent.Action)
+	assert.Equal(t, projectID, listedEvent.ProjectID)
+	assert.Equal(t, "alice", listedEvent.Actor)
+	assert.Equal(t, "request-1", listedEvent.RequestID)
+	assert.Equal(t, map[string]any{"name": "Old name"}, listedEvent.Before)
+	assert.Equal(t, map[string]any{"name": "New name"}, listedEvent.After)
+}
+
+func (suite *ActivityServiceTestSuite) TestRecord_AnonymousActor() {
+	t := suite.T()
+	projectID := uuid.New()
+
+	event, err := suite.service.Record(NewEvent(Origin{}, ActionCreated, projectID, nil, nil, map[string]any{"name": "Project"}))
+	require.NoError(t, err)
+	assert.Equal(t, AnonymousActor, event.Actor)
+	assert.Nil(t, event.TaskID)
+	assert.Nil(t, event.Before)
+}
+
+func (suite *ActivityServiceTestSuite) TestListProjectActivity_Pagination() {
+	t := suite.T()
+	projectID := uuid.New()
+	taskID := uuid.New()
+
+	recorded := []Event{}
+	for _, event := range []Event{
+		NewEvent(Origin{}, ActionCreated, projectID, nil, nil, map[string]any{"name": "Project"}),
+		NewEvent(Origin{}, ActionCreated, projectID, &taskID, nil, map[string]any{"name": "Task"}),
+		NewEvent(Origin{}, ActionDeleted, projectID, &taskID, map[string]any{"name": "Task"}, nil),
+		// Events of other projects are not listed
+		NewEvent(Origin{}, ActionCreated, uuid.New(), nil, nil, map[string]any{"name": "Other project"}),
+	} {
+		event, err := suite.service.Record(event)
+		require.NoError(t, err)
+		recorded = append(recorded, event)
+	}
+
+	firstPage, err := suite.service.ListProjectActivity(projectID, "", 2)
+	require.NoError(t, err)
+	require.Len(t, firstPage.Events, 2)
+	assert.Equal(t, recorded[2].ID, firstPage.Events[0].ID)
+	assert.Equal(t, recorded[1].ID, firstPage.Events[1].ID)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	secondPage, err := suite.service.ListProjectActivity(projectID, firstPage.NextCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, secondPage.Events, 1)
+	assert.Equal(t, recorded[0].ID, secondPage.Events[0].ID)
+	assert.Empty(t, secondPage.NextCursor)
+}
+
+func (suite *ActivityServiceTestSuite) TestListProjectActivity_InvalidCursor() {
+	_, err := suite.service.ListProjectActivity(uuid.New(), "not a cursor", 0)
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
+
+func TestActivityService(t *testing.T) {
+	suite.Run(t, new(ActivityServiceTestSuite))
+}
+
+func TestCursor(t *testing.T) {
+	eventID, err := decodeCursor(encodeCursor(42))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), eventID)
+
+	// An empty cursor starts from the most recent event
+	eventID, err = decodeCursor("")
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), eventID)
+
+	for _, cursor := range []string{"%%%", encodeCursor(0), "bm90IGFuIGlk"} {
+		_, err = decodeCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Issuer of the tokens, the iss claim
+	Issuer    = "todoctian"
+	algorithm = "ES256"
+	// Types of the tokens, in the typ header, so that one cannot be used as the other. Access
+	// tokens use the type of RFC 9068.
+	accessTokenType  = "at+jwt"
+	refreshTokenType = "rt+jwt"
+)
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// The claims of the tokens. Times are in seconds since the epoch.
+type claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	ID        string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// Name of the user, in access tokens only
+	Name string `json:"name,omitempty"`
+	// ID of the API token the user signed in with, in refresh tokens only. Revoking the API token
+	// ends the refresh chains it started.
+	APITokenID string `json:"api_token_id,omitempty"`
+}
+
+func (c claims) expiresAt() time.Time {
+	return time.Unix(c.ExpiresAt, 0).UTC()
+}
+
+// sign makes a compact JWS of the claims, signed with the signing key of the set.
+func (ks *KeySet) sign(tokenType string, c claims) (string, error) {
+	key := ks.SigningKey()
+	headerJSON, err := json.Marshal(header{Algorithm: algorithm, Type: tokenType, KeyID: key.ID})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key.private, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	// The signature is R and S, each as 32 big-endian bytes (RFC 7518, section 3.4)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+// verify checks that a token of the given type was signed by a key of the set, by this server,
+// and is not expired at now. Returns its claims.
+func (ks *KeySet) verify(token string, tokenType string, now time.Time) (claims, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return claims{}, ErrInvalidToken
+	}
+
+	var h header
+	if !decodeJSONSegment(segments[0], &h) || h.Algorithm != algorithm || h.Type != tokenType {
+		return claims{}, ErrInvalidToken
+	}
+	key, ok := ks.key(h.KeyID)
+	if !ok {
+		return claims{}, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil || len(signature) != 64 {
+		return claims{}, ErrInvalidToken
+	}
+	digest := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.private.PublicKey, digest[:], r, s) {
+		return claims{}, ErrInvalidToken
+	}
+
+	var c claims
+	if !decodeJSONSegment(segments[1], &c) || c.Issuer != Issuer || !now.Before(c.expiresAt()) {
+		return claims{}, ErrInvalidToken
+	}
+	if _, err := uuid.Parse(c.Subject); err != nil {
+		return claims{}, ErrInvalidToken
+	}
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return claims{}, ErrInvalidToken
+	}
+
+	return c, nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJSONSegment(segment string, v any) bool {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(b, v) == nil
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeySet(t *testing.T, ids ...string) *KeySet {
+	keys := []SigningKey{}
+	for _, id := range ids {
+		key, err := GenerateSigningKey(id)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	ks, err := NewKeySet(keys...)
+	require.NoError(t, err)
+	return ks
+}
+
+func newTestClaims(now time.Time, ttl time.Duration) claims {
+	return claims{
+		Issuer:    Issuer,
+		Subject:   uuid.NewString(),
+		ID:        uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	ks := newTestKeySet(t, "2026-10")
+	now := time.Now().UTC()
+	c := newTestClaims(now, time.Minute)
+
+	token, err := ks.sign(accessTokenType, c)
+	require.NoError(t, err)
+
+	verified, err := ks.verify(token, accessTokenType, now)
+	require.NoError(t, err)
+	assert.Equal(t, c, verified)
+
+	// An access token is not a refresh token
+	_, err = ks.verify(token, refreshTokenType, now)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.ErrorIs(t, err, internal.ErrUnauthenticated)
+
+	_, err = ks.verify(token, accessTokenType, now.Add(time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidToken, "the token is expired")
+
+	// Changing the claims invalidates the signature
+	segments := strings.Split(token, ".")
+	forged := c
+	forged.Subject = uuid.NewString()
+	forgedToken, err := ks.sign(accessTokenType, forged)
+	require.NoError(t, err)
+	tampered := segments[0] + "." + strings.Split(forgedToken, ".")[1] + "." + segments[2]
+	_, err = ks.verify(tampered, accessTokenType, now)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Tokens signed by other keys are rejected, even with the same kid
+	other := newTestKeySet(t, "2026-10")
+	_, err = other.verify(token, accessTokenType, now)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	for _, malformed := range []string{"", "a.b", "a.b.c", token + ".d"} {
+		_, err = ks.verify(malformed, accessTokenType, now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	now := time.Now().UTC()
+	oldKeys := newTestKeySet(t, "old")
+	token, err := oldKeys.sign(accessTokenType, newTestClaims(now, time.Minute))
+	require.NoError(t, err)
+
+	// The new key signs, the old one still verifies the tokens it signed
+	newKey, err := GenerateSigningKey("new")
+	require.NoError(t, err)
+	rotated, err := NewKeySet(newKey, oldKeys.SigningKey())
+	require.NoError(t, err)
+	assert.Equal(t, "new", rotated.SigningKey().ID)
+
+	_, err = rotated.verify(token, accessTokenType, now)
+	assert.NoError(t, err)
+
+	newToken, err := rotated.sign(accessTokenType, newTestClaims(now, time.Minute))
+	require.NoError(t, err)
+	_, err = oldKeys.verify(newToken, accessTokenType, now)
+	assert.ErrorIs(t, err, ErrInvalidToken, "the old set does not know the new key")
+
+	_, err = NewKeySet(newKey, newKey)
+	assert.Error(t, err, "key IDs are unique")
+	_, err = NewKeySet()
+	assert.Error(t, err)
+}
+
+// Other services only have the JWKS to verify tokens with.
+func TestJWKS(t *testing.T) {
+	ks := newTestKeySet(t, "new", "old")
+	token, err := ks.sign(accessTokenType, newTestClaims(time.Now(), time.Minute))
+	require.NoError(t, err)
+
+	jwks := ks.JWKS()
+	require.Len(t, jwks, 2)
+	assert.Equal(t, "new", jwks[0].KeyID)
+	assert.Equal(t, "old", jwks[1].KeyID)
+
+	jwk := jwks[0]
+	assert.Equal(t, "EC", jwk.KeyType)
+	assert.Equal(t, "P-256", jwk.Curve)
+	assert.Equal(t, "ES256", jwk.Algorithm)
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	require.NoError(t, err)
+	public := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+
+	segments := strings.Split(token, ".")
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+	assert.True(t, ecdsa.Verify(public, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])))
+}
+
+func TestParseSigningKey(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	require.NoError(t, err)
+
+	sec1, err := x509.MarshalECPrivateKey(private)
+	require.NoError(t, err)
+	key, err := ParseSigningKey("sec1", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}))
+	require.NoError(t, err)
+	assert.Equal(t, "sec1", key.ID)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	_, err = ParseSigningKey("pkcs8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	require.NoError(t, err)
+
+	_, err = ParseSigningKey("", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}))
+	assert.Error(t, err, "keys must have an ID")
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), cryptorand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(p384)
+	require.NoError(t, err)
+	_, err = ParseSigningKey("p384", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	assert.Error(t, err, "ES256 needs P-256 keys")
+
+	_, err = ParseSigningKey("garbage", []byte("not a key"))
+	assert.Error(t, err)
+}
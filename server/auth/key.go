@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// A SigningKey signs tokens with ES256, i.e. ECDSA with the P-256 curve and SHA-256. Its ID is the
+// kid of the tokens it signs, which tells verifiers which key to check them with.
+type SigningKey struct {
+	ID      string
+	private *ecdsa.PrivateKey
+}
+
+// ParseSigningKey parses a P-256 private key, PEM-encoded in the SEC 1 ("EC PRIVATE KEY") or the
+// PKCS #8 ("PRIVATE KEY") format, e.g. as generated by `openssl ecparam -name prime256v1 -genkey`.
+func ParseSigningKey(id string, pemBytes []byte) (SigningKey, error) {
+	if id == "" {
+		return SigningKey{}, errors.New("signing keys must have an ID")
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("signing key %q is not PEM-encoded", id)
+	}
+
+	var private *ecdsa.PrivateKey
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, fmt.Errorf("invalid signing key %q: %w", id, err)
+		}
+		private = key
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, fmt.Errorf("invalid signing key %q: %w", id, err)
+		}
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return SigningKey{}, fmt.Errorf("signing key %q is not an ECDSA key", id)
+		}
+		private = ecKey
+	default:
+		return SigningKey{}, fmt.Errorf("signing key %q is a %s, not a private key", id, block.Type)
+	}
+
+	if private.Curve != elliptic.P256() {
+		return SigningKey{}, fmt.Errorf("signing key %q is not on the P-256 curve", id)
+	}
+
+	return SigningKey{ID: id, private: private}, nil
+}
+
+// GenerateSigningKey generates a new P-256 key.
+func GenerateSigningKey(id string) (SigningKey, error) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{ID: id, private: private}, nil
+}
+
+// A JWK is the public part of a signing key, as a JSON Web Key (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+func (k SigningKey) JWK() JWK {
+	public, err := k.private.PublicKey.ECDH()
+	if err != nil {
+		// The curve was checked when the key was parsed
+		panic(err)
+	}
+	// The uncompressed point: 0x04, then the 32 bytes of each coordinate
+	point := public.Bytes()
+
+	return JWK{
+		KeyType:   "EC",
+		Curve:     "P-256",
+		X:         base64.RawURLEncoding.EncodeToString(point[1:33]),
+		Y:         base64.RawURLEncoding.EncodeToString(point[33:]),
+		KeyID:     k.ID,
+		Algorithm: algorithm,
+		Use:       "sig",
+	}
+}
+
+// A KeySet holds the keys tokens are signed and verified with. The first key signs the new
+// tokens; the others only verify the tokens they signed before they were rotated out. Once those
+// tokens expired, the keys can be removed.
+type KeySet struct {
+	keys []SigningKey
+	byID map[string]SigningKey
+}
+
+func NewKeySet(keys ...SigningKey) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("a key set needs at least one key")
+	}
+
+	byID := map[string]SigningKey{}
+	for _, key := range keys {
+		if _, ok := byID[key.ID]; ok {
+			return nil, fmt.Errorf("two signing keys have the ID %q", key.ID)
+		}
+		byID[key.ID] = key
+	}
+
+	return &KeySet{keys: keys, byID: byID}, nil
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (ks *KeySet) SigningKey() SigningKey {
+	return ks.keys[0]
+}
+
+func (ks *KeySet) key(id string) (SigningKey, bool) {
+	key, ok := ks.byID[id]
+	return key, ok
+}
+
+// JWKS lists the public keys of the set, as a JSON Web Key Set, with which other services verify
+// tokens without calling the server.
+func (ks *KeySet) JWKS() []JWK {
+	jwks := []JWK{}
+	for _, key := range ks.keys {
+		jwks = append(jwks, key.JWK())
+	}
+
+	return jwks
+}
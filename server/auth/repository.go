@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DenylistRepository keeps the IDs of the refresh tokens that were revoked or already used, until
+// they expire.
+type DenylistRepository interface {
+	// Deny a refresh token until it expires. Returns false if it was already denied.
+	Deny(tokenID uuid.UUID, userID uuid.UUID, deniedAt time.Time, expiresAt time.Time) (bool, error)
+	// Delete the tokens that expired before expiredBefore, returns how many were deleted
+	PurgeExpired(expiredBefore time.Time) (int64, error)
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+type DenylistRepositoryPostgres struct {
+	Queries *db.Queries
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewDenylistRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *DenylistRepositoryPostgres {
+	return &DenylistRepositoryPostgres{
+		Queries: db.New(pool),
+		ctx:     ctx,
+		logger:  *internal.NewLogger("DenylistRepositoryPostgres"),
+	}
+}
+
+func (r *DenylistRepositoryPostgres) Deny(tokenID uuid.UUID, userID uuid.UUID, deniedAt time.Time, expiresAt time.Time) (bool, error) {
+	pgTokenID, err := internal.ScanUUID(tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	pgUserID, err := internal.ScanUUID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	denied, err := r.Queries.DenyRefreshToken(r.ctx, db.DenyRefreshTokenParams{
+		ID:        pgTokenID,
+		UserID:    pgUserID,
+		DeniedAt:  pgtype.Timestamptz{Time: deniedAt, Valid: true},
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to deny refresh token", slog.String("tokenID", tokenID.String()), slog.String("err", err.Error()))
+		return false, err
+	}
+
+	return denied == 1, nil
+}
+
+func (r *DenylistRepositoryPostgres) PurgeExpired(expiredBefore time.Time) (int64, error) {
+	pgExpiredBefore := pgtype.Timestamptz{}
+	err := pgExpiredBefore.Scan(expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Queries.PurgeExpiredDeniedRefreshTokens(r.ctx, pgExpiredBefore)
+}
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/user"
+)
+
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var ErrInvalidToken = internal.NewError(internal.ErrUnauthenticated, "invalid, expired or revoked token")
+
+// A TokenPair is what a client gets when it signs in or refreshes its tokens.
+type TokenPair struct {
+	// Short-lived JWT sent in the Authorization header of requests
+	AccessToken string
+	// JWT exchanged for a new pair when the access token expires. It can be used once.
+	RefreshToken string
+	// How long the access token is valid for
+	ExpiresIn time.Duration
+}
+
+// TokenService issues JWT access and refresh tokens, signed with the keys of a KeySet, and
+// verifies them. Access tokens are not stored: they are valid until they expire, which is why they
+// are short-lived. Refresh tokens are revoked with a denylist, in which they also go once they are
+// used, so that each of them is exchanged only once.
+type TokenService struct {
+	keys           *KeySet
+	repository     DenylistRepository
+	userRepository user.UserRepository
+	logger         slog.Logger
+	accessTTL      time.Duration
+	refreshTTL     time.Duration
+}
+
+type TokenServiceOption func(*TokenService)
+
+// WithTokenTTLs sets how long access and refresh tokens are valid for, instead of
+// DefaultAccessTokenTTL and DefaultRefreshTokenTTL.
+func WithTokenTTLs(access time.Duration, refresh time.Duration) TokenServiceOption {
+	return func(s *TokenService) {
+		s.accessTTL = access
+		s.refreshTTL = refresh
+	}
+}
+
+func NewTokenService(keys *KeySet, repository DenylistRepository, userRepository user.UserRepository, opts ...TokenServiceOption) *TokenService {
+	s := &TokenService{
+		keys:           keys,
+		repository:     repository,
+		userRepository: userRepository,
+		logger:         *internal.NewLogger("TokenService"),
+		accessTTL:      DefaultAccessTokenTTL,
+		refreshTTL:     DefaultRefreshTokenTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// IssueTokens issues a new pair of tokens for a user, who must have been authenticated with the
+// given API token. The tokens can be refreshed as long as the API token is not revoked.
+func (s *TokenService) IssueTokens(u user.User, apiTokenID uuid.UUID) (TokenPair, error) {
+	now := time.Now().UTC()
+	accessToken, err := s.keys.sign(accessTokenType, claims{
+		Issuer:    Issuer,
+		Subject:   u.ID.String(),
+		ID:        uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.accessTTL).Unix(),
+		Name:      u.Name,
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshToken, err := s.keys.sign(refreshTokenType, claims{
+		Issuer:     Issuer,
+		Subject:    u.ID.String(),
+		ID:         uuid.NewString(),
+		IssuedAt:   now.Unix(),
+		ExpiresAt:  now.Add(s.refreshTTL).Unix(),
+		APITokenID: apiTokenID.String(),
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresIn: s.accessTTL}, nil
+}
+
+// Refresh exchanges a refresh token for a new pair of tokens. The refresh token is denied, so
+// that it cannot be exchanged again: a client that tries to is rejected with ErrInvalidToken, as
+// is a client whose API token was revoked since it signed in.
+func (s *TokenService) Refresh(refreshToken string) (TokenPair, error) {
+	c, err := s.keys.verify(refreshToken, refreshTokenType, time.Now().UTC())
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	denied, err := s.deny(c)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if !denied {
+		s.logger.Warn("denied refresh token used", slog.String("tokenID", c.ID), slog.String("userID", c.Subject))
+		return TokenPair{}, ErrInvalidToken
+	}
+
+	u, err := s.user(c)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	apiTokenID, err := s.apiToken(c)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return s.IssueTokens(u, apiTokenID)
+}
+
+// Revoke denies a refresh token, e.g. when a client signs out. Revoking a denied token does
+// nothing. The access tokens issued along with it stay valid until they expire.
+func (s *TokenService) Revoke(refreshToken string) error {
+	c, err := s.keys.verify(refreshToken, refreshTokenType, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.deny(c)
+	return err
+}
+
+// Authenticate finds the user of an access token. Fails with ErrInvalidToken if the token is
+// invalid or expired, or if its user no longer exists.
+func (s *TokenService) Authenticate(accessToken string) (user.User, error) {
+	c, err := s.keys.verify(accessToken, accessTokenType, time.Now().UTC())
+	if err != nil {
+		return user.User{}, err
+	}
+
+	return s.user(c)
+}
+
+// JWKS lists the public keys the tokens are verified with, see KeySet.JWKS.
+func (s *TokenService) JWKS() []JWK {
+	return s.keys.JWKS()
+}
+
+// PurgeExpiredTokens deletes the expired refresh tokens from the denylist, which are rejected
+// anyway. Returns the number of purged tokens.
+func (s *TokenService) PurgeExpiredTokens() (int64, error) {
+	return s.repository.PurgeExpired(time.Now().UTC())
+}
+
+func (s *TokenService) deny(c claims) (bool, error) {
+	// The claims were verified, the IDs are valid
+	tokenID := uuid.MustParse(c.ID)
+	userID := uuid.MustParse(c.Subject)
+
+	return s.repository.Deny(tokenID, userID, time.Now().UTC(), c.expiresAt())
+}
+
+func (s *TokenService) user(c claims) (user.User, error) {
+	u, err := s.userRepository.Get(uuid.MustParse(c.Subject))
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			return user.User{}, ErrInvalidToken
+		}
+
+		return user.User{}, err
+	}
+
+	return u, nil
+}
+
+// Checks that the API token the refresh chain of the claims started from is not revoked, and
+// returns its ID.
+func (s *TokenService) apiToken(c claims) (uuid.UUID, error) {
+	// Refresh tokens issued before they were tied to an API token cannot be checked
+	id, err := uuid.Parse(c.APITokenID)
+	if err != nil {
+		return uuid.UUID{}, ErrInvalidToken
+	}
+
+	token, err := s.userRepository.GetToken(id)
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			return uuid.UUID{}, ErrInvalidToken
+		}
+
+		return uuid.UUID{}, err
+	}
+	if token.IsRevoked() || token.UserID.String() != c.Subject {
+		s.logger.Warn("refresh token of a revoked API token used", slog.String("tokenID", c.ID), slog.String("apiTokenID", c.APITokenID))
+		return uuid.UUID{}, ErrInvalidToken
+	}
+
+	return token.ID, nil
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/murasakiwano/todoctian/server/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenServiceTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	pgContainer  *testhelpers.PostgresContainer
+	tokenService *TokenService
+	userService  *user.UserService
+}
+
+func (suite *TokenServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	key, err := GenerateSigningKey("test")
+	if err != nil {
+		log.Fatal(err)
+	}
+	keys, err := NewKeySet(key)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	userRepository := user.NewUserRepositoryPostgres(suite.ctx, pgPool)
+	suite.userService = user.NewUserService(userRepository)
+	suite.tokenService = NewTokenService(keys, NewDenylistRepositoryPostgres(suite.ctx, pgPool), userRepository)
+}
+
+// Setup database before each test
+func (suite *TokenServiceTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupUsersTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupDeniedRefreshTokensTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+}
+
+func (suite *TokenServiceTestSuite) TestRefresh() {
+	t := suite.T()
+
+	alice, err := suite.userService.CreateUser("alice")
+	require.NoError(t, err)
+
+	apiToken, _, err := suite.userService.CreateToken(alice.ID, "cli")
+	require.NoError(t, err)
+	pair, err := suite.tokenService.IssueTokens(alice, apiToken.ID)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAccessTokenTTL, pair.ExpiresIn)
+
+	authenticated, err := suite.tokenService.Authenticate(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, authenticated.ID)
+
+	// Refresh tokens do not authenticate requests
+	_, err = suite.tokenService.Authenticate(pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	refreshed, err := suite.tokenService.Refresh(pair.RefreshToken)
+	require.NoError(t, err)
+	authenticated, err = suite.tokenService.Authenticate(refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, authenticated.ID)
+
+	// Each refresh token is exchanged once
+	_, err = suite.tokenService.Refresh(pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = suite.tokenService.Refresh(pair.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func (suite *TokenServiceTestSuite) TestRefresh_APITokenRevoked() {
+	t := suite.T()
+
+	alice, err := suite.userService.CreateUser("alice")
+	require.NoError(t, err)
+	apiToken, _, err := suite.userService.CreateToken(alice.ID, "cli")
+	require.NoError(t, err)
+	pair, err := suite.tokenService.IssueTokens(alice, apiToken.ID)
+	require.NoError(t, err)
+	pair, err = suite.tokenService.Refresh(pair.RefreshToken)
+	require.NoError(t, err)
+
+	// Revoking the API token ends the refresh chain, however far it went
+	_, err = suite.userService.RevokeToken(alice.ID, apiToken.ID)
+	require.NoError(t, err)
+	_, err = suite.tokenService.Refresh(pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func (suite *TokenServiceTestSuite) TestRevoke() {
+	t := suite.T()
+
+	alice, err := suite.userService.CreateUser("alice")
+	require.NoError(t, err)
+	apiToken, _, err := suite.userService.CreateToken(alice.ID, "cli")
+	require.NoError(t, err)
+	pair, err := suite.tokenService.IssueTokens(alice, apiToken.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, suite.tokenService.Revoke(pair.RefreshToken))
+	// Revoking twice does nothing
+	require.NoError(t, suite.tokenService.Revoke(pair.RefreshToken))
+
+	_, err = suite.tokenService.Refresh(pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// The access token stays valid until it expires
+	_, err = suite.tokenService.Authenticate(pair.AccessToken)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, suite.tokenService.Revoke("not a token"), ErrInvalidToken)
+}
+
+func (suite *TokenServiceTestSuite) TestPurgeExpiredTokens() {
+	t := suite.T()
+
+	alice, err := suite.userService.CreateUser("alice")
+	require.NoError(t, err)
+
+	expiring := NewTokenService(suite.tokenService.keys, suite.tokenService.repository, suite.tokenService.userRepository,
+		WithTokenTTLs(time.Minute, 3*time.Second))
+	apiToken, _, err := suite.userService.CreateToken(alice.ID, "cli")
+	require.NoError(t, err)
+	pair, err := expiring.IssueTokens(alice, apiToken.ID)
+	require.NoError(t, err)
+	require.NoError(t, expiring.Revoke(pair.RefreshToken))
+
+	purged, err := suite.tokenService.PurgeExpiredTokens()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	time.Sleep(4 * time.Second)
+	purged, err = suite.tokenService.PurgeExpiredTokens()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
+
+func TestTokenService(t *testing.T) {
+	suite.Run(t, new(TokenServiceTestSuite))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/murasakiwano/todoctian/server/auth"
+)
+
+// loadSigningKeys loads the JWT signing keys of JWT_SIGNING_KEYS, a comma-separated list of
+// kid=path pairs, e.g. "2026-10=/keys/2026-10.pem,2026-04=/keys/2026-04.pem". The first key signs
+// the new tokens; the others only verify the tokens they signed, until they are removed.
+func loadSigningKeys(spec string) (*auth.KeySet, error) {
+	keys := []auth.SigningKey{}
+	for _, entry := range strings.Split(spec, ",") {
+		id, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("JWT_SIGNING_KEYS must be a list of kid=path pairs, got %q", entry)
+		}
+
+		pemBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read signing key %q: %w", id, err)
+		}
+
+		key, err := auth.ParseSigningKey(id, pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return auth.NewKeySet(keys...)
+}
@@ -2,17 +2,105 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	todoctian "github.com/murasakiwano/todoctian/server"
+	"github.com/murasakiwano/todoctian/server/auth"
+	"github.com/murasakiwano/todoctian/server/internal"
 )
 
 func main() {
 	pgConnString := os.Getenv("PG_DB_URL")
+
+	if len(os.Args) > 1 && os.Args[1] == "users" {
+		err := usersCommand(pgConnString, os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	opts := []todoctian.Option{}
+	if days := os.Getenv("TRASH_RETENTION_DAYS"); days != "" {
+		retentionDays, err := strconv.Atoi(days)
+		if err != nil {
+			log.Fatalf("TRASH_RETENTION_DAYS must be a number of days: %s", err)
+		}
+		opts = append(opts, todoctian.WithTrashRetention(retentionDays))
+	}
+	if reuse := os.Getenv("REUSE_ARCHIVED_PROJECT_NAMES"); reuse != "" {
+		reuseArchivedNames, err := strconv.ParseBool(reuse)
+		if err != nil {
+			log.Fatalf("REUSE_ARCHIVED_PROJECT_NAMES must be a boolean: %s", err)
+		}
+		opts = append(opts, todoctian.WithArchivedProjectNameReuse(reuseArchivedNames))
+	}
+
+	if hours := os.Getenv("IDEMPOTENCY_KEY_TTL_HOURS"); hours != "" {
+		ttlHours, err := strconv.Atoi(hours)
+		if err != nil {
+			log.Fatalf("IDEMPOTENCY_KEY_TTL_HOURS must be a number of hours: %s", err)
+		}
+		opts = append(opts, todoctian.WithIdempotencyKeyTTL(time.Duration(ttlHours)*time.Hour))
+	}
+
+	limits := internal.DefaultLimits
+	for env, limit := range map[string]*int{
+		"MAX_NAME_LENGTH":        &limits.MaxNameLength,
+		"MAX_DESCRIPTION_LENGTH": &limits.MaxDescriptionLength,
+		"MAX_TASK_DEPTH":         &limits.MaxTaskDepth,
+		"MAX_SUBTASKS":           &limits.MaxSubtasks,
+	} {
+		if value := os.Getenv(env); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				log.Fatalf("%s must be a positive number", env)
+			}
+			*limit = n
+		}
+	}
+	opts = append(opts, todoctian.WithLimits(limits))
+
+	if validate := os.Getenv("VALIDATE_RESPONSES"); validate != "" {
+		validateResponses, err := strconv.ParseBool(validate)
+		if err != nil {
+			log.Fatalf("VALIDATE_RESPONSES must be a boolean: %s", err)
+		}
+		opts = append(opts, todoctian.WithResponseValidation(validateResponses))
+	}
+
+	if spec := os.Getenv("JWT_SIGNING_KEYS"); spec != "" {
+		keys, err := loadSigningKeys(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, todoctian.WithSigningKeys(keys))
+	}
+
+	accessTTL, refreshTTL := auth.DefaultAccessTokenTTL, auth.DefaultRefreshTokenTTL
+	if minutes := os.Getenv("JWT_ACCESS_TOKEN_TTL_MINUTES"); minutes != "" {
+		n, err := strconv.Atoi(minutes)
+		if err != nil || n < 1 {
+			log.Fatal("JWT_ACCESS_TOKEN_TTL_MINUTES must be a positive number of minutes")
+		}
+		accessTTL = time.Duration(n) * time.Minute
+	}
+	if hours := os.Getenv("JWT_REFRESH_TOKEN_TTL_HOURS"); hours != "" {
+		n, err := strconv.Atoi(hours)
+		if err != nil || n < 1 {
+			log.Fatal("JWT_REFRESH_TOKEN_TTL_HOURS must be a positive number of hours")
+		}
+		refreshTTL = time.Duration(n) * time.Hour
+	}
+	opts = append(opts, todoctian.WithTokenTTLs(accessTTL, refreshTTL))
+
 	r := chi.NewRouter()
-	r.Mount("/", todoctian.Handler(pgConnString))
+	r.Mount("/", todoctian.Handler(pgConnString, opts...))
 
 	fmt.Println("Server now listening at port 5656")
 	http.ListenAndServe(":5656", r)
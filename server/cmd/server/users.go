@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/user"
+)
+
+const usersUsage = "usage: server users create [-token NAME] USERNAME"
+
+// usersCommand runs the admin commands that manage users, out of band of the API, which cannot be
+// used without a user. `server users create` bootstraps the first account: it creates a user and
+// an API token, whose secret is printed.
+func usersCommand(pgConnString string, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errors.New(usersUsage)
+	}
+
+	flags := flag.NewFlagSet("users create", flag.ContinueOnError)
+	tokenName := flags.String("token", "admin", "name of the API token of the user")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(usersUsage)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, pgConnString)
+	if err != nil {
+		return fmt.Errorf("could not connect to PostgreSQL: %w", err)
+	}
+	defer pool.Close()
+
+	userService := user.NewUserService(user.NewUserRepositoryPostgres(ctx, pool))
+	u, err := userService.CreateUser(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	_, secret, err := userService.CreateToken(u.ID, *tokenName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created user %s (%s)\n", u.Name, u.ID)
+	fmt.Printf("API token %q, shown only once: %s\n", *tokenName, secret)
+	return nil
+}
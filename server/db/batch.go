@@ -17,10 +17,163 @@ var (
 	ErrBatchAlreadyClosed = errors.New("batch already closed")
 )
 
-const batchUpdateTaskOrders = `-- name: BatchUpdateTaskOrders :batchexec
+const batchMoveTaskOrdersAside = `-- name: BatchMoveTaskOrdersAside :batchexec
 UPDATE tasks
+SET "order" = (SELECT max(t."order") + 1 FROM tasks t WHERE t.project_id = tasks.project_id)
+WHERE tasks.id = $1 AND "order" <> $2
+`
+
+type BatchMoveTaskOrdersAsideBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type BatchMoveTaskOrdersAsideParams struct {
+	ID    pgtype.UUID
+	Order int32
+}
+
+func (q *Queries) BatchMoveTaskOrdersAside(ctx context.Context, arg []BatchMoveTaskOrdersAsideParams) *BatchMoveTaskOrdersAsideBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.ID,
+			a.Order,
+		}
+		batch.Queue(batchMoveTaskOrdersAside, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &BatchMoveTaskOrdersAsideBatchResults{br, len(arg), false}
+}
+
+func (b *BatchMoveTaskOrdersAsideBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *BatchMoveTaskOrdersAsideBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const batchUpdateProjectOrders = `-- name: BatchUpdateProjectOrders :batchexec
+UPDATE projects
+SET "order" = $2, version = version + 1
+WHERE projects.id = $1 AND "order" <> $2
+`
+
+type BatchUpdateProjectOrdersBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type BatchUpdateProjectOrdersParams struct {
+	ID    pgtype.UUID
+	Order int32
+}
+
+func (q *Queries) BatchUpdateProjectOrders(ctx context.Context, arg []BatchUpdateProjectOrdersParams) *BatchUpdateProjectOrdersBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.ID,
+			a.Order,
+		}
+		batch.Queue(batchUpdateProjectOrders, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &BatchUpdateProjectOrdersBatchResults{br, len(arg), false}
+}
+
+func (b *BatchUpdateProjectOrdersBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *BatchUpdateProjectOrdersBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const batchUpdateSectionOrders = `-- name: BatchUpdateSectionOrders :batchexec
+UPDATE sections
 SET "order" = $2
-WHERE tasks.id = $1
+WHERE sections.id = $1 AND "order" <> $2
+`
+
+type BatchUpdateSectionOrdersBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type BatchUpdateSectionOrdersParams struct {
+	ID    pgtype.UUID
+	Order int32
+}
+
+func (q *Queries) BatchUpdateSectionOrders(ctx context.Context, arg []BatchUpdateSectionOrdersParams) *BatchUpdateSectionOrdersBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.ID,
+			a.Order,
+		}
+		batch.Queue(batchUpdateSectionOrders, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &BatchUpdateSectionOrdersBatchResults{br, len(arg), false}
+}
+
+func (b *BatchUpdateSectionOrdersBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *BatchUpdateSectionOrdersBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const batchUpdateTaskOrders = `-- name: BatchUpdateTaskOrders :batchexec
+UPDATE tasks
+SET "order" = $2, version = version + 1, updated_at = now()
+WHERE tasks.id = $1 AND "order" <> $2
 `
 
 type BatchUpdateTaskOrdersBatchResults struct {
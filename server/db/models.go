@@ -8,18 +8,144 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ActivityEvent struct {
+	ID        int64
+	CreatedAt pgtype.Timestamptz
+	ProjectID pgtype.UUID
+	TaskID    pgtype.UUID
+	Action    string
+	Actor     string
+	RequestID string
+	Before    []byte
+	After     []byte
+	UserID    pgtype.UUID
+}
+
+type ApiToken struct {
+	ID         pgtype.UUID
+	UserID     pgtype.UUID
+	Name       string
+	TokenHash  []byte
+	CreatedAt  pgtype.Timestamptz
+	LastUsedAt pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+}
+
+type DeniedRefreshToken struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	DeniedAt  pgtype.Timestamptz
+	ExpiresAt pgtype.Timestamptz
+}
+
+type IdempotencyKey struct {
+	Key         string
+	Fingerprint string
+	CreatedAt   pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+	StatusCode  pgtype.Int4
+	Headers     []byte
+	Body        []byte
+	LockedUntil pgtype.Timestamptz
+}
+
+type Iteration struct {
+	ID               pgtype.UUID
+	Name             string
+	StartsAt         pgtype.Timestamptz
+	EndsAt           pgtype.Timestamptz
+	CreatedAt        pgtype.Timestamptz
+	ClosedAt         pgtype.Timestamptz
+	CompletedTasks   pgtype.Int4
+	CompletedPoints  pgtype.Int4
+	CompletedMinutes pgtype.Int4
+}
+
 type Project struct {
+	ID              pgtype.UUID
+	CreatedAt       pgtype.Timestamptz
+	Name            string
+	DeletedAt       pgtype.Timestamptz
+	ArchivedAt      pgtype.Timestamptz
+	Version         int32
+	Description     string
+	Color           pgtype.Text
+	Icon            pgtype.Text
+	Order           int32
+	EstimateUnit    string
+	WorkspaceID     pgtype.UUID
+	ParentProjectID pgtype.UUID
+}
+
+type Section struct {
 	ID        pgtype.UUID
-	CreatedAt pgtype.Timestamp
+	ProjectID pgtype.UUID
 	Name      string
+	Order     int32
+	CreatedAt pgtype.Timestamptz
 }
 
 type Task struct {
-	ID           pgtype.UUID
-	CreatedAt    pgtype.Timestamp
-	ParentTaskID pgtype.UUID
-	ProjectID    pgtype.UUID
-	Status       string
-	Order        int32
-	Name         string
+	ID            pgtype.UUID
+	CreatedAt     pgtype.Timestamptz
+	ParentTaskID  pgtype.UUID
+	ProjectID     pgtype.UUID
+	Status        string
+	Order         int32
+	Name          string
+	DueAt         pgtype.Timestamptz
+	DeletedAt     pgtype.Timestamptz
+	Version       int32
+	UpdatedAt     pgtype.Timestamptz
+	CompletedAt   pgtype.Timestamptz
+	Estimate      pgtype.Int4
+	IterationID   pgtype.UUID
+	SectionID     pgtype.UUID
+	RevisionCount int32
+}
+
+type TaskRevision struct {
+	TaskID    pgtype.UUID
+	Revision  int32
+	CreatedAt pgtype.Timestamptz
+	Action    string
+	State     []byte
+}
+
+type Template struct {
+	ID        pgtype.UUID
+	CreatedAt pgtype.Timestamptz
+	Name      string
+}
+
+type TemplateTask struct {
+	ID                   pgtype.UUID
+	TemplateID           pgtype.UUID
+	ParentTemplateTaskID pgtype.UUID
+	Name                 string
+	Order                int32
+	DueOffsetDays        pgtype.Int4
+}
+
+type TimeEntry struct {
+	ID        pgtype.UUID
+	TaskID    pgtype.UUID
+	Actor     string
+	StartedAt pgtype.Timestamptz
+	EndedAt   pgtype.Timestamptz
+	Note      string
+	CreatedAt pgtype.Timestamptz
+	UserID    pgtype.UUID
+}
+
+type User struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type Workspace struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
 }
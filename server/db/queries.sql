@@ -1,111 +1,989 @@
 -- name: CreateProject :exec
 INSERT INTO projects (
-  id, name, created_at
+  id, name, created_at, description, color, icon, "order", estimate_unit, workspace_id,
+  parent_project_id
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 );
 
 -- name: GetProject :one
 SELECT * FROM projects
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: LockProjectVersion :execrows
+-- Locks a project until the end of the transaction, provided it still has the expected version.
+SELECT id FROM projects
+WHERE id = @id::uuid AND version = @expected_version::integer AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: LockProjectName :exec
+-- Locks a project name of a workspace until the end of the transaction, so that two projects
+-- cannot check that the name is free and take it at the same time.
+SELECT pg_advisory_xact_lock(hashtextextended(COALESCE(@workspace_id::uuid::text, '') || '/' || @name::text, 0));
+
+-- name: LockProjectHierarchy :exec
+-- Locks the hierarchy of the projects until the end of the transaction, so that two projects cannot
+-- be made sub-projects of each other at the same time.
+SELECT pg_advisory_xact_lock(hashtextextended('project-hierarchy', 0));
+
+-- name: LockProjectOrder :exec
+-- Locks the order of the projects until the end of the transaction, so that two projects cannot
+-- be put at the end of the list at the same time.
+SELECT pg_advisory_xact_lock(hashtextextended('project-order', 0));
+
+-- name: GetNextProjectOrder :one
+-- Returns the position after the last project of the list.
+SELECT (coalesce(max("order"), -1) + 1)::integer AS next_order FROM projects
+WHERE deleted_at IS NULL;
 
 -- name: GetProjectByName :one
+-- Project names are unique within a workspace, or among the projects without one. Archived
+-- projects may share their name with an active project, in which case the active one is returned.
 SELECT * FROM projects
-WHERE name = $1 LIMIT 1;
+WHERE name = @name::text AND workspace_id IS NOT DISTINCT FROM @workspace_id::uuid
+  AND deleted_at IS NULL
+ORDER BY archived_at DESC NULLS FIRST
+LIMIT 1;
 
 -- name: ListProjects :many
 SELECT * FROM projects
-ORDER BY name;
+WHERE deleted_at IS NULL
+  AND (@include_archived::boolean OR archived_at IS NULL)
+ORDER BY "order", name, id;
+
+-- name: ListProjectsPage :many
+-- Lists a page of projects, sorted by sort_by ('name', 'created_at' or 'order') and then by ID. With
+-- has_cursor, only the projects after the last one of the previous page, given by its sort key
+-- and ID, are listed.
+SELECT * FROM projects
+WHERE deleted_at IS NULL
+  AND (@include_archived::boolean OR archived_at IS NULL)
+  AND (NOT @filter_workspace::boolean OR workspace_id = @workspace_id::uuid)
+  AND (NOT @has_cursor::boolean OR CASE
+    WHEN @sort_by::text = 'created_at' AND @descending::boolean
+      THEN (created_at, id) < (@after_created_at::timestamptz, @after_id::uuid)
+    WHEN @sort_by::text = 'created_at'
+      THEN (created_at, id) > (@after_created_at::timestamptz, @after_id::uuid)
+    WHEN @sort_by::text = 'order' AND @descending::boolean
+      THEN ("order", id) < (@after_order::integer, @after_id::uuid)
+    WHEN @sort_by::text = 'order'
+      THEN ("order", id) > (@after_order::integer, @after_id::uuid)
+    WHEN @descending::boolean
+      THEN (name, id) < (@after_text::text, @after_id::uuid)
+    ELSE (name, id) > (@after_text::text, @after_id::uuid)
+  END)
+ORDER BY
+  CASE WHEN @sort_by::text = 'created_at' AND NOT @descending::boolean THEN created_at END ASC,
+  CASE WHEN @sort_by::text = 'created_at' AND @descending::boolean THEN created_at END DESC,
+  CASE WHEN @sort_by::text = 'name' AND NOT @descending::boolean THEN name END ASC,
+  CASE WHEN @sort_by::text = 'name' AND @descending::boolean THEN name END DESC,
+  CASE WHEN @sort_by::text = 'order' AND NOT @descending::boolean THEN "order" END ASC,
+  CASE WHEN @sort_by::text = 'order' AND @descending::boolean THEN "order" END DESC,
+  CASE WHEN @descending::boolean THEN id END DESC,
+  id ASC
+LIMIT @max_projects::integer;
+
+-- name: GetProjectTaskSummaries :many
+-- Counts the tasks of each project, leaving out the tasks in the trash. Projects without tasks
+-- have no row.
+SELECT
+  project_id,
+  count(*) AS total,
+  count(*) FILTER (WHERE status = 'pending') AS pending,
+  count(*) FILTER (WHERE status = 'completed') AS completed,
+  count(*) FILTER (WHERE parent_task_id IS NULL) AS root
+FROM tasks
+WHERE project_id = ANY(@project_ids::uuid[]) AND deleted_at IS NULL
+GROUP BY project_id;
+
+-- name: GetProjectEstimateSummaries :many
+-- Sums the estimates of the tasks of each project, leaving out the tasks in the trash. The estimate
+-- of a task stands for its whole subtree, so the estimates of the subtasks of an estimated task
+-- are not counted. Since completing a task completes its subtasks, the remaining work is the sum
+-- of the estimates of the pending tasks. Projects without estimated tasks have no row.
+WITH RECURSIVE estimated_tasks AS (
+  SELECT id, project_id, status, estimate, estimate IS NOT NULL AS covered
+  FROM tasks
+  WHERE project_id = ANY(@project_ids::uuid[]) AND parent_task_id IS NULL AND deleted_at IS NULL
+  UNION ALL
+  SELECT t.id, t.project_id, t.status, t.estimate, t.estimate IS NOT NULL
+  FROM tasks t
+  JOIN estimated_tasks p ON t.parent_task_id = p.id
+  WHERE NOT p.covered AND t.deleted_at IS NULL
+)
+SELECT
+  project_id,
+  sum(estimate)::bigint AS estimate,
+  (coalesce(sum(estimate) FILTER (WHERE status = 'pending'), 0))::bigint AS remaining
+FROM estimated_tasks
+WHERE estimate IS NOT NULL
+GROUP BY project_id;
+
+-- name: ListWorkspaceProjects :many
+-- Lists the projects of a workspace, archived or not, leaving out the ones in the trash.
+SELECT * FROM projects
+WHERE workspace_id = $1 AND deleted_at IS NULL
+ORDER BY "order", name, id;
+
+-- name: ListProjectTree :many
+-- Lists a project and all of its sub-projects, at any depth.
+WITH RECURSIVE tree AS (
+  SELECT * FROM projects
+  WHERE projects.id = @id::uuid AND projects.deleted_at IS NULL
+  UNION ALL
+  SELECT p.* FROM projects p
+  JOIN tree t ON p.parent_project_id = t.id
+  WHERE p.deleted_at IS NULL
+)
+SELECT * FROM tree;
+
+-- name: SetProjectParent :one
+UPDATE projects
+SET parent_project_id = @parent_project_id::uuid, version = version + 1
+WHERE id = @id::uuid AND deleted_at IS NULL
+RETURNING *;
+
+-- name: MoveProjectTreeToWorkspace :many
+-- Moves a project and all of its sub-projects to a workspace, or out of any if workspace_id is
+-- NULL. The project leaves its parent, which stays in the former workspace.
+WITH RECURSIVE tree AS (
+  SELECT projects.id FROM projects
+  WHERE projects.id = @id::uuid AND projects.deleted_at IS NULL
+  UNION ALL
+  SELECT p.id FROM projects p
+  JOIN tree t ON p.parent_project_id = t.id
+  WHERE p.deleted_at IS NULL
+)
+UPDATE projects
+SET
+  workspace_id = @workspace_id::uuid,
+  parent_project_id = CASE WHEN projects.id = @id::uuid THEN NULL ELSE projects.parent_project_id END,
+  version = projects.version + 1
+FROM tree
+WHERE projects.id = tree.id
+RETURNING projects.*;
+
+-- name: ReleaseSubprojects :exec
+-- Moves the sub-projects of a project taken out of the tree, e.g. moved to the trash, up to its
+-- parent.
+UPDATE projects
+SET parent_project_id = @new_parent_project_id::uuid, version = version + 1
+WHERE parent_project_id = @parent_project_id::uuid AND deleted_at IS NULL;
+
+-- name: ArchiveProject :one
+UPDATE projects
+SET archived_at = @archived_at::timestamptz, version = version + 1
+WHERE id = @id::uuid AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UnarchiveProject :one
+UPDATE projects
+SET archived_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
 
 -- name: RenameProject :one
 UPDATE projects
-SET name = $2
-WHERE id = $1
+SET name = $2, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateProjectDetails :one
+UPDATE projects
+SET description = @description::text, color = @color, icon = @icon,
+  estimate_unit = @estimate_unit::text, version = version + 1
+WHERE id = @id::uuid AND deleted_at IS NULL
 RETURNING *;
 
+-- name: BatchUpdateProjectOrders :batchexec
+-- Projects already in place are left untouched, so that their version is kept.
+UPDATE projects
+SET "order" = $2, version = version + 1
+WHERE projects.id = $1 AND "order" <> $2;
+
+-- name: CloseProjectOrderGap :exec
+-- Moves the projects after a project taken out of the list, e.g. moved to the trash, one
+-- position up.
+UPDATE projects
+SET "order" = "order" - 1, version = version + 1
+WHERE deleted_at IS NULL AND "order" > @removed_order::integer;
+
 -- name: DeleteProject :one
 DELETE FROM projects
 WHERE id = $1
 RETURNING *;
 
+-- name: SoftDeleteProject :one
+-- Projects in the trash leave their parent, so they are restored at the top of their workspace.
+UPDATE projects
+SET deleted_at = @deleted_at::timestamptz, parent_project_id = NULL, version = version + 1
+WHERE id = @id::uuid AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetDeletedProject :one
+SELECT * FROM projects
+WHERE id = $1 AND deleted_at IS NOT NULL LIMIT 1;
+
+-- name: ListDeletedProjects :many
+SELECT * FROM projects
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: RestoreProject :one
+-- Restored projects go to the end of the list.
+UPDATE projects
+SET
+  deleted_at = NULL,
+  "order" = (SELECT coalesce(max(p."order"), -1) + 1 FROM projects p WHERE p.deleted_at IS NULL),
+  version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeDeletedProjects :execrows
+DELETE FROM projects
+WHERE deleted_at < @deleted_before::timestamptz;
+
+-- name: CreateSection :exec
+INSERT INTO sections (
+  id, project_id, name, "order", created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: GetSection :one
+SELECT * FROM sections
+WHERE id = $1;
+
+-- name: ListSections :many
+SELECT * FROM sections
+WHERE project_id = $1
+ORDER BY "order", id;
+
+-- name: RenameSection :one
+UPDATE sections
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: BatchUpdateSectionOrders :batchexec
+UPDATE sections
+SET "order" = $2
+WHERE sections.id = $1 AND "order" <> $2;
+
+-- name: DeleteSection :one
+DELETE FROM sections
+WHERE id = $1
+RETURNING *;
+
+-- name: CloseSectionOrderGap :exec
+-- Moves the sections after a deleted section one position up.
+UPDATE sections
+SET "order" = "order" - 1
+WHERE project_id = @project_id::uuid AND "order" > @removed_order::integer;
+
+-- name: ReleaseSectionTasks :many
+-- Moves the root tasks of a section to the end of the root tasks without a section, in the
+-- order they had in the section. Tasks in the trash lose their section along with it.
+WITH unsectioned AS (
+  SELECT count(*)::integer AS task_count FROM tasks
+  WHERE project_id = @project_id::uuid
+    AND parent_task_id IS NULL
+    AND section_id IS NULL
+    AND deleted_at IS NULL
+), released AS (
+  SELECT ts.id, (row_number() OVER (ORDER BY ts."order", ts.id) - 1)::integer AS position
+  FROM tasks ts
+  WHERE ts.section_id = @section_id::uuid AND ts.deleted_at IS NULL
+)
+UPDATE tasks
+SET section_id = NULL, "order" = unsectioned.task_count + released.position,
+  version = version + 1, updated_at = now()
+FROM unsectioned, released
+WHERE tasks.id = released.id
+RETURNING tasks.*;
+
 -- name: CreateTask :exec
 INSERT INTO tasks (
-  id, project_id, name, status, "order", parent_task_id, created_at
+  id, project_id, name, status, "order", parent_task_id, created_at, due_at, updated_at,
+  completed_at, estimate, iteration_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 );
 
 -- name: ListTasks :many
 SELECT * FROM tasks
+WHERE deleted_at IS NULL
 ORDER BY project_id;
 
+-- name: ListTasksPage :many
+-- Lists a page of tasks, sorted by sort_by ('created_at', 'name', 'order' or 'status') and then
+-- by ID. With has_cursor, only the tasks after the last one of the previous page, given by its
+-- sort key and ID, are listed. Each filter only applies if its flag is set.
+SELECT * FROM tasks
+WHERE deleted_at IS NULL
+  AND (NOT @filter_project::boolean OR project_id = @project_id::uuid)
+  AND (NOT @filter_parent::boolean OR parent_task_id = @parent_task_id::uuid)
+  AND (NOT @root_only::boolean OR parent_task_id IS NULL)
+  AND (NOT @filter_section::boolean OR section_id = @section_id::uuid)
+  AND (@status::text = '' OR status = @status::text)
+  AND (NOT @has_cursor::boolean OR CASE
+    WHEN @sort_by::text = 'name' AND @descending::boolean
+      THEN (name, id) < (@after_text::text, @after_id::uuid)
+    WHEN @sort_by::text = 'name'
+      THEN (name, id) > (@after_text::text, @after_id::uuid)
+    WHEN @sort_by::text = 'status' AND @descending::boolean
+      THEN (status, id) < (@after_text::text, @after_id::uuid)
+    WHEN @sort_by::text = 'status'
+      THEN (status, id) > (@after_text::text, @after_id::uuid)
+    WHEN @sort_by::text = 'order' AND @descending::boolean
+      THEN ("order", id) < (@after_order::integer, @after_id::uuid)
+    WHEN @sort_by::text = 'order'
+      THEN ("order", id) > (@after_order::integer, @after_id::uuid)
+    WHEN @descending::boolean
+      THEN (created_at, id) < (@after_created_at::timestamptz, @after_id::uuid)
+    ELSE (created_at, id) > (@after_created_at::timestamptz, @after_id::uuid)
+  END)
+ORDER BY
+  CASE WHEN @sort_by::text = 'name' AND NOT @descending::boolean THEN name END ASC,
+  CASE WHEN @sort_by::text = 'name' AND @descending::boolean THEN name END DESC,
+  CASE WHEN @sort_by::text = 'status' AND NOT @descending::boolean THEN status END ASC,
+  CASE WHEN @sort_by::text = 'status' AND @descending::boolean THEN status END DESC,
+  CASE WHEN @sort_by::text = 'order' AND NOT @descending::boolean THEN "order" END ASC,
+  CASE WHEN @sort_by::text = 'order' AND @descending::boolean THEN "order" END DESC,
+  CASE WHEN @sort_by::text = 'created_at' AND NOT @descending::boolean THEN created_at END ASC,
+  CASE WHEN @sort_by::text = 'created_at' AND @descending::boolean THEN created_at END DESC,
+  CASE WHEN @descending::boolean THEN id END DESC,
+  id ASC
+LIMIT @max_tasks::integer;
+
 -- name: GetTask :one
 SELECT * FROM tasks
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: LockTaskVersion :execrows
+-- Locks a task until the end of the transaction, provided it still has the expected version.
+SELECT id FROM tasks
+WHERE id = @id::uuid AND version = @expected_version::integer AND deleted_at IS NULL
+FOR UPDATE;
 
 -- name: GetSubtasksDirect :many
 SELECT * FROM tasks
-WHERE parent_task_id = $1;
+WHERE parent_task_id = $1 AND deleted_at IS NULL;
 
 -- name: GetSubtasksDeep :many
 WITH RECURSIVE subtasks AS (
   -- Base case: Direct children of the specified parent task
   SELECT * FROM tasks ts
-  WHERE ts.parent_task_id = $1
+  WHERE ts.parent_task_id = $1 AND ts.deleted_at IS NULL
 
   UNION
 
   -- Recursive step: For each found subtask, find its own children
   SELECT t.* FROM tasks t
   INNER JOIN subtasks st ON t.parent_task_id = st.id
+  WHERE t.deleted_at IS NULL
 )
 SELECT * FROM subtasks;
 
 -- name: GetTasksByProject :many
 SELECT * FROM tasks
-WHERE project_id = $1;
+WHERE project_id = $1 AND deleted_at IS NULL;
 
 -- name: GetTasksInProjectRoot :many
 SELECT * FROM tasks
-WHERE project_id = $1 AND parent_task_id IS NULL;
+WHERE project_id = $1 AND parent_task_id IS NULL AND deleted_at IS NULL;
+
+-- name: GetTasksInSection :many
+-- Lists the root tasks of a project in a section or, if section_id is null, in no section.
+SELECT * FROM tasks
+WHERE project_id = @project_id::uuid
+  AND parent_task_id IS NULL
+  AND section_id IS NOT DISTINCT FROM @section_id::uuid
+  AND deleted_at IS NULL;
 
 -- name: GetTasksByStatus :many
 SELECT * FROM tasks
-WHERE project_id = $1 AND status = $2;
+WHERE project_id = $1 AND status = $2 AND deleted_at IS NULL;
 
 -- name: RenameTask :one
 UPDATE tasks
-SET name = $2
-WHERE id = $1
+SET name = $2, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: UpdateTaskOrder :exec
 UPDATE tasks
-SET "order" = $2
+SET "order" = $2, version = version + 1, updated_at = now()
 WHERE id = $1;
 
+-- name: MoveTask :one
+-- Only root tasks are in sections, and root tasks moved out of one are moved to no section.
+UPDATE tasks
+SET parent_task_id = $2, "order" = $3, section_id = NULL, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SetTaskSection :one
+UPDATE tasks
+SET section_id = $2, "order" = $3, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateTaskDueAt :one
+UPDATE tasks
+SET due_at = $2, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateTaskEstimate :one
+UPDATE tasks
+SET estimate = $2, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
 -- name: UpdateTaskStatus :exec
 UPDATE tasks
-SET "status" = $2
-WHERE id = $1;
+SET "status" = $2, completed_at = $3, version = version + 1, updated_at = now()
+WHERE id = $1 AND "status" <> $2;
 
 -- name: DeleteTask :exec
 DELETE FROM tasks
 WHERE id = $1;
 
+-- name: SoftDeleteTaskTree :exec
+WITH RECURSIVE subtree AS (
+  SELECT ts.id FROM tasks ts
+  WHERE ts.id = @id::uuid AND ts.deleted_at IS NULL
+
+  UNION
+
+  SELECT t.id FROM tasks t
+  INNER JOIN subtree st ON t.parent_task_id = st.id
+  WHERE t.deleted_at IS NULL
+)
+UPDATE tasks
+SET deleted_at = @deleted_at::timestamptz, version = version + 1, updated_at = now()
+WHERE id IN (SELECT id FROM subtree);
+
+-- name: RestoreTaskTree :exec
+-- Tasks deleted in the same operation share their deleted_at, so the
+-- subtree of a deleted task is restored together with it, while subtasks
+-- deleted on their own stay in the trash.
+WITH RECURSIVE subtree AS (
+  SELECT ts.id FROM tasks ts
+  WHERE ts.id = @id::uuid AND ts.deleted_at = @deleted_at::timestamptz
+
+  UNION
+
+  SELECT t.id FROM tasks t
+  INNER JOIN subtree st ON t.parent_task_id = st.id
+  WHERE t.deleted_at = @deleted_at::timestamptz
+)
+UPDATE tasks
+SET deleted_at = NULL, version = version + 1, updated_at = now()
+WHERE id IN (SELECT id FROM subtree);
+
+-- name: SoftDeleteProjectTasks :exec
+UPDATE tasks
+SET deleted_at = @deleted_at::timestamptz, version = version + 1, updated_at = now()
+WHERE project_id = @project_id::uuid AND deleted_at IS NULL;
+
+-- name: RestoreProjectTasks :exec
+UPDATE tasks
+SET deleted_at = NULL, version = version + 1, updated_at = now()
+WHERE project_id = @project_id::uuid AND deleted_at = @deleted_at::timestamptz;
+
+-- name: ListCompletedTasks :many
+-- Lists the tasks completed from completed_from (inclusive) to completed_to (exclusive), most
+-- recently completed first.
+SELECT * FROM tasks
+WHERE deleted_at IS NULL
+  AND completed_at >= @completed_from::timestamptz
+  AND completed_at < @completed_to::timestamptz
+ORDER BY completed_at DESC, id;
+
+-- name: ListProjectCompletedTasks :many
+-- Lists the tasks of a project completed from completed_from (inclusive) to completed_to
+-- (exclusive), most recently completed first.
+SELECT * FROM tasks
+WHERE project_id = @project_id::uuid
+  AND deleted_at IS NULL
+  AND completed_at >= @completed_from::timestamptz
+  AND completed_at < @completed_to::timestamptz
+ORDER BY completed_at DESC, id;
+
+-- name: GetProjectTaskSeries :many
+-- Counts, for each period given by its start and end, the tasks of a project created and completed
+-- in the period, and the tasks still open at its end. Tasks in the trash are left out.
+SELECT
+  b.bucket_start::timestamptz AS bucket_start,
+  b.bucket_end::timestamptz AS bucket_end,
+  count(t.id) FILTER (
+    WHERE t.created_at >= b.bucket_start AND t.created_at < b.bucket_end
+  ) AS created,
+  count(t.id) FILTER (
+    WHERE t.completed_at >= b.bucket_start AND t.completed_at < b.bucket_end
+  ) AS completed,
+  count(t.id) FILTER (
+    WHERE t.created_at < b.bucket_end AND (t.completed_at IS NULL OR t.completed_at >= b.bucket_end)
+  ) AS open
+FROM unnest(@bucket_starts::timestamptz[], @bucket_ends::timestamptz[]) AS b(bucket_start, bucket_end)
+LEFT JOIN tasks t ON t.project_id = @project_id::uuid AND t.deleted_at IS NULL
+GROUP BY b.bucket_start, b.bucket_end
+ORDER BY b.bucket_start;
+
+-- name: GetProjectCycleTime :one
+-- Averages the time from creation to completion of the tasks of a project completed from
+-- completed_from (inclusive) to completed_to (exclusive).
+SELECT
+  count(*) AS completed,
+  coalesce(avg(extract(epoch FROM completed_at - created_at)), 0)::float8 AS average_seconds
+FROM tasks
+WHERE project_id = @project_id::uuid
+  AND deleted_at IS NULL
+  AND completed_at >= @completed_from::timestamptz
+  AND completed_at < @completed_to::timestamptz;
+
+-- name: ListOldestOpenTasks :many
+SELECT * FROM tasks
+WHERE project_id = @project_id::uuid AND status = 'pending' AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT @max_tasks::integer;
+
+-- name: GetDeletedTask :one
+SELECT * FROM tasks
+WHERE id = $1 AND deleted_at IS NOT NULL LIMIT 1;
+
+-- name: ListDeletedTasks :many
+-- Only the tasks that were deleted on their own are listed: tasks deleted
+-- together with their parent task or project are restored with it.
+SELECT t.* FROM tasks t
+INNER JOIN projects p ON p.id = t.project_id
+LEFT JOIN tasks parent ON parent.id = t.parent_task_id
+WHERE t.deleted_at IS NOT NULL
+  AND (p.deleted_at IS NULL OR p.deleted_at <> t.deleted_at)
+  AND (parent.deleted_at IS NULL OR parent.deleted_at <> t.deleted_at)
+ORDER BY t.deleted_at DESC;
+
+-- name: PurgeDeletedTasks :execrows
+DELETE FROM tasks
+WHERE deleted_at < @deleted_before::timestamptz;
+
 -- WARN: the following two queries should be used together
 -- in the scope of a transaction!
 
 -- name: OffsetTaskOrders :exec
 UPDATE tasks
-SET "order" = "order" + 1000
+SET "order" = "order" + 1000, version = version + 1, updated_at = now()
 WHERE project_id = @project_id::uuid
   AND (
     (parent_task_id IS NULL AND @parent_task_id::uuid IS NULL) OR
     (parent_task_id = @parent_task_id::uuid)
-  );
+  )
+  AND deleted_at IS NULL;
+
+-- name: BatchMoveTaskOrdersAside :batchexec
+-- Moves a task whose order changes past the orders of every task of its project, so that the
+-- orders of its siblings can then be rearranged in any order without ever being taken twice. Its
+-- version is only incremented once it gets its new order with BatchUpdateTaskOrders.
+UPDATE tasks
+SET "order" = (SELECT max(t."order") + 1 FROM tasks t WHERE t.project_id = tasks.project_id)
+WHERE tasks.id = $1 AND "order" <> $2;
 
 -- name: BatchUpdateTaskOrders :batchexec
+-- Tasks already in place are left untouched, so that their version is kept.
 UPDATE tasks
-SET "order" = $2
-WHERE tasks.id = $1;
+SET "order" = $2, version = version + 1, updated_at = now()
+WHERE tasks.id = $1 AND "order" <> $2;
+
+-- name: CreateTemplate :exec
+INSERT INTO templates (
+  id, name, created_at
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: CreateTemplateTask :exec
+INSERT INTO template_tasks (
+  id, template_id, parent_template_task_id, name, "order", due_offset_days
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+);
+
+-- name: GetTemplate :one
+SELECT * FROM templates
+WHERE id = $1 LIMIT 1;
+
+-- name: ListTemplates :many
+SELECT * FROM templates
+ORDER BY name;
+
+-- name: GetTemplateTasks :many
+SELECT * FROM template_tasks
+WHERE template_id = $1
+ORDER BY "order";
+
+-- name: DeleteTemplate :one
+DELETE FROM templates
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateActivityEvent :one
+INSERT INTO activity_events (
+  created_at, project_id, task_id, action, actor, request_id, before, after, user_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: ListProjectActivity :many
+-- Lists the events of a project and of its tasks, newest first. Only the events with an ID
+-- lower than before_id are listed, which lets callers page through the history.
+SELECT * FROM activity_events
+WHERE project_id = @project_id::uuid AND id < @before_id::bigint
+ORDER BY id DESC
+LIMIT @max_events::integer;
+
+-- name: ListTaskActivity :many
+-- Lists the events of a task, newest first. Only the events with an ID lower than before_id
+-- are listed.
+SELECT * FROM activity_events
+WHERE task_id = @task_id::uuid AND id < @before_id::bigint
+ORDER BY id DESC
+LIMIT @max_events::integer;
+
+-- name: CreateTaskRevision :one
+-- Revisions are numbered from 1 for each task. The number is taken from a counter on the task,
+-- whose row lock keeps concurrent revisions of the same task from getting the same number.
+WITH counter AS (
+  UPDATE tasks SET revision_count = revision_count + 1
+  WHERE id = @task_id::uuid
+  RETURNING revision_count
+)
+INSERT INTO task_revisions (
+  task_id, revision, created_at, action, state
+)
+SELECT
+  @task_id::uuid,
+  counter.revision_count,
+  @created_at::timestamptz,
+  @action::text,
+  @state::jsonb
+FROM counter
+RETURNING *;
+
+-- name: ListTaskRevisions :many
+SELECT * FROM task_revisions
+WHERE task_id = $1
+ORDER BY revision;
+
+-- name: GetTaskRevision :one
+SELECT * FROM task_revisions
+WHERE task_id = $1 AND revision = $2;
+
+-- name: ClaimIdempotencyKey :one
+-- Inserts the key, or takes it over if it expired, or if a request with the same fingerprint
+-- claimed it but did not complete it in time, e.g. because the server stopped while handling it.
+-- Claims made before they had a deadline are taken over too. Returns no rows if the key is still
+-- in use.
+INSERT INTO idempotency_keys (
+  key, fingerprint, created_at, expires_at, locked_until
+) VALUES (
+  @key::text, @fingerprint::text, @created_at::timestamptz, @expires_at::timestamptz, @locked_until::timestamptz
+)
+ON CONFLICT (key) DO UPDATE
+SET fingerprint = EXCLUDED.fingerprint,
+  created_at = EXCLUDED.created_at,
+  expires_at = EXCLUDED.expires_at,
+  locked_until = EXCLUDED.locked_until,
+  status_code = NULL,
+  headers = NULL,
+  body = NULL
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+  OR (
+    idempotency_keys.status_code IS NULL
+    AND idempotency_keys.fingerprint = EXCLUDED.fingerprint
+    AND (idempotency_keys.locked_until IS NULL OR idempotency_keys.locked_until <= EXCLUDED.created_at)
+  )
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE key = $1;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = @status_code::integer, headers = @headers::jsonb, body = @body::bytea
+WHERE key = @key::text;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1;
+
+-- name: PurgeExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at < @expired_before::timestamptz;
+
+-- name: CreateTimeEntry :one
+INSERT INTO time_entries (
+  id, task_id, user_id, actor, started_at, ended_at, note, created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetTimeEntry :one
+SELECT * FROM time_entries
+WHERE id = $1;
+
+-- name: GetRunningTimeEntry :one
+SELECT * FROM time_entries
+WHERE user_id = $1 AND ended_at IS NULL;
+
+-- name: StopTimeEntry :one
+UPDATE time_entries
+SET ended_at = @ended_at::timestamptz
+WHERE user_id = @user_id::uuid AND ended_at IS NULL
+RETURNING *;
+
+-- name: ListTaskTimeEntries :many
+SELECT * FROM time_entries
+WHERE task_id = $1
+ORDER BY started_at DESC, id;
+
+-- name: DeleteTimeEntry :execrows
+DELETE FROM time_entries
+WHERE id = $1;
+
+-- name: GetTaskTimeTotals :one
+-- Sums the time tracked on a task, and on the task along with all of its subtasks. Running timers
+-- count until now.
+WITH RECURSIVE subtree AS (
+  SELECT ts.id FROM tasks ts
+  WHERE ts.id = @task_id::uuid AND ts.deleted_at IS NULL
+
+  UNION
+
+  SELECT t.id FROM tasks t
+  INNER JOIN subtree st ON t.parent_task_id = st.id
+  WHERE t.deleted_at IS NULL
+)
+SELECT
+  coalesce(sum(extract(epoch FROM coalesce(e.ended_at, now()) - e.started_at)) FILTER (
+    WHERE e.task_id = @task_id::uuid
+  ), 0)::float8 AS own_seconds,
+  coalesce(sum(extract(epoch FROM coalesce(e.ended_at, now()) - e.started_at)), 0)::float8 AS total_seconds
+FROM time_entries e
+WHERE e.task_id IN (SELECT id FROM subtree);
+
+-- name: GetProjectTimesheet :many
+-- Sums the time tracked by each user on each task of a project from period_from (inclusive) to
+-- period_to (exclusive). Entries overlapping the period only count for their part in it, and
+-- running timers count until now. Tasks in the trash are left out. Users are named by their
+-- current name, entries of no user by the actor they were made by.
+SELECT
+  e.task_id,
+  t.name AS task_name,
+  e.user_id,
+  coalesce(u.name, e.actor)::text AS actor,
+  sum(extract(epoch FROM
+    least(coalesce(e.ended_at, now()), @period_to::timestamptz) - greatest(e.started_at, @period_from::timestamptz)
+  ))::float8 AS seconds
+FROM time_entries e
+INNER JOIN tasks t ON t.id = e.task_id
+LEFT JOIN users u ON u.id = e.user_id
+WHERE t.project_id = @project_id::uuid
+  AND t.deleted_at IS NULL
+  AND e.started_at < @period_to::timestamptz
+  AND coalesce(e.ended_at, now()) > @period_from::timestamptz
+GROUP BY e.task_id, t.name, e.user_id, coalesce(u.name, e.actor)
+ORDER BY t.name, e.task_id, coalesce(u.name, e.actor), e.user_id;
+
+-- name: CreateIteration :exec
+INSERT INTO iterations (
+  id, name, starts_at, ends_at, created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: GetIteration :one
+SELECT * FROM iterations
+WHERE id = $1 LIMIT 1;
+
+-- name: ListIterations :many
+SELECT * FROM iterations
+ORDER BY starts_at, id;
+
+-- name: GetNextIteration :one
+-- Returns the first open iteration starting after the given one.
+SELECT * FROM iterations
+WHERE id <> @id::uuid AND starts_at > @starts_at::timestamptz AND closed_at IS NULL
+ORDER BY starts_at, id
+LIMIT 1;
+
+-- name: UpdateIteration :one
+UPDATE iterations
+SET name = $2, starts_at = $3, ends_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteIteration :execrows
+DELETE FROM iterations
+WHERE id = $1;
+
+-- name: CloseIteration :one
+-- Closes an open iteration and saves its velocity, measured in the same statement so that it
+-- matches the tasks completed when the iteration is closed: the completed tasks of the iteration
+-- are counted and their estimates summed up, separately for each estimate unit. Tasks in the trash
+-- are left out.
+UPDATE iterations
+SET closed_at = @closed_at::timestamptz,
+  completed_tasks = velocity.completed_tasks,
+  completed_points = velocity.completed_points,
+  completed_minutes = velocity.completed_minutes
+FROM (
+  SELECT
+    (count(*) FILTER (WHERE t.status = 'completed'))::integer AS completed_tasks,
+    (coalesce(sum(t.estimate) FILTER (
+      WHERE t.status = 'completed' AND p.estimate_unit = 'points'
+    ), 0))::integer AS completed_points,
+    (coalesce(sum(t.estimate) FILTER (
+      WHERE t.status = 'completed' AND p.estimate_unit = 'minutes'
+    ), 0))::integer AS completed_minutes
+  FROM tasks t
+  INNER JOIN projects p ON p.id = t.project_id
+  WHERE t.iteration_id = @id::uuid AND t.deleted_at IS NULL
+) velocity
+WHERE iterations.id = @id::uuid AND iterations.closed_at IS NULL
+RETURNING iterations.*;
+
+-- name: ListIterationTasks :many
+-- Lists the tasks of an iteration, from every project, pending ones first. Tasks in the trash are
+-- left out.
+SELECT * FROM tasks
+WHERE iteration_id = $1 AND deleted_at IS NULL
+ORDER BY status DESC, project_id, created_at, id;
+
+-- name: SetTaskIteration :one
+-- Plans a task in an open iteration, or in none if iteration_id is NULL. Nothing is returned if the
+-- iteration is closed.
+UPDATE tasks
+SET iteration_id = @iteration_id::uuid, version = version + 1, updated_at = now()
+WHERE id = @id::uuid AND deleted_at IS NULL
+  AND (
+    @iteration_id::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM iterations WHERE iterations.id = @iteration_id::uuid AND iterations.closed_at IS NULL)
+  )
+RETURNING *;
+
+-- name: RollOverIterationTasks :many
+-- Moves the pending tasks of an iteration to another one. The tasks of archived projects are
+-- frozen, so they stay where they are.
+UPDATE tasks
+SET iteration_id = @to_iteration_id::uuid, version = version + 1, updated_at = now()
+WHERE iteration_id = @from_iteration_id::uuid
+  AND status = 'pending'
+  AND deleted_at IS NULL
+  AND project_id IN (SELECT id FROM projects WHERE archived_at IS NULL)
+RETURNING *;
+
+-- name: ClearIterationTasks :many
+-- Takes every task, including the ones in the trash, out of an iteration, before it is deleted.
+UPDATE tasks
+SET iteration_id = NULL, version = version + 1, updated_at = now()
+WHERE iteration_id = $1
+RETURNING *;
+
+-- name: CreateWorkspace :exec
+INSERT INTO workspaces (
+  id, name, created_at
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: GetWorkspace :one
+SELECT * FROM workspaces
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWorkspaces :many
+SELECT * FROM workspaces
+ORDER BY name, id;
+
+-- name: RenameWorkspace :one
+UPDATE workspaces
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: PurgeWorkspaceTrash :exec
+-- Permanently deletes the projects of a workspace that are in the trash, so that the workspace can
+-- be deleted.
+DELETE FROM projects
+WHERE workspace_id = $1 AND deleted_at IS NOT NULL;
+
+-- name: DeleteWorkspace :one
+-- Fails with a foreign key violation if the workspace still has projects, archived or not.
+DELETE FROM workspaces
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateUser :exec
+INSERT INTO users (
+  id, name, created_at
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1 LIMIT 1;
+
+-- name: ListUsers :many
+SELECT * FROM users
+ORDER BY name, id;
+
+-- name: CreateAPIToken :exec
+INSERT INTO api_tokens (
+  id, user_id, name, token_hash, created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: ListUserAPITokens :many
+-- Lists the tokens of a user, revoked or not, newest first. The hashes are listed too, but must not
+-- be disclosed.
+SELECT * FROM api_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC, id;
+
+-- name: RevokeAPIToken :one
+-- Revoking a revoked token keeps the time it was first revoked at.
+UPDATE api_tokens
+SET revoked_at = COALESCE(revoked_at, now())
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetAPIToken :one
+SELECT * FROM api_tokens
+WHERE id = $1;
+
+-- name: UseAPIToken :one
+-- Finds a token that is not revoked, and records that it was used.
+UPDATE api_tokens
+SET last_used_at = $2
+WHERE token_hash = $1 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: DenyRefreshToken :execrows
+-- Denies a refresh token until it expires. No row is inserted if the token was already denied.
+INSERT INTO denied_refresh_tokens (
+  id, user_id, denied_at, expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: PurgeExpiredDeniedRefreshTokens :execrows
+-- Expired tokens are rejected anyway, they need not be denied anymore.
+DELETE FROM denied_refresh_tokens
+WHERE expires_at < @expired_before::timestamptz;
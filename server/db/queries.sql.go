@@ -11,30 +11,417 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveProject = `-- name: ArchiveProject :one
+UPDATE projects
+SET archived_at = $1::timestamptz, version = version + 1
+WHERE id = $2::uuid AND deleted_at IS NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+type ArchiveProjectParams struct {
+	ArchivedAt pgtype.Timestamptz
+	ID         pgtype.UUID
+}
+
+func (q *Queries) ArchiveProject(ctx context.Context, arg ArchiveProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, archiveProject, arg.ArchivedAt, arg.ID)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const claimIdempotencyKey = `-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  key, fingerprint, created_at, expires_at, locked_until
+) VALUES (
+  $1::text, $2::text, $3::timestamptz, $4::timestamptz, $5::timestamptz
+)
+ON CONFLICT (key) DO UPDATE
+SET fingerprint = EXCLUDED.fingerprint,
+  created_at = EXCLUDED.created_at,
+  expires_at = EXCLUDED.expires_at,
+  locked_until = EXCLUDED.locked_until,
+  status_code = NULL,
+  headers = NULL,
+  body = NULL
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+  OR (
+    idempotency_keys.status_code IS NULL
+    AND idempotency_keys.fingerprint = EXCLUDED.fingerprint
+    AND (idempotency_keys.locked_until IS NULL OR idempotency_keys.locked_until <= EXCLUDED.created_at)
+  )
+RETURNING key, fingerprint, created_at, expires_at, status_code, headers, body, locked_until
+`
+
+type ClaimIdempotencyKeyParams struct {
+	Key         string
+	Fingerprint string
+	CreatedAt   pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+	LockedUntil pgtype.Timestamptz
+}
+
+// Inserts the key, or takes it over if it expired, or if a request with the same fingerprint
+// claimed it but did not complete it in time, e.g. because the server stopped while handling it.
+// Claims made before they had a deadline are taken over too. Returns no rows if the key is still
+// in use.
+func (q *Queries) ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, claimIdempotencyKey,
+		arg.Key,
+		arg.Fingerprint,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+		arg.LockedUntil,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.Fingerprint,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.StatusCode,
+		&i.Headers,
+		&i.Body,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const clearIterationTasks = `-- name: ClearIterationTasks :many
+UPDATE tasks
+SET iteration_id = NULL, version = version + 1, updated_at = now()
+WHERE iteration_id = $1
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
+`
+
+// Takes every task, including the ones in the trash, out of an iteration, before it is deleted.
+func (q *Queries) ClearIterationTasks(ctx context.Context, iterationID pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, clearIterationTasks, iterationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const closeIteration = `-- name: CloseIteration :one
+UPDATE iterations
+SET closed_at = $1::timestamptz,
+  completed_tasks = velocity.completed_tasks,
+  completed_points = velocity.completed_points,
+  completed_minutes = velocity.completed_minutes
+FROM (
+  SELECT
+    (count(*) FILTER (WHERE t.status = 'completed'))::integer AS completed_tasks,
+    (coalesce(sum(t.estimate) FILTER (
+      WHERE t.status = 'completed' AND p.estimate_unit = 'points'
+    ), 0))::integer AS completed_points,
+    (coalesce(sum(t.estimate) FILTER (
+      WHERE t.status = 'completed' AND p.estimate_unit = 'minutes'
+    ), 0))::integer AS completed_minutes
+  FROM tasks t
+  INNER JOIN projects p ON p.id = t.project_id
+  WHERE t.iteration_id = $2::uuid AND t.deleted_at IS NULL
+) velocity
+WHERE iterations.id = $2::uuid AND iterations.closed_at IS NULL
+RETURNING iterations.id, iterations.name, iterations.starts_at, iterations.ends_at, iterations.created_at, iterations.closed_at, iterations.completed_tasks, iterations.completed_points, iterations.completed_minutes
+`
+
+type CloseIterationParams struct {
+	ClosedAt pgtype.Timestamptz
+	ID       pgtype.UUID
+}
+
+// Closes an open iteration and saves its velocity, measured in the same statement so that it
+// matches the tasks completed when the iteration is closed: the completed tasks of the iteration
+// are counted and their estimates summed up, separately for each estimate unit. Tasks in the trash
+// are left out.
+func (q *Queries) CloseIteration(ctx context.Context, arg CloseIterationParams) (Iteration, error) {
+	row := q.db.QueryRow(ctx, closeIteration, arg.ClosedAt, arg.ID)
+	var i Iteration
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.CompletedTasks,
+		&i.CompletedPoints,
+		&i.CompletedMinutes,
+	)
+	return i, err
+}
+
+const closeProjectOrderGap = `-- name: CloseProjectOrderGap :exec
+UPDATE projects
+SET "order" = "order" - 1, version = version + 1
+WHERE deleted_at IS NULL AND "order" > $1::integer
+`
+
+// Moves the projects after a project taken out of the list, e.g. moved to the trash, one
+// position up.
+func (q *Queries) CloseProjectOrderGap(ctx context.Context, removedOrder int32) error {
+	_, err := q.db.Exec(ctx, closeProjectOrderGap, removedOrder)
+	return err
+}
+
+const closeSectionOrderGap = `-- name: CloseSectionOrderGap :exec
+UPDATE sections
+SET "order" = "order" - 1
+WHERE project_id = $1::uuid AND "order" > $2::integer
+`
+
+type CloseSectionOrderGapParams struct {
+	ProjectID    pgtype.UUID
+	RemovedOrder int32
+}
+
+// Moves the sections after a deleted section one position up.
+func (q *Queries) CloseSectionOrderGap(ctx context.Context, arg CloseSectionOrderGapParams) error {
+	_, err := q.db.Exec(ctx, closeSectionOrderGap, arg.ProjectID, arg.RemovedOrder)
+	return err
+}
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $1::integer, headers = $2::jsonb, body = $3::bytea
+WHERE key = $4::text
+`
+
+type CompleteIdempotencyKeyParams struct {
+	StatusCode int32
+	Headers    []byte
+	Body       []byte
+	Key        string
+}
+
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, completeIdempotencyKey,
+		arg.StatusCode,
+		arg.Headers,
+		arg.Body,
+		arg.Key,
+	)
+	return err
+}
+
+const createAPIToken = `-- name: CreateAPIToken :exec
+INSERT INTO api_tokens (
+  id, user_id, name, token_hash, created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+`
+
+type CreateAPITokenParams struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	Name      string
+	TokenHash []byte
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) error {
+	_, err := q.db.Exec(ctx, createAPIToken,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.TokenHash,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createActivityEvent = `-- name: CreateActivityEvent :one
+INSERT INTO activity_events (
+  created_at, project_id, task_id, action, actor, request_id, before, after, user_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, created_at, project_id, task_id, action, actor, request_id, before, after, user_id
+`
+
+type CreateActivityEventParams struct {
+	CreatedAt pgtype.Timestamptz
+	ProjectID pgtype.UUID
+	TaskID    pgtype.UUID
+	Action    string
+	Actor     string
+	RequestID string
+	Before    []byte
+	After     []byte
+	UserID    pgtype.UUID
+}
+
+func (q *Queries) CreateActivityEvent(ctx context.Context, arg CreateActivityEventParams) (ActivityEvent, error) {
+	row := q.db.QueryRow(ctx, createActivityEvent,
+		arg.CreatedAt,
+		arg.ProjectID,
+		arg.TaskID,
+		arg.Action,
+		arg.Actor,
+		arg.RequestID,
+		arg.Before,
+		arg.After,
+		arg.UserID,
+	)
+	var i ActivityEvent
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Action,
+		&i.Actor,
+		&i.RequestID,
+		&i.Before,
+		&i.After,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const createIteration = `-- name: CreateIteration :exec
+INSERT INTO iterations (
+  id, name, starts_at, ends_at, created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+`
+
+type CreateIterationParams struct {
+	ID        pgtype.UUID
+	Name      string
+	StartsAt  pgtype.Timestamptz
+	EndsAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateIteration(ctx context.Context, arg CreateIterationParams) error {
+	_, err := q.db.Exec(ctx, createIteration,
+		arg.ID,
+		arg.Name,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createProject = `-- name: CreateProject :exec
 INSERT INTO projects (
-  id, name, created_at
+  id, name, created_at, description, color, icon, "order", estimate_unit, workspace_id,
+  parent_project_id
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 `
 
 type CreateProjectParams struct {
+	ID              pgtype.UUID
+	Name            string
+	CreatedAt       pgtype.Timestamptz
+	Description     string
+	Color           pgtype.Text
+	Icon            pgtype.Text
+	Order           int32
+	EstimateUnit    string
+	WorkspaceID     pgtype.UUID
+	ParentProjectID pgtype.UUID
+}
+
+func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) error {
+	_, err := q.db.Exec(ctx, createProject,
+		arg.ID,
+		arg.Name,
+		arg.CreatedAt,
+		arg.Description,
+		arg.Color,
+		arg.Icon,
+		arg.Order,
+		arg.EstimateUnit,
+		arg.WorkspaceID,
+		arg.ParentProjectID,
+	)
+	return err
+}
+
+const createSection = `-- name: CreateSection :exec
+INSERT INTO sections (
+  id, project_id, name, "order", created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+`
+
+type CreateSectionParams struct {
 	ID        pgtype.UUID
+	ProjectID pgtype.UUID
 	Name      string
-	CreatedAt pgtype.Timestamp
+	Order     int32
+	CreatedAt pgtype.Timestamptz
 }
 
-func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) error {
-	_, err := q.db.Exec(ctx, createProject, arg.ID, arg.Name, arg.CreatedAt)
+func (q *Queries) CreateSection(ctx context.Context, arg CreateSectionParams) error {
+	_, err := q.db.Exec(ctx, createSection,
+		arg.ID,
+		arg.ProjectID,
+		arg.Name,
+		arg.Order,
+		arg.CreatedAt,
+	)
 	return err
 }
 
 const createTask = `-- name: CreateTask :exec
 INSERT INTO tasks (
-  id, project_id, name, status, "order", parent_task_id, created_at
+  id, project_id, name, status, "order", parent_task_id, created_at, due_at, updated_at,
+  completed_at, estimate, iteration_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
 `
 
@@ -45,7 +432,12 @@ type CreateTaskParams struct {
 	Status       string
 	Order        int32
 	ParentTaskID pgtype.UUID
-	CreatedAt    pgtype.Timestamp
+	CreatedAt    pgtype.Timestamptz
+	DueAt        pgtype.Timestamptz
+	UpdatedAt    pgtype.Timestamptz
+	CompletedAt  pgtype.Timestamptz
+	Estimate     pgtype.Int4
+	IterationID  pgtype.UUID
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
@@ -57,80 +449,890 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
 		arg.Order,
 		arg.ParentTaskID,
 		arg.CreatedAt,
+		arg.DueAt,
+		arg.UpdatedAt,
+		arg.CompletedAt,
+		arg.Estimate,
+		arg.IterationID,
 	)
 	return err
 }
 
-const deleteProject = `-- name: DeleteProject :one
-DELETE FROM projects
-WHERE id = $1
-RETURNING id, created_at, name
+const createTaskRevision = `-- name: CreateTaskRevision :one
+WITH counter AS (
+  UPDATE tasks SET revision_count = revision_count + 1
+  WHERE id = $1::uuid
+  RETURNING revision_count
+)
+INSERT INTO task_revisions (
+  task_id, revision, created_at, action, state
+)
+SELECT
+  $1::uuid,
+  counter.revision_count,
+  $2::timestamptz,
+  $3::text,
+  $4::jsonb
+FROM counter
+RETURNING task_id, revision, created_at, action, state
 `
 
-func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (Project, error) {
-	row := q.db.QueryRow(ctx, deleteProject, id)
-	var i Project
-	err := row.Scan(&i.ID, &i.CreatedAt, &i.Name)
+type CreateTaskRevisionParams struct {
+	TaskID    pgtype.UUID
+	CreatedAt pgtype.Timestamptz
+	Action    string
+	State     []byte
+}
+
+// Revisions are numbered from 1 for each task. The number is taken from a counter on the task,
+// whose row lock keeps concurrent revisions of the same task from getting the same number.
+func (q *Queries) CreateTaskRevision(ctx context.Context, arg CreateTaskRevisionParams) (TaskRevision, error) {
+	row := q.db.QueryRow(ctx, createTaskRevision,
+		arg.TaskID,
+		arg.CreatedAt,
+		arg.Action,
+		arg.State,
+	)
+	var i TaskRevision
+	err := row.Scan(
+		&i.TaskID,
+		&i.Revision,
+		&i.CreatedAt,
+		&i.Action,
+		&i.State,
+	)
 	return i, err
 }
 
-const deleteTask = `-- name: DeleteTask :exec
-DELETE FROM tasks
-WHERE id = $1
+const createTemplate = `-- name: CreateTemplate :exec
+INSERT INTO templates (
+  id, name, created_at
+) VALUES (
+  $1, $2, $3
+)
 `
 
-func (q *Queries) DeleteTask(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteTask, id)
+type CreateTemplateParams struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams) error {
+	_, err := q.db.Exec(ctx, createTemplate, arg.ID, arg.Name, arg.CreatedAt)
 	return err
 }
 
-const getProject = `-- name: GetProject :one
-SELECT id, created_at, name FROM projects
-WHERE id = $1 LIMIT 1
+const createTemplateTask = `-- name: CreateTemplateTask :exec
+INSERT INTO template_tasks (
+  id, template_id, parent_template_task_id, name, "order", due_offset_days
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
 `
 
-func (q *Queries) GetProject(ctx context.Context, id pgtype.UUID) (Project, error) {
-	row := q.db.QueryRow(ctx, getProject, id)
-	var i Project
-	err := row.Scan(&i.ID, &i.CreatedAt, &i.Name)
-	return i, err
+type CreateTemplateTaskParams struct {
+	ID                   pgtype.UUID
+	TemplateID           pgtype.UUID
+	ParentTemplateTaskID pgtype.UUID
+	Name                 string
+	Order                int32
+	DueOffsetDays        pgtype.Int4
 }
 
-const getProjectByName = `-- name: GetProjectByName :one
-SELECT id, created_at, name FROM projects
-WHERE name = $1 LIMIT 1
+func (q *Queries) CreateTemplateTask(ctx context.Context, arg CreateTemplateTaskParams) error {
+	_, err := q.db.Exec(ctx, createTemplateTask,
+		arg.ID,
+		arg.TemplateID,
+		arg.ParentTemplateTaskID,
+		arg.Name,
+		arg.Order,
+		arg.DueOffsetDays,
+	)
+	return err
+}
+
+const createTimeEntry = `-- name: CreateTimeEntry :one
+INSERT INTO time_entries (
+  id, task_id, user_id, actor, started_at, ended_at, note, created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, task_id, actor, started_at, ended_at, note, created_at, user_id
 `
 
-func (q *Queries) GetProjectByName(ctx context.Context, name string) (Project, error) {
-	row := q.db.QueryRow(ctx, getProjectByName, name)
-	var i Project
-	err := row.Scan(&i.ID, &i.CreatedAt, &i.Name)
+type CreateTimeEntryParams struct {
+	ID        pgtype.UUID
+	TaskID    pgtype.UUID
+	UserID    pgtype.UUID
+	Actor     string
+	StartedAt pgtype.Timestamptz
+	EndedAt   pgtype.Timestamptz
+	Note      string
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, createTimeEntry,
+		arg.ID,
+		arg.TaskID,
+		arg.UserID,
+		arg.Actor,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.Note,
+		arg.CreatedAt,
+	)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Actor,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UserID,
+	)
 	return i, err
 }
 
-const getSubtasksDeep = `-- name: GetSubtasksDeep :many
-WITH RECURSIVE subtasks AS (
-  -- Base case: Direct children of the specified parent task
-  SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks ts
-  WHERE ts.parent_task_id = $1
+const createUser = `-- name: CreateUser :exec
+INSERT INTO users (
+  id, name, created_at
+) VALUES (
+  $1, $2, $3
+)
+`
 
-  UNION
+type CreateUserParams struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
 
-  -- Recursive step: For each found subtask, find its own children
-  SELECT t.id, t.created_at, t.parent_task_id, t.project_id, t.status, t."order", t.name FROM tasks t
-  INNER JOIN subtasks st ON t.parent_task_id = st.id
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
+	_, err := q.db.Exec(ctx, createUser, arg.ID, arg.Name, arg.CreatedAt)
+	return err
+}
+
+const createWorkspace = `-- name: CreateWorkspace :exec
+INSERT INTO workspaces (
+  id, name, created_at
+) VALUES (
+  $1, $2, $3
 )
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM subtasks
 `
 
-type GetSubtasksDeepRow struct {
-	ID           pgtype.UUID
-	CreatedAt    pgtype.Timestamp
-	ParentTaskID pgtype.UUID
-	ProjectID    pgtype.UUID
-	Status       string
-	Order        int32
-	Name         string
+type CreateWorkspaceParams struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) error {
+	_, err := q.db.Exec(ctx, createWorkspace, arg.ID, arg.Name, arg.CreatedAt)
+	return err
+}
+
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1
+`
+
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	_, err := q.db.Exec(ctx, deleteIdempotencyKey, key)
+	return err
+}
+
+const deleteIteration = `-- name: DeleteIteration :execrows
+DELETE FROM iterations
+WHERE id = $1
+`
+
+func (q *Queries) DeleteIteration(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteIteration, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProject = `-- name: DeleteProject :one
+DELETE FROM projects
+WHERE id = $1
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, deleteProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const deleteSection = `-- name: DeleteSection :one
+DELETE FROM sections
+WHERE id = $1
+RETURNING id, project_id, name, "order", created_at
+`
+
+func (q *Queries) DeleteSection(ctx context.Context, id pgtype.UUID) (Section, error) {
+	row := q.db.QueryRow(ctx, deleteSection, id)
+	var i Section
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Order,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteTask = `-- name: DeleteTask :exec
+DELETE FROM tasks
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTask(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTask, id)
+	return err
+}
+
+const deleteTemplate = `-- name: DeleteTemplate :one
+DELETE FROM templates
+WHERE id = $1
+RETURNING id, created_at, name
+`
+
+func (q *Queries) DeleteTemplate(ctx context.Context, id pgtype.UUID) (Template, error) {
+	row := q.db.QueryRow(ctx, deleteTemplate, id)
+	var i Template
+	err := row.Scan(&i.ID, &i.CreatedAt, &i.Name)
+	return i, err
+}
+
+const deleteTimeEntry = `-- name: DeleteTimeEntry :execrows
+DELETE FROM time_entries
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTimeEntry(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTimeEntry, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWorkspace = `-- name: DeleteWorkspace :one
+DELETE FROM workspaces
+WHERE id = $1
+RETURNING id, name, created_at
+`
+
+// Fails with a foreign key violation if the workspace still has projects, archived or not.
+func (q *Queries) DeleteWorkspace(ctx context.Context, id pgtype.UUID) (Workspace, error) {
+	row := q.db.QueryRow(ctx, deleteWorkspace, id)
+	var i Workspace
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const denyRefreshToken = `-- name: DenyRefreshToken :execrows
+INSERT INTO denied_refresh_tokens (
+  id, user_id, denied_at, expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (id) DO NOTHING
+`
+
+type DenyRefreshTokenParams struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	DeniedAt  pgtype.Timestamptz
+	ExpiresAt pgtype.Timestamptz
+}
+
+// Denies a refresh token until it expires. No row is inserted if the token was already denied.
+func (q *Queries) DenyRefreshToken(ctx context.Context, arg DenyRefreshTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, denyRefreshToken,
+		arg.ID,
+		arg.UserID,
+		arg.DeniedAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAPIToken = `-- name: GetAPIToken :one
+SELECT id, user_id, name, token_hash, created_at, last_used_at, revoked_at FROM api_tokens
+WHERE id = $1
+`
+
+func (q *Queries) GetAPIToken(ctx context.Context, id pgtype.UUID) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, getAPIToken, id)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getDeletedProject = `-- name: GetDeletedProject :one
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE id = $1 AND deleted_at IS NOT NULL LIMIT 1
+`
+
+func (q *Queries) GetDeletedProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, getDeletedProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const getDeletedTask = `-- name: GetDeletedTask :one
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE id = $1 AND deleted_at IS NOT NULL LIMIT 1
+`
+
+func (q *Queries) GetDeletedTask(ctx context.Context, id pgtype.UUID) (Task, error) {
+	row := q.db.QueryRow(ctx, getDeletedTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ParentTaskID,
+		&i.ProjectID,
+		&i.Status,
+		&i.Order,
+		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT key, fingerprint, created_at, expires_at, status_code, headers, body, locked_until FROM idempotency_keys
+WHERE key = $1
+`
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, key string) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.Fingerprint,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.StatusCode,
+		&i.Headers,
+		&i.Body,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const getIteration = `-- name: GetIteration :one
+SELECT id, name, starts_at, ends_at, created_at, closed_at, completed_tasks, completed_points, completed_minutes FROM iterations
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetIteration(ctx context.Context, id pgtype.UUID) (Iteration, error) {
+	row := q.db.QueryRow(ctx, getIteration, id)
+	var i Iteration
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.CompletedTasks,
+		&i.CompletedPoints,
+		&i.CompletedMinutes,
+	)
+	return i, err
+}
+
+const getNextIteration = `-- name: GetNextIteration :one
+SELECT id, name, starts_at, ends_at, created_at, closed_at, completed_tasks, completed_points, completed_minutes FROM iterations
+WHERE id <> $1::uuid AND starts_at > $2::timestamptz AND closed_at IS NULL
+ORDER BY starts_at, id
+LIMIT 1
+`
+
+type GetNextIterationParams struct {
+	ID       pgtype.UUID
+	StartsAt pgtype.Timestamptz
+}
+
+// Returns the first open iteration starting after the given one.
+func (q *Queries) GetNextIteration(ctx context.Context, arg GetNextIterationParams) (Iteration, error) {
+	row := q.db.QueryRow(ctx, getNextIteration, arg.ID, arg.StartsAt)
+	var i Iteration
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.CompletedTasks,
+		&i.CompletedPoints,
+		&i.CompletedMinutes,
+	)
+	return i, err
+}
+
+const getNextProjectOrder = `-- name: GetNextProjectOrder :one
+SELECT (coalesce(max("order"), -1) + 1)::integer AS next_order FROM projects
+WHERE deleted_at IS NULL
+`
+
+// Returns the position after the last project of the list.
+func (q *Queries) GetNextProjectOrder(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, getNextProjectOrder)
+	var nextOrder int32
+	err := row.Scan(&nextOrder)
+	return nextOrder, err
+}
+
+const getProject = `-- name: GetProject :one
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, getProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const getProjectByName = `-- name: GetProjectByName :one
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE name = $1::text AND workspace_id IS NOT DISTINCT FROM $2::uuid
+  AND deleted_at IS NULL
+ORDER BY archived_at DESC NULLS FIRST
+LIMIT 1
+`
+
+type GetProjectByNameParams struct {
+	Name        string
+	WorkspaceID pgtype.UUID
+}
+
+// Project names are unique within a workspace, or among the projects without one. Archived
+// projects may share their name with an active project, in which case the active one is returned.
+func (q *Queries) GetProjectByName(ctx context.Context, arg GetProjectByNameParams) (Project, error) {
+	row := q.db.QueryRow(ctx, getProjectByName, arg.Name, arg.WorkspaceID)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const getProjectCycleTime = `-- name: GetProjectCycleTime :one
+SELECT
+  count(*) AS completed,
+  coalesce(avg(extract(epoch FROM completed_at - created_at)), 0)::float8 AS average_seconds
+FROM tasks
+WHERE project_id = $1::uuid
+  AND deleted_at IS NULL
+  AND completed_at >= $2::timestamptz
+  AND completed_at < $3::timestamptz
+`
+
+type GetProjectCycleTimeParams struct {
+	ProjectID     pgtype.UUID
+	CompletedFrom pgtype.Timestamptz
+	CompletedTo   pgtype.Timestamptz
+}
+
+type GetProjectCycleTimeRow struct {
+	Completed      int64
+	AverageSeconds float64
+}
+
+// Averages the time from creation to completion of the tasks of a project completed from
+// completed_from (inclusive) to completed_to (exclusive).
+func (q *Queries) GetProjectCycleTime(ctx context.Context, arg GetProjectCycleTimeParams) (GetProjectCycleTimeRow, error) {
+	row := q.db.QueryRow(ctx, getProjectCycleTime, arg.ProjectID, arg.CompletedFrom, arg.CompletedTo)
+	var i GetProjectCycleTimeRow
+	err := row.Scan(&i.Completed, &i.AverageSeconds)
+	return i, err
+}
+
+const getProjectEstimateSummaries = `-- name: GetProjectEstimateSummaries :many
+WITH RECURSIVE estimated_tasks AS (
+  SELECT id, project_id, status, estimate, estimate IS NOT NULL AS covered
+  FROM tasks
+  WHERE project_id = ANY($1::uuid[]) AND parent_task_id IS NULL AND deleted_at IS NULL
+  UNION ALL
+  SELECT t.id, t.project_id, t.status, t.estimate, t.estimate IS NOT NULL
+  FROM tasks t
+  JOIN estimated_tasks p ON t.parent_task_id = p.id
+  WHERE NOT p.covered AND t.deleted_at IS NULL
+)
+SELECT
+  project_id,
+  sum(estimate)::bigint AS estimate,
+  (coalesce(sum(estimate) FILTER (WHERE status = 'pending'), 0))::bigint AS remaining
+FROM estimated_tasks
+WHERE estimate IS NOT NULL
+GROUP BY project_id
+`
+
+type GetProjectEstimateSummariesRow struct {
+	ProjectID pgtype.UUID
+	Estimate  int64
+	Remaining int64
+}
+
+// Sums the estimates of the tasks of each project, leaving out the tasks in the trash. The estimate
+// of a task stands for its whole subtree, so the estimates of the subtasks of an estimated task
+// are not counted. Since completing a task completes its subtasks, the remaining work is the sum
+// of the estimates of the pending tasks. Projects without estimated tasks have no row.
+func (q *Queries) GetProjectEstimateSummaries(ctx context.Context, projectIds []pgtype.UUID) ([]GetProjectEstimateSummariesRow, error) {
+	rows, err := q.db.Query(ctx, getProjectEstimateSummaries, projectIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProjectEstimateSummariesRow
+	for rows.Next() {
+		var i GetProjectEstimateSummariesRow
+		if err := rows.Scan(&i.ProjectID, &i.Estimate, &i.Remaining); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectTaskSeries = `-- name: GetProjectTaskSeries :many
+SELECT
+  b.bucket_start::timestamptz AS bucket_start,
+  b.bucket_end::timestamptz AS bucket_end,
+  count(t.id) FILTER (
+    WHERE t.created_at >= b.bucket_start AND t.created_at < b.bucket_end
+  ) AS created,
+  count(t.id) FILTER (
+    WHERE t.completed_at >= b.bucket_start AND t.completed_at < b.bucket_end
+  ) AS completed,
+  count(t.id) FILTER (
+    WHERE t.created_at < b.bucket_end AND (t.completed_at IS NULL OR t.completed_at >= b.bucket_end)
+  ) AS open
+FROM unnest($1::timestamptz[], $2::timestamptz[]) AS b(bucket_start, bucket_end)
+LEFT JOIN tasks t ON t.project_id = $3::uuid AND t.deleted_at IS NULL
+GROUP BY b.bucket_start, b.bucket_end
+ORDER BY b.bucket_start
+`
+
+type GetProjectTaskSeriesParams struct {
+	BucketStarts []pgtype.Timestamptz
+	BucketEnds   []pgtype.Timestamptz
+	ProjectID    pgtype.UUID
+}
+
+type GetProjectTaskSeriesRow struct {
+	BucketStart pgtype.Timestamptz
+	BucketEnd   pgtype.Timestamptz
+	Created     int64
+	Completed   int64
+	Open        int64
+}
+
+// Counts, for each period given by its start and end, the tasks of a project created and completed
+// in the period, and the tasks still open at its end. Tasks in the trash are left out.
+func (q *Queries) GetProjectTaskSeries(ctx context.Context, arg GetProjectTaskSeriesParams) ([]GetProjectTaskSeriesRow, error) {
+	rows, err := q.db.Query(ctx, getProjectTaskSeries, arg.BucketStarts, arg.BucketEnds, arg.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProjectTaskSeriesRow
+	for rows.Next() {
+		var i GetProjectTaskSeriesRow
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.BucketEnd,
+			&i.Created,
+			&i.Completed,
+			&i.Open,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectTaskSummaries = `-- name: GetProjectTaskSummaries :many
+SELECT
+  project_id,
+  count(*) AS total,
+  count(*) FILTER (WHERE status = 'pending') AS pending,
+  count(*) FILTER (WHERE status = 'completed') AS completed,
+  count(*) FILTER (WHERE parent_task_id IS NULL) AS root
+FROM tasks
+WHERE project_id = ANY($1::uuid[]) AND deleted_at IS NULL
+GROUP BY project_id
+`
+
+type GetProjectTaskSummariesRow struct {
+	ProjectID pgtype.UUID
+	Total     int64
+	Pending   int64
+	Completed int64
+	Root      int64
+}
+
+// Counts the tasks of each project, leaving out the tasks in the trash. Projects without tasks
+// have no row.
+func (q *Queries) GetProjectTaskSummaries(ctx context.Context, projectIds []pgtype.UUID) ([]GetProjectTaskSummariesRow, error) {
+	rows, err := q.db.Query(ctx, getProjectTaskSummaries, projectIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProjectTaskSummariesRow
+	for rows.Next() {
+		var i GetProjectTaskSummariesRow
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.Total,
+			&i.Pending,
+			&i.Completed,
+			&i.Root,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectTimesheet = `-- name: GetProjectTimesheet :many
+SELECT
+  e.task_id,
+  t.name AS task_name,
+  e.user_id,
+  coalesce(u.name, e.actor)::text AS actor,
+  sum(extract(epoch FROM
+    least(coalesce(e.ended_at, now()), $1::timestamptz) - greatest(e.started_at, $2::timestamptz)
+  ))::float8 AS seconds
+FROM time_entries e
+INNER JOIN tasks t ON t.id = e.task_id
+LEFT JOIN users u ON u.id = e.user_id
+WHERE t.project_id = $3::uuid
+  AND t.deleted_at IS NULL
+  AND e.started_at < $1::timestamptz
+  AND coalesce(e.ended_at, now()) > $2::timestamptz
+GROUP BY e.task_id, t.name, e.user_id, coalesce(u.name, e.actor)
+ORDER BY t.name, e.task_id, coalesce(u.name, e.actor), e.user_id
+`
+
+type GetProjectTimesheetParams struct {
+	PeriodTo   pgtype.Timestamptz
+	PeriodFrom pgtype.Timestamptz
+	ProjectID  pgtype.UUID
+}
+
+type GetProjectTimesheetRow struct {
+	TaskID   pgtype.UUID
+	TaskName string
+	UserID   pgtype.UUID
+	Actor    string
+	Seconds  float64
+}
+
+// Sums the time tracked by each user on each task of a project from period_from (inclusive) to
+// period_to (exclusive). Entries overlapping the period only count for their part in it, and
+// running timers count until now. Tasks in the trash are left out. Users are named by their
+// current name, entries of no user by the actor they were made by.
+func (q *Queries) GetProjectTimesheet(ctx context.Context, arg GetProjectTimesheetParams) ([]GetProjectTimesheetRow, error) {
+	rows, err := q.db.Query(ctx, getProjectTimesheet, arg.PeriodTo, arg.PeriodFrom, arg.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProjectTimesheetRow
+	for rows.Next() {
+		var i GetProjectTimesheetRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.TaskName,
+			&i.UserID,
+			&i.Actor,
+			&i.Seconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRunningTimeEntry = `-- name: GetRunningTimeEntry :one
+SELECT id, task_id, actor, started_at, ended_at, note, created_at, user_id FROM time_entries
+WHERE user_id = $1 AND ended_at IS NULL
+`
+
+func (q *Queries) GetRunningTimeEntry(ctx context.Context, userID pgtype.UUID) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, getRunningTimeEntry, userID)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Actor,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const getSection = `-- name: GetSection :one
+SELECT id, project_id, name, "order", created_at FROM sections
+WHERE id = $1
+`
+
+func (q *Queries) GetSection(ctx context.Context, id pgtype.UUID) (Section, error) {
+	row := q.db.QueryRow(ctx, getSection, id)
+	var i Section
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Order,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSubtasksDeep = `-- name: GetSubtasksDeep :many
+WITH RECURSIVE subtasks AS (
+  -- Base case: Direct children of the specified parent task
+  SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks ts
+  WHERE ts.parent_task_id = $1 AND ts.deleted_at IS NULL
+
+  UNION
+
+  -- Recursive step: For each found subtask, find its own children
+  SELECT t.id, t.created_at, t.parent_task_id, t.project_id, t.status, t."order", t.name, t.due_at, t.deleted_at, t.version, t.updated_at, t.completed_at, t.estimate, t.iteration_id, t.section_id, t.revision_count FROM tasks t
+  INNER JOIN subtasks st ON t.parent_task_id = st.id
+  WHERE t.deleted_at IS NULL
+)
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM subtasks
+`
+
+type GetSubtasksDeepRow struct {
+	ID            pgtype.UUID
+	CreatedAt     pgtype.Timestamptz
+	ParentTaskID  pgtype.UUID
+	ProjectID     pgtype.UUID
+	Status        string
+	Order         int32
+	Name          string
+	DueAt         pgtype.Timestamptz
+	DeletedAt     pgtype.Timestamptz
+	Version       int32
+	UpdatedAt     pgtype.Timestamptz
+	CompletedAt   pgtype.Timestamptz
+	Estimate      pgtype.Int4
+	IterationID   pgtype.UUID
+	SectionID     pgtype.UUID
+	RevisionCount int32
 }
 
 func (q *Queries) GetSubtasksDeep(ctx context.Context, parentTaskID pgtype.UUID) ([]GetSubtasksDeepRow, error) {
@@ -139,18 +1341,1433 @@ func (q *Queries) GetSubtasksDeep(ctx context.Context, parentTaskID pgtype.UUID)
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetSubtasksDeepRow
+	var items []GetSubtasksDeepRow
+	for rows.Next() {
+		var i GetSubtasksDeepRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSubtasksDirect = `-- name: GetSubtasksDirect :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE parent_task_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetSubtasksDirect(ctx context.Context, parentTaskID pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getSubtasksDirect, parentTaskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTask = `-- name: GetTask :one
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetTask(ctx context.Context, id pgtype.UUID) (Task, error) {
+	row := q.db.QueryRow(ctx, getTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ParentTaskID,
+		&i.ProjectID,
+		&i.Status,
+		&i.Order,
+		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const getTaskRevision = `-- name: GetTaskRevision :one
+SELECT task_id, revision, created_at, action, state FROM task_revisions
+WHERE task_id = $1 AND revision = $2
+`
+
+type GetTaskRevisionParams struct {
+	TaskID   pgtype.UUID
+	Revision int32
+}
+
+func (q *Queries) GetTaskRevision(ctx context.Context, arg GetTaskRevisionParams) (TaskRevision, error) {
+	row := q.db.QueryRow(ctx, getTaskRevision, arg.TaskID, arg.Revision)
+	var i TaskRevision
+	err := row.Scan(
+		&i.TaskID,
+		&i.Revision,
+		&i.CreatedAt,
+		&i.Action,
+		&i.State,
+	)
+	return i, err
+}
+
+const getTaskTimeTotals = `-- name: GetTaskTimeTotals :one
+WITH RECURSIVE subtree AS (
+  SELECT ts.id FROM tasks ts
+  WHERE ts.id = $1::uuid AND ts.deleted_at IS NULL
+
+  UNION
+
+  SELECT t.id FROM tasks t
+  INNER JOIN subtree st ON t.parent_task_id = st.id
+  WHERE t.deleted_at IS NULL
+)
+SELECT
+  coalesce(sum(extract(epoch FROM coalesce(e.ended_at, now()) - e.started_at)) FILTER (
+    WHERE e.task_id = $1::uuid
+  ), 0)::float8 AS own_seconds,
+  coalesce(sum(extract(epoch FROM coalesce(e.ended_at, now()) - e.started_at)), 0)::float8 AS total_seconds
+FROM time_entries e
+WHERE e.task_id IN (SELECT id FROM subtree)
+`
+
+type GetTaskTimeTotalsRow struct {
+	OwnSeconds   float64
+	TotalSeconds float64
+}
+
+// Sums the time tracked on a task, and on the task along with all of its subtasks. Running timers
+// count until now.
+func (q *Queries) GetTaskTimeTotals(ctx context.Context, taskID pgtype.UUID) (GetTaskTimeTotalsRow, error) {
+	row := q.db.QueryRow(ctx, getTaskTimeTotals, taskID)
+	var i GetTaskTimeTotalsRow
+	err := row.Scan(&i.OwnSeconds, &i.TotalSeconds)
+	return i, err
+}
+
+const getTasksByProject = `-- name: GetTasksByProject :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE project_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetTasksByProject(ctx context.Context, projectID pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksByProject, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksByStatus = `-- name: GetTasksByStatus :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE project_id = $1 AND status = $2 AND deleted_at IS NULL
+`
+
+type GetTasksByStatusParams struct {
+	ProjectID pgtype.UUID
+	Status    string
+}
+
+func (q *Queries) GetTasksByStatus(ctx context.Context, arg GetTasksByStatusParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksByStatus, arg.ProjectID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksInProjectRoot = `-- name: GetTasksInProjectRoot :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE project_id = $1 AND parent_task_id IS NULL AND deleted_at IS NULL
+`
+
+func (q *Queries) GetTasksInProjectRoot(ctx context.Context, projectID pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksInProjectRoot, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksInSection = `-- name: GetTasksInSection :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE project_id = $1::uuid
+  AND parent_task_id IS NULL
+  AND section_id IS NOT DISTINCT FROM $2::uuid
+  AND deleted_at IS NULL
+`
+
+type GetTasksInSectionParams struct {
+	ProjectID pgtype.UUID
+	SectionID pgtype.UUID
+}
+
+// Lists the root tasks of a project in a section or, if section_id is null, in no section.
+func (q *Queries) GetTasksInSection(ctx context.Context, arg GetTasksInSectionParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksInSection, arg.ProjectID, arg.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemplate = `-- name: GetTemplate :one
+SELECT id, created_at, name FROM templates
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTemplate(ctx context.Context, id pgtype.UUID) (Template, error) {
+	row := q.db.QueryRow(ctx, getTemplate, id)
+	var i Template
+	err := row.Scan(&i.ID, &i.CreatedAt, &i.Name)
+	return i, err
+}
+
+const getTemplateTasks = `-- name: GetTemplateTasks :many
+SELECT id, template_id, parent_template_task_id, name, "order", due_offset_days FROM template_tasks
+WHERE template_id = $1
+ORDER BY "order"
+`
+
+func (q *Queries) GetTemplateTasks(ctx context.Context, templateID pgtype.UUID) ([]TemplateTask, error) {
+	rows, err := q.db.Query(ctx, getTemplateTasks, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateTask
+	for rows.Next() {
+		var i TemplateTask
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.ParentTemplateTaskID,
+			&i.Name,
+			&i.Order,
+			&i.DueOffsetDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTimeEntry = `-- name: GetTimeEntry :one
+SELECT id, task_id, actor, started_at, ended_at, note, created_at, user_id FROM time_entries
+WHERE id = $1
+`
+
+func (q *Queries) GetTimeEntry(ctx context.Context, id pgtype.UUID) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, getTimeEntry, id)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Actor,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, created_at FROM users
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getWorkspace = `-- name: GetWorkspace :one
+SELECT id, name, created_at FROM workspaces
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWorkspace(ctx context.Context, id pgtype.UUID) (Workspace, error) {
+	row := q.db.QueryRow(ctx, getWorkspace, id)
+	var i Workspace
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const listCompletedTasks = `-- name: ListCompletedTasks :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE deleted_at IS NULL
+  AND completed_at >= $1::timestamptz
+  AND completed_at < $2::timestamptz
+ORDER BY completed_at DESC, id
+`
+
+type ListCompletedTasksParams struct {
+	CompletedFrom pgtype.Timestamptz
+	CompletedTo   pgtype.Timestamptz
+}
+
+// Lists the tasks completed from completed_from (inclusive) to completed_to (exclusive), most
+// recently completed first.
+func (q *Queries) ListCompletedTasks(ctx context.Context, arg ListCompletedTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listCompletedTasks, arg.CompletedFrom, arg.CompletedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedProjects = `-- name: ListDeletedProjects :many
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) ListDeletedProjects(ctx context.Context) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listDeletedProjects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Order,
+			&i.EstimateUnit,
+			&i.WorkspaceID,
+			&i.ParentProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedTasks = `-- name: ListDeletedTasks :many
+SELECT t.id, t.created_at, t.parent_task_id, t.project_id, t.status, t."order", t.name, t.due_at, t.deleted_at, t.version, t.updated_at, t.completed_at, t.estimate, t.iteration_id, t.section_id, t.revision_count FROM tasks t
+INNER JOIN projects p ON p.id = t.project_id
+LEFT JOIN tasks parent ON parent.id = t.parent_task_id
+WHERE t.deleted_at IS NOT NULL
+  AND (p.deleted_at IS NULL OR p.deleted_at <> t.deleted_at)
+  AND (parent.deleted_at IS NULL OR parent.deleted_at <> t.deleted_at)
+ORDER BY t.deleted_at DESC
+`
+
+// Only the tasks that were deleted on their own are listed: tasks deleted
+// together with their parent task or project are restored with it.
+func (q *Queries) ListDeletedTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listDeletedTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIterationTasks = `-- name: ListIterationTasks :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE iteration_id = $1 AND deleted_at IS NULL
+ORDER BY status DESC, project_id, created_at, id
+`
+
+// Lists the tasks of an iteration, from every project, pending ones first. Tasks in the trash are
+// left out.
+func (q *Queries) ListIterationTasks(ctx context.Context, iterationID pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listIterationTasks, iterationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIterations = `-- name: ListIterations :many
+SELECT id, name, starts_at, ends_at, created_at, closed_at, completed_tasks, completed_points, completed_minutes FROM iterations
+ORDER BY starts_at, id
+`
+
+func (q *Queries) ListIterations(ctx context.Context) ([]Iteration, error) {
+	rows, err := q.db.Query(ctx, listIterations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Iteration
+	for rows.Next() {
+		var i Iteration
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+			&i.ClosedAt,
+			&i.CompletedTasks,
+			&i.CompletedPoints,
+			&i.CompletedMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOldestOpenTasks = `-- name: ListOldestOpenTasks :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE project_id = $1::uuid AND status = 'pending' AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT $2::integer
+`
+
+type ListOldestOpenTasksParams struct {
+	ProjectID pgtype.UUID
+	MaxTasks  int32
+}
+
+func (q *Queries) ListOldestOpenTasks(ctx context.Context, arg ListOldestOpenTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listOldestOpenTasks, arg.ProjectID, arg.MaxTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectActivity = `-- name: ListProjectActivity :many
+SELECT id, created_at, project_id, task_id, action, actor, request_id, before, after, user_id FROM activity_events
+WHERE project_id = $1::uuid AND id < $2::bigint
+ORDER BY id DESC
+LIMIT $3::integer
+`
+
+type ListProjectActivityParams struct {
+	ProjectID pgtype.UUID
+	BeforeID  int64
+	MaxEvents int32
+}
+
+// Lists the events of a project and of its tasks, newest first. Only the events with an ID
+// lower than before_id are listed, which lets callers page through the history.
+func (q *Queries) ListProjectActivity(ctx context.Context, arg ListProjectActivityParams) ([]ActivityEvent, error) {
+	rows, err := q.db.Query(ctx, listProjectActivity, arg.ProjectID, arg.BeforeID, arg.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ActivityEvent
+	for rows.Next() {
+		var i ActivityEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.TaskID,
+			&i.Action,
+			&i.Actor,
+			&i.RequestID,
+			&i.Before,
+			&i.After,
+			&i.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectCompletedTasks = `-- name: ListProjectCompletedTasks :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE project_id = $1::uuid
+  AND deleted_at IS NULL
+  AND completed_at >= $2::timestamptz
+  AND completed_at < $3::timestamptz
+ORDER BY completed_at DESC, id
+`
+
+type ListProjectCompletedTasksParams struct {
+	ProjectID     pgtype.UUID
+	CompletedFrom pgtype.Timestamptz
+	CompletedTo   pgtype.Timestamptz
+}
+
+// Lists the tasks of a project completed from completed_from (inclusive) to completed_to
+// (exclusive), most recently completed first.
+func (q *Queries) ListProjectCompletedTasks(ctx context.Context, arg ListProjectCompletedTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listProjectCompletedTasks, arg.ProjectID, arg.CompletedFrom, arg.CompletedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectTree = `-- name: ListProjectTree :many
+WITH RECURSIVE tree AS (
+  SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+  WHERE projects.id = $1::uuid AND projects.deleted_at IS NULL
+  UNION ALL
+  SELECT p.id, p.created_at, p.name, p.deleted_at, p.archived_at, p.version, p.description, p.color, p.icon, p."order", p.estimate_unit, p.workspace_id, p.parent_project_id FROM projects p
+  JOIN tree t ON p.parent_project_id = t.id
+  WHERE p.deleted_at IS NULL
+)
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM tree
+`
+
+// Lists a project and all of its sub-projects, at any depth.
+func (q *Queries) ListProjectTree(ctx context.Context, id pgtype.UUID) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectTree, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Order,
+			&i.EstimateUnit,
+			&i.WorkspaceID,
+			&i.ParentProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjects = `-- name: ListProjects :many
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE deleted_at IS NULL
+  AND ($1::boolean OR archived_at IS NULL)
+ORDER BY "order", name, id
+`
+
+func (q *Queries) ListProjects(ctx context.Context, includeArchived bool) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjects, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Order,
+			&i.EstimateUnit,
+			&i.WorkspaceID,
+			&i.ParentProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectsPage = `-- name: ListProjectsPage :many
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE deleted_at IS NULL
+  AND ($1::boolean OR archived_at IS NULL)
+  AND (NOT $2::boolean OR workspace_id = $3::uuid)
+  AND (NOT $4::boolean OR CASE
+    WHEN $5::text = 'created_at' AND $6::boolean
+      THEN (created_at, id) < ($7::timestamptz, $8::uuid)
+    WHEN $5::text = 'created_at'
+      THEN (created_at, id) > ($7::timestamptz, $8::uuid)
+    WHEN $5::text = 'order' AND $6::boolean
+      THEN ("order", id) < ($9::integer, $8::uuid)
+    WHEN $5::text = 'order'
+      THEN ("order", id) > ($9::integer, $8::uuid)
+    WHEN $6::boolean
+      THEN (name, id) < ($10::text, $8::uuid)
+    ELSE (name, id) > ($10::text, $8::uuid)
+  END)
+ORDER BY
+  CASE WHEN $5::text = 'created_at' AND NOT $6::boolean THEN created_at END ASC,
+  CASE WHEN $5::text = 'created_at' AND $6::boolean THEN created_at END DESC,
+  CASE WHEN $5::text = 'name' AND NOT $6::boolean THEN name END ASC,
+  CASE WHEN $5::text = 'name' AND $6::boolean THEN name END DESC,
+  CASE WHEN $5::text = 'order' AND NOT $6::boolean THEN "order" END ASC,
+  CASE WHEN $5::text = 'order' AND $6::boolean THEN "order" END DESC,
+  CASE WHEN $6::boolean THEN id END DESC,
+  id ASC
+LIMIT $11::integer
+`
+
+type ListProjectsPageParams struct {
+	IncludeArchived bool
+	FilterWorkspace bool
+	WorkspaceID     pgtype.UUID
+	HasCursor       bool
+	SortBy          string
+	Descending      bool
+	AfterCreatedAt  pgtype.Timestamptz
+	AfterID         pgtype.UUID
+	AfterOrder      int32
+	AfterText       string
+	MaxProjects     int32
+}
+
+// Lists a page of projects, sorted by sort_by ('name', 'created_at' or 'order') and then by ID. With
+// has_cursor, only the projects after the last one of the previous page, given by its sort key
+// and ID, are listed.
+func (q *Queries) ListProjectsPage(ctx context.Context, arg ListProjectsPageParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsPage,
+		arg.IncludeArchived,
+		arg.FilterWorkspace,
+		arg.WorkspaceID,
+		arg.HasCursor,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.AfterOrder,
+		arg.AfterText,
+		arg.MaxProjects,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Order,
+			&i.EstimateUnit,
+			&i.WorkspaceID,
+			&i.ParentProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSections = `-- name: ListSections :many
+SELECT id, project_id, name, "order", created_at FROM sections
+WHERE project_id = $1
+ORDER BY "order", id
+`
+
+func (q *Queries) ListSections(ctx context.Context, projectID pgtype.UUID) ([]Section, error) {
+	rows, err := q.db.Query(ctx, listSections, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Section
+	for rows.Next() {
+		var i Section
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Name,
+			&i.Order,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskActivity = `-- name: ListTaskActivity :many
+SELECT id, created_at, project_id, task_id, action, actor, request_id, before, after, user_id FROM activity_events
+WHERE task_id = $1::uuid AND id < $2::bigint
+ORDER BY id DESC
+LIMIT $3::integer
+`
+
+type ListTaskActivityParams struct {
+	TaskID    pgtype.UUID
+	BeforeID  int64
+	MaxEvents int32
+}
+
+// Lists the events of a task, newest first. Only the events with an ID lower than before_id
+// are listed.
+func (q *Queries) ListTaskActivity(ctx context.Context, arg ListTaskActivityParams) ([]ActivityEvent, error) {
+	rows, err := q.db.Query(ctx, listTaskActivity, arg.TaskID, arg.BeforeID, arg.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ActivityEvent
+	for rows.Next() {
+		var i ActivityEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.TaskID,
+			&i.Action,
+			&i.Actor,
+			&i.RequestID,
+			&i.Before,
+			&i.After,
+			&i.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskRevisions = `-- name: ListTaskRevisions :many
+SELECT task_id, revision, created_at, action, state FROM task_revisions
+WHERE task_id = $1
+ORDER BY revision
+`
+
+func (q *Queries) ListTaskRevisions(ctx context.Context, taskID pgtype.UUID) ([]TaskRevision, error) {
+	rows, err := q.db.Query(ctx, listTaskRevisions, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskRevision
+	for rows.Next() {
+		var i TaskRevision
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Revision,
+			&i.CreatedAt,
+			&i.Action,
+			&i.State,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskTimeEntries = `-- name: ListTaskTimeEntries :many
+SELECT id, task_id, actor, started_at, ended_at, note, created_at, user_id FROM time_entries
+WHERE task_id = $1
+ORDER BY started_at DESC, id
+`
+
+func (q *Queries) ListTaskTimeEntries(ctx context.Context, taskID pgtype.UUID) ([]TimeEntry, error) {
+	rows, err := q.db.Query(ctx, listTaskTimeEntries, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TimeEntry
+	for rows.Next() {
+		var i TimeEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Actor,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Note,
+			&i.CreatedAt,
+			&i.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasks = `-- name: ListTasks :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE deleted_at IS NULL
+ORDER BY project_id
+`
+
+func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksPage = `-- name: ListTasksPage :many
+SELECT id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count FROM tasks
+WHERE deleted_at IS NULL
+  AND (NOT $1::boolean OR project_id = $2::uuid)
+  AND (NOT $3::boolean OR parent_task_id = $4::uuid)
+  AND (NOT $5::boolean OR parent_task_id IS NULL)
+  AND (NOT $6::boolean OR section_id = $7::uuid)
+  AND ($8::text = '' OR status = $8::text)
+  AND (NOT $9::boolean OR CASE
+    WHEN $10::text = 'name' AND $11::boolean
+      THEN (name, id) < ($12::text, $13::uuid)
+    WHEN $10::text = 'name'
+      THEN (name, id) > ($12::text, $13::uuid)
+    WHEN $10::text = 'status' AND $11::boolean
+      THEN (status, id) < ($12::text, $13::uuid)
+    WHEN $10::text = 'status'
+      THEN (status, id) > ($12::text, $13::uuid)
+    WHEN $10::text = 'order' AND $11::boolean
+      THEN ("order", id) < ($14::integer, $13::uuid)
+    WHEN $10::text = 'order'
+      THEN ("order", id) > ($14::integer, $13::uuid)
+    WHEN $11::boolean
+      THEN (created_at, id) < ($15::timestamptz, $13::uuid)
+    ELSE (created_at, id) > ($15::timestamptz, $13::uuid)
+  END)
+ORDER BY
+  CASE WHEN $10::text = 'name' AND NOT $11::boolean THEN name END ASC,
+  CASE WHEN $10::text = 'name' AND $11::boolean THEN name END DESC,
+  CASE WHEN $10::text = 'status' AND NOT $11::boolean THEN status END ASC,
+  CASE WHEN $10::text = 'status' AND $11::boolean THEN status END DESC,
+  CASE WHEN $10::text = 'order' AND NOT $11::boolean THEN "order" END ASC,
+  CASE WHEN $10::text = 'order' AND $11::boolean THEN "order" END DESC,
+  CASE WHEN $10::text = 'created_at' AND NOT $11::boolean THEN created_at END ASC,
+  CASE WHEN $10::text = 'created_at' AND $11::boolean THEN created_at END DESC,
+  CASE WHEN $11::boolean THEN id END DESC,
+  id ASC
+LIMIT $16::integer
+`
+
+type ListTasksPageParams struct {
+	FilterProject  bool
+	ProjectID      pgtype.UUID
+	FilterParent   bool
+	ParentTaskID   pgtype.UUID
+	RootOnly       bool
+	FilterSection  bool
+	SectionID      pgtype.UUID
+	Status         string
+	HasCursor      bool
+	SortBy         string
+	Descending     bool
+	AfterText      string
+	AfterID        pgtype.UUID
+	AfterOrder     int32
+	AfterCreatedAt pgtype.Timestamptz
+	MaxTasks       int32
+}
+
+// Lists a page of tasks, sorted by sort_by ('created_at', 'name', 'order' or 'status') and then
+// by ID. With has_cursor, only the tasks after the last one of the previous page, given by its
+// sort key and ID, are listed. Each filter only applies if its flag is set.
+func (q *Queries) ListTasksPage(ctx context.Context, arg ListTasksPageParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasksPage,
+		arg.FilterProject,
+		arg.ProjectID,
+		arg.FilterParent,
+		arg.ParentTaskID,
+		arg.RootOnly,
+		arg.FilterSection,
+		arg.SectionID,
+		arg.Status,
+		arg.HasCursor,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterText,
+		arg.AfterID,
+		arg.AfterOrder,
+		arg.AfterCreatedAt,
+		arg.MaxTasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ParentTaskID,
+			&i.ProjectID,
+			&i.Status,
+			&i.Order,
+			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTemplates = `-- name: ListTemplates :many
+SELECT id, created_at, name FROM templates
+ORDER BY name
+`
+
+func (q *Queries) ListTemplates(ctx context.Context) ([]Template, error) {
+	rows, err := q.db.Query(ctx, listTemplates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Template
+	for rows.Next() {
+		var i Template
+		if err := rows.Scan(&i.ID, &i.CreatedAt, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserAPITokens = `-- name: ListUserAPITokens :many
+SELECT id, user_id, name, token_hash, created_at, last_used_at, revoked_at FROM api_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+`
+
+// Lists the tokens of a user, revoked or not, newest first. The hashes are listed too, but must not
+// be disclosed.
+func (q *Queries) ListUserAPITokens(ctx context.Context, userID pgtype.UUID) ([]ApiToken, error) {
+	rows, err := q.db.Query(ctx, listUserAPITokens, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiToken
+	for rows.Next() {
+		var i ApiToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.TokenHash,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, created_at FROM users
+ORDER BY name, id
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspaceProjects = `-- name: ListWorkspaceProjects :many
+SELECT id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id FROM projects
+WHERE workspace_id = $1 AND deleted_at IS NULL
+ORDER BY "order", name, id
+`
+
+// Lists the projects of a workspace, archived or not, leaving out the ones in the trash.
+func (q *Queries) ListWorkspaceProjects(ctx context.Context, workspaceID pgtype.UUID) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listWorkspaceProjects, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Order,
+			&i.EstimateUnit,
+			&i.WorkspaceID,
+			&i.ParentProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspaces = `-- name: ListWorkspaces :many
+SELECT id, name, created_at FROM workspaces
+ORDER BY name, id
+`
+
+func (q *Queries) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	rows, err := q.db.Query(ctx, listWorkspaces)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Workspace
 	for rows.Next() {
-		var i GetSubtasksDeepRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.CreatedAt,
-			&i.ParentTaskID,
-			&i.ProjectID,
-			&i.Status,
-			&i.Order,
-			&i.Name,
-		); err != nil {
+		var i Workspace
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -161,28 +2778,133 @@ func (q *Queries) GetSubtasksDeep(ctx context.Context, parentTaskID pgtype.UUID)
 	return items, nil
 }
 
-const getSubtasksDirect = `-- name: GetSubtasksDirect :many
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks
-WHERE parent_task_id = $1
+const lockProjectHierarchy = `-- name: LockProjectHierarchy :exec
+SELECT pg_advisory_xact_lock(hashtextextended('project-hierarchy', 0))
 `
 
-func (q *Queries) GetSubtasksDirect(ctx context.Context, parentTaskID pgtype.UUID) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getSubtasksDirect, parentTaskID)
+// Locks the hierarchy of the projects until the end of the transaction, so that two projects cannot
+// be made sub-projects of each other at the same time.
+func (q *Queries) LockProjectHierarchy(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockProjectHierarchy)
+	return err
+}
+
+const lockProjectName = `-- name: LockProjectName :exec
+SELECT pg_advisory_xact_lock(hashtextextended(COALESCE($1::uuid::text, '') || '/' || $2::text, 0))
+`
+
+type LockProjectNameParams struct {
+	WorkspaceID pgtype.UUID
+	Name        string
+}
+
+// Locks a project name of a workspace until the end of the transaction, so that two projects
+// cannot check that the name is free and take it at the same time.
+func (q *Queries) LockProjectName(ctx context.Context, arg LockProjectNameParams) error {
+	_, err := q.db.Exec(ctx, lockProjectName, arg.WorkspaceID, arg.Name)
+	return err
+}
+
+const lockProjectOrder = `-- name: LockProjectOrder :exec
+SELECT pg_advisory_xact_lock(hashtextextended('project-order', 0))
+`
+
+// Locks the order of the projects until the end of the transaction, so that two projects cannot
+// be put at the end of the list at the same time.
+func (q *Queries) LockProjectOrder(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockProjectOrder)
+	return err
+}
+
+const lockProjectVersion = `-- name: LockProjectVersion :execrows
+SELECT id FROM projects
+WHERE id = $1::uuid AND version = $2::integer AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type LockProjectVersionParams struct {
+	ID              pgtype.UUID
+	ExpectedVersion int32
+}
+
+// Locks a project until the end of the transaction, provided it still has the expected version.
+func (q *Queries) LockProjectVersion(ctx context.Context, arg LockProjectVersionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockProjectVersion, arg.ID, arg.ExpectedVersion)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const lockTaskVersion = `-- name: LockTaskVersion :execrows
+SELECT id FROM tasks
+WHERE id = $1::uuid AND version = $2::integer AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type LockTaskVersionParams struct {
+	ID              pgtype.UUID
+	ExpectedVersion int32
+}
+
+// Locks a task until the end of the transaction, provided it still has the expected version.
+func (q *Queries) LockTaskVersion(ctx context.Context, arg LockTaskVersionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockTaskVersion, arg.ID, arg.ExpectedVersion)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveProjectTreeToWorkspace = `-- name: MoveProjectTreeToWorkspace :many
+WITH RECURSIVE tree AS (
+  SELECT projects.id FROM projects
+  WHERE projects.id = $1::uuid AND projects.deleted_at IS NULL
+  UNION ALL
+  SELECT p.id FROM projects p
+  JOIN tree t ON p.parent_project_id = t.id
+  WHERE p.deleted_at IS NULL
+)
+UPDATE projects
+SET
+  workspace_id = $2::uuid,
+  parent_project_id = CASE WHEN projects.id = $1::uuid THEN NULL ELSE projects.parent_project_id END,
+  version = projects.version + 1
+FROM tree
+WHERE projects.id = tree.id
+RETURNING projects.id, projects.created_at, projects.name, projects.deleted_at, projects.archived_at, projects.version, projects.description, projects.color, projects.icon, projects."order", projects.estimate_unit, projects.workspace_id, projects.parent_project_id
+`
+
+type MoveProjectTreeToWorkspaceParams struct {
+	ID          pgtype.UUID
+	WorkspaceID pgtype.UUID
+}
+
+// Moves a project and all of its sub-projects to a workspace, or out of any if workspace_id is
+// NULL. The project leaves its parent, which stays in the former workspace.
+func (q *Queries) MoveProjectTreeToWorkspace(ctx context.Context, arg MoveProjectTreeToWorkspaceParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, moveProjectTreeToWorkspace, arg.ID, arg.WorkspaceID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []Project
 	for rows.Next() {
-		var i Task
+		var i Project
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
-			&i.ParentTaskID,
-			&i.ProjectID,
-			&i.Status,
-			&i.Order,
 			&i.Name,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Order,
+			&i.EstimateUnit,
+			&i.WorkspaceID,
+			&i.ParentProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -194,13 +2916,22 @@ func (q *Queries) GetSubtasksDirect(ctx context.Context, parentTaskID pgtype.UUI
 	return items, nil
 }
 
-const getTask = `-- name: GetTask :one
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks
-WHERE id = $1 LIMIT 1
+const moveTask = `-- name: MoveTask :one
+UPDATE tasks
+SET parent_task_id = $2, "order" = $3, section_id = NULL, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
 `
 
-func (q *Queries) GetTask(ctx context.Context, id pgtype.UUID) (Task, error) {
-	row := q.db.QueryRow(ctx, getTask, id)
+type MoveTaskParams struct {
+	ID           pgtype.UUID
+	ParentTaskID pgtype.UUID
+	Order        int32
+}
+
+// Only root tasks are in sections, and root tasks moved out of one are moved to no section.
+func (q *Queries) MoveTask(ctx context.Context, arg MoveTaskParams) (Task, error) {
+	row := q.db.QueryRow(ctx, moveTask, arg.ID, arg.ParentTaskID, arg.Order)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -210,55 +2941,137 @@ func (q *Queries) GetTask(ctx context.Context, id pgtype.UUID) (Task, error) {
 		&i.Status,
 		&i.Order,
 		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
 	)
 	return i, err
 }
 
-const getTasksByProject = `-- name: GetTasksByProject :many
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks
-WHERE project_id = $1
+const offsetTaskOrders = `-- name: OffsetTaskOrders :exec
+
+UPDATE tasks
+SET "order" = "order" + 1000, version = version + 1, updated_at = now()
+WHERE project_id = $1::uuid
+  AND (
+    (parent_task_id IS NULL AND $2::uuid IS NULL) OR
+    (parent_task_id = $2::uuid)
+  )
+  AND deleted_at IS NULL
 `
 
-func (q *Queries) GetTasksByProject(ctx context.Context, projectID pgtype.UUID) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getTasksByProject, projectID)
+type OffsetTaskOrdersParams struct {
+	ProjectID    pgtype.UUID
+	ParentTaskID pgtype.UUID
+}
+
+// WARN: the following two queries should be used together
+// in the scope of a transaction!
+func (q *Queries) OffsetTaskOrders(ctx context.Context, arg OffsetTaskOrdersParams) error {
+	_, err := q.db.Exec(ctx, offsetTaskOrders, arg.ProjectID, arg.ParentTaskID)
+	return err
+}
+
+const purgeDeletedProjects = `-- name: PurgeDeletedProjects :execrows
+DELETE FROM projects
+WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeDeletedProjects(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedProjects, deletedBefore)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []Task
-	for rows.Next() {
-		var i Task
-		if err := rows.Scan(
-			&i.ID,
-			&i.CreatedAt,
-			&i.ParentTaskID,
-			&i.ProjectID,
-			&i.Status,
-			&i.Order,
-			&i.Name,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+	return result.RowsAffected(), nil
+}
+
+const purgeDeletedTasks = `-- name: PurgeDeletedTasks :execrows
+DELETE FROM tasks
+WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeDeletedTasks(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedTasks, deletedBefore)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected(), nil
+}
+
+const purgeExpiredDeniedRefreshTokens = `-- name: PurgeExpiredDeniedRefreshTokens :execrows
+DELETE FROM denied_refresh_tokens
+WHERE expires_at < $1::timestamptz
+`
+
+// Expired tokens are rejected anyway, they need not be denied anymore.
+func (q *Queries) PurgeExpiredDeniedRefreshTokens(ctx context.Context, expiredBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeExpiredDeniedRefreshTokens, expiredBefore)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const getTasksByStatus = `-- name: GetTasksByStatus :many
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks
-WHERE project_id = $1 AND status = $2
+const purgeExpiredIdempotencyKeys = `-- name: PurgeExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at < $1::timestamptz
 `
 
-type GetTasksByStatusParams struct {
+func (q *Queries) PurgeExpiredIdempotencyKeys(ctx context.Context, expiredBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeExpiredIdempotencyKeys, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeWorkspaceTrash = `-- name: PurgeWorkspaceTrash :exec
+DELETE FROM projects
+WHERE workspace_id = $1 AND deleted_at IS NOT NULL
+`
+
+// Permanently deletes the projects of a workspace that are in the trash, so that the workspace can
+// be deleted.
+func (q *Queries) PurgeWorkspaceTrash(ctx context.Context, workspaceID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, purgeWorkspaceTrash, workspaceID)
+	return err
+}
+
+const releaseSectionTasks = `-- name: ReleaseSectionTasks :many
+WITH unsectioned AS (
+  SELECT count(*)::integer AS task_count FROM tasks
+  WHERE project_id = $1::uuid
+    AND parent_task_id IS NULL
+    AND section_id IS NULL
+    AND deleted_at IS NULL
+), released AS (
+  SELECT ts.id, (row_number() OVER (ORDER BY ts."order", ts.id) - 1)::integer AS position
+  FROM tasks ts
+  WHERE ts.section_id = $2::uuid AND ts.deleted_at IS NULL
+)
+UPDATE tasks
+SET section_id = NULL, "order" = unsectioned.task_count + released.position,
+  version = version + 1, updated_at = now()
+FROM unsectioned, released
+WHERE tasks.id = released.id
+RETURNING tasks.id, tasks.created_at, tasks.parent_task_id, tasks.project_id, tasks.status, tasks."order", tasks.name, tasks.due_at, tasks.deleted_at, tasks.version, tasks.updated_at, tasks.completed_at, tasks.estimate, tasks.iteration_id, tasks.section_id, tasks.revision_count
+`
+
+type ReleaseSectionTasksParams struct {
 	ProjectID pgtype.UUID
-	Status    string
+	SectionID pgtype.UUID
 }
 
-func (q *Queries) GetTasksByStatus(ctx context.Context, arg GetTasksByStatusParams) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getTasksByStatus, arg.ProjectID, arg.Status)
+// Moves the root tasks of a section to the end of the root tasks without a section, in the
+// order they had in the section. Tasks in the trash lose their section along with it.
+func (q *Queries) ReleaseSectionTasks(ctx context.Context, arg ReleaseSectionTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, releaseSectionTasks, arg.ProjectID, arg.SectionID)
 	if err != nil {
 		return nil, err
 	}
@@ -274,6 +3087,15 @@ func (q *Queries) GetTasksByStatus(ctx context.Context, arg GetTasksByStatusPara
 			&i.Status,
 			&i.Order,
 			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
 		); err != nil {
 			return nil, err
 		}
@@ -285,71 +3107,261 @@ func (q *Queries) GetTasksByStatus(ctx context.Context, arg GetTasksByStatusPara
 	return items, nil
 }
 
-const getTasksInProjectRoot = `-- name: GetTasksInProjectRoot :many
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks
-WHERE project_id = $1 AND parent_task_id IS NULL
+const releaseSubprojects = `-- name: ReleaseSubprojects :exec
+UPDATE projects
+SET parent_project_id = $1::uuid, version = version + 1
+WHERE parent_project_id = $2::uuid AND deleted_at IS NULL
+`
+
+type ReleaseSubprojectsParams struct {
+	NewParentProjectID pgtype.UUID
+	ParentProjectID    pgtype.UUID
+}
+
+// Moves the sub-projects of a project taken out of the tree, e.g. moved to the trash, up to its
+// parent.
+func (q *Queries) ReleaseSubprojects(ctx context.Context, arg ReleaseSubprojectsParams) error {
+	_, err := q.db.Exec(ctx, releaseSubprojects, arg.NewParentProjectID, arg.ParentProjectID)
+	return err
+}
+
+const renameProject = `-- name: RenameProject :one
+UPDATE projects
+SET name = $2, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+type RenameProjectParams struct {
+	ID   pgtype.UUID
+	Name string
+}
+
+func (q *Queries) RenameProject(ctx context.Context, arg RenameProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, renameProject, arg.ID, arg.Name)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const renameSection = `-- name: RenameSection :one
+UPDATE sections
+SET name = $2
+WHERE id = $1
+RETURNING id, project_id, name, "order", created_at
+`
+
+type RenameSectionParams struct {
+	ID   pgtype.UUID
+	Name string
+}
+
+func (q *Queries) RenameSection(ctx context.Context, arg RenameSectionParams) (Section, error) {
+	row := q.db.QueryRow(ctx, renameSection, arg.ID, arg.Name)
+	var i Section
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Order,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const renameTask = `-- name: RenameTask :one
+UPDATE tasks
+SET name = $2, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
+`
+
+type RenameTaskParams struct {
+	ID   pgtype.UUID
+	Name string
+}
+
+func (q *Queries) RenameTask(ctx context.Context, arg RenameTaskParams) (Task, error) {
+	row := q.db.QueryRow(ctx, renameTask, arg.ID, arg.Name)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ParentTaskID,
+		&i.ProjectID,
+		&i.Status,
+		&i.Order,
+		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const renameWorkspace = `-- name: RenameWorkspace :one
+UPDATE workspaces
+SET name = $2
+WHERE id = $1
+RETURNING id, name, created_at
+`
+
+type RenameWorkspaceParams struct {
+	ID   pgtype.UUID
+	Name string
+}
+
+func (q *Queries) RenameWorkspace(ctx context.Context, arg RenameWorkspaceParams) (Workspace, error) {
+	row := q.db.QueryRow(ctx, renameWorkspace, arg.ID, arg.Name)
+	var i Workspace
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const restoreProject = `-- name: RestoreProject :one
+UPDATE projects
+SET
+  deleted_at = NULL,
+  "order" = (SELECT coalesce(max(p."order"), -1) + 1 FROM projects p WHERE p.deleted_at IS NULL),
+  version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+// Restored projects go to the end of the list.
+func (q *Queries) RestoreProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, restoreProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const restoreProjectTasks = `-- name: RestoreProjectTasks :exec
+UPDATE tasks
+SET deleted_at = NULL, version = version + 1, updated_at = now()
+WHERE project_id = $1::uuid AND deleted_at = $2::timestamptz
+`
+
+type RestoreProjectTasksParams struct {
+	ProjectID pgtype.UUID
+	DeletedAt pgtype.Timestamptz
+}
+
+func (q *Queries) RestoreProjectTasks(ctx context.Context, arg RestoreProjectTasksParams) error {
+	_, err := q.db.Exec(ctx, restoreProjectTasks, arg.ProjectID, arg.DeletedAt)
+	return err
+}
+
+const restoreTaskTree = `-- name: RestoreTaskTree :exec
+WITH RECURSIVE subtree AS (
+  SELECT ts.id FROM tasks ts
+  WHERE ts.id = $1::uuid AND ts.deleted_at = $2::timestamptz
+
+  UNION
+
+  SELECT t.id FROM tasks t
+  INNER JOIN subtree st ON t.parent_task_id = st.id
+  WHERE t.deleted_at = $2::timestamptz
+)
+UPDATE tasks
+SET deleted_at = NULL, version = version + 1, updated_at = now()
+WHERE id IN (SELECT id FROM subtree)
 `
 
-func (q *Queries) GetTasksInProjectRoot(ctx context.Context, projectID pgtype.UUID) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getTasksInProjectRoot, projectID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Task
-	for rows.Next() {
-		var i Task
-		if err := rows.Scan(
-			&i.ID,
-			&i.CreatedAt,
-			&i.ParentTaskID,
-			&i.ProjectID,
-			&i.Status,
-			&i.Order,
-			&i.Name,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type RestoreTaskTreeParams struct {
+	ID        pgtype.UUID
+	DeletedAt pgtype.Timestamptz
 }
 
-const listProjects = `-- name: ListProjects :many
-SELECT id, created_at, name FROM projects
-ORDER BY name
+// Tasks deleted in the same operation share their deleted_at, so the
+// subtree of a deleted task is restored together with it, while subtasks
+// deleted on their own stay in the trash.
+func (q *Queries) RestoreTaskTree(ctx context.Context, arg RestoreTaskTreeParams) error {
+	_, err := q.db.Exec(ctx, restoreTaskTree, arg.ID, arg.DeletedAt)
+	return err
+}
+
+const revokeAPIToken = `-- name: RevokeAPIToken :one
+UPDATE api_tokens
+SET revoked_at = COALESCE(revoked_at, now())
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, name, token_hash, created_at, last_used_at, revoked_at
 `
 
-func (q *Queries) ListProjects(ctx context.Context) ([]Project, error) {
-	rows, err := q.db.Query(ctx, listProjects)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Project
-	for rows.Next() {
-		var i Project
-		if err := rows.Scan(&i.ID, &i.CreatedAt, &i.Name); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type RevokeAPITokenParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
 }
 
-const listTasks = `-- name: ListTasks :many
-SELECT id, created_at, parent_task_id, project_id, status, "order", name FROM tasks
-ORDER BY project_id
+// Revoking a revoked token keeps the time it was first revoked at.
+func (q *Queries) RevokeAPIToken(ctx context.Context, arg RevokeAPITokenParams) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, revokeAPIToken, arg.ID, arg.UserID)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const rollOverIterationTasks = `-- name: RollOverIterationTasks :many
+UPDATE tasks
+SET iteration_id = $1::uuid, version = version + 1, updated_at = now()
+WHERE iteration_id = $2::uuid
+  AND status = 'pending'
+  AND deleted_at IS NULL
+  AND project_id IN (SELECT id FROM projects WHERE archived_at IS NULL)
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
 `
 
-func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listTasks)
+type RollOverIterationTasksParams struct {
+	ToIterationID   pgtype.UUID
+	FromIterationID pgtype.UUID
+}
+
+// Moves the pending tasks of an iteration to another one. The tasks of archived projects are
+// frozen, so they stay where they are.
+func (q *Queries) RollOverIterationTasks(ctx context.Context, arg RollOverIterationTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, rollOverIterationTasks, arg.ToIterationID, arg.FromIterationID)
 	if err != nil {
 		return nil, err
 	}
@@ -365,6 +3377,15 @@ func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
 			&i.Status,
 			&i.Order,
 			&i.Name,
+			&i.DueAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.Estimate,
+			&i.IterationID,
+			&i.SectionID,
+			&i.RevisionCount,
 		); err != nil {
 			return nil, err
 		}
@@ -376,62 +3397,343 @@ func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
-const offsetTaskOrders = `-- name: OffsetTaskOrders :exec
+const setProjectParent = `-- name: SetProjectParent :one
+UPDATE projects
+SET parent_project_id = $1::uuid, version = version + 1
+WHERE id = $2::uuid AND deleted_at IS NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+type SetProjectParentParams struct {
+	ParentProjectID pgtype.UUID
+	ID              pgtype.UUID
+}
+
+func (q *Queries) SetProjectParent(ctx context.Context, arg SetProjectParentParams) (Project, error) {
+	row := q.db.QueryRow(ctx, setProjectParent, arg.ParentProjectID, arg.ID)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
 
+const setTaskIteration = `-- name: SetTaskIteration :one
 UPDATE tasks
-SET "order" = "order" + 1000
-WHERE project_id = $1::uuid
+SET iteration_id = $1::uuid, version = version + 1, updated_at = now()
+WHERE id = $2::uuid AND deleted_at IS NULL
   AND (
-    (parent_task_id IS NULL AND $2::uuid IS NULL) OR
-    (parent_task_id = $2::uuid)
+    $1::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM iterations WHERE iterations.id = $1::uuid AND iterations.closed_at IS NULL)
   )
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
 `
 
-type OffsetTaskOrdersParams struct {
-	ProjectID    pgtype.UUID
-	ParentTaskID pgtype.UUID
+type SetTaskIterationParams struct {
+	IterationID pgtype.UUID
+	ID          pgtype.UUID
 }
 
-// WARN: the following two queries should be used together
-// in the scope of a transaction!
-func (q *Queries) OffsetTaskOrders(ctx context.Context, arg OffsetTaskOrdersParams) error {
-	_, err := q.db.Exec(ctx, offsetTaskOrders, arg.ProjectID, arg.ParentTaskID)
+// Plans a task in an open iteration, or in none if iteration_id is NULL. Nothing is returned if the
+// iteration is closed.
+func (q *Queries) SetTaskIteration(ctx context.Context, arg SetTaskIterationParams) (Task, error) {
+	row := q.db.QueryRow(ctx, setTaskIteration, arg.IterationID, arg.ID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ParentTaskID,
+		&i.ProjectID,
+		&i.Status,
+		&i.Order,
+		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const setTaskSection = `-- name: SetTaskSection :one
+UPDATE tasks
+SET section_id = $2, "order" = $3, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
+`
+
+type SetTaskSectionParams struct {
+	ID        pgtype.UUID
+	SectionID pgtype.UUID
+	Order     int32
+}
+
+func (q *Queries) SetTaskSection(ctx context.Context, arg SetTaskSectionParams) (Task, error) {
+	row := q.db.QueryRow(ctx, setTaskSection, arg.ID, arg.SectionID, arg.Order)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ParentTaskID,
+		&i.ProjectID,
+		&i.Status,
+		&i.Order,
+		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const softDeleteProject = `-- name: SoftDeleteProject :one
+UPDATE projects
+SET deleted_at = $1::timestamptz, parent_project_id = NULL, version = version + 1
+WHERE id = $2::uuid AND deleted_at IS NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+type SoftDeleteProjectParams struct {
+	DeletedAt pgtype.Timestamptz
+	ID        pgtype.UUID
+}
+
+// Projects in the trash leave their parent, so they are restored at the top of their workspace.
+func (q *Queries) SoftDeleteProject(ctx context.Context, arg SoftDeleteProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, softDeleteProject, arg.DeletedAt, arg.ID)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const softDeleteProjectTasks = `-- name: SoftDeleteProjectTasks :exec
+UPDATE tasks
+SET deleted_at = $1::timestamptz, version = version + 1, updated_at = now()
+WHERE project_id = $2::uuid AND deleted_at IS NULL
+`
+
+type SoftDeleteProjectTasksParams struct {
+	DeletedAt pgtype.Timestamptz
+	ProjectID pgtype.UUID
+}
+
+func (q *Queries) SoftDeleteProjectTasks(ctx context.Context, arg SoftDeleteProjectTasksParams) error {
+	_, err := q.db.Exec(ctx, softDeleteProjectTasks, arg.DeletedAt, arg.ProjectID)
 	return err
 }
 
-const renameProject = `-- name: RenameProject :one
+const softDeleteTaskTree = `-- name: SoftDeleteTaskTree :exec
+WITH RECURSIVE subtree AS (
+  SELECT ts.id FROM tasks ts
+  WHERE ts.id = $1::uuid AND ts.deleted_at IS NULL
+
+  UNION
+
+  SELECT t.id FROM tasks t
+  INNER JOIN subtree st ON t.parent_task_id = st.id
+  WHERE t.deleted_at IS NULL
+)
+UPDATE tasks
+SET deleted_at = $2::timestamptz, version = version + 1, updated_at = now()
+WHERE id IN (SELECT id FROM subtree)
+`
+
+type SoftDeleteTaskTreeParams struct {
+	ID        pgtype.UUID
+	DeletedAt pgtype.Timestamptz
+}
+
+func (q *Queries) SoftDeleteTaskTree(ctx context.Context, arg SoftDeleteTaskTreeParams) error {
+	_, err := q.db.Exec(ctx, softDeleteTaskTree, arg.ID, arg.DeletedAt)
+	return err
+}
+
+const stopTimeEntry = `-- name: StopTimeEntry :one
+UPDATE time_entries
+SET ended_at = $1::timestamptz
+WHERE user_id = $2::uuid AND ended_at IS NULL
+RETURNING id, task_id, actor, started_at, ended_at, note, created_at, user_id
+`
+
+type StopTimeEntryParams struct {
+	EndedAt pgtype.Timestamptz
+	UserID  pgtype.UUID
+}
+
+func (q *Queries) StopTimeEntry(ctx context.Context, arg StopTimeEntryParams) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, stopTimeEntry, arg.EndedAt, arg.UserID)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Actor,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const unarchiveProject = `-- name: UnarchiveProject :one
 UPDATE projects
-SET name = $2
+SET archived_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+func (q *Queries) UnarchiveProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, unarchiveProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
+	return i, err
+}
+
+const updateIteration = `-- name: UpdateIteration :one
+UPDATE iterations
+SET name = $2, starts_at = $3, ends_at = $4
 WHERE id = $1
-RETURNING id, created_at, name
+RETURNING id, name, starts_at, ends_at, created_at, closed_at, completed_tasks, completed_points, completed_minutes
 `
 
-type RenameProjectParams struct {
-	ID   pgtype.UUID
-	Name string
+type UpdateIterationParams struct {
+	ID       pgtype.UUID
+	Name     string
+	StartsAt pgtype.Timestamptz
+	EndsAt   pgtype.Timestamptz
 }
 
-func (q *Queries) RenameProject(ctx context.Context, arg RenameProjectParams) (Project, error) {
-	row := q.db.QueryRow(ctx, renameProject, arg.ID, arg.Name)
+func (q *Queries) UpdateIteration(ctx context.Context, arg UpdateIterationParams) (Iteration, error) {
+	row := q.db.QueryRow(ctx, updateIteration,
+		arg.ID,
+		arg.Name,
+		arg.StartsAt,
+		arg.EndsAt,
+	)
+	var i Iteration
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.CompletedTasks,
+		&i.CompletedPoints,
+		&i.CompletedMinutes,
+	)
+	return i, err
+}
+
+const updateProjectDetails = `-- name: UpdateProjectDetails :one
+UPDATE projects
+SET description = $1::text, color = $2, icon = $3,
+  estimate_unit = $4::text, version = version + 1
+WHERE id = $5::uuid AND deleted_at IS NULL
+RETURNING id, created_at, name, deleted_at, archived_at, version, description, color, icon, "order", estimate_unit, workspace_id, parent_project_id
+`
+
+type UpdateProjectDetailsParams struct {
+	Description  string
+	Color        pgtype.Text
+	Icon         pgtype.Text
+	EstimateUnit string
+	ID           pgtype.UUID
+}
+
+func (q *Queries) UpdateProjectDetails(ctx context.Context, arg UpdateProjectDetailsParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectDetails,
+		arg.Description,
+		arg.Color,
+		arg.Icon,
+		arg.EstimateUnit,
+		arg.ID,
+	)
 	var i Project
-	err := row.Scan(&i.ID, &i.CreatedAt, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Name,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.Version,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Order,
+		&i.EstimateUnit,
+		&i.WorkspaceID,
+		&i.ParentProjectID,
+	)
 	return i, err
 }
 
-const renameTask = `-- name: RenameTask :one
+const updateTaskDueAt = `-- name: UpdateTaskDueAt :one
 UPDATE tasks
-SET name = $2
-WHERE id = $1
-RETURNING id, created_at, parent_task_id, project_id, status, "order", name
+SET due_at = $2, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
 `
 
-type RenameTaskParams struct {
-	ID   pgtype.UUID
-	Name string
+type UpdateTaskDueAtParams struct {
+	ID    pgtype.UUID
+	DueAt pgtype.Timestamptz
 }
 
-func (q *Queries) RenameTask(ctx context.Context, arg RenameTaskParams) (Task, error) {
-	row := q.db.QueryRow(ctx, renameTask, arg.ID, arg.Name)
+func (q *Queries) UpdateTaskDueAt(ctx context.Context, arg UpdateTaskDueAtParams) (Task, error) {
+	row := q.db.QueryRow(ctx, updateTaskDueAt, arg.ID, arg.DueAt)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -441,13 +3743,58 @@ func (q *Queries) RenameTask(ctx context.Context, arg RenameTaskParams) (Task, e
 		&i.Status,
 		&i.Order,
 		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const updateTaskEstimate = `-- name: UpdateTaskEstimate :one
+UPDATE tasks
+SET estimate = $2, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, parent_task_id, project_id, status, "order", name, due_at, deleted_at, version, updated_at, completed_at, estimate, iteration_id, section_id, revision_count
+`
+
+type UpdateTaskEstimateParams struct {
+	ID       pgtype.UUID
+	Estimate pgtype.Int4
+}
+
+func (q *Queries) UpdateTaskEstimate(ctx context.Context, arg UpdateTaskEstimateParams) (Task, error) {
+	row := q.db.QueryRow(ctx, updateTaskEstimate, arg.ID, arg.Estimate)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ParentTaskID,
+		&i.ProjectID,
+		&i.Status,
+		&i.Order,
+		&i.Name,
+		&i.DueAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.Estimate,
+		&i.IterationID,
+		&i.SectionID,
+		&i.RevisionCount,
 	)
 	return i, err
 }
 
 const updateTaskOrder = `-- name: UpdateTaskOrder :exec
 UPDATE tasks
-SET "order" = $2
+SET "order" = $2, version = version + 1, updated_at = now()
 WHERE id = $1
 `
 
@@ -463,16 +3810,45 @@ func (q *Queries) UpdateTaskOrder(ctx context.Context, arg UpdateTaskOrderParams
 
 const updateTaskStatus = `-- name: UpdateTaskStatus :exec
 UPDATE tasks
-SET "status" = $2
-WHERE id = $1
+SET "status" = $2, completed_at = $3, version = version + 1, updated_at = now()
+WHERE id = $1 AND "status" <> $2
 `
 
 type UpdateTaskStatusParams struct {
-	ID     pgtype.UUID
-	Status string
+	ID          pgtype.UUID
+	Status      string
+	CompletedAt pgtype.Timestamptz
 }
 
 func (q *Queries) UpdateTaskStatus(ctx context.Context, arg UpdateTaskStatusParams) error {
-	_, err := q.db.Exec(ctx, updateTaskStatus, arg.ID, arg.Status)
+	_, err := q.db.Exec(ctx, updateTaskStatus, arg.ID, arg.Status, arg.CompletedAt)
 	return err
 }
+
+const useAPIToken = `-- name: UseAPIToken :one
+UPDATE api_tokens
+SET last_used_at = $2
+WHERE token_hash = $1 AND revoked_at IS NULL
+RETURNING id, user_id, name, token_hash, created_at, last_used_at, revoked_at
+`
+
+type UseAPITokenParams struct {
+	TokenHash  []byte
+	LastUsedAt pgtype.Timestamptz
+}
+
+// Finds a token that is not revoked, and records that it was used.
+func (q *Queries) UseAPIToken(ctx context.Context, arg UseAPITokenParams) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, useAPIToken, arg.TokenHash, arg.LastUsedAt)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
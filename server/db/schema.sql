@@ -4,30 +4,259 @@ CREATE SCHEMA IF NOT EXISTS "public";
 -- Set comment to schema: "public"
 COMMENT ON SCHEMA "public" IS 'standard public schema';
 
+-- Create "workspaces" table
+CREATE TABLE "public"."workspaces" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+
+-- Create index "workspaces_name_key" to table: "workspaces"
+CREATE UNIQUE INDEX "workspaces_name_key" ON "public"."workspaces" ("name");
+
 -- Create "projects" table
 CREATE TABLE "public"."projects" (
   "id" uuid NOT NULL DEFAULT gen_random_uuid(),
-  "created_at" timestamp NOT NULL DEFAULT now(),
-  "name" text NOT NULL UNIQUE,
-  PRIMARY KEY ("id")
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "name" text NOT NULL,
+  "deleted_at" timestamptz NULL,
+  "archived_at" timestamptz NULL,
+  "version" integer NOT NULL DEFAULT 1,
+  "description" text NOT NULL DEFAULT '',
+  "color" text NULL,
+  "icon" text NULL,
+  "order" integer NOT NULL DEFAULT 0,
+  "estimate_unit" text NOT NULL DEFAULT 'points',
+  "workspace_id" uuid NULL,
+  "parent_project_id" uuid NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "projects_parent_project_id_fkey" FOREIGN KEY ("parent_project_id") REFERENCES "public"."projects" ("id") ON UPDATE NO ACTION ON DELETE SET NULL,
+  CONSTRAINT "projects_workspace_id_fkey" FOREIGN KEY ("workspace_id") REFERENCES "public"."workspaces" ("id") ON UPDATE NO ACTION ON DELETE RESTRICT,
+  CONSTRAINT "projects_estimate_unit_check" CHECK (estimate_unit = ANY (ARRAY['points'::text, 'minutes'::text])),
+  CONSTRAINT "projects_order_check" CHECK ("order" >= 0)
 );
 
 -- Create index "project_name" to table: "projects"
 CREATE INDEX "project_name" ON "public"."projects" ("name");
 
+-- Create index "projects_parent_project_id" to table: "projects"
+CREATE INDEX "projects_parent_project_id" ON "public"."projects" ("parent_project_id") WHERE (parent_project_id IS NOT NULL);
+
+-- Create index "projects_workspace_id" to table: "projects"
+CREATE INDEX "projects_workspace_id" ON "public"."projects" ("workspace_id") WHERE (workspace_id IS NOT NULL);
+
+-- Create index "projects_workspace_id_name_key" to table: "projects"
+CREATE UNIQUE INDEX "projects_workspace_id_name_key" ON "public"."projects" ("workspace_id", "name") NULLS NOT DISTINCT WHERE ((deleted_at IS NULL) AND (archived_at IS NULL));
+
+-- Create "sections" table
+CREATE TABLE "public"."sections" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "project_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "order" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "sections_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "public"."projects" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "sections_order_check" CHECK ("order" >= 0)
+);
+
+-- Create index "sections_project_id_name_key" to table: "sections"
+CREATE UNIQUE INDEX "sections_project_id_name_key" ON "public"."sections" ("project_id", "name");
+
+-- Create "iterations" table
+CREATE TABLE "public"."iterations" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "closed_at" timestamptz NULL,
+  "completed_tasks" integer NULL,
+  "completed_points" integer NULL,
+  "completed_minutes" integer NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "iterations_check" CHECK (ends_at > starts_at)
+);
+
+-- Create index "iterations_starts_at" to table: "iterations"
+CREATE INDEX "iterations_starts_at" ON "public"."iterations" ("starts_at");
+
 -- Create "tasks" table
 CREATE TABLE "public"."tasks" (
   "id" uuid NOT NULL DEFAULT gen_random_uuid(),
-  "created_at" timestamp NOT NULL DEFAULT now(),
+  "created_at" timestamptz NOT NULL DEFAULT now(),
   "parent_task_id" uuid NULL,
   "project_id" uuid NOT NULL,
   "status" text NOT NULL DEFAULT 'pending',
   "order" integer NOT NULL DEFAULT 0,
   "name" text NOT NULL,
+  "due_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "version" integer NOT NULL DEFAULT 1,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "completed_at" timestamptz NULL,
+  "estimate" integer NULL,
+  "iteration_id" uuid NULL,
+  "section_id" uuid NULL,
+  "revision_count" integer NOT NULL DEFAULT 0,
   PRIMARY KEY ("id"),
-  CONSTRAINT "tasks_project_id_parent_task_id_order_key" UNIQUE ("project_id", "parent_task_id", "order"),
+  CONSTRAINT "tasks_iteration_id_fkey" FOREIGN KEY ("iteration_id") REFERENCES "public"."iterations" ("id") ON UPDATE NO ACTION ON DELETE SET NULL,
   CONSTRAINT "tasks_parent_task_id_fkey" FOREIGN KEY ("parent_task_id") REFERENCES "public"."tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
   CONSTRAINT "tasks_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "public"."projects" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "tasks_section_id_fkey" FOREIGN KEY ("section_id") REFERENCES "public"."sections" ("id") ON UPDATE NO ACTION ON DELETE SET NULL,
+  CONSTRAINT "tasks_estimate_check" CHECK (estimate >= 0),
   CONSTRAINT "tasks_order_check" CHECK ("order" >= 0),
   CONSTRAINT "tasks_status_check" CHECK (status = ANY (ARRAY['pending'::text, 'completed'::text]))
 );
+
+-- Create index "tasks_project_id_parent_task_id_section_id_order_key" to table: "tasks"
+CREATE UNIQUE INDEX "tasks_project_id_parent_task_id_section_id_order_key" ON "public"."tasks" ("project_id", "parent_task_id", "section_id", "order") NULLS NOT DISTINCT WHERE (deleted_at IS NULL);
+
+-- Create index "tasks_deleted_at" to table: "tasks"
+CREATE INDEX "tasks_deleted_at" ON "public"."tasks" ("deleted_at") WHERE (deleted_at IS NOT NULL);
+
+-- Create index "tasks_iteration_id" to table: "tasks"
+CREATE INDEX "tasks_iteration_id" ON "public"."tasks" ("iteration_id") WHERE (iteration_id IS NOT NULL);
+
+-- Create index "tasks_section_id" to table: "tasks"
+CREATE INDEX "tasks_section_id" ON "public"."tasks" ("section_id") WHERE (section_id IS NOT NULL);
+
+-- Create index "tasks_completed_at" to table: "tasks"
+CREATE INDEX "tasks_completed_at" ON "public"."tasks" ("completed_at") WHERE (completed_at IS NOT NULL);
+
+-- Create index "tasks_project_id_completed_at" to table: "tasks"
+CREATE INDEX "tasks_project_id_completed_at" ON "public"."tasks" ("project_id", "completed_at") WHERE (completed_at IS NOT NULL);
+
+-- Create "task_revisions" table
+CREATE TABLE "public"."task_revisions" (
+  "task_id" uuid NOT NULL,
+  "revision" integer NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "action" text NOT NULL,
+  "state" jsonb NOT NULL,
+  PRIMARY KEY ("task_id", "revision"),
+  CONSTRAINT "task_revisions_task_id_fkey" FOREIGN KEY ("task_id") REFERENCES "public"."tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+-- Create "templates" table
+CREATE TABLE "public"."templates" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "name" text NOT NULL UNIQUE,
+  PRIMARY KEY ("id")
+);
+
+-- Create "template_tasks" table
+CREATE TABLE "public"."template_tasks" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "template_id" uuid NOT NULL,
+  "parent_template_task_id" uuid NULL,
+  "name" text NOT NULL,
+  "order" integer NOT NULL DEFAULT 0,
+  "due_offset_days" integer NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "template_tasks_template_id_fkey" FOREIGN KEY ("template_id") REFERENCES "public"."templates" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "template_tasks_parent_template_task_id_fkey" FOREIGN KEY ("parent_template_task_id") REFERENCES "public"."template_tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "template_tasks_order_check" CHECK ("order" >= 0)
+);
+
+-- Create "activity_events" table
+CREATE TABLE "public"."activity_events" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "project_id" uuid NOT NULL,
+  "task_id" uuid NULL,
+  "action" text NOT NULL,
+  "actor" text NOT NULL,
+  "request_id" text NOT NULL DEFAULT '',
+  "before" jsonb NULL,
+  "after" jsonb NULL,
+  "user_id" uuid NULL,
+  PRIMARY KEY ("id")
+);
+
+-- Create index "activity_events_project_id_id" to table: "activity_events"
+CREATE INDEX "activity_events_project_id_id" ON "public"."activity_events" ("project_id", "id");
+
+-- Create index "activity_events_task_id_id" to table: "activity_events"
+CREATE INDEX "activity_events_task_id_id" ON "public"."activity_events" ("task_id", "id") WHERE (task_id IS NOT NULL);
+
+-- Create "idempotency_keys" table
+CREATE TABLE "public"."idempotency_keys" (
+  "key" text NOT NULL,
+  "fingerprint" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "expires_at" timestamptz NOT NULL,
+  "status_code" integer NULL,
+  "headers" jsonb NULL,
+  "body" bytea NULL,
+  "locked_until" timestamptz NULL,
+  PRIMARY KEY ("key")
+);
+
+-- Create index "idempotency_keys_expires_at" to table: "idempotency_keys"
+CREATE INDEX "idempotency_keys_expires_at" ON "public"."idempotency_keys" ("expires_at");
+
+-- Create "time_entries" table
+CREATE TABLE "public"."time_entries" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "task_id" uuid NOT NULL,
+  "actor" text NOT NULL,
+  "started_at" timestamptz NOT NULL,
+  "ended_at" timestamptz NULL,
+  "note" text NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "user_id" uuid NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "time_entries_task_id_fkey" FOREIGN KEY ("task_id") REFERENCES "public"."tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "time_entries_check" CHECK ((ended_at IS NULL) OR (ended_at >= started_at))
+);
+
+-- Create index "time_entries_task_id_started_at" to table: "time_entries"
+CREATE INDEX "time_entries_task_id_started_at" ON "public"."time_entries" ("task_id", "started_at");
+
+-- Create index "time_entries_running_user_id_key" to table: "time_entries"
+CREATE UNIQUE INDEX "time_entries_running_user_id_key" ON "public"."time_entries" ("user_id") WHERE (ended_at IS NULL);
+
+-- Create "users" table
+CREATE TABLE "public"."users" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+
+-- Create index "users_name_key" to table: "users"
+CREATE UNIQUE INDEX "users_name_key" ON "public"."users" ("name");
+
+-- Create "api_tokens" table
+CREATE TABLE "public"."api_tokens" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "token_hash" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "last_used_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "api_tokens_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+-- Create index "api_tokens_token_hash_key" to table: "api_tokens"
+CREATE UNIQUE INDEX "api_tokens_token_hash_key" ON "public"."api_tokens" ("token_hash");
+
+-- Create index "api_tokens_user_id" to table: "api_tokens"
+CREATE INDEX "api_tokens_user_id" ON "public"."api_tokens" ("user_id");
+
+-- Create "denied_refresh_tokens" table
+CREATE TABLE "public"."denied_refresh_tokens" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "denied_at" timestamptz NOT NULL DEFAULT now(),
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- Create index "denied_refresh_tokens_expires_at" to table: "denied_refresh_tokens"
+CREATE INDEX "denied_refresh_tokens_expires_at" ON "public"."denied_refresh_tokens" ("expires_at");
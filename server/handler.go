@@ -1,15 +1,126 @@
 package todoctian
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/murasakiwano/todoctian/server/auth"
+	"github.com/murasakiwano/todoctian/server/idempotency"
+	"github.com/murasakiwano/todoctian/server/internal"
 	"github.com/murasakiwano/todoctian/server/internal/openapi"
 )
 
-func Handler(pgConnString string) http.Handler {
-	server := NewServer(pgConnString)
+type config struct {
+	trashRetentionDays        int
+	reuseArchivedProjectNames bool
+	idempotencyKeyTTL         time.Duration
+	validateResponses         bool
+	limits                    internal.Limits
+	signingKeys               *auth.KeySet
+	accessTokenTTL            time.Duration
+	refreshTokenTTL           time.Duration
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{
+		trashRetentionDays: DefaultTrashRetentionDays,
+		idempotencyKeyTTL:  idempotency.DefaultTTL,
+		limits:             internal.DefaultLimits,
+		accessTokenTTL:     auth.DefaultAccessTokenTTL,
+		refreshTokenTTL:    auth.DefaultRefreshTokenTTL,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// Option configures the handler returned by Handler.
+type Option func(*config)
+
+// WithTrashRetention sets the number of days deleted projects and tasks are kept in the trash
+// before they are purged. A value of zero or less disables purging.
+func WithTrashRetention(days int) Option {
+	return func(c *config) {
+		c.trashRetentionDays = days
+	}
+}
+
+// WithArchivedProjectNameReuse lets new projects take the names of archived projects. By default,
+// the names of archived projects stay reserved.
+func WithArchivedProjectNameReuse(reuse bool) Option {
+	return func(c *config) {
+		c.reuseArchivedProjectNames = reuse
+	}
+}
+
+// WithIdempotencyKeyTTL sets how long the responses to requests made with an Idempotency-Key
+// header are kept, to be replayed to retries.
+func WithIdempotencyKeyTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.idempotencyKeyTTL = ttl
+	}
+}
+
+// WithResponseValidation logs the responses that do not conform to the OpenAPI spec. Validating
+// responses is slow, it is meant for development.
+func WithResponseValidation(validate bool) Option {
+	return func(c *config) {
+		c.validateResponses = validate
+	}
+}
+
+// WithLimits sets the maximum length of names and the maximum size of task trees.
+func WithLimits(limits internal.Limits) Option {
+	return func(c *config) {
+		c.limits = limits
+	}
+}
+
+// WithSigningKeys sets the keys JWT access and refresh tokens are signed with, see auth.KeySet.
+// Without them, tokens are signed with a key generated at startup: they are invalidated when the
+// server restarts, and are not valid on other instances.
+func WithSigningKeys(keys *auth.KeySet) Option {
+	return func(c *config) {
+		c.signingKeys = keys
+	}
+}
+
+// WithTokenTTLs sets how long JWT access and refresh tokens are valid for.
+func WithTokenTTLs(access time.Duration, refresh time.Duration) Option {
+	return func(c *config) {
+		c.accessTokenTTL = access
+		c.refreshTokenTTL = refresh
+	}
+}
+
+func Handler(pgConnString string, opts ...Option) http.Handler {
+	cfg := newConfig(opts...)
+
+	server := NewServer(pgConnString, opts...)
+	if cfg.trashRetentionDays > 0 {
+		retention := time.Duration(cfg.trashRetentionDays) * 24 * time.Hour
+		go server.runTrashRetentionJob(context.Background(), retention, trashPurgeInterval)
+	}
+	go server.runIdempotencyKeyPurgeJob(context.Background(), idempotencyKeyPurgeInterval)
+	go server.runDeniedTokenPurgeJob(context.Background(), deniedTokenPurgeInterval)
+
+	specRouter, err := newSpecRouter()
+	if err != nil {
+		log.Fatalf("could not load the OpenAPI spec: %s", err)
+	}
+
 	return openapi.Handler(server, openapi.ServerOption(func(so *openapi.ServerOptions) {
-		so.BaseRouter.Use(middleware.Logger)
-	}))
+		so.BaseRouter.Use(middleware.RequestID, requestIDHeader, operationID, middleware.Logger)
+		if cfg.validateResponses {
+			so.BaseRouter.Use(server.validateResponses(specRouter))
+		}
+		so.BaseRouter.Use(server.authenticate(specRouter), validateRequests(specRouter), server.idempotentRequests)
+		so.BaseRouter.NotFound(routeNotFound)
+		so.BaseRouter.MethodNotAllowed(methodNotAllowed)
+	}), openapi.WithErrorHandler(requestError))
 }
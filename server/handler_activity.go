@@ -0,0 +1,188 @@
+package todoctian
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/user"
+)
+
+// requestOrigin tells who made a request, so that the changes it makes can be attributed in the
+// activity history. Changes are always attributed to the authenticated user, never to a name given
+// by the client.
+func requestOrigin(r *http.Request) activity.Origin {
+	origin := activity.Origin{
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+	if u, ok := user.FromContext(r.Context()); ok {
+		origin.UserID = &u.ID
+		origin.Actor = u.Name
+	}
+
+	return origin
+}
+
+// requestSession gives the client session of the request, if any. Sessions are namespaced by the
+// authenticated user, so that no user can undo the operations of another by sending the same
+// session ID.
+func requestSession(r *http.Request) string {
+	session := r.Header.Get(sessionHeader)
+	if session == "" {
+		return ""
+	}
+	if u, ok := user.FromContext(r.Context()); ok {
+		return u.ID.String() + ":" + session
+	}
+
+	return session
+}
+
+// The task service to use for changes made by the request. The changes are logged under the
+// client session of the request, if any, so that they can be undone, and the ones made by the
+// same request are undone together.
+func (s *Server) tasks(r *http.Request) *task.TaskService {
+	operation, _ := r.Context().Value(operationKey{}).(string)
+	return s.TaskService.WithOrigin(requestOrigin(r)).WithSession(requestSession(r)).WithOperation(operation)
+}
+
+// The project service to use for changes made by the request.
+func (s *Server) projects(r *http.Request) *project.ProjectService {
+	return s.ProjectService.WithOrigin(requestOrigin(r))
+}
+
+type operationKey struct{}
+
+// Gives each request an ID under which the operations it makes are grouped for undo. Unlike the
+// request ID, which clients may set with the X-Request-Id header, it is always generated by the
+// server, so that no two requests share it.
+func operationID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), operationKey{}, uuid.NewString())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Echoes the request ID set by middleware.RequestID, so that clients can find the changes made by
+// their requests in the activity history.
+func requestIDHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(middleware.RequestIDHeader, middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Get a project's activity history.
+// (GET /projects/{projectID}/activity)
+func (s *Server) GetProjectsProjectIDActivity(w http.ResponseWriter, r *http.Request, projectID string, params openapi.GetProjectsProjectIDActivityParams) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	cursor, limit := activityPageParams(params.Cursor, params.Limit)
+	page, err := s.ActivityService.ListProjectActivity(projectUUID, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetProjectsProjectIDActivityJSON200Response(activityPageModelToActivityPageOAPI(page))
+}
+
+// Get a task's activity history.
+// (GET /tasks/{taskID}/activity)
+func (s *Server) GetTasksTaskIDActivity(w http.ResponseWriter, r *http.Request, taskID string, params openapi.GetTasksTaskIDActivityParams) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	cursor, limit := activityPageParams(params.Cursor, params.Limit)
+	page, err := s.ActivityService.ListTaskActivity(taskUUID, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetTasksTaskIDActivityJSON200Response(activityPageModelToActivityPageOAPI(page))
+}
+
+func activityPageParams(cursorParam *string, limitParam *int) (string, int) {
+	cursor := ""
+	if cursorParam != nil {
+		cursor = *cursorParam
+	}
+
+	limit := 0 // the default page size
+	if limitParam != nil {
+		limit = *limitParam
+	}
+
+	return cursor, limit
+}
+
+func activityPageModelToActivityPageOAPI(page activity.Page) openapi.ActivityPage {
+	events := []openapi.ActivityEvent{}
+	for _, event := range page.Events {
+		events = append(events, activityEventModelToActivityEventOAPI(event))
+	}
+
+	var nextCursor *string
+	if page.NextCursor != "" {
+		nextCursor = &page.NextCursor
+	}
+
+	return openapi.ActivityPage{
+		Events:     events,
+		NextCursor: nextCursor,
+	}
+}
+
+func activityEventModelToActivityEventOAPI(event activity.Event) openapi.ActivityEvent {
+	projectID := event.ProjectID.String()
+
+	var taskID *string
+	if event.TaskID != nil {
+		tID := event.TaskID.String()
+		taskID = &tID
+	}
+
+	var userID *string
+	if event.UserID != nil {
+		uID := event.UserID.String()
+		userID = &uID
+	}
+
+	action := openapi.ActivityEventAction{}
+	// Unknown actions are left empty rather than failing the whole page
+	_ = action.FromValue(string(event.Action))
+
+	var before, after *map[string]any
+	if event.Before != nil {
+		before = &event.Before
+	}
+	if event.After != nil {
+		after = &event.After
+	}
+
+	return openapi.ActivityEvent{
+		ID:        &event.ID,
+		CreatedAt: &event.CreatedAt,
+		ProjectID: &projectID,
+		TaskID:    taskID,
+		Action:    &action,
+		Actor:     &event.Actor,
+		UserID:    userID,
+		RequestID: &event.RequestID,
+		Before:    before,
+		After:     after,
+	}
+}
@@ -0,0 +1,44 @@
+package todoctian
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+)
+
+// Archive a project.
+// (POST /projects/{projectID}/archive)
+func (s *Server) PostProjectsProjectIDArchive(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	project, err := s.projects(r).ArchiveProject(projectUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostProjectsProjectIDArchiveJSON200Response(projectModelToProjectOAPI(project))
+}
+
+// Unarchive a project.
+// (POST /projects/{projectID}/unarchive)
+func (s *Server) PostProjectsProjectIDUnarchive(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	project, err := s.projects(r).UnarchiveProject(projectUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostProjectsProjectIDUnarchiveJSON200Response(projectModelToProjectOAPI(project))
+}
@@ -0,0 +1,286 @@
+package todoctian
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/auth"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/user"
+)
+
+// How often the expired refresh tokens are purged from the denylist.
+const deniedTokenPurgeInterval = time.Hour
+
+// authenticate rejects the requests that are not made with a valid API token or JWT access token,
+// sent in the Authorization header as a bearer token. The user of the token is put in the context
+// of the request, see user.FromContext, so that the changes it makes are attributed to them. The
+// operations the spec declares without security requirements, such as getting a token, are public.
+func (s *Server) authenticate(router routers.Router) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, _, err := router.FindRoute(r)
+			if err == nil && route.Operation.Security != nil && len(*route.Operation.Security) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scheme, secret, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeProblem(w, problemUnauthenticated, "the Authorization header must hold a bearer token")
+				return
+			}
+
+			// API tokens are recognised by their prefix, other tokens are JWTs
+			secret = strings.TrimSpace(secret)
+			var u user.User
+			if strings.HasPrefix(secret, user.TokenPrefix) {
+				u, err = s.UserService.Authenticate(secret)
+			} else {
+				u, err = s.TokenService.Authenticate(secret)
+			}
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				s.writeError(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(user.NewContext(r.Context(), u)))
+		})
+	}
+}
+
+// Get a JWT access token.
+// (POST /auth/token)
+func (s *Server) PostAuthToken(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	var body openapi.PostAuthTokenJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	var pair auth.TokenPair
+	switch body.GrantType {
+	case openapi.TokenRequestGrantTypeAPIToken:
+		if body.APIToken == nil {
+			badRequest(w, "the api_token grant needs an apiToken")
+			return
+		}
+
+		u, apiToken, err := s.UserService.AuthenticateToken(*body.APIToken)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		pair, err = s.TokenService.IssueTokens(u, apiToken.ID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	case openapi.TokenRequestGrantTypeRefreshToken:
+		if body.RefreshToken == nil {
+			badRequest(w, "the refresh_token grant needs a refreshToken")
+			return
+		}
+
+		pair, err = s.TokenService.Refresh(*body.RefreshToken)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	default:
+		badRequest(w, "unknown grant type")
+		return
+	}
+
+	// The tokens must not be kept by caches (RFC 6749, section 5.1), nor by idempotentRequests
+	w.Header().Set("Cache-Control", "no-store")
+	return openapi.PostAuthTokenJSON200Response(openapi.TokenResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    openapi.TokenResponseTokenTypeBearer,
+		ExpiresIn:    int(pair.ExpiresIn.Seconds()),
+	})
+}
+
+// Revoke a refresh token.
+// (POST /auth/revoke)
+func (s *Server) PostAuthRevoke(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	var body openapi.PostAuthRevokeJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	err = s.TokenService.Revoke(body.RefreshToken)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return &openapi.Response{Code: http.StatusNoContent}
+}
+
+// Get the keys JWT access tokens are signed with.
+// (GET /.well-known/jwks.json)
+func (s *Server) GetWellKnownJwksJSON(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	keys := []openapi.JWK{}
+	for _, jwk := range s.TokenService.JWKS() {
+		keys = append(keys, openapi.JWK{
+			Kty: jwk.KeyType,
+			Crv: jwk.Curve,
+			X:   jwk.X,
+			Y:   jwk.Y,
+			Kid: jwk.KeyID,
+			Alg: jwk.Algorithm,
+			Use: jwk.Use,
+		})
+	}
+
+	// Verifiers fetch the keys again within minutes, and when they get a token with an unknown kid
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	return openapi.GetWellKnownJwksJSONJSON200Response(openapi.JWKS{Keys: keys})
+}
+
+// authenticatedUser returns the user a request is made by. Writes the error response and returns
+// false if the request was not authenticated, which only happens without the authenticate
+// middleware.
+func authenticatedUser(w http.ResponseWriter, r *http.Request) (user.User, bool) {
+	u, ok := user.FromContext(r.Context())
+	if !ok {
+		writeProblem(w, problemUnauthenticated, "")
+	}
+
+	return u, ok
+}
+
+// Get the authenticated user.
+// (GET /me)
+func (s *Server) GetMe(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	u, ok := authenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	return openapi.GetMeJSON200Response(openapi.User{
+		ID:        u.ID.String(),
+		Name:      u.Name,
+		CreatedAt: u.CreatedAt,
+	})
+}
+
+// Get the API tokens of the authenticated user.
+// (GET /me/tokens)
+func (s *Server) GetMeTokens(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	u, ok := authenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := s.UserService.ListTokens(u.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	tokensOAPI := []openapi.APIToken{}
+	for _, token := range tokens {
+		tokensOAPI = append(tokensOAPI, apiTokenModelToAPITokenOAPI(token))
+	}
+
+	return openapi.GetMeTokensJSON200Response(tokensOAPI)
+}
+
+// Create an API token.
+// (POST /me/tokens)
+func (s *Server) PostMeTokens(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	u, ok := authenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	var body openapi.PostMeTokensJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	token, secret, err := s.UserService.CreateToken(u.ID, body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	// The secret must not be kept anywhere, not even by idempotentRequests
+	w.Header().Set("Cache-Control", "no-store")
+	return openapi.PostMeTokensJSON201Response(openapi.CreatedAPIToken{
+		APIToken: apiTokenModelToAPITokenOAPI(token),
+		Secret:   secret,
+	})
+}
+
+// Revoke an API token.
+// (DELETE /me/tokens/{tokenID})
+func (s *Server) DeleteMeTokensTokenID(w http.ResponseWriter, r *http.Request, tokenID string) (_ *openapi.Response) {
+	tokenUUID, err := uuid.Parse(tokenID)
+	if err != nil {
+		badRequest(w, "malformed token ID")
+		return
+	}
+
+	u, ok := authenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := s.UserService.RevokeToken(u.ID, tokenUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.DeleteMeTokensTokenIDJSON200Response(apiTokenModelToAPITokenOAPI(token))
+}
+
+func apiTokenModelToAPITokenOAPI(token user.APIToken) openapi.APIToken {
+	return openapi.APIToken{
+		ID:         token.ID.String(),
+		Name:       token.Name,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+	}
+}
+
+// runDeniedTokenPurgeJob purges the expired refresh tokens from the denylist every interval until
+// ctx is done.
+func (s *Server) runDeniedTokenPurgeJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.TokenService.PurgeExpiredTokens()
+		if err != nil {
+			s.logger.Error("failed to purge expired refresh tokens", slog.Any("err", err))
+		} else {
+			s.logger.Info("purged expired refresh tokens", slog.Int64("tokens", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package todoctian
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// Run several task operations at once.
+// (POST /tasks/batch)
+func (s *Server) PostTasksBatch(w http.ResponseWriter, r *http.Request, _ openapi.PostTasksBatchParams) (_ *openapi.Response) {
+	if r.Body == nil {
+		badRequest(w, "request body is required for this operation")
+		return
+	}
+
+	var body openapi.PostTasksBatchJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	operations := make([]task.BatchOperation, 0, len(body.Operations))
+	for i, opOAPI := range body.Operations {
+		op, err := batchOperationOAPIToBatchOperationModel(opOAPI)
+		if err != nil {
+			s.batchError(w, r, &task.BatchError{Index: i, Kind: task.BatchOperationKind(opOAPI.Op.ToValue()), Err: err})
+			return
+		}
+
+		operations = append(operations, op)
+	}
+
+	results, err := s.tasks(r).RunBatch(operations)
+	if err != nil {
+		s.batchError(w, r, err)
+		return
+	}
+
+	resultsOAPI := make([]openapi.TaskBatchOperationResult, 0, len(results))
+	for _, result := range results {
+		taskOAPI, err := taskModelToTaskOAPI(result.Task)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		resultOAPI := openapi.TaskBatchOperationResult{Task: &taskOAPI}
+		kind := string(result.Kind)
+		resultOAPI.Op = &kind
+		if result.TempID != "" {
+			tempID := result.TempID
+			resultOAPI.TempID = &tempID
+		}
+
+		resultsOAPI = append(resultsOAPI, resultOAPI)
+	}
+
+	return openapi.PostTasksBatchJSON200Response(openapi.TaskBatchResult{Results: resultsOAPI})
+}
+
+// batchError tells which operation made the batch fail, and why.
+func (s *Server) batchError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := openapi.TaskBatchProblem{Problem: problemOf(err)}
+
+	var batchErr *task.BatchError
+	if errors.As(err, &batchErr) {
+		kind := string(batchErr.Kind)
+		problem.Index = &batchErr.Index
+		problem.Op = &kind
+		if problem.Detail != nil {
+			detail := batchErr.Err.Error()
+			problem.Detail = &detail
+		}
+	}
+
+	if problem.Status >= http.StatusInternalServerError {
+		s.writeError(w, r, err)
+		return
+	}
+
+	writeProblemBody(w, problem.Status, problem)
+}
+
+func batchOperationOAPIToBatchOperationModel(opOAPI openapi.TaskBatchOperation) (task.BatchOperation, error) {
+	op := task.BatchOperation{Kind: task.BatchOperationKind(opOAPI.Op.ToValue())}
+	if opOAPI.TempID != nil {
+		op.TempID = *opOAPI.TempID
+	}
+	if opOAPI.TaskID != nil {
+		op.TaskRef = *opOAPI.TaskID
+	}
+	if opOAPI.ParentTaskID != nil {
+		op.ParentRef = *opOAPI.ParentTaskID
+	}
+	if opOAPI.ProjectID != nil && *opOAPI.ProjectID != "" {
+		projectID, err := uuid.Parse(*opOAPI.ProjectID)
+		if err != nil {
+			return task.BatchOperation{}, internal.NewValidationError("malformed project ID")
+		}
+		op.ProjectID = projectID
+	}
+	if opOAPI.Name != nil {
+		op.Name = *opOAPI.Name
+	}
+	if opOAPI.Status != nil {
+		op.Status = opOAPI.Status.ToValue()
+	}
+	op.DueAt = opOAPI.DueAt
+
+	return op, nil
+}
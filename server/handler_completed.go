@@ -0,0 +1,60 @@
+package todoctian
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+)
+
+// Get the tasks of a project completed in a period.
+// (GET /projects/{projectID}/tasks/completed)
+func (s *Server) GetProjectsProjectIDTasksCompleted(w http.ResponseWriter, r *http.Request, projectID string, params openapi.GetProjectsProjectIDTasksCompletedParams) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	tasksOAPI, ok := s.listCompletedTasks(w, r, &projectUUID, time.Time(params.From), time.Time(params.To))
+	if !ok {
+		return
+	}
+
+	return openapi.GetProjectsProjectIDTasksCompletedJSON200Response(tasksOAPI)
+}
+
+// Get the tasks completed in a period.
+// (GET /tasks/completed)
+func (s *Server) GetTasksCompleted(w http.ResponseWriter, r *http.Request, params openapi.GetTasksCompletedParams) (_ *openapi.Response) {
+	tasksOAPI, ok := s.listCompletedTasks(w, r, nil, time.Time(params.From), time.Time(params.To))
+	if !ok {
+		return
+	}
+
+	return openapi.GetTasksCompletedJSON200Response(tasksOAPI)
+}
+
+// listCompletedTasks lists the tasks completed in a period. Writes the error response and returns
+// false if they could not be listed.
+func (s *Server) listCompletedTasks(w http.ResponseWriter, r *http.Request, projectID *uuid.UUID, from time.Time, to time.Time) ([]openapi.Task, bool) {
+	tasks, err := s.TaskService.ListCompletedTasks(projectID, from, to)
+	if err != nil {
+		s.writeError(w, r, err)
+		return nil, false
+	}
+
+	tasksOAPI := []openapi.Task{}
+	for _, t := range tasks {
+		taskOAPI, err := taskModelToTaskOAPI(t)
+		if err != nil {
+			s.writeError(w, r, err)
+			return nil, false
+		}
+
+		tasksOAPI = append(tasksOAPI, taskOAPI)
+	}
+
+	return tasksOAPI, true
+}
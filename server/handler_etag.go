@@ -0,0 +1,151 @@
+package todoctian
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// versionETag is the ETag of a project or task, derived from its version.
+func versionETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func setVersionETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", versionETag(version))
+}
+
+// projectETag is the ETag of a project fetched with its task summary. The summary changes along
+// with the tasks of the project, whose version does not, so it is part of the ETag too, e.g.
+// "3.10.4.2" for version 3 and 10 tasks, 4 of which are completed and 2 at the root. The estimate
+// and the remaining work follow for projects with estimated tasks, e.g. "3.10.4.2.20.8".
+func projectETag(p project.Project) string {
+	if p.TaskSummary == nil {
+		return versionETag(p.Version)
+	}
+
+	s := p.TaskSummary
+	if s.Estimate != 0 {
+		return fmt.Sprintf(`"%d.%d.%d.%d.%d.%d"`, p.Version, s.Total, s.Completed, s.Root, s.Estimate, s.RemainingWork)
+	}
+
+	return fmt.Sprintf(`"%d.%d.%d.%d"`, p.Version, s.Total, s.Completed, s.Root)
+}
+
+// taskETag is the ETag of a task fetched with its progress. The progress changes along with the
+// subtasks of the task, whose version does not, so it is part of the ETag of tasks with subtasks,
+// e.g. "3.2.5.1" for version 3 with 2 direct subtasks, 5 descendants and 1 of them completed. The
+// effective estimate and the remaining work follow if there are any, e.g. "3.2.5.1.13.8".
+func taskETag(t task.Task) string {
+	if t.Progress == nil || t.Progress.Descendants == 0 {
+		return versionETag(t.Version)
+	}
+
+	p := t.Progress
+	if p.EffectiveEstimate != nil {
+		return fmt.Sprintf(`"%d.%d.%d.%d.%d.%d"`, t.Version, p.ChildCount, p.Descendants, p.CompletedDescendants,
+			*p.EffectiveEstimate, *p.RemainingWork)
+	}
+
+	return fmt.Sprintf(`"%d.%d.%d.%d"`, t.Version, p.ChildCount, p.Descendants, p.CompletedDescendants)
+}
+
+// versionTags reduces the project and task ETags of an If-Match header to the versions of the
+// projects and tasks, so that changes of their tasks and subtasks do not prevent changing them.
+func versionTags(header string) string {
+	tags := strings.Split(header, ",")
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if version, _, found := strings.Cut(tag, "."); found && strings.HasPrefix(tag, `"`) {
+			tag = version + `"`
+		}
+		tags[i] = tag
+	}
+
+	return strings.Join(tags, ", ")
+}
+
+// etagListed tells if an If-Match or If-None-Match header lists the ETag. If-Match uses the strong
+// comparison, so a weak ETag never matches, whereas If-None-Match uses the weak comparison.
+func etagListed(header string, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notModified answers a conditional GET with 304 Not Modified if the client already has the
+// current version, whose ETag is given. The ETag header must be set beforehand.
+func notModified(w http.ResponseWriter, ifNoneMatch *string, etag string) bool {
+	if ifNoneMatch == nil || !etagListed(*ifNoneMatch, etag, true) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// checkIfMatch makes sure that the client changes the version it last fetched, so that it does not
+// overwrite the changes made by someone else in the meantime. Writes the error response and
+// returns false if the precondition fails.
+func checkIfMatch(w http.ResponseWriter, ifMatch *string, version int) bool {
+	if ifMatch == nil {
+		writeProblem(w, problemPreconditionRequired, "the If-Match header is required for this operation")
+		return false
+	}
+
+	if !etagListed(*ifMatch, versionETag(version), false) {
+		writeProblem(w, problemPreconditionFailed, "the resource was changed since it was fetched")
+		return false
+	}
+
+	return true
+}
+
+// checkTaskIfMatch checks the If-Match header of a request that changes a task, and gives the
+// version the client changes. The change must be made with TaskService.IfVersion, so that it fails
+// if the task is changed in between. Writes the error response and returns false if the task does
+// not exist or the precondition fails.
+func (s *Server) checkTaskIfMatch(w http.ResponseWriter, r *http.Request, taskID uuid.UUID, ifMatch *string) (int, bool) {
+	task, err := s.TaskService.FindTaskByID(taskID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return 0, false
+	}
+
+	if ifMatch != nil {
+		tags := versionTags(*ifMatch)
+		ifMatch = &tags
+	}
+	return task.Version, checkIfMatch(w, ifMatch, task.Version)
+}
+
+// checkProjectIfMatch checks the If-Match header of a request that changes a project, and gives
+// the version the client changes. The change must be made with ProjectService.IfVersion, so that it
+// fails if the project is changed in between. Writes the error response and returns false if the
+// project does not exist or the precondition fails.
+func (s *Server) checkProjectIfMatch(w http.ResponseWriter, r *http.Request, projectID uuid.UUID, ifMatch *string) (int, bool) {
+	project, err := s.ProjectService.GetProject(projectID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return 0, false
+	}
+
+	if ifMatch != nil {
+		tags := versionTags(*ifMatch)
+		ifMatch = &tags
+	}
+	return project.Version, checkIfMatch(w, ifMatch, project.Version)
+}
@@ -0,0 +1,173 @@
+package todoctian
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/murasakiwano/todoctian/server/idempotency"
+	"github.com/murasakiwano/todoctian/server/user"
+)
+
+// Request header with which clients make their POST requests safe to retry.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// Response header telling clients that the response is the one to their original request.
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
+// Longest idempotency key accepted. Clients are expected to send UUIDs.
+const maxIdempotencyKeyLength = 255
+
+// How often expired idempotency keys are purged.
+const idempotencyKeyPurgeInterval = time.Hour
+
+// Headers of the original response that are replayed along with its body.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// idempotentRequests handles the POST requests that have an Idempotency-Key header only once: retries
+// get the response to the original request. Keys are scoped to the authenticated user, so that the
+// keys of different users never clash and no one gets the response to someone else's request.
+//
+// Responses to requests that failed with a server error are not stored, so that they can be
+// retried. Neither are the responses that must not be stored (Cache-Control: no-store), such as
+// the ones holding the secrets of new tokens: those requests are handled again when retried.
+func (s *Server) idempotentRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			badRequest(w, "the idempotency key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			badRequest(w, "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := idempotencyScope(r)
+		key = scope + ":" + key
+		fingerprint := idempotency.Fingerprint(scope, r.Method, r.URL.Path, body)
+		response, err := s.IdempotencyService.Begin(key, fingerprint)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		if response != nil {
+			replayResponse(w, *response)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if recorder.statusCode >= http.StatusInternalServerError || isNoStore(recorder.Header()) {
+			err = s.IdempotencyService.Release(key)
+		} else {
+			err = s.IdempotencyService.Complete(key, recorder.response())
+		}
+		if err != nil {
+			// The request was handled, only retrying it is not safe
+			s.logger.Error("failed to store idempotent response", slog.Any("err", err))
+		}
+	})
+}
+
+// idempotencyScope tells who the idempotency keys of a request belong to: its user, or no one for
+// the public operations.
+func idempotencyScope(r *http.Request) string {
+	if u, ok := user.FromContext(r.Context()); ok {
+		return u.ID.String()
+	}
+
+	return "public"
+}
+
+func isNoStore(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func replayResponse(w http.ResponseWriter, response idempotency.Response) {
+	for name, value := range response.Header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+
+	w.WriteHeader(response.StatusCode)
+	w.Write(response.Body)
+}
+
+// responseRecorder keeps a copy of the response it writes, so that it can be replayed.
+type responseRecorder struct {
+	http.ResponseWriter
+	body        bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+}
+
+func (rr *responseRecorder) WriteHeader(statusCode int) {
+	if !rr.wroteHeader {
+		rr.statusCode = statusCode
+		rr.wroteHeader = true
+	}
+	rr.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+func (rr *responseRecorder) response() idempotency.Response {
+	header := map[string]string{}
+	for _, name := range replayedHeaders {
+		if value := rr.Header().Get(name); value != "" {
+			header[name] = value
+		}
+	}
+
+	return idempotency.Response{
+		StatusCode: rr.statusCode,
+		Header:     header,
+		Body:       rr.body.Bytes(),
+	}
+}
+
+// runIdempotencyKeyPurgeJob purges the expired idempotency keys every interval until ctx is done.
+func (s *Server) runIdempotencyKeyPurgeJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.IdempotencyService.PurgeExpiredKeys()
+		if err != nil {
+			s.logger.Error("failed to purge expired idempotency keys", slog.Any("err", err))
+		} else {
+			s.logger.Info("purged expired idempotency keys", slog.Int64("keys", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package todoctian
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/iteration"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// The iteration service to use for changes made by the request.
+func (s *Server) iterations(r *http.Request) *iteration.IterationService {
+	return s.IterationService.WithOrigin(requestOrigin(r))
+}
+
+// Get all iterations.
+// (GET /iterations)
+func (s *Server) GetIterations(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	iterations, err := s.IterationService.ListIterations()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	iterationsOAPI := []openapi.Iteration{}
+	for _, it := range iterations {
+		iterationsOAPI = append(iterationsOAPI, iterationModelToIterationOAPI(it))
+	}
+
+	return openapi.GetIterationsJSON200Response(iterationsOAPI)
+}
+
+// Create an iteration.
+// (POST /iterations)
+func (s *Server) PostIterations(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	var body openapi.PostIterationsJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	it, err := s.IterationService.CreateIteration(body.Name, body.StartsAt, body.EndsAt)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostIterationsJSON201Response(iterationModelToIterationOAPI(it))
+}
+
+// Get a single iteration.
+// (GET /iterations/{iterationID})
+func (s *Server) GetIterationsIterationID(w http.ResponseWriter, r *http.Request, iterationID string) (_ *openapi.Response) {
+	iterationUUID, err := uuid.Parse(iterationID)
+	if err != nil {
+		badRequest(w, "malformed iteration ID")
+		return
+	}
+
+	it, err := s.IterationService.GetIteration(iterationUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetIterationsIterationIDJSON200Response(iterationModelToIterationOAPI(it))
+}
+
+// Update an iteration.
+// (PATCH /iterations/{iterationID})
+func (s *Server) PatchIterationsIterationID(w http.ResponseWriter, r *http.Request, iterationID string) (_ *openapi.Response) {
+	iterationUUID, err := uuid.Parse(iterationID)
+	if err != nil {
+		badRequest(w, "malformed iteration ID")
+		return
+	}
+
+	var body openapi.PatchIterationsIterationIDJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	it, err := s.IterationService.UpdateIteration(iterationUUID, iteration.IterationUpdate{
+		Name:     body.Name,
+		StartsAt: body.StartsAt,
+		EndsAt:   body.EndsAt,
+	})
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PatchIterationsIterationIDJSON200Response(iterationModelToIterationOAPI(it))
+}
+
+// Delete an iteration.
+// (DELETE /iterations/{iterationID})
+func (s *Server) DeleteIterationsIterationID(w http.ResponseWriter, r *http.Request, iterationID string) (_ *openapi.Response) {
+	iterationUUID, err := uuid.Parse(iterationID)
+	if err != nil {
+		badRequest(w, "malformed iteration ID")
+		return
+	}
+
+	it, err := s.iterations(r).DeleteIteration(iterationUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.DeleteIterationsIterationIDJSON204Response(iterationModelToIterationOAPI(it))
+}
+
+// Get the tasks of an iteration.
+// (GET /iterations/{iterationID}/tasks)
+func (s *Server) GetIterationsIterationIDTasks(w http.ResponseWriter, r *http.Request, iterationID string) (_ *openapi.Response) {
+	iterationUUID, err := uuid.Parse(iterationID)
+	if err != nil {
+		badRequest(w, "malformed iteration ID")
+		return
+	}
+
+	tasks, err := s.IterationService.ListIterationTasks(iterationUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	tasksOAPI, err := taskModelsToTaskOAPIs(tasks)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetIterationsIterationIDTasksJSON200Response(tasksOAPI)
+}
+
+// Close an iteration.
+// (POST /iterations/{iterationID}/close)
+func (s *Server) PostIterationsIterationIDClose(w http.ResponseWriter, r *http.Request, iterationID string) (_ *openapi.Response) {
+	iterationUUID, err := uuid.Parse(iterationID)
+	if err != nil {
+		badRequest(w, "malformed iteration ID")
+		return
+	}
+
+	it, err := s.IterationService.CloseIteration(iterationUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostIterationsIterationIDCloseJSON200Response(iterationModelToIterationOAPI(it))
+}
+
+// Roll the incomplete tasks of an iteration over.
+// (POST /iterations/{iterationID}/roll-over)
+func (s *Server) PostIterationsIterationIDRollOver(w http.ResponseWriter, r *http.Request, iterationID string) (_ *openapi.Response) {
+	iterationUUID, err := uuid.Parse(iterationID)
+	if err != nil {
+		badRequest(w, "malformed iteration ID")
+		return
+	}
+
+	// The body is optional, so an empty one is fine
+	var body openapi.PostIterationsIterationIDRollOverJSONRequestBody
+	if r.Body != nil && r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			badRequest(w, "malformed request body")
+			return
+		}
+	}
+
+	var toID *uuid.UUID
+	if body.ToIterationID != nil {
+		id, err := uuid.Parse(*body.ToIterationID)
+		if err != nil {
+			badRequest(w, "malformed iteration ID")
+			return
+		}
+		toID = &id
+	}
+
+	next, tasks, err := s.iterations(r).RollOverTasks(iterationUUID, toID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	tasksOAPI, err := taskModelsToTaskOAPIs(tasks)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostIterationsIterationIDRollOverJSON200Response(openapi.RollOver{
+		Iteration: iterationModelToIterationOAPI(next),
+		Tasks:     tasksOAPI,
+	})
+}
+
+// Plan a task in an iteration.
+// (PUT /tasks/{taskID}/iteration)
+func (s *Server) PutTasksTaskIDIteration(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	var body openapi.PutTasksTaskIDIterationJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	var iterationUUID *uuid.UUID
+	if body.IterationID != nil {
+		id, err := uuid.Parse(*body.IterationID)
+		if err != nil {
+			badRequest(w, "malformed iteration ID")
+			return
+		}
+		iterationUUID = &id
+	}
+
+	t, err := s.iterations(r).PlanTask(taskUUID, iterationUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	taskOAPI, err := taskModelToTaskOAPI(t)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	setVersionETag(w, t.Version)
+	return openapi.PutTasksTaskIDIterationJSON200Response(taskOAPI)
+}
+
+func iterationModelToIterationOAPI(it iteration.Iteration) openapi.Iteration {
+	var velocity *openapi.Velocity
+	if it.Velocity != nil {
+		velocity = &openapi.Velocity{
+			CompletedTasks: it.Velocity.CompletedTasks,
+			Points:         it.Velocity.Points,
+			Minutes:        it.Velocity.Minutes,
+		}
+	}
+
+	return openapi.Iteration{
+		ID:        it.ID.String(),
+		Name:      it.Name,
+		StartsAt:  it.StartsAt,
+		EndsAt:    it.EndsAt,
+		CreatedAt: it.CreatedAt,
+		Closed:    it.IsClosed(),
+		ClosedAt:  it.ClosedAt,
+		Velocity:  velocity,
+	}
+}
+
+func taskModelsToTaskOAPIs(tasks []task.Task) ([]openapi.Task, error) {
+	tasksOAPI := []openapi.Task{}
+	for _, t := range tasks {
+		tOAPI, err := taskModelToTaskOAPI(t)
+		if err != nil {
+			return nil, err
+		}
+		tasksOAPI = append(tasksOAPI, tOAPI)
+	}
+
+	return tasksOAPI, nil
+}
@@ -0,0 +1,172 @@
+package todoctian
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// The sort query parameters, by their name in the API.
+var (
+	taskSortFields = map[string]task.SortField{
+		"createdAt": task.SortByCreatedAt,
+		"name":      task.SortByName,
+		"order":     task.SortByOrder,
+		"status":    task.SortByStatus,
+	}
+	projectSortFields = map[string]project.SortField{
+		"createdAt": project.SortByCreatedAt,
+		"name":      project.SortByName,
+		"order":     project.SortByOrder,
+	}
+)
+
+// setNextPageLink points the client to the next page of a list with a Link header. The URL of the
+// next page is the URL of the request with the cursor of the next page.
+func setNextPageLink(w http.ResponseWriter, r *http.Request, nextCursor string) {
+	if nextCursor == "" {
+		return
+	}
+
+	query := r.URL.Query()
+	query.Set("cursor", nextCursor)
+	w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, query.Encode()))
+}
+
+// parseSort splits a sort query parameter, such as "-createdAt", into the name of the field and
+// the direction.
+func parseSort(sort string) (string, bool) {
+	if strings.HasPrefix(sort, "-") {
+		return sort[1:], true
+	}
+
+	return sort, false
+}
+
+// Builds the options of a task list from the query parameters shared by the task list endpoints.
+func taskListOptions(sort *string, status *openapi.TaskStatusFilter, parentTaskID *openapi.ParentTaskIDFilter, sectionID *openapi.SectionIDFilter, root *openapi.RootFilter, cursor *openapi.Cursor, limit *openapi.Limit) (task.ListOptions, error) {
+	opts := task.ListOptions{}
+	if sort != nil {
+		name, descending := parseSort(*sort)
+		field, ok := taskSortFields[name]
+		if !ok {
+			return task.ListOptions{}, fmt.Errorf("unknown sort field %q", name)
+		}
+		opts.SortBy = field
+		opts.Descending = descending
+	}
+
+	if status != nil {
+		taskStatus := task.TaskStatus{}
+		err := taskStatus.FromString(string(*status))
+		if err != nil {
+			return task.ListOptions{}, fmt.Errorf("unknown task status %q", *status)
+		}
+		opts.Status = &taskStatus
+	}
+
+	if parentTaskID != nil {
+		parentUUID, err := uuid.Parse(string(*parentTaskID))
+		if err != nil {
+			return task.ListOptions{}, errors.New("malformed parent task ID")
+		}
+		opts.ParentTaskID = &parentUUID
+	}
+
+	if sectionID != nil {
+		sectionUUID, err := uuid.Parse(string(*sectionID))
+		if err != nil {
+			return task.ListOptions{}, errors.New("malformed section ID")
+		}
+		opts.SectionID = &sectionUUID
+	}
+
+	opts.RootOnly = root != nil && bool(*root)
+	if cursor != nil {
+		opts.Cursor = string(*cursor)
+	}
+	if limit != nil {
+		opts.Limit = int(*limit)
+	}
+
+	return opts, nil
+}
+
+// projectListOptions converts the query parameters of the project lists to list options.
+func projectListOptions(archived *bool, sort *string, cursor *openapi.Cursor, limit *openapi.Limit) (project.ListOptions, error) {
+	opts := project.ListOptions{IncludeArchived: archived != nil && *archived}
+	if sort != nil {
+		name, descending := parseSort(*sort)
+		field, ok := projectSortFields[name]
+		if !ok {
+			return project.ListOptions{}, fmt.Errorf("unknown sort field %q", name)
+		}
+		opts.SortBy = field
+		opts.Descending = descending
+	}
+	if cursor != nil {
+		opts.Cursor = string(*cursor)
+	}
+	if limit != nil {
+		opts.Limit = int(*limit)
+	}
+
+	return opts, nil
+}
+
+// listProjectsPage lists a page of projects, with their task summaries, and links to the next
+// one. Writes the error response and returns false if it cannot be listed.
+func (s *Server) listProjectsPage(w http.ResponseWriter, r *http.Request, opts project.ListOptions) ([]openapi.Project, bool) {
+	page, err := s.ProjectService.ListProjectsPage(opts)
+	if err != nil {
+		s.writeError(w, r, err)
+		return nil, false
+	}
+
+	page.Projects, err = s.ProjectService.SummarizeTasks(page.Projects)
+	if err != nil {
+		s.writeError(w, r, err)
+		return nil, false
+	}
+
+	s.logger.Debug("got project list", slog.Any("projects", page.Projects))
+
+	projects := []openapi.Project{}
+	for _, p := range page.Projects {
+		projects = append(projects, projectModelToProjectOAPI(p))
+	}
+
+	setNextPageLink(w, r, page.NextCursor)
+	return projects, true
+}
+
+// listTasksPage lists a page of tasks and links to the next one. Writes the error response and
+// returns false if it cannot be listed.
+func (s *Server) listTasksPage(w http.ResponseWriter, r *http.Request, opts task.ListOptions) ([]openapi.Task, bool) {
+	page, err := s.TaskService.ListTasksPage(opts)
+	if err != nil {
+		s.writeError(w, r, err)
+		return nil, false
+	}
+
+	tasksOAPI := []openapi.Task{}
+	for _, task := range page.Tasks {
+		taskOAPI, err := taskModelToTaskOAPI(task)
+		if err != nil {
+			s.writeError(w, r, fmt.Errorf("failed to convert task %s to OAPI model: %w", task.ID, err))
+			return nil, false
+		}
+
+		tasksOAPI = append(tasksOAPI, taskOAPI)
+	}
+
+	setNextPageLink(w, r, page.NextCursor)
+	return tasksOAPI, true
+}
@@ -0,0 +1,165 @@
+package todoctian
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/murasakiwano/todoctian/server/idempotency"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+)
+
+// Content type of the error responses, see RFC 9457.
+const problemContentType = "application/problem+json"
+
+// A problemType is a kind of error reported by the API. Its URI is part of the API: clients
+// identify errors by it, so it must never change.
+type problemType struct {
+	uri    string
+	title  string
+	status int
+}
+
+var (
+	problemUnauthenticated = problemType{
+		"urn:todoctian:problem:unauthenticated", "Authentication required", http.StatusUnauthorized,
+	}
+	problemNotFound = problemType{
+		"urn:todoctian:problem:not-found", "Resource not found", http.StatusNotFound,
+	}
+	problemAlreadyExists = problemType{
+		"urn:todoctian:problem:already-exists", "Resource already exists", http.StatusConflict,
+	}
+	problemValidation = problemType{
+		"urn:todoctian:problem:validation", "Invalid request", http.StatusBadRequest,
+	}
+	problemConflict = problemType{
+		"urn:todoctian:problem:conflict", "Conflict with the current state", http.StatusConflict,
+	}
+	problemPreconditionFailed = problemType{
+		"urn:todoctian:problem:precondition-failed", "Precondition failed", http.StatusPreconditionFailed,
+	}
+	problemPreconditionRequired = problemType{
+		"urn:todoctian:problem:precondition-required", "Precondition required", http.StatusPreconditionRequired,
+	}
+	problemIdempotencyKeyReused = problemType{
+		"urn:todoctian:problem:idempotency-key-reused", "Idempotency key reused", http.StatusUnprocessableEntity,
+	}
+	problemMethodNotAllowed = problemType{
+		"urn:todoctian:problem:method-not-allowed", "Method not allowed", http.StatusMethodNotAllowed,
+	}
+	problemInternal = problemType{
+		"urn:todoctian:problem:internal", "Internal server error", http.StatusInternalServerError,
+	}
+)
+
+// The problem types of the errors, most specific first. Errors that match none of them are
+// internal errors.
+var errorProblemTypes = []struct {
+	err         error
+	problemType problemType
+}{
+	{idempotency.ErrKeyReused, problemIdempotencyKeyReused},
+	{internal.ErrUnauthenticated, problemUnauthenticated},
+	{internal.ErrNotFound, problemNotFound},
+	{internal.ErrAlreadyExists, problemAlreadyExists},
+	{internal.ErrValidation, problemValidation},
+	{internal.ErrConflict, problemConflict},
+	{internal.ErrPreconditionFailed, problemPreconditionFailed},
+	{internal.ErrPreconditionRequired, problemPreconditionRequired},
+}
+
+// problemTypeOf tells how an error is reported to clients. The second value is false for internal
+// errors, whose details must not be disclosed.
+func problemTypeOf(err error) (problemType, bool) {
+	for _, p := range errorProblemTypes {
+		if errors.Is(err, p.err) {
+			return p.problemType, true
+		}
+	}
+
+	return problemInternal, false
+}
+
+func newProblem(p problemType, detail string) openapi.Problem {
+	problem := openapi.Problem{Type: p.uri, Title: p.title, Status: p.status}
+	if detail != "" {
+		problem.Detail = &detail
+	}
+
+	return problem
+}
+
+// problemOf makes the problem details of an error. Internal errors have no detail, and the
+// invalid fields of validation errors are listed.
+func problemOf(err error) openapi.Problem {
+	p, ok := problemTypeOf(err)
+	if !ok {
+		return newProblem(p, "")
+	}
+
+	problem := newProblem(p, err.Error())
+	var fieldErrs internal.FieldErrors
+	if errors.As(err, &fieldErrs) {
+		for _, fieldErr := range fieldErrs {
+			problem.Errors = append(problem.Errors, openapi.FieldError{Field: fieldErr.Field, Message: fieldErr.Message})
+		}
+	}
+
+	return problem
+}
+
+func writeProblemBody(w http.ResponseWriter, status int, problem any) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// writeError is where every error ends up: it writes the problem details of the error, with the
+// status of its kind. Internal errors are logged, since the client is not told about them.
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := problemOf(err)
+	if problem.Status >= http.StatusInternalServerError {
+		s.logger.Error("failed to handle request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+			slog.Any("err", err),
+		)
+	}
+
+	writeProblemBody(w, problem.Status, problem)
+}
+
+// writeProblem writes a problem of the given type, for the errors that are detected by the
+// handlers themselves.
+func writeProblem(w http.ResponseWriter, p problemType, detail string) {
+	writeProblemBody(w, p.status, newProblem(p, detail))
+}
+
+// badRequest rejects a malformed request.
+func badRequest(w http.ResponseWriter, detail string) {
+	writeProblem(w, problemValidation, detail)
+}
+
+// requestError reports the errors of the generated router, e.g. a malformed query parameter.
+func requestError(w http.ResponseWriter, _ *http.Request, err error) {
+	badRequest(w, err.Error())
+}
+
+func routeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, problemNotFound, fmt.Sprintf("there is no route %s", r.URL.Path))
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, problemMethodNotAllowed, fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path))
+}
+
+func internalServerError(w http.ResponseWriter) {
+	writeProblem(w, problemInternal, "")
+}
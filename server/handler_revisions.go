@@ -0,0 +1,101 @@
+package todoctian
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// Get a task's revisions.
+// (GET /tasks/{taskID}/revisions)
+func (s *Server) GetTasksTaskIDRevisions(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	revisions, err := s.TaskService.ListRevisions(taskUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	revisionsOAPI := []openapi.TaskRevision{}
+	for _, revision := range revisions {
+		revisionOAPI, err := taskRevisionModelToTaskRevisionOAPI(revision)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		revisionsOAPI = append(revisionsOAPI, revisionOAPI)
+	}
+
+	return openapi.GetTasksTaskIDRevisionsJSON200Response(revisionsOAPI)
+}
+
+// Get a single revision of a task.
+// (GET /tasks/{taskID}/revisions/{revision})
+func (s *Server) GetTasksTaskIDRevisionsRevision(w http.ResponseWriter, r *http.Request, taskID string, revision int) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	taskRevision, err := s.TaskService.GetRevision(taskUUID, revision)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	revisionOAPI, err := taskRevisionModelToTaskRevisionOAPI(taskRevision)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetTasksTaskIDRevisionsRevisionJSON200Response(revisionOAPI)
+}
+
+// Restore a revision of a task.
+// (POST /tasks/{taskID}/revisions/{revision}/restore)
+func (s *Server) PostTasksTaskIDRevisionsRevisionRestore(w http.ResponseWriter, r *http.Request, taskID string, revision int) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	restoredTask, err := s.tasks(r).RestoreRevision(taskUUID, revision)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	taskOAPI, err := taskModelToTaskOAPI(restoredTask)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostTasksTaskIDRevisionsRevisionRestoreJSON200Response(taskOAPI)
+}
+
+func taskRevisionModelToTaskRevisionOAPI(revision task.Revision) (openapi.TaskRevision, error) {
+	taskOAPI, err := taskModelToTaskOAPI(revision.Task)
+	if err != nil {
+		return openapi.TaskRevision{}, err
+	}
+
+	action := string(revision.Action)
+	return openapi.TaskRevision{
+		Revision:  &revision.Number,
+		CreatedAt: &revision.CreatedAt,
+		Action:    &action,
+		Task:      &taskOAPI,
+	}, nil
+}
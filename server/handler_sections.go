@@ -0,0 +1,216 @@
+package todoctian
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/project"
+)
+
+// Get the sections of a project.
+// (GET /projects/{projectID}/sections)
+func (s *Server) GetProjectsProjectIDSections(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	sections, err := s.ProjectService.ListSections(projectUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	sectionsOAPI := []openapi.Section{}
+	for _, section := range sections {
+		sectionsOAPI = append(sectionsOAPI, sectionModelToSectionOAPI(section))
+	}
+
+	return openapi.GetProjectsProjectIDSectionsJSON200Response(sectionsOAPI)
+}
+
+// Create a section.
+// (POST /projects/{projectID}/sections)
+func (s *Server) PostProjectsProjectIDSections(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	var body openapi.PostProjectsProjectIDSectionsJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	section, err := s.projects(r).CreateSection(projectUUID, body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostProjectsProjectIDSectionsJSON201Response(sectionModelToSectionOAPI(section))
+}
+
+// Get a single section.
+// (GET /projects/{projectID}/sections/{sectionID})
+func (s *Server) GetProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) (_ *openapi.Response) {
+	projectUUID, sectionUUID, ok := parseSectionPath(w, projectID, sectionID)
+	if !ok {
+		return
+	}
+
+	section, err := s.ProjectService.GetSection(projectUUID, sectionUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetProjectsProjectIDSectionsSectionIDJSON200Response(sectionModelToSectionOAPI(section))
+}
+
+// Rename a section.
+// (PATCH /projects/{projectID}/sections/{sectionID})
+func (s *Server) PatchProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) (_ *openapi.Response) {
+	projectUUID, sectionUUID, ok := parseSectionPath(w, projectID, sectionID)
+	if !ok {
+		return
+	}
+
+	var body openapi.PatchProjectsProjectIDSectionsSectionIDJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	section, err := s.projects(r).RenameSection(projectUUID, sectionUUID, body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PatchProjectsProjectIDSectionsSectionIDJSON200Response(sectionModelToSectionOAPI(section))
+}
+
+// Delete a section.
+// (DELETE /projects/{projectID}/sections/{sectionID})
+func (s *Server) DeleteProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) (_ *openapi.Response) {
+	projectUUID, sectionUUID, ok := parseSectionPath(w, projectID, sectionID)
+	if !ok {
+		return
+	}
+
+	section, err := s.projects(r).DeleteSection(projectUUID, sectionUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.DeleteProjectsProjectIDSectionsSectionIDJSON204Response(sectionModelToSectionOAPI(section))
+}
+
+// Move a section in the list of sections of its project.
+// (PUT /projects/{projectID}/sections/{sectionID}/position)
+func (s *Server) PutProjectsProjectIDSectionsSectionIDPosition(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) (_ *openapi.Response) {
+	projectUUID, sectionUUID, ok := parseSectionPath(w, projectID, sectionID)
+	if !ok {
+		return
+	}
+
+	var body openapi.PutProjectsProjectIDSectionsSectionIDPositionJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "body must be a json object with an \"order\" field")
+		return
+	}
+
+	section, err := s.projects(r).ReorderSection(projectUUID, sectionUUID, body.Order)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PutProjectsProjectIDSectionsSectionIDPositionJSON200Response(sectionModelToSectionOAPI(section))
+}
+
+// Move a task to a section.
+// (PUT /tasks/{taskID}/section)
+func (s *Server) PutTasksTaskIDSection(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	var body openapi.PutTasksTaskIDSectionJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	var sectionUUID *uuid.UUID
+	if body.SectionID != nil {
+		id, err := uuid.Parse(*body.SectionID)
+		if err != nil {
+			badRequest(w, "malformed section ID")
+			return
+		}
+		sectionUUID = &id
+	}
+	// Positions past the end bring the task to the end
+	position := math.MaxInt
+	if body.Position != nil {
+		position = *body.Position
+	}
+
+	t, err := s.tasks(r).MoveTaskToSection(taskUUID, sectionUUID, position)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	taskOAPI, err := taskModelToTaskOAPI(t)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	setVersionETag(w, t.Version)
+	return openapi.PutTasksTaskIDSectionJSON200Response(taskOAPI)
+}
+
+// parseSectionPath parses the IDs of the path of a section. Writes the error response and returns
+// false if one of them is malformed.
+func parseSectionPath(w http.ResponseWriter, projectID string, sectionID string) (uuid.UUID, uuid.UUID, bool) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return uuid.UUID{}, uuid.UUID{}, false
+	}
+
+	sectionUUID, err := uuid.Parse(sectionID)
+	if err != nil {
+		badRequest(w, "malformed section ID")
+		return uuid.UUID{}, uuid.UUID{}, false
+	}
+
+	return projectUUID, sectionUUID, true
+}
+
+func sectionModelToSectionOAPI(section project.Section) openapi.Section {
+	return openapi.Section{
+		ID:        section.ID.String(),
+		ProjectID: section.ProjectID.String(),
+		Name:      section.Name,
+		Order:     section.Order,
+		CreatedAt: section.CreatedAt,
+	}
+}
@@ -3,27 +3,50 @@ package todoctian
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/auth"
+	"github.com/murasakiwano/todoctian/server/idempotency"
 	"github.com/murasakiwano/todoctian/server/internal"
 	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/iteration"
 	"github.com/murasakiwano/todoctian/server/project"
 	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/template"
+	"github.com/murasakiwano/todoctian/server/timetracking"
+	"github.com/murasakiwano/todoctian/server/user"
+	"github.com/murasakiwano/todoctian/server/workspace"
 )
 
 type Server struct {
-	TaskService    *task.TaskService
-	ProjectService *project.ProjectService
-	logger         slog.Logger
+	TaskService     *task.TaskService
+	ProjectService  *project.ProjectService
+	TemplateService *template.TemplateService
+	ActivityService *activity.ActivityService
+	// Tracks the time spent on tasks, see handler_timetracking.go
+	TimeTrackingService *timetracking.TimeTrackingService
+	// Plans tasks from any project in iterations, see handler_iterations.go
+	IterationService *iteration.IterationService
+	// Groups projects in workspaces, see handler_workspaces.go
+	WorkspaceService *workspace.WorkspaceService
+	// Authenticates the requests with the API tokens of users, see handler_auth.go
+	UserService *user.UserService
+	// Issues and verifies JWT access and refresh tokens, see handler_auth.go
+	TokenService *auth.TokenService
+	// Makes POST requests safe to retry, see idempotentRequests
+	IdempotencyService *idempotency.KeyService
+	logger             slog.Logger
 }
 
-func NewServer(connString string) *Server {
+func NewServer(connString string, opts ...Option) *Server {
+	cfg := newConfig(opts...)
+
 	ctx := context.Background()
 	pool, err := pgxpool.New(ctx, connString)
 	if err != nil {
@@ -32,41 +55,93 @@ func NewServer(connString string) *Server {
 
 	projectRepository := project.NewProjectRepositoryPostgres(ctx, pool)
 	taskRepository := task.NewTaskRepositoryPostgres(ctx, pool)
-
-	projectService := project.NewProjectService(projectRepository)
-	taskService := task.NewTaskService(taskRepository, projectRepository)
+	templateRepository := template.NewTemplateRepositoryPostgres(ctx, pool)
+	activityRepository := activity.NewActivityRepositoryPostgres(ctx, pool)
+	idempotencyKeyRepository := idempotency.NewKeyRepositoryPostgres(ctx, pool)
+	timeEntryRepository := timetracking.NewTimeEntryRepositoryPostgres(ctx, pool)
+	iterationRepository := iteration.NewIterationRepositoryPostgres(ctx, pool)
+	workspaceRepository := workspace.NewWorkspaceRepositoryPostgres(ctx, pool)
+	userRepository := user.NewUserRepositoryPostgres(ctx, pool)
+	denylistRepository := auth.NewDenylistRepositoryPostgres(ctx, pool)
+
+	activityService := activity.NewActivityService(activityRepository)
+	projectServiceOpts := []project.ProjectServiceOption{
+		project.WithActivityRecorder(activityService),
+		project.WithLimits(cfg.limits),
+	}
+	if cfg.reuseArchivedProjectNames {
+		projectServiceOpts = append(projectServiceOpts, project.WithArchivedNameReuse())
+	}
+
+	projectService := project.NewProjectService(projectRepository, templateRepository, projectServiceOpts...)
+	taskService := task.NewTaskService(
+		taskRepository,
+		projectRepository,
+		task.WithActivityRecorder(activityService),
+		task.WithLimits(cfg.limits),
+	)
+	templateService := template.NewTemplateService(templateRepository)
+
+	signingKeys := cfg.signingKeys
+	if signingKeys == nil {
+		log.Print("no JWT signing keys are configured: tokens are signed with a generated key, which is lost on restart")
+		key, err := auth.GenerateSigningKey(uuid.NewString())
+		if err != nil {
+			log.Fatalf("could not generate a JWT signing key: %s", err)
+		}
+		signingKeys, err = auth.NewKeySet(key)
+		if err != nil {
+			log.Fatalf("could not generate a JWT signing key: %s", err)
+		}
+	}
 
 	return &Server{
-		TaskService:    taskService,
-		ProjectService: projectService,
-		logger:         *internal.NewLogger("Server"),
+		TaskService:     taskService,
+		ProjectService:  projectService,
+		TemplateService: templateService,
+		ActivityService: activityService,
+		TimeTrackingService: timetracking.NewTimeTrackingService(
+			timeEntryRepository,
+			taskRepository,
+			projectRepository,
+		),
+		IterationService: iteration.NewIterationService(
+			iterationRepository,
+			taskRepository,
+			projectRepository,
+			iteration.WithActivityRecorder(activityService),
+			iteration.WithLimits(cfg.limits),
+		),
+		WorkspaceService: workspace.NewWorkspaceService(
+			workspaceRepository,
+			projectRepository,
+			workspace.WithLimits(cfg.limits),
+		),
+		UserService: user.NewUserService(userRepository, user.WithLimits(cfg.limits)),
+		TokenService: auth.NewTokenService(
+			signingKeys,
+			denylistRepository,
+			userRepository,
+			auth.WithTokenTTLs(cfg.accessTokenTTL, cfg.refreshTokenTTL),
+		),
+		IdempotencyService: idempotency.NewKeyService(idempotencyKeyRepository, cfg.idempotencyKeyTTL),
+		logger:             *internal.NewLogger("Server"),
 	}
 }
 
-// TODO: LOG ERRORS
-
 // Get all projects
 // (GET /projects)
-func (s *Server) GetProjects(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+func (s *Server) GetProjects(w http.ResponseWriter, r *http.Request, params openapi.GetProjectsParams) (_ *openapi.Response) {
 	s.logger.Info("received request to GET /projects")
-	projectList, err := s.ProjectService.ListProjects()
+	opts, err := projectListOptions(params.Archived, (*string)(params.Sort), params.Cursor, params.Limit)
 	if err != nil {
-		s.logger.Error("could not list projects", slog.Any("err", err.Error()))
-
-		internalServerError(w)
+		badRequest(w, err.Error())
 		return
 	}
 
-	s.logger.Debug("got project list", slog.Any("projects", projectList))
-
-	projects := []openapi.Project{}
-	for _, p := range projectList {
-		pID := p.ID.String()
-		projects = append(projects, openapi.Project{
-			CreatedAt: &p.CreatedAt,
-			ID:        &pID,
-			Name:      &p.Name,
-		})
+	projects, ok := s.listProjectsPage(w, r, opts)
+	if !ok {
+		return
 	}
 
 	resp := openapi.GetProjectsJSON200Response(projects)
@@ -76,9 +151,11 @@ func (s *Server) GetProjects(w http.ResponseWriter, r *http.Request) (_ *openapi
 
 // Create a project.
 // (POST /projects)
-func (s *Server) PostProjects(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+//
+// The Idempotency-Key header is handled by the idempotentRequests middleware.
+func (s *Server) PostProjects(w http.ResponseWriter, r *http.Request, _ openapi.PostProjectsParams) (_ *openapi.Response) {
 	if r.Body == nil {
-		http.Error(w, "request body is required for this operation", http.StatusBadRequest)
+		badRequest(w, "request body is required for this operation")
 		return
 	}
 
@@ -87,20 +164,23 @@ func (s *Server) PostProjects(w http.ResponseWriter, r *http.Request) (_ *openap
 	err := decoder.Decode(&body)
 	if err != nil || body.Name == nil {
 		slog.Error("failed to decode request body", slog.Any("err", err))
-		http.Error(w, "body must be a json object with a \"name\" field", http.StatusBadRequest)
+		badRequest(w, "body must be a json object with a \"name\" field")
+		return
+	}
+
+	workspaceUUID, ok := parseOptionalUUID(w, body.WorkspaceID, "malformed workspace ID")
+	if !ok {
+		return
+	}
+	parentUUID, ok := parseOptionalUUID(w, body.ParentProjectID, "malformed parent project ID")
+	if !ok {
 		return
 	}
 
 	projectName := *body.Name
-	project, err := s.ProjectService.CreateProject(projectName)
+	project, err := s.projects(r).CreateProjectIn(projectName, workspaceUUID, parentUUID)
 	if err != nil {
-		if errors.Is(err, internal.ErrAlreadyExists) {
-			http.Error(w, fmt.Sprintf("project \"%s\" already exists", projectName), http.StatusConflict)
-			return
-		}
-
-		s.logger.Error("an error occurred", slog.Any("err", err))
-		internalServerError(w)
+		s.writeError(w, r, err)
 		return
 	}
 
@@ -110,120 +190,166 @@ func (s *Server) PostProjects(w http.ResponseWriter, r *http.Request) (_ *openap
 
 // Delete a project. Also deletes the project's tasks.
 // (DELETE /projects/{projectID})
-func (s *Server) DeleteProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+func (s *Server) DeleteProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string, params openapi.DeleteProjectsProjectIDParams) (_ *openapi.Response) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		badRequest(w, err.Error())
+		return
+	}
+
+	version, ok := s.checkProjectIfMatch(w, r, projectUUID, params.IfMatch)
+	if !ok {
 		return
 	}
 
-	project, err := s.ProjectService.DeleteProject(projectUUID)
+	var deletedProject project.Project
+	err = s.projects(r).IfVersion(projectUUID, version, func(projectService *project.ProjectService) (err error) {
+		deletedProject, err = projectService.DeleteProject(projectUUID)
+		return err
+	})
 	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			http.Error(w, fmt.Sprintf("Could not find project %s", project.Name), http.StatusNotFound)
-			return
-		}
+		s.writeError(w, r, err)
+		return
 	}
 
-	projectOAPI := projectModelToProjectOAPI(project)
+	projectOAPI := projectModelToProjectOAPI(deletedProject)
 	return openapi.DeleteProjectsProjectIDJSON204Response(projectOAPI)
 }
 
 // Get a single project
 // (GET /projects/{projectID})
-func (s *Server) GetProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+func (s *Server) GetProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string, params openapi.GetProjectsProjectIDParams) (_ *openapi.Response) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
-		http.Error(w, "malformed project ID", http.StatusBadRequest)
+		badRequest(w, "malformed project ID")
 		return
 	}
 
-	project, err := s.ProjectService.GetProject(projectUUID)
+	proj, err := s.ProjectService.GetProject(projectUUID)
 	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			http.NotFound(w, r)
-			return
-		}
+		s.writeError(w, r, err)
+		return
+	}
 
-		internalServerError(w)
+	projects, err := s.ProjectService.SummarizeTasks([]project.Project{proj})
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
+	proj = projects[0]
 
-	projectOAPI := projectModelToProjectOAPI(project)
+	etag := projectETag(proj)
+	w.Header().Set("ETag", etag)
+	if notModified(w, params.IfNoneMatch, etag) {
+		return
+	}
+
+	projectOAPI := projectModelToProjectOAPI(proj)
 	return openapi.GetProjectsProjectIDJSON200Response(projectOAPI)
 }
 
-// Rename a project
+// Update a project
 // (PATCH /projects/{projectID})
-func (s *Server) PatchProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+func (s *Server) PatchProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string, params openapi.PatchProjectsProjectIDParams) (_ *openapi.Response) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
-		http.Error(w, "malformed project ID", http.StatusBadRequest)
+		badRequest(w, "malformed project ID")
 		return
 	}
 
 	if r.Body == nil {
-		http.Error(w, "request body is required for this operation", http.StatusBadRequest)
+		badRequest(w, "request body is required for this operation")
 		return
 	}
 
-	var params openapi.PatchProjectsProjectIDJSONRequestBody
+	var body openapi.PatchProjectsProjectIDJSONRequestBody
 	decoder := json.NewDecoder(r.Body)
-	err = decoder.Decode(&params)
-	if err != nil || params.Name == nil {
-		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+	err = decoder.Decode(&body)
+	if err != nil || (body.Name == nil && body.Description == nil && body.Color == nil && body.Icon == nil && body.EstimateUnit == nil) {
+		badRequest(w, "body must be a json object with a \"name\", \"description\", \"color\", \"icon\" or \"estimateUnit\" field")
 		return
 	}
 
-	project, err := s.ProjectService.RenameProject(projectUUID, *params.Name)
-	if err != nil {
-		if errors.Is(err, internal.ErrAlreadyExists) {
-			http.Error(w, "project name already taken", http.StatusConflict)
-			return
-		}
+	version, ok := s.checkProjectIfMatch(w, r, projectUUID, params.IfMatch)
+	if !ok {
+		return
+	}
 
-		if errors.Is(err, internal.ErrNotFound) {
-			http.NotFound(w, r)
-			return
-		}
+	update := project.ProjectUpdate{
+		Name:        body.Name,
+		Description: body.Description,
+		Color:       body.Color,
+		Icon:        body.Icon,
+	}
+	if body.EstimateUnit != nil {
+		estimateUnit := project.EstimateUnit(body.EstimateUnit.ToValue())
+		update.EstimateUnit = &estimateUnit
+	}
 
-		internalServerError(w)
+	var updatedProject project.Project
+	err = s.projects(r).IfVersion(projectUUID, version, func(projectService *project.ProjectService) (err error) {
+		updatedProject, err = projectService.UpdateProject(projectUUID, update)
+		return err
+	})
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
 
-	projectOAPI := projectModelToProjectOAPI(project)
+	setVersionETag(w, updatedProject.Version)
+	projectOAPI := projectModelToProjectOAPI(updatedProject)
 	return openapi.PatchProjectsProjectIDJSON200Response(projectOAPI)
 }
 
-// Get all project's tasks.
-// (GET /projects/{projectID}/tasks)
-func (s *Server) GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+// Move a project in the list of projects.
+// (PUT /projects/{projectID}/position)
+func (s *Server) PutProjectsProjectIDPosition(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
-		http.Error(w, "malformed project ID", http.StatusBadRequest)
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	if r.Body == nil {
+		badRequest(w, "request body is required for this operation")
 		return
 	}
 
-	tasks, err := s.TaskService.SearchTaskByProject(projectUUID)
+	var body openapi.PutProjectsProjectIDPositionJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			http.NotFound(w, r)
-			return
-		}
+		badRequest(w, "body must be a json object with an \"order\" field")
+		return
+	}
 
-		internalServerError(w)
+	project, err := s.projects(r).ReorderProject(projectUUID, body.Order)
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
 
-	tasksOAPI := []openapi.Task{}
-	for _, task := range tasks {
-		taskOAPI, err := taskModelToTaskOAPI(task)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to convert task %s to OAPI model", task.ID), http.StatusInternalServerError)
-			return
-		}
+	return openapi.PutProjectsProjectIDPositionJSON200Response(projectModelToProjectOAPI(project))
+}
 
-		tasksOAPI = append(tasksOAPI, taskOAPI)
+// Get all project's tasks.
+// (GET /projects/{projectID}/tasks)
+func (s *Server) GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Request, projectID string, params openapi.GetProjectsProjectIDTasksParams) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	opts, err := taskListOptions((*string)(params.Sort), params.Status, params.ParentTaskID, params.SectionID, params.Root, params.Cursor, params.Limit)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	opts.ProjectID = &projectUUID
+
+	tasksOAPI, ok := s.listTasksPage(w, r, opts)
+	if !ok {
+		return
 	}
 
 	return openapi.GetProjectsProjectIDTasksJSON200Response(tasksOAPI)
@@ -231,22 +357,16 @@ func (s *Server) GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Reques
 
 // Get all tasks
 // (GET /tasks)
-func (s *Server) GetTasks(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
-	tasks, err := s.TaskService.ListTasks()
+func (s *Server) GetTasks(w http.ResponseWriter, r *http.Request, params openapi.GetTasksParams) (_ *openapi.Response) {
+	opts, err := taskListOptions((*string)(params.Sort), params.Status, params.ParentTaskID, params.SectionID, params.Root, params.Cursor, params.Limit)
 	if err != nil {
-		internalServerError(w)
+		badRequest(w, err.Error())
 		return
 	}
 
-	tasksOAPI := []openapi.Task{}
-	for _, task := range tasks {
-		taskOAPI, err := taskModelToTaskOAPI(task)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to convert task %s to OAPI model", task.ID), http.StatusInternalServerError)
-			return
-		}
-
-		tasksOAPI = append(tasksOAPI, taskOAPI)
+	tasksOAPI, ok := s.listTasksPage(w, r, opts)
+	if !ok {
+		return
 	}
 
 	return openapi.GetTasksJSON200Response(tasksOAPI)
@@ -254,9 +374,11 @@ func (s *Server) GetTasks(w http.ResponseWriter, r *http.Request) (_ *openapi.Re
 
 // Create a new task.
 // (POST /tasks)
-func (s *Server) PostTasks(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+//
+// The Idempotency-Key header is handled by the idempotentRequests middleware.
+func (s *Server) PostTasks(w http.ResponseWriter, r *http.Request, _ openapi.PostTasksParams) (_ *openapi.Response) {
 	if r.Body == nil {
-		http.Error(w, "request body is required for this operation", http.StatusBadRequest)
+		badRequest(w, "request body is required for this operation")
 		return
 	}
 
@@ -264,16 +386,16 @@ func (s *Server) PostTasks(w http.ResponseWriter, r *http.Request) (_ *openapi.R
 	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&body)
 	if err != nil {
-		http.Error(w, "malformed request body", http.StatusBadRequest)
+		badRequest(w, "malformed request body")
 		return
 	}
 
 	if body.Name == nil || *body.Name == "" {
-		http.Error(w, "request body is missing task name", http.StatusBadRequest)
+		badRequest(w, "request body is missing task name")
 		return
 	}
 	if body.ProjectID == nil || *body.ProjectID == "" {
-		http.Error(w, "request body is missing a project ID", http.StatusBadRequest)
+		badRequest(w, "request body is missing a project ID")
 		return
 	}
 
@@ -281,32 +403,30 @@ func (s *Server) PostTasks(w http.ResponseWriter, r *http.Request) (_ *openapi.R
 	taskModel.Name = *body.Name
 	projectID, err := uuid.Parse(*body.ProjectID)
 	if err != nil {
-		http.Error(w, "malformed project id", http.StatusBadRequest)
+		badRequest(w, "malformed project id")
 		return
 	}
 	taskModel.ProjectID = projectID
 	if body.ParentTaskID != nil && *body.ParentTaskID != "" {
 		parentTaskID, err := uuid.Parse(*body.ParentTaskID)
 		if err != nil {
-			http.Error(w, "malformed parent task ID", http.StatusBadRequest)
+			badRequest(w, "malformed parent task ID")
 			return
 		}
 		taskModel.ParentTaskID = &parentTaskID
 	}
 
-	task, err := s.TaskService.CreateTask(taskModel.Name, taskModel.ProjectID, taskModel.ParentTaskID)
-	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			return openapi.PostTasksJSON404Response(openapi.Project{ID: body.ProjectID})
-		}
+	taskModel.DueAt = body.DueAt
 
-		internalServerError(w)
+	task, err := s.tasks(r).CreateTaskWithDueDate(taskModel.Name, taskModel.ProjectID, taskModel.ParentTaskID, taskModel.DueAt)
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
 
 	taskOAPI, err := taskModelToTaskOAPI(task)
 	if err != nil {
-		internalServerError(w)
+		s.writeError(w, r, err)
 		return
 	}
 
@@ -315,27 +435,31 @@ func (s *Server) PostTasks(w http.ResponseWriter, r *http.Request) (_ *openapi.R
 
 // Delete a task.
 // (DELETE /tasks/{taskID})
-func (s *Server) DeleteTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+func (s *Server) DeleteTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params openapi.DeleteTasksTaskIDParams) (_ *openapi.Response) {
 	taskUUID, err := uuid.Parse(taskID)
 	if err != nil {
-		http.Error(w, "malformed task ID", http.StatusBadRequest)
+		badRequest(w, "malformed task ID")
 		return
 	}
 
-	deletedTask, err := s.TaskService.DeleteTask(taskUUID)
-	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			http.NotFound(w, r)
-			return
-		}
+	version, ok := s.checkTaskIfMatch(w, r, taskUUID, params.IfMatch)
+	if !ok {
+		return
+	}
 
-		internalServerError(w)
+	var deletedTask task.Task
+	err = s.tasks(r).IfVersion(taskUUID, version, func(taskService *task.TaskService) (err error) {
+		deletedTask, err = taskService.DeleteTask(taskUUID)
+		return err
+	})
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
 
 	deletedTaskOAPI, err := taskModelToTaskOAPI(deletedTask)
 	if err != nil {
-		internalServerError(w)
+		s.writeError(w, r, err)
 		return
 	}
 
@@ -347,51 +471,126 @@ func (s *Server) DeleteTasksTaskID(w http.ResponseWriter, r *http.Request, taskI
 func (s *Server) GetTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params openapi.GetTasksTaskIDParams) (_ *openapi.Response) {
 	taskUUID, err := uuid.Parse(taskID)
 	if err != nil {
-		http.Error(w, "malformed task ID", http.StatusBadRequest)
+		badRequest(w, "malformed task ID")
 		return
 	}
 
-	task, err := s.TaskService.FindTaskByID(taskUUID)
-	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			http.NotFound(w, r)
+	var taskModel task.Task
+	if params.WithSubtasks != nil && *params.WithSubtasks {
+		taskModel, err = s.TaskService.FetchTaskTree(taskUUID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	} else {
+		taskModel, err = s.TaskService.FetchTaskWithProgress(taskUUID)
+		if err != nil {
+			s.writeError(w, r, err)
 			return
 		}
 
-		internalServerError(w)
+		// The ETag covers the progress of the task, but not its subtasks
+		etag := taskETag(taskModel)
+		w.Header().Set("ETag", etag)
+		if notModified(w, params.IfNoneMatch, etag) {
+			return
+		}
+	}
+
+	taskOAPI, err := taskModelToTaskOAPI(taskModel)
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
 
-	if params.WithSubtasks != nil && *params.WithSubtasks {
-		subtasks, err := s.buildSubtasksStructure(taskUUID)
-		if err != nil {
-			s.logger.Error("failed to build subtasks structure", slog.String("taskID", taskUUID.String()), slog.Any("err", err.Error()))
-			internalServerError(w)
-			return
+	return openapi.GetTasksTaskIDJSON200Response(taskOAPI)
+}
+
+// Rename or reorder a task.
+// (PATCH /tasks/{taskID})
+func (s *Server) PatchTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params openapi.PatchTasksTaskIDParams) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	if r.Body == nil {
+		badRequest(w, "request body is required for this operation")
+		return
+	}
+
+	// A null estimate removes it, so whether the field is there at all matters too
+	var fields map[string]json.RawMessage
+	var body openapi.PatchTasksTaskIDJSONBody
+	raw, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(raw, &fields)
+	}
+	if err == nil {
+		err = json.Unmarshal(raw, &body)
+	}
+	_, hasEstimate := fields["estimate"]
+	if err != nil || (body.Name == nil && body.Order == nil && !hasEstimate) {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	version, ok := s.checkTaskIfMatch(w, r, taskUUID, params.IfMatch)
+	if !ok {
+		return
+	}
+
+	// The changes are all saved or none of them
+	taskService := s.tasks(r)
+	err = taskService.IfVersion(taskUUID, version, func(taskService *task.TaskService) (err error) {
+		if body.Name != nil {
+			_, err = taskService.RenameTask(taskUUID, *body.Name)
+		}
+		if err == nil && body.Order != nil {
+			var taskModel task.Task
+			taskModel, err = taskService.FindTaskByID(taskUUID)
+			if err == nil {
+				err = taskService.ReorderTask(taskModel, *body.Order)
+			}
 		}
-		task.Subtasks = subtasks
+		if err == nil && hasEstimate {
+			_, err = taskService.UpdateTaskEstimate(taskUUID, body.Estimate)
+		}
+		return err
+	})
+	if err != nil {
+		s.writeError(w, r, err)
+		return
 	}
 
-	taskOAPI, err := taskModelToTaskOAPI(task)
+	updatedTask, err := taskService.FindTaskByID(taskUUID)
 	if err != nil {
-		internalServerError(w)
+		s.writeError(w, r, err)
 		return
 	}
 
-	return openapi.GetTasksTaskIDJSON200Response(taskOAPI)
+	taskOAPI, err := taskModelToTaskOAPI(updatedTask)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	setVersionETag(w, updatedTask.Version)
+	return openapi.PatchTasksTaskIDJSON200Response(taskOAPI)
 }
 
 // Update a task's status.
 // (PATCH /tasks/{taskID}/status)
-func (s *Server) PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+func (s *Server) PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request, taskID string, params openapi.PatchTasksTaskIDStatusParams) (_ *openapi.Response) {
 	taskUUID, err := uuid.Parse(taskID)
 	if err != nil {
-		http.Error(w, "malformed task ID", http.StatusBadRequest)
+		badRequest(w, "malformed task ID")
 		return
 	}
 
 	if r.Body == nil {
-		http.Error(w, "request body is required for this operation", http.StatusBadRequest)
+		badRequest(w, "request body is required for this operation")
 		return
 	}
 
@@ -399,18 +598,20 @@ func (s *Server) PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request,
 	decoder := json.NewDecoder(r.Body)
 	err = decoder.Decode(&body)
 	if err != nil || body.Status == nil {
-		http.Error(w, "malformed request body", http.StatusBadRequest)
+		badRequest(w, "malformed request body")
 		return
 	}
 
-	err = s.TaskService.UpdateTaskStatus(taskUUID, body.Status.ToValue())
-	if err != nil {
-		if errors.Is(err, internal.ErrNotFound) {
-			http.NotFound(w, r)
-			return
-		}
+	version, ok := s.checkTaskIfMatch(w, r, taskUUID, params.IfMatch)
+	if !ok {
+		return
+	}
 
-		internalServerError(w)
+	err = s.tasks(r).IfVersion(taskUUID, version, func(taskService *task.TaskService) error {
+		return taskService.UpdateTaskStatus(taskUUID, body.Status.ToValue())
+	})
+	if err != nil {
+		s.writeError(w, r, err)
 		return
 	}
 
@@ -434,20 +635,77 @@ func projectOAPIToProjectModel(projectOAPI openapi.Project) (project.Project, er
 
 func projectModelToProjectOAPI(projectModel project.Project) openapi.Project {
 	projectIDString := projectModel.ID.String()
+	var workspaceID, parentProjectID *string
+	if projectModel.WorkspaceID != nil {
+		id := projectModel.WorkspaceID.String()
+		workspaceID = &id
+	}
+	if projectModel.ParentProjectID != nil {
+		id := projectModel.ParentProjectID.String()
+		parentProjectID = &id
+	}
 
 	return openapi.Project{
-		ID:        &projectIDString,
-		Name:      &projectModel.Name,
-		CreatedAt: &projectModel.CreatedAt,
+		ID:              &projectIDString,
+		Name:            &projectModel.Name,
+		Description:     &projectModel.Description,
+		Color:           projectModel.Color,
+		Icon:            projectModel.Icon,
+		Order:           &projectModel.Order,
+		CreatedAt:       &projectModel.CreatedAt,
+		DeletedAt:       projectModel.DeletedAt,
+		ArchivedAt:      projectModel.ArchivedAt,
+		Version:         &projectModel.Version,
+		EstimateUnit:    estimateUnitModelToEstimateUnitOAPI(projectModel.EstimateUnit),
+		TaskSummary:     taskSummaryModelToTaskSummaryOAPI(projectModel.TaskSummary),
+		WorkspaceID:     workspaceID,
+		ParentProjectID: parentProjectID,
+	}
+}
+
+func taskSummaryModelToTaskSummaryOAPI(summary *project.TaskSummary) *openapi.TaskSummary {
+	if summary == nil {
+		return nil
+	}
+
+	return &openapi.TaskSummary{
+		Total:         summary.Total,
+		Pending:       summary.Pending,
+		Completed:     summary.Completed,
+		PercentDone:   summary.PercentDone(),
+		RootTasks:     summary.Root,
+		Estimate:      summary.Estimate,
+		RemainingWork: summary.RemainingWork,
 	}
 }
 
+func estimateUnitModelToEstimateUnitOAPI(unit project.EstimateUnit) *openapi.EstimateUnit {
+	estimateUnit := openapi.EstimateUnit{}
+	if estimateUnit.FromValue(string(unit)) != nil {
+		return nil
+	}
+
+	return &estimateUnit
+}
+
 func taskModelToTaskOAPI(taskModel task.Task) (openapi.Task, error) {
 	taskID := taskModel.ID.String()
 	projectID := taskModel.ProjectID.String()
-	parentTaskID := ""
+	// Root tasks have no parent task ID, rather than an empty one
+	var parentTaskID *string
 	if taskModel.ParentTaskID != nil {
-		parentTaskID = taskModel.ParentTaskID.String()
+		pTaskID := taskModel.ParentTaskID.String()
+		parentTaskID = &pTaskID
+	}
+	var iterationID *string
+	if taskModel.IterationID != nil {
+		id := taskModel.IterationID.String()
+		iterationID = &id
+	}
+	var sectionID *string
+	if taskModel.SectionID != nil {
+		id := taskModel.SectionID.String()
+		sectionID = &id
 	}
 
 	taskStatus := openapi.TaskStatus{}
@@ -456,6 +714,18 @@ func taskModelToTaskOAPI(taskModel task.Task) (openapi.Task, error) {
 		return openapi.Task{}, err
 	}
 
+	var progress *float32
+	var childCount, effectiveEstimate, remainingWork *int
+	if taskModel.Progress != nil {
+		if ratio := taskModel.Progress.Ratio(); ratio != nil {
+			p := float32(*ratio)
+			progress = &p
+		}
+		childCount = &taskModel.Progress.ChildCount
+		effectiveEstimate = taskModel.Progress.EffectiveEstimate
+		remainingWork = taskModel.Progress.RemainingWork
+	}
+
 	subtasks := []openapi.Task{}
 	for _, st := range taskModel.Subtasks {
 		stOAPI, err := taskModelToTaskOAPI(st)
@@ -466,35 +736,24 @@ func taskModelToTaskOAPI(taskModel task.Task) (openapi.Task, error) {
 	}
 
 	return openapi.Task{
-		CreatedAt:    &taskModel.CreatedAt,
-		ID:           &taskID,
-		Name:         &taskModel.Name,
-		ParentTaskID: &parentTaskID,
-		ProjectID:    &projectID,
-		Status:       &taskStatus,
-		Subtasks:     subtasks,
+		CreatedAt:         &taskModel.CreatedAt,
+		ID:                &taskID,
+		Name:              &taskModel.Name,
+		ParentTaskID:      parentTaskID,
+		ProjectID:         &projectID,
+		Status:            &taskStatus,
+		Subtasks:          subtasks,
+		DueAt:             taskModel.DueAt,
+		DeletedAt:         taskModel.DeletedAt,
+		UpdatedAt:         &taskModel.UpdatedAt,
+		CompletedAt:       taskModel.CompletedAt,
+		Version:           &taskModel.Version,
+		Progress:          progress,
+		ChildCount:        childCount,
+		Estimate:          taskModel.Estimate,
+		EffectiveEstimate: effectiveEstimate,
+		RemainingWork:     remainingWork,
+		IterationID:       iterationID,
+		SectionID:         sectionID,
 	}, nil
 }
-
-func internalServerError(w http.ResponseWriter) {
-	http.Error(w, "internal server error", http.StatusInternalServerError)
-}
-
-func (s *Server) buildSubtasksStructure(taskUUID uuid.UUID) ([]task.Task, error) {
-	subtasks, err := s.TaskService.FetchSubtasksDirect(taskUUID)
-	if err != nil {
-		return nil, err
-	}
-
-	for i, st := range subtasks {
-		ssubtasks, err := s.buildSubtasksStructure(st.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		st.Subtasks = ssubtasks
-		subtasks[i] = st
-	}
-
-	return subtasks, nil
-}
@@ -0,0 +1,84 @@
+package todoctian
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// Get the statistics of a project.
+// (GET /projects/{projectID}/stats)
+func (s *Server) GetProjectsProjectIDStats(w http.ResponseWriter, r *http.Request, projectID string, params openapi.GetProjectsProjectIDStatsParams) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	var bucket task.StatsBucket
+	if params.Bucket != nil {
+		bucket = task.StatsBucket(*params.Bucket)
+	}
+
+	stats, err := s.TaskService.GetProjectStats(projectUUID, time.Time(params.From), time.Time(params.To), bucket)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	statsOAPI, err := projectStatsModelToProjectStatsOAPI(stats)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetProjectsProjectIDStatsJSON200Response(statsOAPI)
+}
+
+func projectStatsModelToProjectStatsOAPI(stats task.ProjectStats) (openapi.ProjectStats, error) {
+	series := []openapi.StatsPoint{}
+	for _, point := range stats.Series {
+		series = append(series, openapi.StatsPoint{
+			Start:     point.Start,
+			End:       point.End,
+			Created:   point.Created,
+			Completed: point.Completed,
+			Open:      point.Open,
+		})
+	}
+
+	oldestOpenTasks := []openapi.Task{}
+	for _, t := range stats.OldestOpenTasks {
+		taskOAPI, err := taskModelToTaskOAPI(t)
+		if err != nil {
+			return openapi.ProjectStats{}, err
+		}
+
+		oldestOpenTasks = append(oldestOpenTasks, taskOAPI)
+	}
+
+	bucket := openapi.ProjectStatsBucket{}
+	err := bucket.FromValue(string(stats.Bucket))
+	if err != nil {
+		return openapi.ProjectStats{}, err
+	}
+
+	var averageCycleTime *float64
+	if stats.AverageCycleTime != nil {
+		seconds := stats.AverageCycleTime.Seconds()
+		averageCycleTime = &seconds
+	}
+
+	return openapi.ProjectStats{
+		From:                    stats.From,
+		To:                      stats.To,
+		Bucket:                  bucket,
+		Series:                  series,
+		Completed:               stats.Completed,
+		AverageCycleTimeSeconds: averageCycleTime,
+		OldestOpenTasks:         oldestOpenTasks,
+	}, nil
+}
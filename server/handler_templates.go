@@ -0,0 +1,283 @@
+package todoctian
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/template"
+)
+
+// Create a template from an existing project.
+// (POST /projects/{projectID}/template)
+func (s *Server) PostProjectsProjectIDTemplate(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	if r.Body == nil {
+		badRequest(w, "request body is required for this operation")
+		return
+	}
+
+	var body openapi.PostProjectsProjectIDTemplateJSONRequestBody
+	decoder := json.NewDecoder(r.Body)
+	err = decoder.Decode(&body)
+	if err != nil || body.Name == nil {
+		badRequest(w, "body must be a json object with a \"name\" field")
+		return
+	}
+
+	tmpl, err := s.ProjectService.CreateTemplate(projectUUID, *body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostProjectsProjectIDTemplateJSON201Response(templateModelToTemplateOAPI(tmpl))
+}
+
+// Get all templates
+// (GET /templates)
+func (s *Server) GetTemplates(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	templates, err := s.TemplateService.ListTemplates()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	templatesOAPI := []openapi.Template{}
+	for _, tmpl := range templates {
+		templatesOAPI = append(templatesOAPI, templateModelToTemplateOAPI(tmpl))
+	}
+
+	return openapi.GetTemplatesJSON200Response(templatesOAPI)
+}
+
+// Create a template.
+// (POST /templates)
+func (s *Server) PostTemplates(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	if r.Body == nil {
+		badRequest(w, "request body is required for this operation")
+		return
+	}
+
+	var body openapi.PostTemplatesJSONRequestBody
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil || body.Name == nil {
+		badRequest(w, "body must be a json object with a \"name\" field")
+		return
+	}
+
+	tmpl, err := s.TemplateService.CreateTemplate(*body.Name, templateTasksOAPIToTemplateTasksModel(body.Tasks))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostTemplatesJSON201Response(templateModelToTemplateOAPI(tmpl))
+}
+
+// Delete a template.
+// (DELETE /templates/{templateID})
+func (s *Server) DeleteTemplatesTemplateID(w http.ResponseWriter, r *http.Request, templateID string) (_ *openapi.Response) {
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		badRequest(w, "malformed template ID")
+		return
+	}
+
+	tmpl, err := s.TemplateService.DeleteTemplate(templateUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.DeleteTemplatesTemplateIDJSON204Response(templateModelToTemplateOAPI(tmpl))
+}
+
+// Get a single template.
+// (GET /templates/{templateID})
+func (s *Server) GetTemplatesTemplateID(w http.ResponseWriter, r *http.Request, templateID string) (_ *openapi.Response) {
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		badRequest(w, "malformed template ID")
+		return
+	}
+
+	tmpl, err := s.TemplateService.GetTemplate(templateUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetTemplatesTemplateIDJSON200Response(templateModelToTemplateOAPI(tmpl))
+}
+
+// Instantiate a template, either as a new project or inside an existing one.
+// (POST /templates/{templateID}/instantiate)
+func (s *Server) PostTemplatesTemplateIDInstantiate(w http.ResponseWriter, r *http.Request, templateID string) (_ *openapi.Response) {
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		badRequest(w, "malformed template ID")
+		return
+	}
+
+	if r.Body == nil {
+		badRequest(w, "request body is required for this operation")
+		return
+	}
+
+	var body openapi.PostTemplatesTemplateIDInstantiateJSONRequestBody
+	decoder := json.NewDecoder(r.Body)
+	err = decoder.Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	hasProjectName := body.ProjectName != nil && *body.ProjectName != ""
+	hasProjectID := body.ProjectID != nil && *body.ProjectID != ""
+	if hasProjectName == hasProjectID {
+		badRequest(w, "exactly one of \"projectName\" and \"projectID\" is required")
+		return
+	}
+
+	var parentTaskID *uuid.UUID
+	if body.ParentTaskID != nil && *body.ParentTaskID != "" {
+		if !hasProjectID {
+			badRequest(w, "\"parentTaskID\" requires \"projectID\"")
+			return
+		}
+
+		parsed, err := uuid.Parse(*body.ParentTaskID)
+		if err != nil {
+			badRequest(w, "malformed parent task ID")
+			return
+		}
+		parentTaskID = &parsed
+	}
+
+	vars := map[string]string{}
+	if body.Variables != nil && body.Variables.AdditionalProperties != nil {
+		vars = body.Variables.AdditionalProperties
+	}
+
+	tmpl, err := s.TemplateService.GetTemplate(templateUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	// Render everything before touching the database, so that a missing variable does not leave a
+	// half-created project behind.
+	rendered, err := tmpl.Render(vars)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+
+	var proj project.Project
+	var tasks []task.Task
+	if hasProjectName {
+		projectName, err := template.RenderName(*body.ProjectName, vars)
+		if err != nil {
+			badRequest(w, err.Error())
+			return
+		}
+
+		// The project is only created if the tasks can be
+		proj, tasks, err = s.tasks(r).InstantiateTemplateInNewProject(rendered, s.projects(r), projectName)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	} else {
+		projectUUID, err := uuid.Parse(*body.ProjectID)
+		if err != nil {
+			badRequest(w, "malformed project ID")
+			return
+		}
+
+		proj, err = s.ProjectService.GetProject(projectUUID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		tasks, err = s.tasks(r).InstantiateTemplate(rendered, proj.ID, parentTaskID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+
+	tasksOAPI := []openapi.Task{}
+	for _, taskModel := range tasks {
+		taskOAPI, err := taskModelToTaskOAPI(taskModel)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		tasksOAPI = append(tasksOAPI, taskOAPI)
+	}
+
+	projectOAPI := projectModelToProjectOAPI(proj)
+	return openapi.PostTemplatesTemplateIDInstantiateJSON201Response(openapi.TemplateInstance{
+		Project: &projectOAPI,
+		Tasks:   tasksOAPI,
+	})
+}
+
+func templateModelToTemplateOAPI(templateModel template.Template) openapi.Template {
+	templateID := templateModel.ID.String()
+
+	return openapi.Template{
+		ID:        &templateID,
+		Name:      &templateModel.Name,
+		CreatedAt: &templateModel.CreatedAt,
+		Variables: templateModel.Variables(),
+		Tasks:     templateTasksModelToTemplateTasksOAPI(templateModel.Tasks),
+	}
+}
+
+func templateTasksModelToTemplateTasksOAPI(tasks []template.TemplateTask) []openapi.TemplateTask {
+	tasksOAPI := []openapi.TemplateTask{}
+	for _, tt := range tasks {
+		taskID := tt.ID.String()
+		tasksOAPI = append(tasksOAPI, openapi.TemplateTask{
+			ID:            &taskID,
+			Name:          &tt.Name,
+			DueOffsetDays: tt.DueOffsetDays,
+			Subtasks:      templateTasksModelToTemplateTasksOAPI(tt.Subtasks),
+		})
+	}
+
+	return tasksOAPI
+}
+
+// IDs sent by the client are ignored, since template.NewTemplate assigns new ones.
+func templateTasksOAPIToTemplateTasksModel(tasksOAPI []openapi.TemplateTask) []template.TemplateTask {
+	tasks := []template.TemplateTask{}
+	for _, tt := range tasksOAPI {
+		name := ""
+		if tt.Name != nil {
+			name = *tt.Name
+		}
+
+		tasks = append(tasks, template.TemplateTask{
+			Name:          name,
+			DueOffsetDays: tt.DueOffsetDays,
+			Subtasks:      templateTasksOAPIToTemplateTasksModel(tt.Subtasks),
+		})
+	}
+
+	return tasks
+}
@@ -3,6 +3,7 @@ package todoctian
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,18 +11,25 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/getkin/kin-openapi/routers"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/auth"
+	"github.com/murasakiwano/todoctian/server/idempotency"
 	"github.com/murasakiwano/todoctian/server/internal"
 	"github.com/murasakiwano/todoctian/server/internal/openapi"
 	"github.com/murasakiwano/todoctian/server/project"
 	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/template"
 	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/murasakiwano/todoctian/server/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -38,11 +46,20 @@ const (
 // executeRequest, creates a new ResponseRecorder
 // then executes the request by calling ServeHTTP in the router
 // after which the handler writes the response to the response recorder
-// which we can then inspect.
+// which we can then inspect. Every response is checked against the OpenAPI spec.
+// executeRequest serves a request, authenticated with the token of the test user unless it has
+// its own Authorization header.
 func executeRequest(req *http.Request, s *HandlerTestSuite) *httptest.ResponseRecorder {
+	if req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
 	rr := httptest.NewRecorder()
 	s.router.ServeHTTP(rr, req)
 
+	err := validateResponse(s.specRouter, req, rr.Code, rr.Header(), rr.Body.Bytes())
+	assert.NoError(s.T(), err, "the response to %s %s does not conform to the OpenAPI spec", req.Method, req.URL)
+
 	return rr
 }
 
@@ -63,6 +80,10 @@ type HandlerTestSuite struct {
 	handler           http.Handler
 	pool              *pgxpool.Pool
 	router            *chi.Mux
+	specRouter        routers.Router
+	userService       *user.UserService
+	// API token of the user the requests are made by
+	token string
 }
 
 func (suite *HandlerTestSuite) SetupSuite() {
@@ -98,15 +119,22 @@ func (suite *HandlerTestSuite) SetupSuite() {
 
 	suite.projectRepository = project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
 	suite.taskRepository = task.NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	templateRepository := template.NewTemplateRepositoryPostgres(suite.ctx, pgPool)
 
-	suite.projectService = project.NewProjectService(suite.projectRepository)
+	suite.projectService = project.NewProjectService(suite.projectRepository, templateRepository)
 	suite.taskService = task.NewTaskService(suite.taskRepository, suite.projectRepository)
+	suite.userService = user.NewUserService(user.NewUserRepositoryPostgres(suite.ctx, pgPool))
 
 	suite.handler = Handler(connStr)
 
 	r := chi.NewRouter()
 	r.Mount("/", suite.handler)
 	suite.router = r
+
+	suite.specRouter, err = newSpecRouter()
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 // Setup database before each test
@@ -115,6 +143,30 @@ func (suite *HandlerTestSuite) SetupTest() {
 	t.Log("cleaning up database before test...")
 	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
 	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupTemplatesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupActivityTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupIdempotencyKeysTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupTimeEntriesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupIterationsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupWorkspacesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupUsersTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupDeniedRefreshTokensTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	testUser, err := suite.userService.CreateUser("tester")
+	require.NoError(t, err)
+	_, suite.token, err = suite.userService.CreateToken(testUser.ID, "tests")
+	require.NoError(t, err)
+}
+
+// createUserToken creates another user and gives the bearer token with which it authenticates.
+func (suite *HandlerTestSuite) createUserToken(name string) string {
+	t := suite.T()
+	u, err := suite.userService.CreateUser(name)
+	require.NoError(t, err)
+	_, token, err := suite.userService.CreateToken(u.ID, "tests")
+	require.NoError(t, err)
+
+	return "Bearer " + token
 }
 
 func (suite *HandlerTestSuite) insertTestProjectsInTheDatabase() []uuid.UUID {
@@ -225,6 +277,7 @@ func (suite *HandlerTestSuite) TestDeleteProject_DeletesAProject() {
 	projectIDs := suite.insertTestProjectsInTheDatabase()
 	reqPath := fmt.Sprintf("/projects/%s", projectIDs[0])
 	req, _ := http.NewRequest("DELETE", reqPath, nil)
+	req.Header.Set("If-Match", versionETag(1))
 	rr := executeRequest(req, suite)
 	checkResponseCode(t, http.StatusNoContent, rr.Code)
 
@@ -293,6 +346,7 @@ func (suite *HandlerTestSuite) TestPatchProjectsProjectID_RenamesProject() {
 	}
 	buff := bodyInBytes(t, body)
 	req, _ := http.NewRequest("PATCH", reqPath, buff)
+	req.Header.Set("If-Match", versionETag(1))
 	rr := executeRequest(req, suite)
 	checkResponseCode(t, http.StatusOK, rr.Code)
 
@@ -316,6 +370,91 @@ func (suite *HandlerTestSuite) TestPatchProjectsProjectID_BadRequest() {
 	checkResponseCode(t, http.StatusBadRequest, rr.Code)
 }
 
+func (suite *HandlerTestSuite) TestPatchProjectsProjectID_UpdatesDetails() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	reqPath := fmt.Sprintf("/projects/%s", projectIDs[0])
+
+	description, color, icon := "Things to buy", "#ff8800", "🛒"
+	req, _ := http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{
+		Description: &description,
+		Color:       &color,
+		Icon:        &icon,
+	}))
+	req.Header.Set("If-Match", "*")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var respBody openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &respBody))
+	assert.Equal(t, TestProjectName, *respBody.Name)
+	assert.Equal(t, description, *respBody.Description)
+	assert.Equal(t, color, *respBody.Color)
+	assert.Equal(t, icon, *respBody.Icon)
+
+	// An empty color removes it, and invalid fields are all reported
+	color, icon = "", "\n"
+	req, _ = http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{Color: &color}))
+	req.Header.Set("If-Match", "*")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &respBody))
+	assert.Nil(t, respBody.Color)
+
+	color = "orange"
+	req, _ = http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{Color: &color, Icon: &icon}))
+	req.Header.Set("If-Match", "*")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	problem := decodeProblem(t, rr)
+	if assert.Len(t, problem.Errors, 2) {
+		assert.Equal(t, "color", problem.Errors[0].Field)
+		assert.Equal(t, "icon", problem.Errors[1].Field)
+	}
+}
+
+func (suite *HandlerTestSuite) TestPutProjectsProjectIDPosition() {
+	t := suite.T()
+
+	names := []string{"First", "Second", "Third"}
+	ids := []string{}
+	for _, name := range names {
+		req, _ := http.NewRequest("POST", "/projects", bodyInBytes(t, openapi.PostProjectsJSONRequestBody{Name: &name}))
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+
+		var project openapi.Project
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &project))
+		ids = append(ids, *project.ID)
+	}
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/projects/%s/position", ids[2]), bytes.NewBufferString(`{"order": 0}`))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var project openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &project))
+	assert.Equal(t, 0, *project.Order)
+
+	// Projects are listed in their order by default
+	req, _ = http.NewRequest("GET", "/projects", nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var projects []openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &projects))
+	listedNames := []string{}
+	for _, p := range projects {
+		listedNames = append(listedNames, *p.Name)
+	}
+	assert.Equal(t, []string{"Third", "First", "Second"}, listedNames)
+
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/projects/%s/position", uuid.NewString()), bytes.NewBufferString(`{"order": 0}`))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
 func (suite *HandlerTestSuite) TestPatchProjectsProjectID_NameIsAlreadyTaken() {
 	t := suite.T()
 
@@ -327,6 +466,7 @@ func (suite *HandlerTestSuite) TestPatchProjectsProjectID_NameIsAlreadyTaken() {
 		Name: &newName,
 	})
 	req, _ := http.NewRequest("PATCH", reqPath, buff)
+	req.Header.Set("If-Match", versionETag(1))
 	rr := executeRequest(req, suite)
 	checkResponseCode(t, http.StatusConflict, rr.Code)
 }
@@ -413,6 +553,68 @@ func (suite *HandlerTestSuite) TestGetTasks_ReturnsTasks() {
 	}
 }
 
+func (suite *HandlerTestSuite) TestGetTasks_Pagination() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	for _, name := range []string{"b", "c", "a"} {
+		_, err := suite.taskService.CreateTask(name, projectIDs[0], nil)
+		require.NoError(t, err)
+	}
+
+	names := []string{}
+	url := "/tasks?sort=-name&limit=2"
+	for url != "" {
+		req, _ := http.NewRequest("GET", url, nil)
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		var tasks []openapi.Task
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tasks))
+		for _, task := range tasks {
+			names = append(names, *task.Name)
+		}
+
+		url = ""
+		link := rr.Header().Get("Link")
+		if link != "" {
+			require.True(t, strings.HasSuffix(link, `>; rel="next"`), link)
+			url = strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+		}
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, names)
+}
+
+func (suite *HandlerTestSuite) TestGetTasks_InvalidParameters() {
+	t := suite.T()
+
+	for _, query := range []string{"sort=priority", "status=done", "parentTaskID=123", "cursor=invalid"} {
+		req, _ := http.NewRequest("GET", "/tasks?"+query, nil)
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGetProjectsProjectIDTasks_RootOnly() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parentTask, err := suite.taskService.CreateTask("parent task", projectIDs[0], nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("subtask", projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/projects/%s/tasks?root=true", projectIDs[0]), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var tasks []openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tasks))
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "parent task", *tasks[0].Name)
+	assert.Empty(t, rr.Header().Get("Link"))
+}
+
 func (suite *HandlerTestSuite) TestPostTasks_NoBody() {
 	req, _ := http.NewRequest("POST", "/tasks", nil)
 	rr := executeRequest(req, suite)
@@ -551,6 +753,7 @@ func (suite *HandlerTestSuite) TestDeleteTasksTaskID_TaskIsDeleted() {
 
 	reqPath := fmt.Sprintf("/tasks/%s", task.ID)
 	req, _ := http.NewRequest("DELETE", reqPath, nil)
+	req.Header.Set("If-Match", versionETag(1))
 	rr := executeRequest(req, suite)
 	checkResponseCode(t, http.StatusNoContent, rr.Code)
 
@@ -594,6 +797,283 @@ func (suite *HandlerTestSuite) TestPatchTasksTaskIDStatus_MarksPendingTaskAsComp
 	suite.checkMarkTaskAsCompleted(taskModel)
 }
 
+func (suite *HandlerTestSuite) TestGetTasksCompleted() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	completedTask, err := suite.taskService.CreateTask("Completed task", projectIDs[0], nil)
+	require.NoError(t, err)
+	otherCompletedTask, err := suite.taskService.CreateTask("Other completed task", projectIDs[1], nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Pending task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	require.NoError(t, suite.taskService.UpdateTaskStatus(completedTask.ID, task.TaskStatusCompleted.String()))
+	require.NoError(t, suite.taskService.UpdateTaskStatus(otherCompletedTask.ID, task.TaskStatusCompleted.String()))
+
+	period := url.Values{
+		"from": {time.Now().Add(-time.Hour).Format(time.RFC3339)},
+		"to":   {time.Now().Add(time.Hour).In(time.FixedZone("BRT", -3*60*60)).Format(time.RFC3339)},
+	}.Encode()
+
+	req, _ := http.NewRequest("GET", "/tasks/completed?"+period, nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var tasksOAPI []openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tasksOAPI))
+	require.Len(t, tasksOAPI, 2)
+	assert.Equal(t, otherCompletedTask.ID.String(), *tasksOAPI[0].ID)
+	assert.NotNil(t, tasksOAPI[0].CompletedAt)
+	assert.NotNil(t, tasksOAPI[0].UpdatedAt)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/tasks/completed?%s", projectIDs[0], period), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tasksOAPI))
+	require.Len(t, tasksOAPI, 1)
+	assert.Equal(t, completedTask.ID.String(), *tasksOAPI[0].ID)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/tasks/completed?%s", uuid.New(), period), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+
+	// The period must not be empty
+	emptyPeriod := url.Values{"from": {"2026-10-01T00:00:00Z"}, "to": {"2026-10-01T00:00:00Z"}}.Encode()
+	req, _ = http.NewRequest("GET", "/tasks/completed?"+emptyPeriod, nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestGetProjectsProjectIDStats() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	completedTask, err := suite.taskService.CreateTask("Completed task", projectIDs[0], nil)
+	require.NoError(t, err)
+	openTask, err := suite.taskService.CreateTask("Open task", projectIDs[0], nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(completedTask.ID, task.TaskStatusCompleted.String()))
+
+	// Two weeks and the last two hours
+	now := time.Now().In(time.FixedZone("BRT", -3*60*60))
+	from := now.Add(-time.Hour).AddDate(0, 0, -14)
+	to := now.Add(time.Hour)
+	period := url.Values{
+		"from":   {from.Format(time.RFC3339)},
+		"to":     {to.Format(time.RFC3339)},
+		"bucket": {"week"},
+	}.Encode()
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/projects/%s/stats?%s", projectIDs[0], period), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var statsOAPI openapi.ProjectStats
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &statsOAPI))
+	assert.Equal(t, openapi.ProjectStatsBucketWeek, statsOAPI.Bucket)
+	require.Len(t, statsOAPI.Series, 3)
+	last := statsOAPI.Series[2]
+	assert.Equal(t, 2, last.Created)
+	assert.Equal(t, 1, last.Completed)
+	assert.Equal(t, 1, last.Open)
+	assert.Equal(t, 1, statsOAPI.Completed)
+	assert.NotNil(t, statsOAPI.AverageCycleTimeSeconds)
+	require.Len(t, statsOAPI.OldestOpenTasks, 1)
+	assert.Equal(t, openTask.ID.String(), *statsOAPI.OldestOpenTasks[0].ID)
+
+	// The buckets are in the time zone of the period
+	_, offset := last.Start.Zone()
+	assert.Equal(t, -3*60*60, offset)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/stats?%s", projectIDs[0], url.Values{
+		"from":   {from.Format(time.RFC3339)},
+		"to":     {to.Format(time.RFC3339)},
+		"bucket": {"month"},
+	}.Encode()), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/stats?%s", uuid.New(), period), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestTimer() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	otherTask, err := suite.taskService.CreateTask("other task", projectIDs[0], nil)
+	require.NoError(t, err)
+	alice := suite.createUserToken("alice")
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/tasks/%s/timer", taskModel.ID), strings.NewReader(`{"note": "Reading"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", alice)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	var timer openapi.TimeEntry
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &timer))
+	assert.True(t, timer.Running)
+	assert.Nil(t, timer.EndedAt)
+	assert.Equal(t, "alice", timer.Actor)
+	assert.NotNil(t, timer.UserID)
+	assert.Equal(t, "Reading", timer.Note)
+
+	// A user runs one timer at a time, even on another task
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/tasks/%s/timer", otherTask.ID), nil)
+	req.Header.Set("Authorization", alice)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	// But other users have their own, whatever actor they claim to be
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/tasks/%s/timer", otherTask.ID), nil)
+	req.Header.Set("X-Actor", "alice")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	var otherTimer openapi.TimeEntry
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &otherTimer))
+	assert.Equal(t, "tester", otherTimer.Actor)
+
+	req, _ = http.NewRequest("GET", "/timer", nil)
+	req.Header.Set("Authorization", alice)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var running openapi.TimeEntry
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &running))
+	assert.Equal(t, timer.ID, running.ID)
+
+	req, _ = http.NewRequest("POST", "/timer/stop", nil)
+	req.Header.Set("Authorization", alice)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var stopped openapi.TimeEntry
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stopped))
+	assert.False(t, stopped.Running)
+	assert.NotNil(t, stopped.EndedAt)
+
+	req, _ = http.NewRequest("GET", "/timer", nil)
+	req.Header.Set("Authorization", alice)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/tasks/%s/time-entries", taskModel.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var entries []openapi.TimeEntry
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, timer.ID, entries[0].ID)
+}
+
+func (suite *HandlerTestSuite) TestPostTasksTaskIDTimeEntries() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parent, err := suite.taskService.CreateTask("parent", projectIDs[0], nil)
+	require.NoError(t, err)
+	child, err := suite.taskService.CreateTask("child", projectIDs[0], &parent.ID)
+	require.NoError(t, err)
+
+	startedAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	postEntry := func(taskID uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/tasks/%s/time-entries", taskID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return executeRequest(req, suite)
+	}
+
+	rr := postEntry(parent.ID, fmt.Sprintf(`{"startedAt": %q, "durationSeconds": 1800}`, startedAt.Format(time.RFC3339)))
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	var entry openapi.TimeEntry
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entry))
+	assert.Equal(t, 1800.0, entry.DurationSeconds)
+	assert.False(t, entry.Running)
+	if assert.NotNil(t, entry.EndedAt) {
+		assert.True(t, startedAt.Add(30*time.Minute).Equal(*entry.EndedAt))
+	}
+
+	rr = postEntry(child.ID, fmt.Sprintf(`{"startedAt": %q, "endedAt": %q, "note": "Fixed it"}`,
+		startedAt.Format(time.RFC3339), startedAt.Add(time.Hour).Format(time.RFC3339)))
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	// The end must not be before the start
+	rr = postEntry(child.ID, fmt.Sprintf(`{"startedAt": %q, "endedAt": %q}`,
+		startedAt.Format(time.RFC3339), startedAt.Add(-time.Hour).Format(time.RFC3339)))
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	// Either the end or the duration is required
+	rr = postEntry(child.ID, fmt.Sprintf(`{"startedAt": %q}`, startedAt.Format(time.RFC3339)))
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	// The totals of the parent include the time spent on its subtasks
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/tasks/%s/time-totals", parent.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var totals openapi.TimeTotals
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &totals))
+	assert.Equal(t, openapi.TimeTotals{OwnSeconds: 1800, TotalSeconds: 5400}, totals)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/time-entries/%s", entry.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/time-entries/%s", entry.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestGetProjectsProjectIDTimesheet() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Write, then review", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	startedAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	for _, actor := range []string{"alice", "bob"} {
+		token := suite.createUserToken(actor)
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/tasks/%s/time-entries", taskModel.ID), strings.NewReader(
+			fmt.Sprintf(`{"startedAt": %q, "durationSeconds": 5400}`, startedAt.Format(time.RFC3339))))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", token)
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+	}
+
+	// The period ends an hour into the entries
+	period := url.Values{
+		"from": {startedAt.Add(-time.Hour).Format(time.RFC3339)},
+		"to":   {startedAt.Add(time.Hour).Format(time.RFC3339)},
+	}.Encode()
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/projects/%s/timesheet?%s", projectIDs[0], period), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var timesheet openapi.Timesheet
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &timesheet))
+	require.Len(t, timesheet.Rows, 2)
+	assert.Equal(t, "alice", timesheet.Rows[0].Actor)
+	assert.Equal(t, 3600.0, timesheet.Rows[0].Seconds)
+	assert.Equal(t, 7200.0, timesheet.TotalSeconds)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/timesheet?%s", projectIDs[0], period), nil)
+	req.Header.Set("Accept", "text/csv")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, fmt.Sprintf(
+		"task_id,task_name,actor,seconds,hours\n%[1]s,\"Write, then review\",alice,3600,1.00\n%[1]s,\"Write, then review\",bob,3600,1.00\n",
+		taskModel.ID,
+	), rr.Body.String())
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/timesheet?%s", uuid.New(), period), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
 func (suite *HandlerTestSuite) TestPatchTasksTaskIDStatus_MarksPendingTaskAsPending() {
 	t := suite.T()
 
@@ -643,24 +1123,1771 @@ func (suite *HandlerTestSuite) checkMarkTaskStatus(taskModel task.Task, taskStat
 		Status: &taskStatus,
 	}
 	buff := bodyInBytes(t, body)
+	taskModel, err := suite.taskService.FindTaskByID(taskModel.ID)
+	require.NoError(t, err)
 	req, _ := http.NewRequest("PATCH", reqPath, buff)
+	req.Header.Set("If-Match", versionETag(taskModel.Version))
 	rr := executeRequest(req, suite)
 	checkResponseCode(t, http.StatusOK, rr.Code)
 
 	slog.Info("request body", slog.Any("body", rr.Body))
 
-	taskModel, err := suite.taskService.FindTaskByID(taskModel.ID)
+	taskModel, err = suite.taskService.FindTaskByID(taskModel.ID)
 	require.NoError(t, err)
 	require.Equal(t, taskStatus.ToValue(), taskModel.Status.String())
 }
 
-func bodyInBytes(t *testing.T, body interface{}) *bytes.Buffer {
-	bodystr, err := json.Marshal(body)
+func (suite *HandlerTestSuite) postTemplate(name string, tasks []openapi.TemplateTask) openapi.Template {
+	t := suite.T()
+	body := openapi.PostTemplatesJSONRequestBody{Name: &name, Tasks: tasks}
+	req, _ := http.NewRequest("POST", "/templates", bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	var tmpl openapi.Template
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tmpl))
+
+	return tmpl
+}
+
+func sampleTemplateTasksOAPI() []openapi.TemplateTask {
+	rootName := "Onboard {{employee}}"
+	subtaskName := "Order laptop"
+	offset := 3
+
+	return []openapi.TemplateTask{
+		{
+			Name: &rootName,
+			Subtasks: []openapi.TemplateTask{
+				{Name: &subtaskName, DueOffsetDays: &offset},
+			},
+		},
+	}
+}
+
+func (suite *HandlerTestSuite) TestPostTemplates_CreatesTemplate() {
+	t := suite.T()
+
+	tmpl := suite.postTemplate("Onboarding", sampleTemplateTasksOAPI())
+
+	require.NotNil(t, tmpl.Name)
+	assert.Equal(t, "Onboarding", *tmpl.Name)
+	assert.Equal(t, []string{"employee"}, tmpl.Variables)
+	require.Len(t, tmpl.Tasks, 1)
+	require.Len(t, tmpl.Tasks[0].Subtasks, 1)
+
+	reqPath := fmt.Sprintf("/templates/%s", *tmpl.ID)
+	req, _ := http.NewRequest("GET", reqPath, nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostTemplates_FailsIfDuplicate() {
+	t := suite.T()
+
+	name := "Onboarding"
+	suite.postTemplate(name, nil)
+
+	body := openapi.PostTemplatesJSONRequestBody{Name: &name}
+	req, _ := http.NewRequest("POST", "/templates", bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestDeleteTemplatesTemplateID() {
+	t := suite.T()
+
+	tmpl := suite.postTemplate("Onboarding", sampleTemplateTasksOAPI())
+
+	reqPath := fmt.Sprintf("/templates/%s", *tmpl.ID)
+	req, _ := http.NewRequest("DELETE", reqPath, nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	req, _ = http.NewRequest("GET", reqPath, nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostTemplatesTemplateIDInstantiate_NewProject() {
+	t := suite.T()
+
+	tmpl := suite.postTemplate("Onboarding", sampleTemplateTasksOAPI())
+
+	projectName := "Onboarding {{employee}}"
+	body := openapi.PostTemplatesTemplateIDInstantiateJSONRequestBody{
+		ProjectName: &projectName,
+		Variables: &openapi.TemplateInstantiation_Variables{
+			AdditionalProperties: map[string]string{"employee": "Alice"},
+		},
+	}
+	reqPath := fmt.Sprintf("/templates/%s/instantiate", *tmpl.ID)
+	req, _ := http.NewRequest("POST", reqPath, bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	var instance openapi.TemplateInstance
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &instance))
+
+	require.NotNil(t, instance.Project)
+	assert.Equal(t, "Onboarding Alice", *instance.Project.Name)
+	require.Len(t, instance.Tasks, 1)
+	assert.Equal(t, "Onboard Alice", *instance.Tasks[0].Name)
+	require.Len(t, instance.Tasks[0].Subtasks, 1)
+	assert.NotNil(t, instance.Tasks[0].Subtasks[0].DueAt)
+}
+
+func (suite *HandlerTestSuite) TestPostTemplatesTemplateIDInstantiate_ExistingProject() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parentTask, err := suite.taskService.CreateTask("People", projectIDs[0], nil)
 	require.NoError(t, err)
 
-	return bytes.NewBuffer(bodystr)
+	tmpl := suite.postTemplate("Onboarding", sampleTemplateTasksOAPI())
+
+	projectID := projectIDs[0].String()
+	parentTaskID := parentTask.ID.String()
+	body := openapi.PostTemplatesTemplateIDInstantiateJSONRequestBody{
+		ProjectID:    &projectID,
+		ParentTaskID: &parentTaskID,
+		Variables: &openapi.TemplateInstantiation_Variables{
+			AdditionalProperties: map[string]string{"employee": "Bob"},
+		},
+	}
+	reqPath := fmt.Sprintf("/templates/%s/instantiate", *tmpl.ID)
+	req, _ := http.NewRequest("POST", reqPath, bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	subtasks, err := suite.taskService.FetchSubtasksDirect(parentTask.ID)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 1)
+	assert.Equal(t, "Onboard Bob", subtasks[0].Name)
 }
 
-func TestHandler(t *testing.T) {
-	suite.Run(t, new(HandlerTestSuite))
+func (suite *HandlerTestSuite) TestPostTemplatesTemplateIDInstantiate_MissingVariables() {
+	t := suite.T()
+
+	tmpl := suite.postTemplate("Onboarding", sampleTemplateTasksOAPI())
+
+	projectName := "Onboarding"
+	body := openapi.PostTemplatesTemplateIDInstantiateJSONRequestBody{ProjectName: &projectName}
+	reqPath := fmt.Sprintf("/templates/%s/instantiate", *tmpl.ID)
+	req, _ := http.NewRequest("POST", reqPath, bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	// Nothing should have been created
+	projects, err := suite.projectService.ListProjects()
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+}
+
+func (suite *HandlerTestSuite) TestPostTemplatesTemplateIDInstantiate_InvalidTask() {
+	t := suite.T()
+
+	// The second task gets a name too long once rendered, after the first one was created
+	welcomeName := "Welcome"
+	onboardName := "Onboard {{employee}}"
+	tmpl := suite.postTemplate("Onboarding", []openapi.TemplateTask{{Name: &welcomeName}, {Name: &onboardName}})
+	variables := &openapi.TemplateInstantiation_Variables{
+		AdditionalProperties: map[string]string{"employee": strings.Repeat("a", 200)},
+	}
+	reqPath := fmt.Sprintf("/templates/%s/instantiate", *tmpl.ID)
+
+	projectName := "Onboarding"
+	req, _ := http.NewRequest("POST", reqPath, bodyInBytes(t, openapi.PostTemplatesTemplateIDInstantiateJSONRequestBody{
+		ProjectName: &projectName,
+		Variables:   variables,
+	}))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	// Neither the project nor any of its tasks are left behind
+	projects, err := suite.projectService.ListProjects()
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+	tasks, err := suite.taskService.ListTasks()
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	projectID := projectIDs[0].String()
+	req, _ = http.NewRequest("POST", reqPath, bodyInBytes(t, openapi.PostTemplatesTemplateIDInstantiateJSONRequestBody{
+		ProjectID: &projectID,
+		Variables: variables,
+	}))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	tasks, err = suite.taskService.ListTasks()
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func (suite *HandlerTestSuite) TestPostProjectsProjectIDTemplate() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	_, err := suite.taskService.CreateTask("Write report", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	name := "Reporting"
+	body := openapi.PostProjectsProjectIDTemplateJSONRequestBody{Name: &name}
+	reqPath := fmt.Sprintf("/projects/%s/template", projectIDs[0])
+	req, _ := http.NewRequest("POST", reqPath, bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	var tmpl openapi.Template
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tmpl))
+	require.Len(t, tmpl.Tasks, 1)
+	assert.Equal(t, "Write report", *tmpl.Tasks[0].Name)
+}
+
+func (suite *HandlerTestSuite) TestGetTrash_ListsDeletedItems() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	task, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+	_, err = suite.projectService.DeleteProject(projectIDs[1])
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "/trash", nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var items []openapi.TrashItem
+	err = json.Unmarshal(rr.Body.Bytes(), &items)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// Most recently deleted first
+	assert.Equal(t, projectIDs[1].String(), *items[0].ID)
+	assert.Equal(t, openapi.TrashItemTypeProject, *items[0].Type)
+	assert.Equal(t, task.ID.String(), *items[1].ID)
+	assert.Equal(t, openapi.TrashItemTypeTask, *items[1].Type)
+}
+
+func (suite *HandlerTestSuite) TestPostTrashItemIDRestore_RestoresTask() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	task, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/tasks/%s", task.ID), nil)
+	req.Header.Set("If-Match", versionETag(1))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/trash/%s/restore", task.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/tasks/%s", task.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostTrashItemIDRestore_RestoresProject() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/projects/%s", projectIDs[0]), nil)
+	req.Header.Set("If-Match", versionETag(1))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/trash/%s/restore", projectIDs[0]), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var item openapi.TrashItem
+	err := json.Unmarshal(rr.Body.Bytes(), &item)
+	if assert.NoError(t, err) {
+		assert.Equal(t, openapi.TrashItemTypeProject, *item.Type)
+	}
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s", projectIDs[0]), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostTrashItemIDRestore_NotInTrash() {
+	t := suite.T()
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/trash/%s/restore", uuid.New()), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostProjectsProjectIDArchive_HidesProject() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/projects/%s/archive", projectIDs[0]), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var project openapi.Project
+	err := json.Unmarshal(rr.Body.Bytes(), &project)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, project.ArchivedAt)
+	}
+
+	req, _ = http.NewRequest("GET", "/projects", nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var projects []openapi.Project
+	err = json.Unmarshal(rr.Body.Bytes(), &projects)
+	if assert.NoError(t, err) {
+		assert.Len(t, projects, 1)
+	}
+
+	req, _ = http.NewRequest("GET", "/projects?archived=true", nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	err = json.Unmarshal(rr.Body.Bytes(), &projects)
+	if assert.NoError(t, err) {
+		assert.Len(t, projects, 2)
+	}
+}
+
+func (suite *HandlerTestSuite) TestPostProjectsProjectIDArchive_RejectsTaskChanges() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	task, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/projects/%s/archive", projectIDs[0]), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	projectID := projectIDs[0].String()
+	taskName := "New task"
+	body := bodyInBytes(t, openapi.Task{Name: &taskName, ProjectID: &projectID})
+	req, _ = http.NewRequest("POST", "/tasks", body)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	status := openapi.TaskStatusCompleted
+	body = bodyInBytes(t, openapi.PatchTasksTaskIDStatusJSONRequestBody{Status: &status})
+	req, _ = http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s/status", task.ID), body)
+	req.Header.Set("If-Match", versionETag(1))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/tasks/%s", task.ID), nil)
+	req.Header.Set("If-Match", versionETag(1))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/projects/%s/unarchive", projectIDs[0]), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/tasks/%s", task.ID), nil)
+	req.Header.Set("If-Match", versionETag(1))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostProjectsProjectIDArchive_ProjectDoesNotExist() {
+	t := suite.T()
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/projects/%s/archive", uuid.New()), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/projects/%s/unarchive", uuid.New()), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestGetProjectsProjectIDActivity_RecordsChanges() {
+	t := suite.T()
+
+	projectName := "Test project"
+	req, _ := http.NewRequest("POST", "/projects", bodyInBytes(t, openapi.PostProjectsJSONRequestBody{Name: &projectName}))
+	req.Header.Set("Authorization", suite.createUserToken("alice"))
+	// Clients cannot attribute their changes to someone else
+	req.Header.Set("X-Actor", "mallory")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	var project openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &project))
+
+	newName := "Renamed project"
+	req, _ = http.NewRequest("PATCH", fmt.Sprintf("/projects/%s", *project.ID), bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{Name: &newName}))
+	req.Header.Set("If-Match", versionETag(1))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/activity?limit=1", *project.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var page openapi.ActivityPage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, openapi.ActivityEventActionRenamed, *page.Events[0].Action)
+	assert.Equal(t, "tester", *page.Events[0].Actor)
+	assert.NotNil(t, page.Events[0].UserID)
+	assert.Equal(t, map[string]interface{}{"name": newName}, *page.Events[0].After)
+	require.NotNil(t, page.NextCursor)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/activity?limit=1&cursor=%s", *project.ID, *page.NextCursor), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, openapi.ActivityEventActionCreated, *page.Events[0].Action)
+	assert.Equal(t, "alice", *page.Events[0].Actor)
+	assert.Nil(t, page.NextCursor)
+}
+
+func (suite *HandlerTestSuite) TestGetTasksTaskIDActivity_RecordsCascadedChanges() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parentTask, err := suite.taskService.CreateTask("Parent task", projectIDs[0], nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+
+	status := openapi.TaskStatusCompleted
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s/status", parentTask.ID), bodyInBytes(t, openapi.PatchTasksTaskIDStatusJSONRequestBody{Status: &status}))
+	req.Header.Set("If-Match", versionETag(1))
+	req.Header.Set("X-Request-Id", "complete-parent")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "complete-parent", rr.Header().Get("X-Request-Id"))
+
+	// Completing the parent task completed the subtask, as part of the same request
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/tasks/%s/activity", subtask.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var page openapi.ActivityPage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	require.Len(t, page.Events, 1)
+	event := page.Events[0]
+	assert.Equal(t, openapi.ActivityEventActionStatusChanged, *event.Action)
+	assert.Equal(t, "complete-parent", *event.RequestID)
+	assert.Equal(t, map[string]interface{}{"status": "pending"}, *event.Before)
+	assert.Equal(t, map[string]interface{}{"status": "completed"}, *event.After)
+}
+
+func (suite *HandlerTestSuite) TestGetTasksTaskIDActivity_InvalidCursor() {
+	t := suite.T()
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/tasks/%s/activity?cursor=invalid", uuid.New()), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestGetTasksTaskIDRevisions_ListsRevisions() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(taskModel.ID, "Renamed task")
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/tasks/%s/revisions", taskModel.ID), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var revisions []openapi.TaskRevision
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &revisions))
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 1, *revisions[0].Revision)
+	assert.Equal(t, "created", *revisions[0].Action)
+	assert.Equal(t, "Test task", *revisions[0].Task.Name)
+	assert.Equal(t, "renamed", *revisions[1].Action)
+	assert.Equal(t, "Renamed task", *revisions[1].Task.Name)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/tasks/%s/revisions/1", taskModel.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var revision openapi.TaskRevision
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &revision))
+	assert.Equal(t, "Test task", *revision.Task.Name)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/tasks/%s/revisions/3", taskModel.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostTasksTaskIDRevisionsRevisionRestore() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(taskModel.ID, "Renamed task")
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/tasks/%s/revisions/1/restore", taskModel.ID), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var restoredTask openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &restoredTask))
+	assert.Equal(t, "Test task", *restoredTask.Name)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/tasks/%s/revisions/1/restore", uuid.New()), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostUndo_UndoesStatusChange() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parentTask, err := suite.taskService.CreateTask("Parent task", projectIDs[0], nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+
+	status := openapi.TaskStatusCompleted
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s/status", parentTask.ID), bodyInBytes(t, openapi.PatchTasksTaskIDStatusJSONRequestBody{Status: &status}))
+	req.Header.Set("If-Match", versionETag(1))
+	req.Header.Set("X-Session-Id", "test session")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("POST", "/undo", nil)
+	req.Header.Set("X-Session-Id", "test session")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var step openapi.UndoStep
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &step))
+	assert.Equal(t, "status_changed", *step.Action)
+	require.Len(t, step.Tasks, 2)
+	for _, taskOAPI := range step.Tasks {
+		assert.Equal(t, openapi.TaskStatusPending, *taskOAPI.Status)
+	}
+
+	subtask, err = suite.taskService.FindTaskByID(subtask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.TaskStatusPending, subtask.Status)
+
+	req, _ = http.NewRequest("POST", "/redo", nil)
+	req.Header.Set("X-Session-Id", "test session")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	subtask, err = suite.taskService.FindTaskByID(subtask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.TaskStatusCompleted, subtask.Status)
+}
+
+func (suite *HandlerTestSuite) TestPostUndo_SessionsBelongToUsers() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	newName := "Renamed task"
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s", taskModel.ID), bodyInBytes(t, openapi.PatchTasksTaskIDJSONRequestBody{Name: &newName}))
+	req.Header.Set("If-Match", versionETag(1))
+	req.Header.Set("X-Session-Id", "test session")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	// Another user sending the same session ID has nothing to undo
+	req, _ = http.NewRequest("POST", "/undo", nil)
+	req.Header.Set("Authorization", suite.createUserToken("other"))
+	req.Header.Set("X-Session-Id", "test session")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	taskModel, err = suite.taskService.FindTaskByID(taskModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newName, taskModel.Name)
+}
+
+func (suite *HandlerTestSuite) TestPostUndo_RequestsWithTheSameRequestID() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	sectionIDs := []string{}
+	for _, name := range []string{"Backlog", "This week"} {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/projects/%s/sections", projectIDs[0]),
+			bodyInBytes(t, openapi.PostProjectsProjectIDSectionsJSONRequestBody{Name: name}))
+		req.Header.Set("Content-Type", "application/json")
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+
+		var section openapi.Section
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &section))
+		sectionIDs = append(sectionIDs, section.ID)
+	}
+
+	// The client reuses its request ID, but the moves are still undone one at a time
+	for _, sectionID := range sectionIDs {
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/tasks/%s/section", taskModel.ID),
+			strings.NewReader(fmt.Sprintf(`{"sectionID": %q}`, sectionID)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-Id", "same request")
+		req.Header.Set("X-Session-Id", "test session")
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	}
+
+	req, _ := http.NewRequest("POST", "/undo", nil)
+	req.Header.Set("X-Session-Id", "test session")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	taskModel, err = suite.taskService.FindTaskByID(taskModel.ID)
+	require.NoError(t, err)
+	require.NotNil(t, taskModel.SectionID)
+	assert.Equal(t, sectionIDs[0], taskModel.SectionID.String())
+}
+
+func (suite *HandlerTestSuite) TestPostUndo_NothingToUndo() {
+	t := suite.T()
+
+	req, _ := http.NewRequest("POST", "/undo", nil)
+	req.Header.Set("X-Session-Id", "empty session")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	req, _ = http.NewRequest("POST", "/redo", nil)
+	req.Header.Set("X-Session-Id", "empty session")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostUndo_MissingSession() {
+	t := suite.T()
+
+	req, _ := http.NewRequest("POST", "/undo", nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestGetProjectsProjectID_ConditionalGet() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	reqPath := fmt.Sprintf("/projects/%s", projectIDs[0])
+	req, _ := http.NewRequest("GET", reqPath, nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+	assert.Equal(t, `"1.0.0.0"`, etag)
+
+	req, _ = http.NewRequest("GET", reqPath, nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.Bytes())
+
+	_, err := suite.projectService.RenameProject(projectIDs[0], "Renamed project")
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest("GET", reqPath, nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	etag = rr.Header().Get("ETag")
+	assert.Equal(t, `"2.0.0.0"`, etag)
+
+	// The task summary is covered by the ETag...
+	_, err = suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest("GET", reqPath, nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"2.1.0.1"`, rr.Header().Get("ETag"))
+
+	// ...but changes of the tasks do not prevent changing the project
+	newName := "new project name"
+	req, _ = http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{Name: &newName}))
+	req.Header.Set("If-Match", etag)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestGetProjects_TaskSummary() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parentTask, err := suite.taskService.CreateTask("Parent task", projectIDs[0], nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Other task", projectIDs[0], nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(subtask.ID, task.TaskStatusCompleted.String()))
+
+	req, _ := http.NewRequest("GET", "/projects?sort=name", nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var projects []openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &projects))
+	summaries := map[string]openapi.TaskSummary{}
+	for _, p := range projects {
+		require.NotNil(t, p.TaskSummary)
+		summaries[*p.ID] = *p.TaskSummary
+	}
+	assert.Equal(t, openapi.TaskSummary{Total: 3, Pending: 2, Completed: 1, PercentDone: 33, RootTasks: 2}, summaries[projectIDs[0].String()])
+	assert.Equal(t, openapi.TaskSummary{}, summaries[projectIDs[1].String()])
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s", projectIDs[0]), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var project openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &project))
+	if assert.NotNil(t, project.TaskSummary) {
+		assert.Equal(t, 33, project.TaskSummary.PercentDone)
+	}
+}
+
+func (suite *HandlerTestSuite) TestEstimates() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	parentTask, err := suite.taskService.CreateTask("Parent task", projectIDs[0], nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+	otherSubtask, err := suite.taskService.CreateTask("Other subtask", projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+
+	patchTask := func(taskID uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s", taskID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", "*")
+		return executeRequest(req, suite)
+	}
+
+	rr := patchTask(subtask.ID, `{"estimate": 30}`)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var taskOAPI openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	if assert.NotNil(t, taskOAPI.Estimate) {
+		assert.Equal(t, 30, *taskOAPI.Estimate)
+	}
+
+	rr = patchTask(otherSubtask.ID, `{"estimate": 90}`)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(subtask.ID, task.TaskStatusCompleted.String()))
+
+	rr = patchTask(otherSubtask.ID, `{"estimate": -1}`)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	// The estimate of the parent task is derived from its subtasks
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/tasks/%s", parentTask.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	assert.Nil(t, taskOAPI.Estimate)
+	if assert.NotNil(t, taskOAPI.EffectiveEstimate) && assert.NotNil(t, taskOAPI.RemainingWork) {
+		assert.Equal(t, 120, *taskOAPI.EffectiveEstimate)
+		assert.Equal(t, 90, *taskOAPI.RemainingWork)
+	}
+
+	minutes := openapi.EstimateUnitMinutes
+	req, _ = http.NewRequest("PATCH", fmt.Sprintf("/projects/%s", projectIDs[0]),
+		bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{EstimateUnit: &minutes}))
+	req.Header.Set("If-Match", "*")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s", projectIDs[0]), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var project openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &project))
+	assert.Equal(t, &minutes, project.EstimateUnit)
+	if assert.NotNil(t, project.TaskSummary) {
+		assert.Equal(t, 120, project.TaskSummary.Estimate)
+		assert.Equal(t, 90, project.TaskSummary.RemainingWork)
+	}
+
+	// The estimate of the parent task overrides those of its subtasks, until it is removed
+	rr = patchTask(parentTask.ID, `{"estimate": 60}`)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	summaries, err := suite.projectRepository.GetTaskSummaries(projectIDs[:1])
+	require.NoError(t, err)
+	assert.Equal(t, 60, summaries[projectIDs[0]].Estimate)
+	assert.Equal(t, 60, summaries[projectIDs[0]].RemainingWork)
+
+	rr = patchTask(parentTask.ID, `{"estimate": null}`)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	assert.Nil(t, taskOAPI.Estimate)
+	summaries, err = suite.projectRepository.GetTaskSummaries(projectIDs[:1])
+	require.NoError(t, err)
+	assert.Equal(t, 120, summaries[projectIDs[0]].Estimate)
+}
+
+func (suite *HandlerTestSuite) TestIterations() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	otherTask, err := suite.taskService.CreateTask("other task", projectIDs[1], nil)
+	require.NoError(t, err)
+
+	postIteration := func(name string, startsAt time.Time) openapi.Iteration {
+		req, _ := http.NewRequest("POST", "/iterations", bodyInBytes(t, openapi.PostIterationsJSONRequestBody{
+			Name:     name,
+			StartsAt: startsAt,
+			EndsAt:   startsAt.AddDate(0, 0, 14),
+		}))
+		req.Header.Set("Content-Type", "application/json")
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+
+		var it openapi.Iteration
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &it))
+		return it
+	}
+	startsAt := time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC)
+	first := postIteration("Sprint 1", startsAt)
+	second := postIteration("Sprint 2", startsAt.AddDate(0, 0, 14))
+	assert.False(t, first.Closed)
+
+	// Tasks from any project can be planned
+	for _, taskID := range []uuid.UUID{taskModel.ID, otherTask.ID} {
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/tasks/%s/iteration", taskID),
+			strings.NewReader(fmt.Sprintf(`{"iterationID": %q}`, first.ID)))
+		req.Header.Set("Content-Type", "application/json")
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		var taskOAPI openapi.Task
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+		assert.Equal(t, &first.ID, taskOAPI.IterationID)
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/iterations/%s/tasks", first.ID), nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var tasks []openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tasks))
+	assert.Len(t, tasks, 2)
+
+	require.NoError(t, suite.taskService.UpdateTaskStatus(taskModel.ID, task.TaskStatusCompleted.String()))
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/iterations/%s/close", first.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var closed openapi.Iteration
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &closed))
+	assert.True(t, closed.Closed)
+	if assert.NotNil(t, closed.Velocity) {
+		assert.Equal(t, 1, closed.Velocity.CompletedTasks)
+	}
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/iterations/%s/close", first.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	// The incomplete task goes to the next iteration
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/iterations/%s/roll-over", first.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var rollOver openapi.RollOver
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rollOver))
+	assert.Equal(t, second.ID, rollOver.Iteration.ID)
+	require.Len(t, rollOver.Tasks, 1)
+	assert.Equal(t, otherTask.ID.String(), *rollOver.Tasks[0].ID)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/iterations/%s", second.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/tasks/%s", otherTask.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var taskOAPI openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	assert.Nil(t, taskOAPI.IterationID)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/iterations/%s", second.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestSections() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	first, err := suite.taskService.CreateTask("first task", projectIDs[0], nil)
+	require.NoError(t, err)
+	second, err := suite.taskService.CreateTask("second task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	postSection := func(name string) openapi.Section {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/projects/%s/sections", projectIDs[0]),
+			bodyInBytes(t, openapi.PostProjectsProjectIDSectionsJSONRequestBody{Name: name}))
+		req.Header.Set("Content-Type", "application/json")
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+
+		var section openapi.Section
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &section))
+		return section
+	}
+	backlog := postSection("Backlog")
+	thisWeek := postSection("This week")
+	assert.Equal(t, 1, thisWeek.Order)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/projects/%s/sections/%s/position", projectIDs[0], thisWeek.ID),
+		strings.NewReader(`{"order": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/sections", projectIDs[0]), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var sections []openapi.Section
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &sections))
+	if assert.Len(t, sections, 2) {
+		assert.Equal(t, "This week", sections[0].Name)
+		assert.Equal(t, "Backlog", sections[1].Name)
+	}
+
+	// Without a position, tasks go to the end of the section
+	for _, taskID := range []uuid.UUID{first.ID, second.ID} {
+		req, _ = http.NewRequest("PUT", fmt.Sprintf("/tasks/%s/section", taskID),
+			strings.NewReader(fmt.Sprintf(`{"sectionID": %q}`, backlog.ID)))
+		req.Header.Set("Content-Type", "application/json")
+		rr = executeRequest(req, suite)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	}
+
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/tasks/%s/section", second.ID),
+		strings.NewReader(fmt.Sprintf(`{"sectionID": %q, "position": 0}`, thisWeek.ID)))
+	req.Header.Set("Content-Type", "application/json")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var taskOAPI openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	assert.Equal(t, &thisWeek.ID, taskOAPI.SectionID)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/tasks?sectionID=%s", projectIDs[0], backlog.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var tasks []openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tasks))
+	if assert.Len(t, tasks, 1) {
+		assert.Equal(t, first.ID.String(), *tasks[0].ID)
+	}
+
+	// Sections of other projects are not found
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/projects/%s/sections/%s", projectIDs[1], backlog.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+
+	// Deleting a section keeps its tasks
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/projects/%s/sections/%s", projectIDs[0], backlog.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	fetched, err := suite.taskService.FindTaskByID(first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, fetched.SectionID)
+}
+
+func (suite *HandlerTestSuite) TestWorkspaces() {
+	t := suite.T()
+
+	postWorkspace := func(name string) openapi.Workspace {
+		req, _ := http.NewRequest("POST", "/workspaces", bodyInBytes(t, openapi.PostWorkspacesJSONRequestBody{Name: name}))
+		req.Header.Set("Content-Type", "application/json")
+		rr := executeRequest(req, suite)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+
+		var ws openapi.Workspace
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &ws))
+		return ws
+	}
+	postProject := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/projects", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return executeRequest(req, suite)
+	}
+	platform := postWorkspace("Platform")
+	mobile := postWorkspace("Mobile")
+
+	// Both workspaces can have a "Backlog"
+	var backlog openapi.Project
+	for _, ws := range []openapi.Workspace{platform, mobile} {
+		rr := postProject(fmt.Sprintf(`{"name": "Backlog", "workspaceID": %q}`, ws.ID))
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &backlog))
+		assert.Equal(t, &ws.ID, backlog.WorkspaceID)
+	}
+	rr := postProject(fmt.Sprintf(`{"name": "Backlog", "workspaceID": %q}`, mobile.ID))
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	rr = postProject(fmt.Sprintf(`{"name": "Sprint board", "parentProjectID": %q}`, *backlog.ID))
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	var board openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &board))
+	assert.Equal(t, backlog.ID, board.ParentProjectID)
+	assert.Equal(t, &mobile.ID, board.WorkspaceID)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/workspaces/%s/projects?sort=name", mobile.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var projects []openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &projects))
+	if assert.Len(t, projects, 2) {
+		assert.Equal(t, "Backlog", *projects[0].Name)
+		assert.Equal(t, "Sprint board", *projects[1].Name)
+	}
+
+	_, err := suite.taskService.CreateTask("test task", uuid.MustParse(*board.ID), nil)
+	require.NoError(t, err)
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/workspaces/%s/stats", mobile.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var stats openapi.WorkspaceStats
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Equal(t, 2, stats.Projects)
+	assert.Equal(t, 1, stats.Tasks.Total)
+
+	// Moving the sprint board on its own takes it out of its parent
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/projects/%s/workspace", *board.ID),
+		strings.NewReader(fmt.Sprintf(`{"workspaceID": %q}`, platform.ID)))
+	req.Header.Set("Content-Type", "application/json")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &board))
+	assert.Equal(t, &platform.ID, board.WorkspaceID)
+	assert.Nil(t, board.ParentProjectID)
+
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/projects/%s/parent", *board.ID),
+		strings.NewReader(fmt.Sprintf(`{"parentProjectID": %q}`, *backlog.ID)))
+	req.Header.Set("Content-Type", "application/json")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/workspaces/%s", platform.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/workspaces/%s/projects", uuid.New()), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPatchProjectsProjectID_StaleVersion() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	reqPath := fmt.Sprintf("/projects/%s", projectIDs[0])
+
+	// Someone else renames the project in the meantime
+	_, err := suite.projectService.RenameProject(projectIDs[0], "Renamed project")
+	require.NoError(t, err)
+
+	newName := "new project name"
+	req, _ := http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{Name: &newName}))
+	req.Header.Set("If-Match", versionETag(1))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusPreconditionFailed, rr.Code)
+
+	req, _ = http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchProjectsProjectIDJSONRequestBody{Name: &newName}))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusPreconditionRequired, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", reqPath, nil)
+	req.Header.Set("If-Match", versionETag(1))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusPreconditionFailed, rr.Code)
+
+	project, err := suite.projectService.GetProject(projectIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed project", project.Name)
+}
+
+func (suite *HandlerTestSuite) TestGetTasksTaskID_ConditionalGet() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	reqPath := fmt.Sprintf("/tasks/%s", taskModel.ID)
+	req, _ := http.NewRequest("GET", reqPath, nil)
+	req.Header.Set("If-None-Match", versionETag(1))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotModified, rr.Code)
+	assert.Equal(t, versionETag(1), rr.Header().Get("ETag"))
+
+	// The subtasks are not covered by the ETag
+	req, _ = http.NewRequest("GET", reqPath+"?withSubtasks=true", nil)
+	req.Header.Set("If-None-Match", versionETag(1))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("ETag"))
+}
+
+func (suite *HandlerTestSuite) TestGetTasksTaskID_Progress() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", projectIDs[0], &taskModel.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Other subtask", projectIDs[0], &taskModel.ID)
+	require.NoError(t, err)
+
+	reqPath := fmt.Sprintf("/tasks/%s", taskModel.ID)
+	req, _ := http.NewRequest("GET", reqPath, nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+
+	var taskOAPI openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	if assert.NotNil(t, taskOAPI.Progress) {
+		assert.Equal(t, float32(0), *taskOAPI.Progress)
+	}
+	if assert.NotNil(t, taskOAPI.ChildCount) {
+		assert.Equal(t, 2, *taskOAPI.ChildCount)
+	}
+
+	err = suite.taskService.UpdateTaskStatus(subtask.ID, task.TaskStatusCompleted.String())
+	require.NoError(t, err)
+
+	// Completing a subtask does not change the version of the task, but changes its ETag
+	req, _ = http.NewRequest("GET", reqPath, nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+	etag = rr.Header().Get("ETag")
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	if assert.NotNil(t, taskOAPI.Progress) {
+		assert.Equal(t, float32(0.5), *taskOAPI.Progress)
+	}
+
+	// The subtasks in the tree have their own progress
+	req, _ = http.NewRequest("GET", reqPath+"?withSubtasks=true", nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	require.Len(t, taskOAPI.Subtasks, 2)
+	assert.Nil(t, taskOAPI.Subtasks[0].Progress)
+	if assert.NotNil(t, taskOAPI.Subtasks[0].ChildCount) {
+		assert.Equal(t, 0, *taskOAPI.Subtasks[0].ChildCount)
+	}
+
+	// If-Match only compares the version of the task
+	newName := "Renamed task"
+	req, _ = http.NewRequest("PATCH", reqPath, bodyInBytes(t, openapi.PatchTasksTaskIDJSONBody{Name: &newName}))
+	req.Header.Set("If-Match", etag)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPatchTasksTaskID_RenamesAndReordersTask() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Other task", projectIDs[0], nil)
+	require.NoError(t, err)
+
+	newName := "Renamed task"
+	order := 1
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s", taskModel.ID), bodyInBytes(t, openapi.PatchTasksTaskIDJSONBody{Name: &newName, Order: &order}))
+	req.Header.Set("If-Match", versionETag(1))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var taskOAPI openapi.Task
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &taskOAPI))
+	assert.Equal(t, newName, *taskOAPI.Name)
+	assert.Equal(t, versionETag(*taskOAPI.Version), rr.Header().Get("ETag"))
+
+	taskModel, err = suite.taskService.FindTaskByID(taskModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, taskModel.Order)
+	assert.Equal(t, 3, taskModel.Version)
+}
+
+func (suite *HandlerTestSuite) TestPatchTasksTaskID_StaleVersion() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	taskModel, err := suite.taskService.CreateTask("Test task", projectIDs[0], nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(taskModel.ID, "Renamed elsewhere")
+	require.NoError(t, err)
+
+	newName := "Renamed task"
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/tasks/%s", taskModel.ID), bodyInBytes(t, openapi.PatchTasksTaskIDJSONBody{Name: &newName}))
+	req.Header.Set("If-Match", versionETag(1))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusPreconditionFailed, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/tasks/%s", taskModel.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusPreconditionRequired, rr.Code)
+
+	// Any version matches *
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/tasks/%s", taskModel.ID), nil)
+	req.Header.Set("If-Match", "*")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostTasks_RetryWithIdempotencyKey() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	projectID := projectIDs[0].String()
+	taskName := "Test task"
+	body, err := json.Marshal(openapi.Task{Name: &taskName, ProjectID: &projectID})
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("POST", "/tasks", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-test-task")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	originalBody := rr.Body.String()
+
+	req, _ = http.NewRequest("POST", "/tasks", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-test-task")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, originalBody, rr.Body.String())
+	assert.Equal(t, "true", rr.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	tasks, err := suite.taskService.SearchTaskByProject(projectIDs[0])
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	otherName := "Other task"
+	req, _ = http.NewRequest("POST", "/tasks", bodyInBytes(t, openapi.Task{Name: &otherName, ProjectID: &projectID}))
+	req.Header.Set("Idempotency-Key", "create-test-task")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestPostProjects_RetryWithIdempotencyKey() {
+	t := suite.T()
+
+	projectName := "Test project"
+	body, err := json.Marshal(openapi.PostProjectsJSONRequestBody{Name: &projectName})
+	require.NoError(t, err)
+
+	for range 2 {
+		req, _ := http.NewRequest("POST", "/projects", bytes.NewReader(body))
+		req.Header.Set("Idempotency-Key", "create-test-project")
+		rr := executeRequest(req, suite)
+		// A retry without the key would fail because the name is taken
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+	}
+
+	// Errors are replayed too
+	req, _ := http.NewRequest("POST", "/projects", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-duplicate-project")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+
+	req, _ = http.NewRequest("POST", "/projects", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-duplicate-project")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, "true", rr.Header().Get("Idempotent-Replayed"))
+}
+
+func (suite *HandlerTestSuite) TestIdempotencyKeys_ScopedToUsers() {
+	t := suite.T()
+
+	otherToken := suite.createUserToken("other")
+
+	body := `{"name": "laptop"}`
+	req, _ := http.NewRequest("POST", "/me/tokens", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-token")
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	var first openapi.CreatedAPIToken
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &first))
+
+	// Another user with the same key and body gets their own token
+	req, _ = http.NewRequest("POST", "/me/tokens", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-token")
+	req.Header.Set("Authorization", otherToken)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	assert.Empty(t, rr.Header().Get("Idempotent-Replayed"))
+	var second openapi.CreatedAPIToken
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &second))
+	assert.NotEqual(t, first.Secret, second.Secret)
+
+	// Responses holding secrets are not stored: a retry creates another token
+	req, _ = http.NewRequest("POST", "/me/tokens", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", "create-token")
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	assert.Empty(t, rr.Header().Get("Idempotent-Replayed"))
+	assert.NotContains(t, rr.Body.String(), first.Secret)
+
+	var stored int
+	require.NoError(t, suite.pool.QueryRow(suite.ctx, "SELECT count(*) FROM idempotency_keys").Scan(&stored))
+	assert.Equal(t, 0, stored)
+}
+
+func (suite *HandlerTestSuite) TestPostTasksBatch() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	projectID := projectIDs[0].String()
+	checklist, item, name, completed := "checklist", "item", "Checklist", openapi.TaskStatusCompleted
+	itemName := "First item"
+	body := openapi.PostTasksBatchJSONRequestBody{Operations: []openapi.TaskBatchOperation{
+		{Op: openapi.TaskBatchOperationOpCreate, TempID: &checklist, Name: &name, ProjectID: &projectID},
+		{Op: openapi.TaskBatchOperationOpCreate, TempID: &item, Name: &itemName, ParentTaskID: &checklist},
+		{Op: openapi.TaskBatchOperationOpSetStatus, TaskID: &item, Status: &completed},
+	}}
+
+	req, _ := http.NewRequest("POST", "/tasks/batch", bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var result openapi.TaskBatchResult
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	require.Len(t, result.Results, 3)
+	assert.Equal(t, "checklist", *result.Results[0].TempID)
+	assert.Equal(t, *result.Results[0].Task.ID, *result.Results[1].Task.ParentTaskID)
+	assert.Equal(t, openapi.TaskStatusCompleted, *result.Results[2].Task.Status)
+
+	tasks, err := suite.taskService.ListTasks()
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func (suite *HandlerTestSuite) TestPostTasksBatch_FailsAtomically() {
+	t := suite.T()
+
+	projectIDs := suite.insertTestProjectsInTheDatabase()
+	projectID := projectIDs[0].String()
+	name, missingTask := "New task", uuid.NewString()
+	body := openapi.PostTasksBatchJSONRequestBody{Operations: []openapi.TaskBatchOperation{
+		{Op: openapi.TaskBatchOperationOpCreate, Name: &name, ProjectID: &projectID},
+		{Op: openapi.TaskBatchOperationOpDelete, TaskID: &missingTask},
+	}}
+
+	req, _ := http.NewRequest("POST", "/tasks/batch", bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+
+	var batchErr openapi.TaskBatchProblem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &batchErr))
+	assert.Equal(t, 1, *batchErr.Index)
+	assert.Equal(t, "delete", *batchErr.Op)
+	assert.Equal(t, "urn:todoctian:problem:not-found", batchErr.Type)
+
+	tasks, err := suite.taskService.ListTasks()
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	// References to unknown temporary IDs are rejected as malformed
+	unknown := "unknown"
+	body.Operations[1].TaskID = &unknown
+	req, _ = http.NewRequest("POST", "/tasks/batch", bodyInBytes(t, body))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestErrorsAreProblemDetails() {
+	t := suite.T()
+
+	projectName := "test project"
+	body := openapi.PostProjectsJSONRequestBody{Name: &projectName}
+	req, _ := http.NewRequest("POST", "/projects", bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	req, _ = http.NewRequest("POST", "/projects", bodyInBytes(t, body))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusConflict, rr.Code)
+	problem := decodeProblem(t, rr)
+	assert.Equal(t, "urn:todoctian:problem:already-exists", problem.Type)
+	assert.Equal(t, http.StatusConflict, problem.Status)
+	assert.Equal(t, `Project "test project" already exists`, *problem.Detail)
+
+	req, _ = http.NewRequest("GET", "/tasks/"+uuid.NewString(), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "urn:todoctian:problem:not-found", decodeProblem(t, rr).Type)
+
+	req, _ = http.NewRequest("GET", "/tasks?limit=many", nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "urn:todoctian:problem:validation", decodeProblem(t, rr).Type)
+
+	req, _ = http.NewRequest("GET", "/nowhere", nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "urn:todoctian:problem:not-found", decodeProblem(t, rr).Type)
+}
+
+func (suite *HandlerTestSuite) TestInvalidNamesAreReportedByField() {
+	t := suite.T()
+
+	projectName := strings.Repeat("a", internal.DefaultLimits.MaxNameLength+1)
+	body := openapi.PostProjectsJSONRequestBody{Name: &projectName}
+	req, _ := http.NewRequest("POST", "/projects", bodyInBytes(t, body))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	problem := decodeProblem(t, rr)
+	assert.Equal(t, "urn:todoctian:problem:validation", problem.Type)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "name", problem.Errors[0].Field)
+	}
+
+	projectName = "  test project  "
+	req, _ = http.NewRequest("POST", "/projects", bodyInBytes(t, body))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+	var project openapi.Project
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &project))
+	assert.Equal(t, "test project", project.Name)
+}
+
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) openapi.Problem {
+	assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+	var problem openapi.Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	return problem
+}
+
+func bodyInBytes(t *testing.T, body interface{}) *bytes.Buffer {
+	bodystr, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(bodystr)
+}
+
+func (suite *HandlerTestSuite) TestAuthentication() {
+	t := suite.T()
+
+	for _, authorization := range []string{"", "Basic dGVzdGVyOnRlc3Rz", "Bearer tdt_unknown"} {
+		req, _ := http.NewRequest("GET", "/projects", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+		checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Header().Get("WWW-Authenticate"), "Bearer")
+
+		var problem openapi.Problem
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		assert.Equal(t, "urn:todoctian:problem:unauthenticated", problem.Type)
+	}
+
+	req, _ := http.NewRequest("GET", "/me", nil)
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var me openapi.User
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &me))
+	assert.Equal(t, "tester", me.Name)
+}
+
+func (suite *HandlerTestSuite) TestAPITokens() {
+	t := suite.T()
+
+	req, _ := http.NewRequest("POST", "/me/tokens", strings.NewReader(`{"name": "laptop"}`))
+	rr := executeRequest(req, suite)
+	checkResponseCode(t, http.StatusCreated, rr.Code)
+
+	var created openapi.CreatedAPIToken
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "laptop", created.Name)
+	assert.True(t, strings.HasPrefix(created.Secret, user.TokenPrefix))
+
+	// The new token authenticates the same user
+	req, _ = http.NewRequest("GET", "/me/tokens", nil)
+	req.Header.Set("Authorization", "Bearer "+created.Secret)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var tokens []openapi.APIToken
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tokens))
+	require.Len(t, tokens, 2)
+	assert.Equal(t, created.ID, tokens[0].ID)
+	assert.NotNil(t, tokens[0].LastUsedAt)
+	assert.NotContains(t, rr.Body.String(), "secret")
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/me/tokens/%s", created.ID), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	var revoked openapi.APIToken
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &revoked))
+	assert.NotNil(t, revoked.RevokedAt)
+
+	req, _ = http.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+created.Secret)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/me/tokens/%s", uuid.New()), nil)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNotFound, rr.Code)
+}
+
+func (suite *HandlerTestSuite) TestJWT() {
+	t := suite.T()
+
+	requestTokens := func(body string) *httptest.ResponseRecorder {
+		// Getting tokens needs no authentication
+		req, _ := http.NewRequest("POST", "/auth/token", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := requestTokens(fmt.Sprintf(`{"grantType": "api_token", "apiToken": %q}`, suite.token))
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+
+	var tokens openapi.TokenResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tokens))
+	assert.Equal(t, openapi.TokenResponseTokenTypeBearer, tokens.TokenType)
+	assert.Equal(t, int(auth.DefaultAccessTokenTTL.Seconds()), tokens.ExpiresIn)
+
+	req, _ := http.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var me openapi.User
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &me))
+	assert.Equal(t, "tester", me.Name)
+
+	// The refresh token does not authenticate requests
+	req, _ = http.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.RefreshToken)
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+
+	// The access token was signed with the published key
+	req, _ = http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	rr = httptest.NewRecorder()
+	suite.router.ServeHTTP(rr, req)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var jwks openapi.JWKS
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	headerJSON, err := base64.RawURLEncoding.DecodeString(strings.Split(tokens.AccessToken, ".")[0])
+	require.NoError(t, err)
+	var header struct {
+		Kid string `json:"kid"`
+	}
+	require.NoError(t, json.Unmarshal(headerJSON, &header))
+	assert.Equal(t, jwks.Keys[0].Kid, header.Kid)
+
+	rr = requestTokens(fmt.Sprintf(`{"grantType": "refresh_token", "refreshToken": %q}`, tokens.RefreshToken))
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	var refreshed openapi.TokenResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &refreshed))
+
+	// Refresh tokens are exchanged once
+	rr = requestTokens(fmt.Sprintf(`{"grantType": "refresh_token", "refreshToken": %q}`, tokens.RefreshToken))
+	checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+
+	req, _ = http.NewRequest("POST", "/auth/revoke", strings.NewReader(fmt.Sprintf(`{"refreshToken": %q}`, refreshed.RefreshToken)))
+	rr = executeRequest(req, suite)
+	checkResponseCode(t, http.StatusNoContent, rr.Code)
+
+	rr = requestTokens(fmt.Sprintf(`{"grantType": "refresh_token", "refreshToken": %q}`, refreshed.RefreshToken))
+	checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+
+	rr = requestTokens(`{"grantType": "api_token", "apiToken": "tdt_unknown"}`)
+	checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+
+	rr = requestTokens(`{"grantType": "api_token"}`)
+	checkResponseCode(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandler(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func TestProblemOf(t *testing.T) {
+	tests := []struct {
+		err    error
+		uri    string
+		status int
+		detail string
+	}{
+		{internal.NewAlreadyExistsError(`Project "Groceries"`), "urn:todoctian:problem:already-exists", http.StatusConflict, `Project "Groceries" already exists`},
+		{internal.NewNotFoundError("task 1"), "urn:todoctian:problem:not-found", http.StatusNotFound, "task 1 not found"},
+		{fmt.Errorf("%w: name", task.ErrInvalidSort), "urn:todoctian:problem:validation", http.StatusBadRequest, "invalid sort field: name"},
+		{project.ErrProjectArchived, "urn:todoctian:problem:conflict", http.StatusConflict, "project is archived"},
+		{idempotency.ErrKeyReused, "urn:todoctian:problem:idempotency-key-reused", http.StatusUnprocessableEntity, idempotency.ErrKeyReused.Error()},
+		{auth.ErrInvalidToken, "urn:todoctian:problem:unauthenticated", http.StatusUnauthorized, "invalid, expired or revoked token"},
+		{user.ErrInvalidToken, "urn:todoctian:problem:unauthenticated", http.StatusUnauthorized, "invalid or revoked API token"},
+		{errors.New("connection refused"), "urn:todoctian:problem:internal", http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		problem := problemOf(tt.err)
+		assert.Equal(t, tt.uri, problem.Type)
+		assert.Equal(t, tt.status, problem.Status)
+		if tt.detail == "" {
+			// The details of internal errors are not disclosed
+			assert.Nil(t, problem.Detail)
+		} else {
+			assert.Equal(t, tt.detail, *problem.Detail)
+		}
+	}
+}
+
+func TestProblemOf_FieldErrors(t *testing.T) {
+	err := fmt.Errorf("Could not create task: %w", internal.FieldErrors{
+		{Field: "name", Message: "must not be empty"},
+		{Field: "parentTaskID", Message: "a task must not have more than 2 subtasks"},
+	})
+
+	problem := problemOf(err)
+	assert.Equal(t, "urn:todoctian:problem:validation", problem.Type)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, []openapi.FieldError{
+		{Field: "name", Message: "must not be empty"},
+		{Field: "parentTaskID", Message: "a task must not have more than 2 subtasks"},
+	}, problem.Errors)
+}
+
+func TestVersionTags(t *testing.T) {
+	assert.Equal(t, `"3"`, versionTags(`"3.10.4.2"`))
+	// Weak tags never match an If-Match header, they are left as they are
+	assert.Equal(t, `"3", W/"2.1.0.1", *`, versionTags(` "3.1.0.1" ,W/"2.1.0.1", *`))
+}
+
+func TestTaskETag(t *testing.T) {
+	assert.Equal(t, `"3"`, taskETag(task.Task{Version: 3}))
+	assert.Equal(t, `"3"`, taskETag(task.Task{Version: 3, Progress: &task.Progress{}}))
+	assert.Equal(t, `"3.2.5.1"`, taskETag(task.Task{
+		Version:  3,
+		Progress: &task.Progress{ChildCount: 2, Descendants: 5, CompletedDescendants: 1},
+	}))
+
+	estimate, remaining := 13, 8
+	assert.Equal(t, `"3.2.5.1.13.8"`, taskETag(task.Task{
+		Version: 3,
+		Progress: &task.Progress{
+			ChildCount:           2,
+			Descendants:          5,
+			CompletedDescendants: 1,
+			EffectiveEstimate:    &estimate,
+			RemainingWork:        &remaining,
+		},
+	}))
+}
+
+func TestValidateRequests(t *testing.T) {
+	specRouter, err := newSpecRouter()
+	require.NoError(t, err)
+
+	handled := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := validateRequests(specRouter)(handled)
+
+	taskID := uuid.NewString()
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"GET", "/tasks/" + taskID, "", http.StatusTeapot},
+		{"GET", "/tasks/not-a-uuid", "", http.StatusBadRequest},
+		{"GET", "/tasks?limit=1000", "", http.StatusBadRequest},
+		{"GET", "/tasks?sort=priority", "", http.StatusBadRequest},
+		{"POST", "/tasks", `{"name": "Buy milk", "projectID": "` + uuid.NewString() + `"}`, http.StatusTeapot},
+		{"POST", "/tasks", `{"name": "Buy milk", "projectID": "groceries"}`, http.StatusBadRequest},
+		{"POST", "/tasks", "", http.StatusBadRequest},
+		{"PATCH", "/tasks/" + taskID + "/status", `{"status": "done"}`, http.StatusBadRequest},
+		{"POST", "/tasks/batch", `{"operations": [{"op": "copy"}]}`, http.StatusBadRequest},
+		// Unknown routes are left to the router
+		{"GET", "/nowhere", "", http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.status, rr.Code, "%s %s", tt.method, tt.path)
+		if tt.status == http.StatusBadRequest {
+			assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	specRouter, err := newSpecRouter()
+	require.NoError(t, err)
+
+	taskOAPI, err := taskModelToTaskOAPI(task.Task{
+		ID:        uuid.New(),
+		ProjectID: uuid.New(),
+		Name:      "Buy milk",
+		Status:    task.TaskStatusPending,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	body, err := json.Marshal(taskOAPI)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/tasks/"+*taskOAPI.ID, nil)
+	header := http.Header{"Content-Type": {"application/json"}}
+	assert.NoError(t, validateResponse(specRouter, req, http.StatusOK, header, body))
+
+	// A task is not a problem
+	header.Set("Content-Type", "application/problem+json")
+	assert.Error(t, validateResponse(specRouter, req, http.StatusNotFound, header, body))
+
+	problem, err := json.Marshal(problemOf(internal.NewNotFoundError("task")))
+	require.NoError(t, err)
+	assert.NoError(t, validateResponse(specRouter, req, http.StatusNotFound, header, problem))
 }
@@ -0,0 +1,284 @@
+package todoctian
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/timetracking"
+)
+
+// Get the time entries of a task.
+// (GET /tasks/{taskID}/time-entries)
+func (s *Server) GetTasksTaskIDTimeEntries(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	entries, err := s.TimeTrackingService.ListTimeEntries(taskUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	entriesOAPI := []openapi.TimeEntry{}
+	for _, e := range entries {
+		entriesOAPI = append(entriesOAPI, timeEntryModelToTimeEntryOAPI(e, now))
+	}
+
+	return openapi.GetTasksTaskIDTimeEntriesJSON200Response(entriesOAPI)
+}
+
+// Add a time entry to a task.
+// (POST /tasks/{taskID}/time-entries)
+func (s *Server) PostTasksTaskIDTimeEntries(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	var body openapi.PostTasksTaskIDTimeEntriesJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	var endedAt time.Time
+	switch {
+	case body.EndedAt != nil && body.DurationSeconds != nil:
+		badRequest(w, "body must have either an \"endedAt\" or a \"durationSeconds\" field, not both")
+		return
+	case body.EndedAt != nil:
+		endedAt = *body.EndedAt
+	case body.DurationSeconds != nil:
+		endedAt = body.StartedAt.Add(time.Duration(*body.DurationSeconds) * time.Second)
+	default:
+		badRequest(w, "body must have an \"endedAt\" or a \"durationSeconds\" field")
+		return
+	}
+
+	var note string
+	if body.Note != nil {
+		note = *body.Note
+	}
+
+	entry, err := s.TimeTrackingService.AddTimeEntry(taskUUID, requestOrigin(r), body.StartedAt, endedAt, note)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostTasksTaskIDTimeEntriesJSON201Response(timeEntryModelToTimeEntryOAPI(entry, time.Now()))
+}
+
+// Get the time tracked on a task.
+// (GET /tasks/{taskID}/time-totals)
+func (s *Server) GetTasksTaskIDTimeTotals(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	totals, err := s.TimeTrackingService.GetTotals(taskUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetTasksTaskIDTimeTotalsJSON200Response(openapi.TimeTotals{
+		OwnSeconds:   totals.Own.Seconds(),
+		TotalSeconds: totals.Total.Seconds(),
+	})
+}
+
+// Start a timer on a task.
+// (POST /tasks/{taskID}/timer)
+func (s *Server) PostTasksTaskIDTimer(w http.ResponseWriter, r *http.Request, taskID string) (_ *openapi.Response) {
+	taskUUID, err := uuid.Parse(taskID)
+	if err != nil {
+		badRequest(w, "malformed task ID")
+		return
+	}
+
+	// The body is optional, so an empty one is fine
+	var body openapi.PostTasksTaskIDTimerJSONRequestBody
+	if r.Body != nil && r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			badRequest(w, "malformed request body")
+			return
+		}
+	}
+
+	var note string
+	if body.Note != nil {
+		note = *body.Note
+	}
+
+	entry, err := s.TimeTrackingService.StartTimer(taskUUID, requestOrigin(r), note)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostTasksTaskIDTimerJSON201Response(timeEntryModelToTimeEntryOAPI(entry, time.Now()))
+}
+
+// Delete a time entry.
+// (DELETE /time-entries/{entryID})
+func (s *Server) DeleteTimeEntriesEntryID(w http.ResponseWriter, r *http.Request, entryID string) (_ *openapi.Response) {
+	entryUUID, err := uuid.Parse(entryID)
+	if err != nil {
+		badRequest(w, "malformed time entry ID")
+		return
+	}
+
+	entry, err := s.TimeTrackingService.DeleteTimeEntry(entryUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.DeleteTimeEntriesEntryIDJSON204Response(timeEntryModelToTimeEntryOAPI(entry, time.Now()))
+}
+
+// Get the running timer of the user.
+// (GET /timer)
+func (s *Server) GetTimer(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	entry, err := s.TimeTrackingService.GetRunningTimer(requestOrigin(r))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetTimerJSON200Response(timeEntryModelToTimeEntryOAPI(entry, time.Now()))
+}
+
+// Stop the running timer of the user.
+// (POST /timer/stop)
+func (s *Server) PostTimerStop(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	entry, err := s.TimeTrackingService.StopTimer(requestOrigin(r))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostTimerStopJSON200Response(timeEntryModelToTimeEntryOAPI(entry, time.Now()))
+}
+
+// Get the timesheet of a project.
+// (GET /projects/{projectID}/timesheet)
+func (s *Server) GetProjectsProjectIDTimesheet(w http.ResponseWriter, r *http.Request, projectID string, params openapi.GetProjectsProjectIDTimesheetParams) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	timesheet, err := s.TimeTrackingService.GetTimesheet(projectUUID, time.Time(params.From), time.Time(params.To))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	if acceptsCSV(r) {
+		writeTimesheetCSV(w, timesheet)
+		return
+	}
+
+	return openapi.GetProjectsProjectIDTimesheetJSON200Response(timesheetModelToTimesheetOAPI(timesheet))
+}
+
+// acceptsCSV tells whether the client asked for CSV rather than JSON.
+func acceptsCSV(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == "text/csv" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeTimesheetCSV writes a timesheet as CSV, with one line for each task and user.
+func writeTimesheetCSV(w http.ResponseWriter, timesheet timetracking.Timesheet) {
+	filename := fmt.Sprintf("timesheet-%s-%s.csv", timesheet.From.Format(time.DateOnly), timesheet.To.Format(time.DateOnly))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent, so write errors cannot be reported to the client
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"task_id", "task_name", "actor", "seconds", "hours"})
+	for _, row := range timesheet.Rows {
+		_ = writer.Write([]string{
+			row.TaskID.String(),
+			row.TaskName,
+			row.Actor,
+			strconv.FormatInt(int64(row.Duration.Seconds()), 10),
+			strconv.FormatFloat(row.Duration.Hours(), 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+}
+
+func timeEntryModelToTimeEntryOAPI(entry timetracking.TimeEntry, now time.Time) openapi.TimeEntry {
+	var userID *string
+	if entry.UserID != nil {
+		uID := entry.UserID.String()
+		userID = &uID
+	}
+
+	return openapi.TimeEntry{
+		ID:              entry.ID.String(),
+		TaskID:          entry.TaskID.String(),
+		UserID:          userID,
+		Actor:           entry.Actor,
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		DurationSeconds: entry.Duration(now).Seconds(),
+		Running:         entry.IsRunning(),
+		Note:            entry.Note,
+	}
+}
+
+func timesheetModelToTimesheetOAPI(timesheet timetracking.Timesheet) openapi.Timesheet {
+	rows := []openapi.TimesheetRow{}
+	for _, row := range timesheet.Rows {
+		var userID *string
+		if row.UserID != nil {
+			uID := row.UserID.String()
+			userID = &uID
+		}
+
+		rows = append(rows, openapi.TimesheetRow{
+			TaskID:   row.TaskID.String(),
+			TaskName: row.TaskName,
+			UserID:   userID,
+			Actor:    row.Actor,
+			Seconds:  row.Duration.Seconds(),
+		})
+	}
+
+	return openapi.Timesheet{
+		ProjectID:    timesheet.ProjectID.String(),
+		From:         timesheet.From,
+		To:           timesheet.To,
+		Rows:         rows,
+		TotalSeconds: timesheet.Total.Seconds(),
+	}
+}
@@ -0,0 +1,106 @@
+package todoctian
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// Get the items in the trash.
+// (GET /trash)
+func (s *Server) GetTrash(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	projects, err := s.ProjectService.ListDeletedProjects()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	tasks, err := s.TaskService.ListDeletedTasks()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	items := []openapi.TrashItem{}
+	for _, p := range projects {
+		items = append(items, deletedProjectToTrashItemOAPI(p))
+	}
+	for _, t := range tasks {
+		items = append(items, deletedTaskToTrashItemOAPI(t))
+	}
+
+	// Most recently deleted first
+	slices.SortStableFunc(items, func(a, b openapi.TrashItem) int {
+		return cmp.Compare(b.DeletedAt.UnixNano(), a.DeletedAt.UnixNano())
+	})
+
+	return openapi.GetTrashJSON200Response(items)
+}
+
+// Restore an item from the trash.
+// (POST /trash/{itemID}/restore)
+func (s *Server) PostTrashItemIDRestore(w http.ResponseWriter, r *http.Request, itemID string) (_ *openapi.Response) {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		badRequest(w, "malformed item ID")
+		return
+	}
+
+	// Projects and tasks are identified by UUIDs, so the item is one or the other
+	restoredTask, err := s.tasks(r).RestoreTask(itemUUID)
+	if err == nil {
+		return openapi.PostTrashItemIDRestoreJSON200Response(deletedTaskToTrashItemOAPI(restoredTask))
+	}
+	if !errors.Is(err, internal.ErrNotFound) {
+		s.writeError(w, r, err)
+		return
+	}
+
+	restoredProject, err := s.projects(r).RestoreProject(itemUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostTrashItemIDRestoreJSON200Response(deletedProjectToTrashItemOAPI(restoredProject))
+}
+
+func deletedProjectToTrashItemOAPI(projectModel project.Project) openapi.TrashItem {
+	projectID := projectModel.ID.String()
+	itemType := openapi.TrashItemTypeProject
+
+	return openapi.TrashItem{
+		ID:        &projectID,
+		Type:      &itemType,
+		Name:      &projectModel.Name,
+		DeletedAt: projectModel.DeletedAt,
+	}
+}
+
+func deletedTaskToTrashItemOAPI(taskModel task.Task) openapi.TrashItem {
+	taskID := taskModel.ID.String()
+	projectID := taskModel.ProjectID.String()
+	itemType := openapi.TrashItemTypeTask
+
+	var parentTaskID *string
+	if taskModel.ParentTaskID != nil {
+		pTaskID := taskModel.ParentTaskID.String()
+		parentTaskID = &pTaskID
+	}
+
+	return openapi.TrashItem{
+		ID:           &taskID,
+		Type:         &itemType,
+		Name:         &taskModel.Name,
+		ProjectID:    &projectID,
+		ParentTaskID: parentTaskID,
+		DeletedAt:    taskModel.DeletedAt,
+	}
+}
@@ -0,0 +1,84 @@
+package todoctian
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// Request header identifying the client session whose operations can be undone.
+const sessionHeader = "X-Session-Id"
+
+// Undo the last operation of a client session.
+// (POST /undo)
+func (s *Server) PostUndo(w http.ResponseWriter, r *http.Request, params openapi.PostUndoParams) (_ *openapi.Response) {
+	step, err := s.tasks(r).Undo()
+	if err != nil {
+		s.undoError(w, r, "undo", err)
+		return
+	}
+
+	stepOAPI, err := undoStepModelToUndoStepOAPI(step)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostUndoJSON200Response(stepOAPI)
+}
+
+// Redo the last undone operation of a client session.
+// (POST /redo)
+func (s *Server) PostRedo(w http.ResponseWriter, r *http.Request, params openapi.PostRedoParams) (_ *openapi.Response) {
+	step, err := s.tasks(r).Redo()
+	if err != nil {
+		s.undoError(w, r, "redo", err)
+		return
+	}
+
+	stepOAPI, err := undoStepModelToUndoStepOAPI(step)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostRedoJSON200Response(stepOAPI)
+}
+
+// undoError reports why an operation could not be undone or redone. The tasks may have changed
+// since the operation was made in a way that prevents reverting it, e.g. a task was deleted, which
+// is a conflict rather than a missing resource.
+func (s *Server) undoError(w http.ResponseWriter, r *http.Request, operation string, err error) {
+	switch {
+	case errors.Is(err, task.ErrMissingSession):
+		badRequest(w, fmt.Sprintf("the %s header is required", sessionHeader))
+
+	case errors.Is(err, internal.ErrNotFound), errors.Is(err, internal.ErrConflict):
+		writeProblem(w, problemConflict, fmt.Sprintf("could not %s: %s", operation, err))
+
+	default:
+		s.writeError(w, r, err)
+	}
+}
+
+func undoStepModelToUndoStepOAPI(step task.UndoStep) (openapi.UndoStep, error) {
+	tasksOAPI := []openapi.Task{}
+	for _, taskModel := range step.Tasks {
+		taskOAPI, err := taskModelToTaskOAPI(taskModel)
+		if err != nil {
+			return openapi.UndoStep{}, err
+		}
+
+		tasksOAPI = append(tasksOAPI, taskOAPI)
+	}
+
+	action := string(step.Action)
+	return openapi.UndoStep{
+		Action: &action,
+		Tasks:  tasksOAPI,
+	}, nil
+}
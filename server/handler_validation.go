@@ -0,0 +1,140 @@
+package todoctian
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+)
+
+func init() {
+	// The spec uses the uuid format for IDs, which is not validated unless it is defined
+	openapi3.DefineStringFormatCallback("uuid", func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	})
+
+	// Otherwise, the errors include the whole schema and value, which is too much for a response
+	openapi3.SchemaErrorDetailsDisabled = true
+}
+
+var validationOptions = &openapi3filter.Options{
+	AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	IncludeResponseStatus: true,
+}
+
+// newSpecRouter finds the operations of the OpenAPI spec that requests are made to.
+func newSpecRouter() (routers.Router, error) {
+	spec, err := openapi.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+
+	// The servers of the spec are where the API is deployed, routes are matched on the path only
+	spec.Servers = nil
+	return legacy.NewRouter(spec)
+}
+
+// validateRequests rejects the requests that do not conform to the OpenAPI spec, e.g. a malformed
+// ID or a missing field, so that the handlers only get valid requests. Requests to unknown routes
+// are passed on, to be rejected by the router.
+func validateRequests(router routers.Router) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Clients may leave out the content type, the bodies of requests are always JSON
+			if r.ContentLength != 0 && r.Header.Get("Content-Type") == "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    validationOptions,
+			})
+			if err != nil {
+				badRequest(w, requestValidationDetail(err))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestValidationDetail tells what is wrong with a request, without the internals of the
+// validation.
+func requestValidationDetail(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	var schemaErr *openapi3.SchemaError
+	switch {
+	case requestErr.Parameter != nil && errors.As(requestErr.Err, &schemaErr):
+		return fmt.Sprintf("parameter %q in %s: %s", requestErr.Parameter.Name, requestErr.Parameter.In, schemaErr.Reason)
+	case requestErr.RequestBody != nil && errors.As(requestErr.Err, &schemaErr):
+		return fmt.Sprintf("request body: %s", schemaErr)
+	}
+
+	return requestErr.Error()
+}
+
+// validateResponses logs the responses that do not conform to the OpenAPI spec. It is meant for
+// development, to find where the handlers and the spec drifted apart.
+func (s *Server) validateResponses(router routers.Router) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			err := validateResponse(router, r, recorder.statusCode, recorder.Header(), recorder.body.Bytes())
+			if err != nil {
+				s.logger.Warn("the response does not conform to the OpenAPI spec",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("status", recorder.statusCode),
+					slog.Any("err", err),
+				)
+			}
+		})
+	}
+}
+
+// validateResponse checks a response against the operation of the spec the request was made to.
+// Responses to unknown routes are not checked.
+func validateResponse(router routers.Router, r *http.Request, status int, header http.Header, body []byte) error {
+	route, pathParams, err := router.FindRoute(r)
+	if err != nil {
+		return nil
+	}
+
+	return openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    validationOptions,
+		},
+		Status:  status,
+		Header:  header,
+		Body:    io.NopCloser(bytes.NewReader(body)),
+		Options: validationOptions,
+	})
+}
@@ -0,0 +1,246 @@
+package todoctian
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal/openapi"
+	"github.com/murasakiwano/todoctian/server/workspace"
+)
+
+// Get all workspaces.
+// (GET /workspaces)
+func (s *Server) GetWorkspaces(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	workspaces, err := s.WorkspaceService.ListWorkspaces()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	workspacesOAPI := []openapi.Workspace{}
+	for _, ws := range workspaces {
+		workspacesOAPI = append(workspacesOAPI, workspaceModelToWorkspaceOAPI(ws))
+	}
+
+	return openapi.GetWorkspacesJSON200Response(workspacesOAPI)
+}
+
+// Create a workspace.
+// (POST /workspaces)
+func (s *Server) PostWorkspaces(w http.ResponseWriter, r *http.Request) (_ *openapi.Response) {
+	var body openapi.PostWorkspacesJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	ws, err := s.WorkspaceService.CreateWorkspace(body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PostWorkspacesJSON201Response(workspaceModelToWorkspaceOAPI(ws))
+}
+
+// Get a single workspace.
+// (GET /workspaces/{workspaceID})
+func (s *Server) GetWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request, workspaceID string) (_ *openapi.Response) {
+	workspaceUUID, err := uuid.Parse(workspaceID)
+	if err != nil {
+		badRequest(w, "malformed workspace ID")
+		return
+	}
+
+	ws, err := s.WorkspaceService.GetWorkspace(workspaceUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetWorkspacesWorkspaceIDJSON200Response(workspaceModelToWorkspaceOAPI(ws))
+}
+
+// Rename a workspace.
+// (PATCH /workspaces/{workspaceID})
+func (s *Server) PatchWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request, workspaceID string) (_ *openapi.Response) {
+	workspaceUUID, err := uuid.Parse(workspaceID)
+	if err != nil {
+		badRequest(w, "malformed workspace ID")
+		return
+	}
+
+	var body openapi.PatchWorkspacesWorkspaceIDJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	ws, err := s.WorkspaceService.RenameWorkspace(workspaceUUID, body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.PatchWorkspacesWorkspaceIDJSON200Response(workspaceModelToWorkspaceOAPI(ws))
+}
+
+// Delete a workspace.
+// (DELETE /workspaces/{workspaceID})
+func (s *Server) DeleteWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request, workspaceID string) (_ *openapi.Response) {
+	workspaceUUID, err := uuid.Parse(workspaceID)
+	if err != nil {
+		badRequest(w, "malformed workspace ID")
+		return
+	}
+
+	ws, err := s.WorkspaceService.DeleteWorkspace(workspaceUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.DeleteWorkspacesWorkspaceIDJSON204Response(workspaceModelToWorkspaceOAPI(ws))
+}
+
+// Get the projects of a workspace.
+// (GET /workspaces/{workspaceID}/projects)
+func (s *Server) GetWorkspacesWorkspaceIDProjects(w http.ResponseWriter, r *http.Request, workspaceID string, params openapi.GetWorkspacesWorkspaceIDProjectsParams) (_ *openapi.Response) {
+	workspaceUUID, err := uuid.Parse(workspaceID)
+	if err != nil {
+		badRequest(w, "malformed workspace ID")
+		return
+	}
+
+	opts, err := projectListOptions(params.Archived, (*string)(params.Sort), params.Cursor, params.Limit)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	opts.WorkspaceID = &workspaceUUID
+
+	// An unknown workspace is not found, rather than empty
+	_, err = s.WorkspaceService.GetWorkspace(workspaceUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	projects, ok := s.listProjectsPage(w, r, opts)
+	if !ok {
+		return
+	}
+
+	return openapi.GetWorkspacesWorkspaceIDProjectsJSON200Response(projects)
+}
+
+// Get the statistics of a workspace.
+// (GET /workspaces/{workspaceID}/stats)
+func (s *Server) GetWorkspacesWorkspaceIDStats(w http.ResponseWriter, r *http.Request, workspaceID string) (_ *openapi.Response) {
+	workspaceUUID, err := uuid.Parse(workspaceID)
+	if err != nil {
+		badRequest(w, "malformed workspace ID")
+		return
+	}
+
+	stats, err := s.WorkspaceService.GetWorkspaceStats(workspaceUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	return openapi.GetWorkspacesWorkspaceIDStatsJSON200Response(openapi.WorkspaceStats{
+		Projects:         stats.Projects,
+		ArchivedProjects: stats.ArchivedProjects,
+		Tasks:            *taskSummaryModelToTaskSummaryOAPI(&stats.Tasks),
+	})
+}
+
+// Move a project to a workspace.
+// (PUT /projects/{projectID}/workspace)
+func (s *Server) PutProjectsProjectIDWorkspace(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	var body openapi.PutProjectsProjectIDWorkspaceJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	workspaceUUID, ok := parseOptionalUUID(w, body.WorkspaceID, "malformed workspace ID")
+	if !ok {
+		return
+	}
+
+	project, err := s.projects(r).MoveProjectToWorkspace(projectUUID, workspaceUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	setVersionETag(w, project.Version)
+	return openapi.PutProjectsProjectIDWorkspaceJSON200Response(projectModelToProjectOAPI(project))
+}
+
+// Make a project a sub-project of another one.
+// (PUT /projects/{projectID}/parent)
+func (s *Server) PutProjectsProjectIDParent(w http.ResponseWriter, r *http.Request, projectID string) (_ *openapi.Response) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		badRequest(w, "malformed project ID")
+		return
+	}
+
+	var body openapi.PutProjectsProjectIDParentJSONRequestBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, "malformed request body")
+		return
+	}
+
+	parentUUID, ok := parseOptionalUUID(w, body.ParentProjectID, "malformed parent project ID")
+	if !ok {
+		return
+	}
+
+	project, err := s.projects(r).SetParentProject(projectUUID, parentUUID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	setVersionETag(w, project.Version)
+	return openapi.PutProjectsProjectIDParentJSON200Response(projectModelToProjectOAPI(project))
+}
+
+// parseOptionalUUID parses an ID that may be left out. Writes the error response with the given
+// message and returns false if it is malformed.
+func parseOptionalUUID(w http.ResponseWriter, id *string, malformedMessage string) (*uuid.UUID, bool) {
+	if id == nil {
+		return nil, true
+	}
+
+	parsed, err := uuid.Parse(*id)
+	if err != nil {
+		badRequest(w, malformedMessage)
+		return nil, false
+	}
+
+	return &parsed, true
+}
+
+func workspaceModelToWorkspaceOAPI(ws workspace.Workspace) openapi.Workspace {
+	return openapi.Workspace{
+		ID:        ws.ID.String(),
+		Name:      ws.Name,
+		CreatedAt: ws.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package idempotency
+
+import (
+	"encoding/json"
+
+	"github.com/murasakiwano/todoctian/server/db"
+)
+
+func IdempotencyKeyDBToKeyModel(keyDB db.IdempotencyKey) (Key, error) {
+	key := Key{
+		Key:         keyDB.Key,
+		Fingerprint: keyDB.Fingerprint,
+		CreatedAt:   keyDB.CreatedAt.Time,
+		ExpiresAt:   keyDB.ExpiresAt.Time,
+		LockedUntil: keyDB.LockedUntil.Time,
+	}
+
+	if keyDB.StatusCode.Valid {
+		response := Response{
+			StatusCode: int(keyDB.StatusCode.Int32),
+			Body:       keyDB.Body,
+		}
+		if keyDB.Headers != nil {
+			err := json.Unmarshal(keyDB.Headers, &response.Header)
+			if err != nil {
+				return Key{}, err
+			}
+		}
+
+		key.Response = &response
+	}
+
+	return key, nil
+}
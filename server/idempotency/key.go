@@ -0,0 +1,26 @@
+package idempotency
+
+import "time"
+
+// A Key is an idempotency key sent by a client, along with the request it was first used for and
+// the response to that request.
+type Key struct {
+	CreatedAt time.Time
+	// When the key can be used for another request
+	ExpiresAt time.Time
+	// When the claim of a request in progress goes stale, after which a retry can take the key over
+	LockedUntil time.Time
+	// The key, as sent by the client
+	Key string
+	// Identifies the request the key was first used for, see Fingerprint
+	Fingerprint string
+	// The response to the request, nil while the request is in progress
+	Response *Response
+}
+
+// A Response is what gets replayed to clients retrying a request.
+type Response struct {
+	Header     map[string]string
+	Body       []byte
+	StatusCode int
+}
@@ -0,0 +1,21 @@
+package idempotency
+
+import "time"
+
+type KeyRepository interface {
+	// Store a key that is not in use, or that expired, for a new request, or take it over from a
+	// stale claim of the same request. Fails with internal.ErrAlreadyExists if the key is in use
+	Claim(key Key) (Key, error)
+
+	// Retrieve a key
+	Get(key string) (Key, error)
+
+	// Store the response to the request a key was claimed for
+	Complete(key string, response Response) error
+
+	// Delete a key, so that it can be claimed again
+	Delete(key string) error
+
+	// Delete the keys that expired before the given time
+	PurgeExpired(expiredBefore time.Time) (int64, error)
+}
@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+type KeyRepositoryPostgres struct {
+	Queries *db.Queries
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewKeyRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *KeyRepositoryPostgres {
+	return &KeyRepositoryPostgres{
+		Queries: db.New(pool),
+		ctx:     ctx,
+		logger:  *internal.NewLogger("KeyRepositoryPostgres"),
+	}
+}
+
+func (r *KeyRepositoryPostgres) Claim(key Key) (Key, error) {
+	pgCreatedAt := pgtype.Timestamptz{}
+	err := pgCreatedAt.Scan(key.CreatedAt)
+	if err != nil {
+		return Key{}, err
+	}
+
+	pgExpiresAt := pgtype.Timestamptz{}
+	err = pgExpiresAt.Scan(key.ExpiresAt)
+	if err != nil {
+		return Key{}, err
+	}
+
+	pgLockedUntil := pgtype.Timestamptz{}
+	err = pgLockedUntil.Scan(key.LockedUntil)
+	if err != nil {
+		return Key{}, err
+	}
+
+	keyDB, err := r.Queries.ClaimIdempotencyKey(r.ctx, db.ClaimIdempotencyKeyParams{
+		Key:         key.Key,
+		Fingerprint: key.Fingerprint,
+		CreatedAt:   pgCreatedAt,
+		ExpiresAt:   pgExpiresAt,
+		LockedUntil: pgLockedUntil,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Key{}, internal.NewAlreadyExistsError(fmt.Sprintf("idempotency key %q", key.Key))
+		}
+
+		r.logger.Error("failed to claim idempotency key", slog.String("err", err.Error()))
+		return Key{}, err
+	}
+
+	return IdempotencyKeyDBToKeyModel(keyDB)
+}
+
+func (r *KeyRepositoryPostgres) Get(key string) (Key, error) {
+	keyDB, err := r.Queries.GetIdempotencyKey(r.ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Key{}, internal.NewNotFoundError(fmt.Sprintf("idempotency key %q", key))
+		}
+
+		return Key{}, err
+	}
+
+	return IdempotencyKeyDBToKeyModel(keyDB)
+}
+
+func (r *KeyRepositoryPostgres) Complete(key string, response Response) error {
+	headers, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+
+	return r.Queries.CompleteIdempotencyKey(r.ctx, db.CompleteIdempotencyKeyParams{
+		Key:        key,
+		StatusCode: int32(response.StatusCode),
+		Headers:    headers,
+		Body:       response.Body,
+	})
+}
+
+func (r *KeyRepositoryPostgres) Delete(key string) error {
+	return r.Queries.DeleteIdempotencyKey(r.ctx, key)
+}
+
+func (r *KeyRepositoryPostgres) PurgeExpired(expiredBefore time.Time) (int64, error) {
+	pgExpiredBefore := pgtype.Timestamptz{}
+	err := pgExpiredBefore.Scan(expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Queries.PurgeExpiredIdempotencyKeys(r.ctx, pgExpiredBefore)
+}
@@ -0,0 +1,114 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// DefaultTTL is how long keys are kept when no TTL is configured.
+const DefaultTTL = 24 * time.Hour
+
+// ClaimTimeout is how long a request holds its key. It is far longer than any request takes, so a
+// request that still holds its key after that was lost, e.g. because the server stopped while
+// handling it, and a retry can take the key over.
+const ClaimTimeout = 5 * time.Minute
+
+var (
+	ErrKeyReused         = internal.NewConflictError("the idempotency key was already used for a different request")
+	ErrRequestInProgress = internal.NewConflictError("a request with the same idempotency key is in progress")
+)
+
+// KeyService makes requests idempotent: a request retried with the same key gets the response to
+// the original request, instead of being handled again.
+type KeyService struct {
+	repository KeyRepository
+	logger     slog.Logger
+	// How long a key is kept after the request it was first used for
+	ttl time.Duration
+	// How long a request holds its key, see ClaimTimeout
+	claimTimeout time.Duration
+}
+
+func NewKeyService(repository KeyRepository, ttl time.Duration) *KeyService {
+	return &KeyService{
+		repository:   repository,
+		logger:       *internal.NewLogger("KeyService"),
+		ttl:          ttl,
+		claimTimeout: ClaimTimeout,
+	}
+}
+
+// Fingerprint identifies a request, so that a key reused for a different request can be told
+// apart from a retry. The scope is who the request is made by, e.g. the ID of a user: the same
+// request made by someone else is a different request.
+func Fingerprint(scope, method, path string, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s %s\n", scope, method, path)
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Begin claims a key for the request with the given fingerprint. If the request was already
+// handled, the response to replay is returned. Otherwise, the response is nil and the request
+// must be handled, after which either Complete or Release must be called.
+//
+// Begin fails with ErrKeyReused if the key was used for a different request, and with
+// ErrRequestInProgress if the original request is still being handled. A request that did not
+// complete within ClaimTimeout is taken for lost, so a retry takes its key over.
+func (s *KeyService) Begin(key, fingerprint string) (*Response, error) {
+	now := time.Now().UTC()
+	_, err := s.repository.Claim(Key{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+		LockedUntil: now.Add(s.claimTimeout),
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, internal.ErrAlreadyExists) {
+		return nil, fmt.Errorf("Failed to claim idempotency key: %w", err)
+	}
+
+	stored, err := s.repository.Get(key)
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			// The original request failed and released the key in the meantime
+			return nil, ErrRequestInProgress
+		}
+
+		return nil, err
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, ErrKeyReused
+	}
+	if stored.Response == nil {
+		return nil, ErrRequestInProgress
+	}
+
+	return stored.Response, nil
+}
+
+// Complete stores the response to the request a key was claimed for, to be replayed to retries.
+func (s *KeyService) Complete(key string, response Response) error {
+	return s.repository.Complete(key, response)
+}
+
+// Release frees a key whose request could not be handled, so that it can be retried.
+func (s *KeyService) Release(key string) error {
+	return s.repository.Delete(key)
+}
+
+// PurgeExpiredKeys deletes the keys that expired. Returns the number of purged keys.
+func (s *KeyService) PurgeExpiredKeys() (int64, error) {
+	return s.repository.PurgeExpired(time.Now().UTC())
+}
@@ -0,0 +1,149 @@
+package idempotency
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	repository  *KeyRepositoryPostgres
+	service     *KeyService
+}
+
+func (suite *KeyServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.repository = NewKeyRepositoryPostgres(suite.ctx, pgPool)
+	suite.service = NewKeyService(suite.repository, DefaultTTL)
+}
+
+func (suite *KeyServiceTestSuite) SetupTest() {
+	testhelpers.CleanupIdempotencyKeysTable(suite.ctx, suite.T(), suite.pgContainer.ConnectionString)
+}
+
+func (suite *KeyServiceTestSuite) TestRetryReplaysResponse() {
+	t := suite.T()
+	fingerprint := Fingerprint("alice", http.MethodPost, "/tasks", []byte(`{"name":"Test task"}`))
+
+	response, err := suite.service.Begin("key", fingerprint)
+	require.NoError(t, err)
+	assert.Nil(t, response)
+
+	// The retry comes while the original request is being handled
+	_, err = suite.service.Begin("key", fingerprint)
+	assert.ErrorIs(t, err, ErrRequestInProgress)
+
+	original := Response{
+		StatusCode: http.StatusCreated,
+		Header:     map[string]string{"Content-Type": "application/json"},
+		Body:       []byte(`{"id":"1"}`),
+	}
+	require.NoError(t, suite.service.Complete("key", original))
+
+	response, err = suite.service.Begin("key", fingerprint)
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, original, *response)
+}
+
+func (suite *KeyServiceTestSuite) TestKeyReusedForDifferentRequest() {
+	t := suite.T()
+
+	_, err := suite.service.Begin("key", Fingerprint("alice", http.MethodPost, "/tasks", []byte(`{"name":"Test task"}`)))
+	require.NoError(t, err)
+
+	_, err = suite.service.Begin("key", Fingerprint("alice", http.MethodPost, "/tasks", []byte(`{"name":"Other task"}`)))
+	assert.ErrorIs(t, err, ErrKeyReused)
+
+	_, err = suite.service.Begin("key", Fingerprint("alice", http.MethodPost, "/projects", []byte(`{"name":"Test task"}`)))
+	assert.ErrorIs(t, err, ErrKeyReused)
+}
+
+func (suite *KeyServiceTestSuite) TestReleasedKeyCanBeRetried() {
+	t := suite.T()
+	fingerprint := Fingerprint("alice", http.MethodPost, "/tasks", nil)
+
+	_, err := suite.service.Begin("key", fingerprint)
+	require.NoError(t, err)
+	require.NoError(t, suite.service.Release("key"))
+
+	response, err := suite.service.Begin("key", fingerprint)
+	require.NoError(t, err)
+	assert.Nil(t, response)
+}
+
+func (suite *KeyServiceTestSuite) TestExpiredKeyCanBeReused() {
+	t := suite.T()
+	expiringService := NewKeyService(suite.repository, -time.Minute)
+
+	_, err := expiringService.Begin("key", Fingerprint("alice", http.MethodPost, "/tasks", []byte("first")))
+	require.NoError(t, err)
+	require.NoError(t, expiringService.Complete("key", Response{StatusCode: http.StatusCreated}))
+
+	response, err := suite.service.Begin("key", Fingerprint("alice", http.MethodPost, "/tasks", []byte("second")))
+	require.NoError(t, err)
+	assert.Nil(t, response)
+
+	purged, err := expiringService.PurgeExpiredKeys()
+	require.NoError(t, err)
+	assert.Zero(t, purged, "the key was taken over by the new request")
+}
+
+func (suite *KeyServiceTestSuite) TestStaleClaimCanBeTakenOver() {
+	t := suite.T()
+	fingerprint := Fingerprint("alice", http.MethodPost, "/tasks", nil)
+	stalingService := NewKeyService(suite.repository, DefaultTTL)
+	stalingService.claimTimeout = -time.Minute
+
+	// The original request never completes, e.g. because the server stopped while handling it
+	_, err := stalingService.Begin("key", fingerprint)
+	require.NoError(t, err)
+
+	// A different request still cannot reuse the key
+	_, err = suite.service.Begin("key", Fingerprint("alice", http.MethodPost, "/tasks", []byte("other")))
+	assert.ErrorIs(t, err, ErrKeyReused)
+
+	response, err := suite.service.Begin("key", fingerprint)
+	require.NoError(t, err)
+	assert.Nil(t, response)
+
+	// The retry now holds the key
+	_, err = suite.service.Begin("key", fingerprint)
+	assert.ErrorIs(t, err, ErrRequestInProgress)
+}
+
+func TestKeyService(t *testing.T) {
+	suite.Run(t, new(KeyServiceTestSuite))
+}
+
+func TestFingerprint(t *testing.T) {
+	body := []byte(`{"name":"Test task"}`)
+	fingerprint := Fingerprint("alice", http.MethodPost, "/tasks", body)
+
+	assert.Equal(t, fingerprint, Fingerprint("alice", http.MethodPost, "/tasks", body))
+	assert.NotEqual(t, fingerprint, Fingerprint("bob", http.MethodPost, "/tasks", body))
+	assert.NotEqual(t, fingerprint, Fingerprint("alice", http.MethodPost, "/projects", body))
+	assert.NotEqual(t, fingerprint, Fingerprint("alice", http.MethodPost, "/tasks", nil))
+}
@@ -5,9 +5,21 @@ import (
 	"fmt"
 )
 
+// The kinds of errors the services return. Every error a client can do something about wraps one
+// of them, which tells how it is reported by the API.
 var (
 	ErrNotFound      = errors.New("Resource not found.")
 	ErrAlreadyExists = errors.New("Resource already exists.")
+	// The input of the operation is invalid
+	ErrValidation = errors.New("Invalid input.")
+	// The operation cannot be made in the current state of the resources
+	ErrConflict = errors.New("Conflicting state.")
+	// The resource was changed since the client fetched it
+	ErrPreconditionFailed = errors.New("Precondition failed.")
+	// The operation requires the client to state which version of the resource it changes
+	ErrPreconditionRequired = errors.New("Precondition required.")
+	// The client did not authenticate, or its credentials are invalid
+	ErrUnauthenticated = errors.New("Unauthenticated.")
 )
 
 type RepositoryError struct {
@@ -16,6 +28,10 @@ type RepositoryError struct {
 }
 
 func (e RepositoryError) Error() string {
+	if errors.Is(e.Err, ErrAlreadyExists) {
+		return fmt.Sprintf("%s already exists", e.Resource)
+	}
+
 	return fmt.Sprintf("%s not found", e.Resource)
 }
 
@@ -31,7 +47,30 @@ func NewAlreadyExistsError(resource string) RepositoryError {
 	return RepositoryError{Resource: resource, Err: ErrAlreadyExists}
 }
 
-type AlreadyExistsError struct {
-	Err      error
-	Resource string
+// DomainError is an error of one of the kinds above, with a message meant for the client.
+type DomainError struct {
+	Kind    error
+	Message string
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Kind
+}
+
+// NewError returns an error of the given kind. Services declare their sentinel errors with it, so
+// that errors.Is matches both the sentinel and its kind.
+func NewError(kind error, message string) error {
+	return &DomainError{Kind: kind, Message: message}
+}
+
+func NewValidationError(message string) error {
+	return NewError(ErrValidation, message)
+}
+
+func NewConflictError(message string) error {
+	return NewError(ErrConflict, message)
 }
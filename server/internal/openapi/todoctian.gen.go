@@ -6,6 +6,7 @@ package openapi
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -22,6 +23,74 @@ import (
 	"github.com/go-chi/render"
 )
 
+const (
+	APITokenScopes = "apiToken.Scopes"
+)
+
+// Defines values for ActivityEventAction.
+var (
+	UnknownActivityEventAction = ActivityEventAction{}
+
+	ActivityEventActionArchived = ActivityEventAction{"archived"}
+
+	ActivityEventActionCreated = ActivityEventAction{"created"}
+
+	ActivityEventActionDeleted = ActivityEventAction{"deleted"}
+
+	ActivityEventActionDueAtChanged = ActivityEventAction{"due_at_changed"}
+
+	ActivityEventActionEstimated = ActivityEventAction{"estimated"}
+
+	ActivityEventActionMoved = ActivityEventAction{"moved"}
+
+	ActivityEventActionPlanned = ActivityEventAction{"planned"}
+
+	ActivityEventActionRenamed = ActivityEventAction{"renamed"}
+
+	ActivityEventActionReordered = ActivityEventAction{"reordered"}
+
+	ActivityEventActionRestored = ActivityEventAction{"restored"}
+
+	ActivityEventActionStatusChanged = ActivityEventAction{"status_changed"}
+
+	ActivityEventActionUnarchived = ActivityEventAction{"unarchived"}
+
+	ActivityEventActionUpdated = ActivityEventAction{"updated"}
+)
+
+// Defines values for EstimateUnit.
+var (
+	UnknownEstimateUnit = EstimateUnit{}
+
+	EstimateUnitMinutes = EstimateUnit{"minutes"}
+
+	EstimateUnitPoints = EstimateUnit{"points"}
+)
+
+// Defines values for ProjectStatsBucket.
+var (
+	UnknownProjectStatsBucket = ProjectStatsBucket{}
+
+	ProjectStatsBucketDay = ProjectStatsBucket{"day"}
+
+	ProjectStatsBucketWeek = ProjectStatsBucket{"week"}
+)
+
+// Defines values for TaskBatchOperationOp.
+var (
+	UnknownTaskBatchOperationOp = TaskBatchOperationOp{}
+
+	TaskBatchOperationOpCreate = TaskBatchOperationOp{"create"}
+
+	TaskBatchOperationOpDelete = TaskBatchOperationOp{"delete"}
+
+	TaskBatchOperationOpMove = TaskBatchOperationOp{"move"}
+
+	TaskBatchOperationOpRename = TaskBatchOperationOp{"rename"}
+
+	TaskBatchOperationOpSetStatus = TaskBatchOperationOp{"set_status"}
+)
+
 // Defines values for TaskStatus.
 var (
 	UnknownTaskStatus = TaskStatus{}
@@ -31,334 +100,4952 @@ var (
 	TaskStatusPending = TaskStatus{"pending"}
 )
 
+// Defines values for TokenRequestGrantType.
+var (
+	UnknownTokenRequestGrantType = TokenRequestGrantType{}
+
+	TokenRequestGrantTypeAPIToken = TokenRequestGrantType{"api_token"}
+
+	TokenRequestGrantTypeRefreshToken = TokenRequestGrantType{"refresh_token"}
+)
+
+// Defines values for TokenResponseTokenType.
+var (
+	UnknownTokenResponseTokenType = TokenResponseTokenType{}
+
+	TokenResponseTokenTypeBearer = TokenResponseTokenType{"Bearer"}
+)
+
+// Defines values for TrashItemType.
+var (
+	UnknownTrashItemType = TrashItemType{}
+
+	TrashItemTypeProject = TrashItemType{"project"}
+
+	TrashItemTypeTask = TrashItemType{"task"}
+)
+
+// Defines values for TaskSort.
+var (
+	UnknownTaskSort = TaskSort{}
+
+	CreatedAt = TaskSort{"createdAt"}
+
+	CreatedAt1 = TaskSort{"-createdAt"}
+
+	Name = TaskSort{"name"}
+
+	Name1 = TaskSort{"-name"}
+
+	Order = TaskSort{"order"}
+
+	Order1 = TaskSort{"-order"}
+
+	Status = TaskSort{"status"}
+
+	Status1 = TaskSort{"-status"}
+)
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+
+	// When the token was last used to authenticate, null if it never was.
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+
+	// Tells the tokens of a user apart, e.g. the device it is used on.
+	Name string `json:"name"`
+
+	// When the token was revoked, null if it still authenticates its user.
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+// ActivityEvent defines model for ActivityEvent.
+type ActivityEvent struct {
+	// The kind of change.
+	Action *ActivityEventAction `json:"action,omitempty"`
+
+	// The name of the authenticated user who made the change, or `anonymous` for changes made before users existed.
+	Actor *string `json:"actor,omitempty"`
+
+	// Values of the changed fields after the change, null for deleted items.
+	After *map[string]interface{} `json:"after"`
+
+	// Values of the changed fields before the change, null for created items.
+	Before *map[string]interface{} `json:"before"`
+
+	// When the change was made.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Events are numbered in the order they were recorded.
+	ID *int64 `json:"id,omitempty"`
+
+	// The project that was changed, or the project of the task that was changed.
+	ProjectID *string `json:"projectID,omitempty"`
+
+	// ID of the request that made the change, as given by the `X-Request-Id` request header or generated by the server. Cascaded changes share the ID of their request.
+	RequestID *string `json:"requestID,omitempty"`
+
+	// The task that was changed, null for changes of the project itself.
+	TaskID *string `json:"taskID"`
+
+	// The authenticated user who made the change, null for changes made before users existed.
+	UserID *string `json:"userID"`
+}
+
+// ActivityPage defines model for ActivityPage.
+type ActivityPage struct {
+	Events []ActivityEvent `json:"events,omitempty"`
+
+	// Cursor of the next (older) page, null on the last page.
+	NextCursor *string `json:"nextCursor"`
+}
+
+// CreatedAPIToken defines model for CreatedAPIToken.
+type CreatedAPIToken struct {
+	// Embedded struct due to allOf(#/components/schemas/APIToken)
+	APIToken `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	// The secret to send in the `Authorization: Bearer` header. It is only given here.
+	Secret string `json:"secret"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Name of the invalid field, as in the request body.
+	Field string `json:"field"`
+
+	// What is wrong with the field.
+	Message string `json:"message"`
+}
+
+// Iteration defines model for Iteration.
+type Iteration struct {
+	Closed bool `json:"closed"`
+
+	// When the iteration was closed, if it is closed.
+	ClosedAt  *time.Time `json:"closedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+
+	// When the iteration ends, exclusive.
+	EndsAt   time.Time `json:"endsAt"`
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"startsAt"`
+
+	// What was completed in an iteration, measured when it was closed. Estimates are summed per unit, since the projects of the tasks may estimate them differently.
+	Velocity *Velocity `json:"velocity,omitempty"`
+}
+
+// IterationUpdate defines model for IterationUpdate.
+type IterationUpdate struct {
+	EndsAt   *time.Time `json:"endsAt,omitempty"`
+	Name     *string    `json:"name,omitempty"`
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+}
+
+// A P-256 public key, with which ES256 tokens are verified.
+type JWK struct {
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKS defines model for JWKS.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewAPIToken defines model for NewAPIToken.
+type NewAPIToken struct {
+	Name string `json:"name"`
+}
+
+// NewIteration defines model for NewIteration.
+type NewIteration struct {
+	EndsAt   time.Time `json:"endsAt"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"startsAt"`
+}
+
+// NewSection defines model for NewSection.
+type NewSection struct {
+	// Unique within the project.
+	Name string `json:"name"`
+}
+
+// NewTimeEntry defines model for NewTimeEntry.
+type NewTimeEntry struct {
+	// How long the work took, instead of `endedAt`.
+	DurationSeconds *int `json:"durationSeconds,omitempty"`
+
+	// When the work ended. Either `endedAt` or `durationSeconds` is required.
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+	StartedAt time.Time  `json:"startedAt"`
+}
+
+// NewWorkspace defines model for NewWorkspace.
+type NewWorkspace struct {
+	// Unique among the workspaces.
+	Name string `json:"name"`
+}
+
+// An error, as described by RFC 9457 (Problem Details for HTTP APIs). Every error response has one, with the `application/problem+json` content type.
+type Problem struct {
+	// What went wrong in this occurrence of the error.
+	Detail *string `json:"detail,omitempty"`
+
+	// The invalid fields of the request, for `urn:todoctian:problem:validation` errors raised by the validation of names and task trees.
+	Errors []FieldError `json:"errors,omitempty"`
+
+	// The HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Short summary of the kind of error, the same for every error of this type.
+	Title string `json:"title"`
+
+	// Identifies the kind of error, e.g. `urn:todoctian:problem:not-found`. It does not change between releases, so clients can rely on it rather than on the status or the detail.
+	Type string `json:"type"`
+}
+
 // Project defines model for Project.
 type Project struct {
+	// When the project was archived, if it is archived.
+	ArchivedAt *time.Time `json:"archivedAt"`
+
+	// Color of the project, as a hex color.
+	Color *string `json:"color"`
+
 	// The creation date of the project.
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
 
+	// When the project was moved to the trash, if it is in the trash.
+	DeletedAt *time.Time `json:"deletedAt"`
+
+	// Free text about the project, empty if there is none.
+	Description *string `json:"description,omitempty"`
+
+	// What the estimates of the tasks of a project count, shared by all of them.
+	EstimateUnit *EstimateUnit `json:"estimateUnit,omitempty"`
+
+	// Emoji or short text shown next to the name of the project.
+	Icon *string `json:"icon"`
+
 	// Unique identifier for the project.
 	ID *string `json:"id,omitempty"`
 
 	// Name of the project.
 	Name *string `json:"name,omitempty"`
+
+	// Position of the project in the list of projects, starting from 0.
+	Order *int `json:"order,omitempty"`
+
+	// ID of the project this one is a sub-project of, if any. Set with `PUT /projects/{projectID}/parent`.
+	ParentProjectID *string `json:"parentProjectID"`
+
+	// Counts of the tasks of a project, leaving out the tasks in the trash. Only included when projects are fetched with `GET /projects` and `GET /projects/{projectID}`.
+	TaskSummary *TaskSummary `json:"taskSummary,omitempty"`
+
+	// Incremented on every change of the project.
+	Version *int `json:"version,omitempty"`
+
+	// ID of the workspace the project belongs to, if any. Set with `PUT /projects/{projectID}/workspace`.
+	WorkspaceID *string `json:"workspaceID"`
+}
+
+// ProjectParent defines model for ProjectParent.
+type ProjectParent struct {
+	// The project to make the project a sub-project of, or null for none.
+	ParentProjectID *string `json:"parentProjectID"`
+}
+
+// ProjectStats defines model for ProjectStats.
+type ProjectStats struct {
+	// Average number of seconds from the creation to the completion of the tasks completed in the period. Null if no task was completed.
+	AverageCycleTimeSeconds *float64           `json:"averageCycleTimeSeconds"`
+	Bucket                  ProjectStatsBucket `json:"bucket"`
+
+	// Number of tasks completed in the period.
+	Completed int       `json:"completed"`
+	From      time.Time `json:"from"`
+
+	// The 10 pending tasks of the project open for the longest time, oldest first.
+	OldestOpenTasks []Task `json:"oldestOpenTasks"`
+
+	// The tasks created, completed and open in each bucket of the period, in order.
+	Series []StatsPoint `json:"series"`
+	To     time.Time    `json:"to"`
+}
+
+// ProjectWorkspace defines model for ProjectWorkspace.
+type ProjectWorkspace struct {
+	// The workspace to move the project to, or null to take it out of its workspace.
+	WorkspaceID *string `json:"workspaceID"`
+}
+
+// RefreshTokenRevocation defines model for RefreshTokenRevocation.
+type RefreshTokenRevocation struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RollOver defines model for RollOver.
+type RollOver struct {
+	Iteration Iteration `json:"iteration"`
+
+	// The tasks that were moved.
+	Tasks []Task `json:"tasks"`
+}
+
+// Section defines model for Section.
+type Section struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+
+	// The position of the section in its project, starting from 0.
+	Order     int    `json:"order"`
+	ProjectID string `json:"projectID"`
+}
+
+// SectionUpdate defines model for SectionUpdate.
+type SectionUpdate struct {
+	// The new name of the section, unique within the project.
+	Name string `json:"name"`
+}
+
+// StatsPoint defines model for StatsPoint.
+type StatsPoint struct {
+	// Number of tasks completed in the bucket.
+	Completed int `json:"completed"`
+
+	// Number of tasks created in the bucket.
+	Created int `json:"created"`
+
+	// End of the bucket, exclusive.
+	End time.Time `json:"end"`
+
+	// Number of tasks open at the end of the bucket.
+	Open int `json:"open"`
+
+	// Start of the bucket, inclusive.
+	Start time.Time `json:"start"`
 }
 
 // Task defines model for Task.
 type Task struct {
+	// Number of direct subtasks of the task. Only included by `GET /tasks/{taskID}`, for the task and its subtasks.
+	ChildCount *int `json:"childCount,omitempty"`
+
+	// When the task was completed, if it is completed.
+	CompletedAt *time.Time `json:"completedAt"`
+
 	// The creation date of the task.
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
 
+	// When the task was moved to the trash, if it is in the trash.
+	DeletedAt *time.Time `json:"deletedAt"`
+
+	// When the task is due, if it has a due date.
+	DueAt *time.Time `json:"dueAt"`
+
+	// The estimate of the task or, if it has none, the sum of the effective estimates of its subtasks. Null if neither the task nor its subtasks were estimated. Only included by `GET /tasks/{taskID}`, for the task and its subtasks.
+	EffectiveEstimate *int `json:"effectiveEstimate"`
+
+	// How much work the task takes, in the estimate unit of its project. Null if the task was not estimated. Given to a task with subtasks, it overrides their estimates.
+	Estimate *int `json:"estimate"`
+
 	// Unique identifier for the task.
 	ID *string `json:"id,omitempty"`
 
+	// ID of the iteration the task is planned in, if any. Set with `PUT /tasks/{taskID}/iteration`.
+	IterationID *string `json:"iterationID"`
+
 	// Name of the task.
 	Name *string `json:"name,omitempty"`
 
 	// ID of the parent task, if it exists.
 	ParentTaskID *string `json:"parentTaskID,omitempty"`
 
+	// Fraction of the subtasks of the task, at any depth, that are completed. Null for tasks without subtasks. Only included by `GET /tasks/{taskID}`, for the task and its subtasks.
+	Progress *float32 `json:"progress"`
+
 	// ID of the project the task belongs to.
 	ProjectID *string `json:"projectID,omitempty"`
 
+	// The part of the effective estimate that is not done yet: the estimate of the task while it is pending or, if it has none, the remaining work of its subtasks. Only included by `GET /tasks/{taskID}`, for the task and its subtasks.
+	RemainingWork *int `json:"remainingWork"`
+
+	// ID of the section of its project the task is in, if any. Only root tasks are in sections. Set with `PUT /tasks/{taskID}/section`.
+	SectionID *string `json:"sectionID"`
+
 	// The current status of the task.
 	Status   *TaskStatus `json:"status,omitempty"`
 	Subtasks []Task      `json:"subtasks,omitempty"`
-}
 
-// The current status of the task.
-type TaskStatus struct {
-	value string
-}
+	// When the task was last changed.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 
-func (t *TaskStatus) ToValue() string {
-	return t.value
+	// Incremented on every change of the task.
+	Version *int `json:"version,omitempty"`
 }
 
-func (t TaskStatus) MarshalJSON() ([]byte, error) {
-	return json.Marshal(t.value)
+// TaskBatch defines model for TaskBatch.
+type TaskBatch struct {
+	Operations []TaskBatchOperation `json:"operations"`
 }
 
-func (t *TaskStatus) UnmarshalJSON(data []byte) error {
-	var value string
-	if err := json.Unmarshal(data, &value); err != nil {
-		return err
-	}
-	return t.FromValue(value)
-}
+// TaskBatchOperation defines model for TaskBatchOperation.
+type TaskBatchOperation struct {
+	// When the task to create is due.
+	DueAt *time.Time `json:"dueAt"`
 
-func (t *TaskStatus) FromValue(value string) error {
-	switch value {
+	// The name of the task to create, or the new name of the task to rename.
+	Name *string `json:"name,omitempty"`
 
-	case TaskStatusCompleted.value:
-		t.value = value
-		return nil
+	// The operation to make.
+	Op TaskBatchOperationOp `json:"op"`
 
-	case TaskStatusPending.value:
-		t.value = value
-		return nil
+	// The parent of the task to create or move, by ID or temporary ID. The task is a root task of the project if it is missing.
+	ParentTaskID *string `json:"parentTaskID"`
 
-	}
-	return fmt.Errorf("unknown enum value: %v", value)
-}
+	// The project of the task to create. Defaults to the project of the parent task.
+	ProjectID *string `json:"projectID,omitempty"`
 
-// PostProjectsJSONBody defines parameters for PostProjects.
-type PostProjectsJSONBody struct {
-	// Name of the project.
-	Name *string `json:"name,omitempty"`
+	// The current status of the task.
+	Status *TaskStatus `json:"status,omitempty"`
+
+	// The task to rename, update, move or delete: its ID, or the temporary ID of a task created earlier in the batch.
+	TaskID *string `json:"taskID,omitempty"`
+
+	// Temporary ID of the task to create, with which later operations of the batch can reference it. Only for create operations.
+	TempID *string `json:"tempID,omitempty"`
 }
 
-// PatchProjectsProjectIDJSONBody defines parameters for PatchProjectsProjectID.
-type PatchProjectsProjectIDJSONBody struct {
-	// The new name for the project.
-	Name *string `json:"name,omitempty"`
+// TaskBatchOperationResult defines model for TaskBatchOperationResult.
+type TaskBatchOperationResult struct {
+	// The operation that was made.
+	Op   *string `json:"op,omitempty"`
+	Task *Task   `json:"task,omitempty"`
+
+	// The temporary ID of the created task, if it had one.
+	TempID *string `json:"tempID,omitempty"`
 }
 
-// PostTasksJSONBody defines parameters for PostTasks.
-type PostTasksJSONBody Task
+// TaskBatchProblem defines model for TaskBatchProblem.
+type TaskBatchProblem struct {
+	// Embedded struct due to allOf(#/components/schemas/Problem)
+	Problem `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	// Position of the operation that failed in the batch, starting at 0.
+	Index *int `json:"index,omitempty"`
 
-// GetTasksTaskIDParams defines parameters for GetTasksTaskID.
-type GetTasksTaskIDParams struct {
-	WithSubtasks *bool `json:"withSubtasks,omitempty"`
+	// The operation that failed.
+	Op *string `json:"op,omitempty"`
 }
 
-// PatchTasksTaskIDStatusJSONBody defines parameters for PatchTasksTaskIDStatus.
-type PatchTasksTaskIDStatusJSONBody struct {
-	// The current status of the task.
-	Status *TaskStatus `json:"status,omitempty"`
+// TaskBatchResult defines model for TaskBatchResult.
+type TaskBatchResult struct {
+	Results []TaskBatchOperationResult `json:"results,omitempty"`
 }
 
-// PostProjectsJSONRequestBody defines body for PostProjects for application/json ContentType.
-type PostProjectsJSONRequestBody PostProjectsJSONBody
+// TaskIteration defines model for TaskIteration.
+type TaskIteration struct {
+	// The iteration to plan the task in, or null to take it out of its iteration.
+	IterationID *string `json:"iterationID"`
+}
 
-// Bind implements render.Binder.
-func (PostProjectsJSONRequestBody) Bind(*http.Request) error {
-	return nil
+// TaskRevision defines model for TaskRevision.
+type TaskRevision struct {
+	// The change that produced the revision, as in the activity history.
+	Action *string `json:"action,omitempty"`
+
+	// When the change was made.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Revisions are numbered from 1 for each task.
+	Revision *int  `json:"revision,omitempty"`
+	Task     *Task `json:"task,omitempty"`
 }
 
-// PatchProjectsProjectIDJSONRequestBody defines body for PatchProjectsProjectID for application/json ContentType.
-type PatchProjectsProjectIDJSONRequestBody PatchProjectsProjectIDJSONBody
+// TaskSection defines model for TaskSection.
+type TaskSection struct {
+	// The position of the task among the tasks of the section, starting from 0. Defaults to the end of the section.
+	Position *int `json:"position,omitempty"`
 
-// Bind implements render.Binder.
-func (PatchProjectsProjectIDJSONRequestBody) Bind(*http.Request) error {
-	return nil
+	// The section to move the task to, or null to take it out of its section.
+	SectionID *string `json:"sectionID"`
 }
 
-// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
-type PostTasksJSONRequestBody PostTasksJSONBody
+// Counts of the tasks of a project, leaving out the tasks in the trash. Only included when projects are fetched with `GET /projects` and `GET /projects/{projectID}`.
+type TaskSummary struct {
+	// Number of completed tasks.
+	Completed int `json:"completed"`
 
-// Bind implements render.Binder.
-func (PostTasksJSONRequestBody) Bind(*http.Request) error {
-	return nil
+	// All the work estimated for the project, in its estimate unit: the sum of the effective estimates of its root tasks.
+	Estimate int `json:"estimate"`
+
+	// Number of pending tasks.
+	Pending int `json:"pending"`
+
+	// Percentage of the tasks that are completed, rounded down. 0 if there are no tasks.
+	PercentDone int `json:"percentDone"`
+
+	// The part of the estimate that is not done yet, i.e. of the pending tasks. A task with its own estimate counts for its whole subtree until it is completed.
+	RemainingWork int `json:"remainingWork"`
+
+	// Number of tasks at the root of the project, i.e. without a parent task.
+	RootTasks int `json:"rootTasks"`
+
+	// Number of tasks of the project, including subtasks.
+	Total int `json:"total"`
 }
 
-// PatchTasksTaskIDStatusJSONRequestBody defines body for PatchTasksTaskIDStatus for application/json ContentType.
-type PatchTasksTaskIDStatusJSONRequestBody PatchTasksTaskIDStatusJSONBody
+// Template defines model for Template.
+type Template struct {
+	// The creation date of the template.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
 
-// Bind implements render.Binder.
-func (PatchTasksTaskIDStatusJSONRequestBody) Bind(*http.Request) error {
-	return nil
+	// Unique identifier for the template.
+	ID *string `json:"id,omitempty"`
+
+	// Name of the template.
+	Name  *string        `json:"name,omitempty"`
+	Tasks []TemplateTask `json:"tasks,omitempty"`
+
+	// Names of the placeholders used by the template's tasks.
+	Variables []string `json:"variables,omitempty"`
 }
 
-// Response is a common response struct for all the API calls.
-// A Response object may be instantiated via functions for specific operation responses.
-// It may also be instantiated directly, for the purpose of responding with a single status code.
-type Response struct {
-	body        interface{}
-	Code        int
-	contentType string
+// TemplateInstance defines model for TemplateInstance.
+type TemplateInstance struct {
+	Project *Project `json:"project,omitempty"`
+	Tasks   []Task   `json:"tasks,omitempty"`
 }
 
-// Render implements the render.Renderer interface. It sets the Content-Type header
-// and status code based on the response definition.
-func (resp *Response) Render(w http.ResponseWriter, r *http.Request) error {
-	w.Header().Set("Content-Type", resp.contentType)
-	render.Status(r, resp.Code)
-	return nil
+// TemplateInstantiation defines model for TemplateInstantiation.
+type TemplateInstantiation struct {
+	// ID of an existing task to add the tasks below. Requires `projectID`.
+	ParentTaskID *string `json:"parentTaskID,omitempty"`
+
+	// ID of an existing project to add the tasks to.
+	ProjectID *string `json:"projectID,omitempty"`
+
+	// Name of the project to create. May contain placeholders. Mutually exclusive with `projectID`.
+	ProjectName *string `json:"projectName,omitempty"`
+
+	// Values of the template's placeholders.
+	Variables *TemplateInstantiation_Variables `json:"variables,omitempty"`
 }
 
-// Status is a builder method to override the default status code for a response.
-func (resp *Response) Status(code int) *Response {
-	resp.Code = code
-	return resp
+// Values of the template's placeholders.
+type TemplateInstantiation_Variables struct {
+	AdditionalProperties map[string]string `json:"-"`
 }
 
-// ContentType is a builder method to override the default content type for a response.
-func (resp *Response) ContentType(contentType string) *Response {
-	resp.contentType = contentType
-	return resp
+// TemplateTask defines model for TemplateTask.
+type TemplateTask struct {
+	// Number of days between the instantiation of the template and the due date of the task. Tasks without an offset have no due date.
+	DueOffsetDays *int `json:"dueOffsetDays"`
+
+	// Unique identifier for the template task.
+	ID *string `json:"id,omitempty"`
+
+	// Name of the task. May contain `{{variable}}` placeholders.
+	Name     *string        `json:"name,omitempty"`
+	Subtasks []TemplateTask `json:"subtasks,omitempty"`
 }
 
-// MarshalJSON implements the json.Marshaler interface.
-// This is used to only marshal the body of the response.
-func (resp *Response) MarshalJSON() ([]byte, error) {
-	return json.Marshal(resp.body)
+// TimeEntry defines model for TimeEntry.
+type TimeEntry struct {
+	// The name of the user who spent the time, or `anonymous`.
+	Actor string `json:"actor"`
+
+	// The time spent, counted until now for running timers.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// When the work ended. Null while the timer is running.
+	EndedAt *time.Time `json:"endedAt"`
+	ID      string     `json:"id"`
+
+	// What was done.
+	Note string `json:"note"`
+
+	// Whether the entry is a timer that was not stopped yet.
+	Running   bool      `json:"running"`
+	StartedAt time.Time `json:"startedAt"`
+	TaskID    string    `json:"taskID"`
+
+	// The user who spent the time, null for entries made before users existed. Each user runs at most one timer at a time.
+	UserID *string `json:"userID"`
 }
 
-// MarshalXML implements the xml.Marshaler interface.
-// This is used to only marshal the body of the response.
-func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	return e.Encode(resp.body)
+// TimeTotals defines model for TimeTotals.
+type TimeTotals struct {
+	// Time tracked on the task itself.
+	OwnSeconds float64 `json:"ownSeconds"`
+
+	// Time tracked on the task and all of its subtasks.
+	TotalSeconds float64 `json:"totalSeconds"`
 }
 
-// GetProjectsJSON200Response is a constructor method for a GetProjects response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetProjectsJSON200Response(body []Project) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+// TimerStart defines model for TimerStart.
+type TimerStart struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// Timesheet defines model for Timesheet.
+type Timesheet struct {
+	From      time.Time `json:"from"`
+	ProjectID string    `json:"projectID"`
+
+	// The time spent by each actor on each task, sorted by task name.
+	Rows         []TimesheetRow `json:"rows"`
+	To           time.Time      `json:"to"`
+	TotalSeconds float64        `json:"totalSeconds"`
+}
+
+// TimesheetRow defines model for TimesheetRow.
+type TimesheetRow struct {
+	// The current name of the user, or the name the entries were made by.
+	Actor    string  `json:"actor"`
+	Seconds  float64 `json:"seconds"`
+	TaskID   string  `json:"taskID"`
+	TaskName string  `json:"taskName"`
+
+	// The user who spent the time, null for entries made before users existed.
+	UserID *string `json:"userID"`
+}
+
+// TokenRequest defines model for TokenRequest.
+type TokenRequest struct {
+	// The API token to exchange, for the `api_token` grant.
+	APIToken  *string               `json:"apiToken,omitempty"`
+	GrantType TokenRequestGrantType `json:"grantType"`
+
+	// The refresh token to exchange, for the `refresh_token` grant.
+	RefreshToken *string `json:"refreshToken,omitempty"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	AccessToken string `json:"accessToken"`
+
+	// Number of seconds the access token is valid for.
+	ExpiresIn int `json:"expiresIn"`
+
+	// Exchanged for new tokens when the access token expires. It can be used once.
+	RefreshToken string                 `json:"refreshToken"`
+	TokenType    TokenResponseTokenType `json:"tokenType"`
+}
+
+// TrashItem defines model for TrashItem.
+type TrashItem struct {
+	// When the item was moved to the trash.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// ID of the deleted project or task.
+	ID *string `json:"id,omitempty"`
+
+	// Name of the project or task.
+	Name *string `json:"name,omitempty"`
+
+	// ID of the parent task, if the item is a subtask.
+	ParentTaskID *string `json:"parentTaskID,omitempty"`
+
+	// ID of the project the task belongs to. Only set for tasks.
+	ProjectID *string `json:"projectID,omitempty"`
+
+	// Whether the item is a project or a task.
+	Type *TrashItemType `json:"type,omitempty"`
+}
+
+// UndoStep defines model for UndoStep.
+type UndoStep struct {
+	// The operation, as in the activity history.
+	Action *string `json:"action,omitempty"`
+
+	// The tasks changed by the operation, as they are now.
+	Tasks []Task `json:"tasks,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+}
+
+// What was completed in an iteration, measured when it was closed. Estimates are summed per unit, since the projects of the tasks may estimate them differently.
+type Velocity struct {
+	CompletedTasks int `json:"completedTasks"`
+
+	// Sum of the estimates of the completed tasks of projects estimated in minutes.
+	Minutes int `json:"minutes"`
+
+	// Sum of the estimates of the completed tasks of projects estimated in points.
+	Points int `json:"points"`
+}
+
+// Workspace defines model for Workspace.
+type Workspace struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+}
+
+// WorkspaceStats defines model for WorkspaceStats.
+type WorkspaceStats struct {
+	ArchivedProjects int `json:"archivedProjects"`
+
+	// Number of projects in the workspace, including the archived ones.
+	Projects int `json:"projects"`
+
+	// Counts of the tasks of a project, leaving out the tasks in the trash. Only included when projects are fetched with `GET /projects` and `GET /projects/{projectID}`.
+	Tasks TaskSummary `json:"tasks"`
+}
+
+// WorkspaceUpdate defines model for WorkspaceUpdate.
+type WorkspaceUpdate struct {
+	// The new name of the workspace.
+	Name string `json:"name"`
+}
+
+// Cursor defines model for Cursor.
+type Cursor string
+
+// Limit defines model for Limit.
+type Limit int
+
+// ParentTaskIDFilter defines model for ParentTaskIDFilter.
+type ParentTaskIDFilter string
+
+// PeriodFrom defines model for PeriodFrom.
+type PeriodFrom time.Time
+
+// PeriodTo defines model for PeriodTo.
+type PeriodTo time.Time
+
+// RootFilter defines model for RootFilter.
+type RootFilter bool
+
+// SectionIDFilter defines model for SectionIDFilter.
+type SectionIDFilter string
+
+// TaskStatusFilter defines model for TaskStatusFilter.
+type TaskStatusFilter string
+
+// The kind of change.
+type ActivityEventAction struct {
+	value string
+}
+
+func (t *ActivityEventAction) ToValue() string {
+	return t.value
+}
+
+func (t ActivityEventAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *ActivityEventAction) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
 	}
+	return t.FromValue(value)
 }
 
-// PostProjectsJSON201Response is a constructor method for a PostProjects response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostProjectsJSON201Response(body Project) *Response {
-	return &Response{
-		body:        body,
-		Code:        201,
-		contentType: "application/json",
+func (t *ActivityEventAction) FromValue(value string) error {
+	switch value {
+
+	case ActivityEventActionArchived.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionCreated.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionDeleted.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionDueAtChanged.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionEstimated.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionMoved.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionPlanned.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionRenamed.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionReordered.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionRestored.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionStatusChanged.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionUnarchived.value:
+		t.value = value
+		return nil
+
+	case ActivityEventActionUpdated.value:
+		t.value = value
+		return nil
+
 	}
+	return fmt.Errorf("unknown enum value: %v", value)
 }
 
-// DeleteProjectsProjectIDJSON204Response is a constructor method for a DeleteProjectsProjectID response.
-// A *Response is returned with the configured status code and content type from the spec.
-func DeleteProjectsProjectIDJSON204Response(body Project) *Response {
-	return &Response{
-		body:        body,
-		Code:        204,
-		contentType: "application/json",
+// What the estimates of the tasks of a project count, shared by all of them.
+type EstimateUnit struct {
+	value string
+}
+
+func (t *EstimateUnit) ToValue() string {
+	return t.value
+}
+
+func (t EstimateUnit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *EstimateUnit) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
 	}
+	return t.FromValue(value)
 }
 
-// GetProjectsProjectIDJSON200Response is a constructor method for a GetProjectsProjectID response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetProjectsProjectIDJSON200Response(body Project) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+func (t *EstimateUnit) FromValue(value string) error {
+	switch value {
+
+	case EstimateUnitMinutes.value:
+		t.value = value
+		return nil
+
+	case EstimateUnitPoints.value:
+		t.value = value
+		return nil
+
 	}
+	return fmt.Errorf("unknown enum value: %v", value)
 }
 
-// PatchProjectsProjectIDJSON200Response is a constructor method for a PatchProjectsProjectID response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PatchProjectsProjectIDJSON200Response(body Project) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+// ProjectStatsBucket defines model for ProjectStats.Bucket.
+type ProjectStatsBucket struct {
+	value string
+}
+
+func (t *ProjectStatsBucket) ToValue() string {
+	return t.value
+}
+
+func (t ProjectStatsBucket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *ProjectStatsBucket) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
 	}
+	return t.FromValue(value)
 }
 
-// GetProjectsProjectIDTasksJSON200Response is a constructor method for a GetProjectsProjectIDTasks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetProjectsProjectIDTasksJSON200Response(body []Task) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+func (t *ProjectStatsBucket) FromValue(value string) error {
+	switch value {
+
+	case ProjectStatsBucketDay.value:
+		t.value = value
+		return nil
+
+	case ProjectStatsBucketWeek.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// The operation to make.
+type TaskBatchOperationOp struct {
+	value string
+}
+
+func (t *TaskBatchOperationOp) ToValue() string {
+	return t.value
+}
+
+func (t TaskBatchOperationOp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *TaskBatchOperationOp) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
 	}
+	return t.FromValue(value)
+}
+
+func (t *TaskBatchOperationOp) FromValue(value string) error {
+	switch value {
+
+	case TaskBatchOperationOpCreate.value:
+		t.value = value
+		return nil
+
+	case TaskBatchOperationOpDelete.value:
+		t.value = value
+		return nil
+
+	case TaskBatchOperationOpMove.value:
+		t.value = value
+		return nil
+
+	case TaskBatchOperationOpRename.value:
+		t.value = value
+		return nil
+
+	case TaskBatchOperationOpSetStatus.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// The current status of the task.
+type TaskStatus struct {
+	value string
+}
+
+func (t *TaskStatus) ToValue() string {
+	return t.value
+}
+
+func (t TaskStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *TaskStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+
+func (t *TaskStatus) FromValue(value string) error {
+	switch value {
+
+	case TaskStatusCompleted.value:
+		t.value = value
+		return nil
+
+	case TaskStatusPending.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// TokenRequestGrantType defines model for TokenRequest.GrantType.
+type TokenRequestGrantType struct {
+	value string
+}
+
+func (t *TokenRequestGrantType) ToValue() string {
+	return t.value
+}
+
+func (t TokenRequestGrantType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *TokenRequestGrantType) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+
+func (t *TokenRequestGrantType) FromValue(value string) error {
+	switch value {
+
+	case TokenRequestGrantTypeAPIToken.value:
+		t.value = value
+		return nil
+
+	case TokenRequestGrantTypeRefreshToken.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// TokenResponseTokenType defines model for TokenResponse.TokenType.
+type TokenResponseTokenType struct {
+	value string
+}
+
+func (t *TokenResponseTokenType) ToValue() string {
+	return t.value
+}
+
+func (t TokenResponseTokenType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *TokenResponseTokenType) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+
+func (t *TokenResponseTokenType) FromValue(value string) error {
+	switch value {
+	case TokenResponseTokenTypeBearer.value:
+		t.value = value
+		return nil
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// Whether the item is a project or a task.
+type TrashItemType struct {
+	value string
+}
+
+func (t *TrashItemType) ToValue() string {
+	return t.value
+}
+
+func (t TrashItemType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *TrashItemType) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+
+func (t *TrashItemType) FromValue(value string) error {
+	switch value {
+
+	case TrashItemTypeProject.value:
+		t.value = value
+		return nil
+
+	case TrashItemTypeTask.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// TaskSort defines model for TaskSort.
+type TaskSort struct {
+	value string
+}
+
+func (t *TaskSort) ToValue() string {
+	return t.value
+}
+
+func (t TaskSort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+func (t *TaskSort) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+
+func (t *TaskSort) FromValue(value string) error {
+	switch value {
+
+	case CreatedAt.value:
+		t.value = value
+		return nil
+
+	case CreatedAt1.value:
+		t.value = value
+		return nil
+
+	case Name.value:
+		t.value = value
+		return nil
+
+	case Name1.value:
+		t.value = value
+		return nil
+
+	case Order.value:
+		t.value = value
+		return nil
+
+	case Order1.value:
+		t.value = value
+		return nil
+
+	case Status.value:
+		t.value = value
+		return nil
+
+	case Status1.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// PostAuthRevokeJSONBody defines parameters for PostAuthRevoke.
+type PostAuthRevokeJSONBody RefreshTokenRevocation
+
+// PostAuthTokenJSONBody defines parameters for PostAuthToken.
+type PostAuthTokenJSONBody TokenRequest
+
+// PostIterationsJSONBody defines parameters for PostIterations.
+type PostIterationsJSONBody NewIteration
+
+// PatchIterationsIterationIDJSONBody defines parameters for PatchIterationsIterationID.
+type PatchIterationsIterationIDJSONBody IterationUpdate
+
+// PostIterationsIterationIDRollOverJSONBody defines parameters for PostIterationsIterationIDRollOver.
+type PostIterationsIterationIDRollOverJSONBody struct {
+	// The iteration to move the tasks to, instead of the next one.
+	ToIterationID *string `json:"toIterationID,omitempty"`
+}
+
+// PostMeTokensJSONBody defines parameters for PostMeTokens.
+type PostMeTokensJSONBody NewAPIToken
+
+// GetProjectsParams defines parameters for GetProjects.
+type GetProjectsParams struct {
+	// Whether to include the archived projects.
+	Archived *bool `json:"archived,omitempty"`
+
+	// The field to sort the projects by, prefixed by `-` for descending order.
+	Sort *GetProjectsParamsSort `json:"sort,omitempty"`
+
+	// Where the page starts, as given in the `Link` header of the previous page. Omit it to get the first page. A cursor is only valid with the sort it was made with.
+	Cursor *Cursor `json:"cursor,omitempty"`
+
+	// Maximum number of items in the page.
+	Limit *Limit `json:"limit,omitempty"`
+}
+
+// GetProjectsParamsSort defines parameters for GetProjects.
+type GetProjectsParamsSort string
+
+// PostProjectsJSONBody defines parameters for PostProjects.
+type PostProjectsJSONBody struct {
+	// Name of the project, unique within its workspace.
+	Name *string `json:"name,omitempty"`
+
+	// The project to make the new project a sub-project of. Sub-projects go in the workspace of their parent.
+	ParentProjectID *string `json:"parentProjectID,omitempty"`
+
+	// The workspace to create the project in. Projects without a workspace share their names with the other projects without one.
+	WorkspaceID *string `json:"workspaceID,omitempty"`
+}
+
+// PostProjectsParams defines parameters for PostProjects.
+type PostProjectsParams struct {
+	// A unique key, such as a UUID, with which the request can be safely retried: retries get the response to the original request instead of creating the project again. Keys are kept for 24 hours by default.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteProjectsProjectIDParams defines parameters for DeleteProjectsProjectID.
+type DeleteProjectsProjectIDParams struct {
+	// The ETag of the project as last fetched, so that it is only changed if no one else changed it in the meantime. `*` matches any version.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetProjectsProjectIDParams defines parameters for GetProjectsProjectID.
+type GetProjectsProjectIDParams struct {
+	// The ETag of a previously fetched project, to only fetch it again if it changed.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PatchProjectsProjectIDJSONBody defines parameters for PatchProjectsProjectID.
+type PatchProjectsProjectIDJSONBody struct {
+	// The new color of the project, as a hex color. An empty string removes it.
+	Color *string `json:"color,omitempty"`
+
+	// The new description of the project.
+	Description *string `json:"description,omitempty"`
+
+	// What the estimates of the tasks of a project count, shared by all of them.
+	EstimateUnit *EstimateUnit `json:"estimateUnit,omitempty"`
+
+	// The new emoji or icon of the project. An empty string removes it.
+	Icon *string `json:"icon,omitempty"`
+
+	// The new name for the project.
+	Name *string `json:"name,omitempty"`
+}
+
+// PatchProjectsProjectIDParams defines parameters for PatchProjectsProjectID.
+type PatchProjectsProjectIDParams struct {
+	// The ETag of the project as last fetched, so that it is only changed if no one else changed it in the meantime. `*` matches any version.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetProjectsProjectIDActivityParams defines parameters for GetProjectsProjectIDActivity.
+type GetProjectsProjectIDActivityParams struct {
+	// The `nextCursor` of the previous page. Omit it to get the first page.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Maximum number of events in the page.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// PutProjectsProjectIDParentJSONBody defines parameters for PutProjectsProjectIDParent.
+type PutProjectsProjectIDParentJSONBody ProjectParent
+
+// PutProjectsProjectIDPositionJSONBody defines parameters for PutProjectsProjectIDPosition.
+type PutProjectsProjectIDPositionJSONBody struct {
+	// The new position of the project.
+	Order int `json:"order"`
+}
+
+// PostProjectsProjectIDSectionsJSONBody defines parameters for PostProjectsProjectIDSections.
+type PostProjectsProjectIDSectionsJSONBody NewSection
+
+// PatchProjectsProjectIDSectionsSectionIDJSONBody defines parameters for PatchProjectsProjectIDSectionsSectionID.
+type PatchProjectsProjectIDSectionsSectionIDJSONBody SectionUpdate
+
+// PutProjectsProjectIDSectionsSectionIDPositionJSONBody defines parameters for PutProjectsProjectIDSectionsSectionIDPosition.
+type PutProjectsProjectIDSectionsSectionIDPositionJSONBody struct {
+	// The new position of the section.
+	Order int `json:"order"`
+}
+
+// GetProjectsProjectIDStatsParams defines parameters for GetProjectsProjectIDStats.
+type GetProjectsProjectIDStatsParams struct {
+	// Start of the period, inclusive, with its time zone, e.g. `2026-10-01T00:00:00-03:00`.
+	From PeriodFrom `json:"from"`
+
+	// End of the period, exclusive, with its time zone.
+	To PeriodTo `json:"to"`
+
+	// The length of the buckets the period is split into, at most 366 of them.
+	Bucket *GetProjectsProjectIDStatsParamsBucket `json:"bucket,omitempty"`
+}
+
+// GetProjectsProjectIDStatsParamsBucket defines parameters for GetProjectsProjectIDStats.
+type GetProjectsProjectIDStatsParamsBucket string
+
+// GetProjectsProjectIDTasksParams defines parameters for GetProjectsProjectIDTasks.
+type GetProjectsProjectIDTasksParams struct {
+	// The field to sort the tasks by, prefixed by `-` for descending order.
+	Sort *GetProjectsProjectIDTasksParamsSort `json:"sort,omitempty"`
+
+	// Only list the tasks with this status, `pending` or `completed`.
+	Status *TaskStatusFilter `json:"status,omitempty"`
+
+	// Only list the direct subtasks of this task.
+	ParentTaskID *ParentTaskIDFilter `json:"parentTaskID,omitempty"`
+
+	// Only list the root tasks in this section.
+	SectionID *SectionIDFilter `json:"sectionID,omitempty"`
+
+	// Only list the tasks at the root of their project.
+	Root *RootFilter `json:"root,omitempty"`
+
+	// Where the page starts, as given in the `Link` header of the previous page. Omit it to get the first page. A cursor is only valid with the sort it was made with.
+	Cursor *Cursor `json:"cursor,omitempty"`
+
+	// Maximum number of items in the page.
+	Limit *Limit `json:"limit,omitempty"`
+}
+
+// GetProjectsProjectIDTasksParamsSort defines parameters for GetProjectsProjectIDTasks.
+type GetProjectsProjectIDTasksParamsSort string
+
+// GetProjectsProjectIDTasksCompletedParams defines parameters for GetProjectsProjectIDTasksCompleted.
+type GetProjectsProjectIDTasksCompletedParams struct {
+	// Start of the period, inclusive, with its time zone, e.g. `2026-10-01T00:00:00-03:00`.
+	From PeriodFrom `json:"from"`
+
+	// End of the period, exclusive, with its time zone.
+	To PeriodTo `json:"to"`
+}
+
+// PostProjectsProjectIDTemplateJSONBody defines parameters for PostProjectsProjectIDTemplate.
+type PostProjectsProjectIDTemplateJSONBody struct {
+	// Name of the new template.
+	Name *string `json:"name,omitempty"`
+}
+
+// GetProjectsProjectIDTimesheetParams defines parameters for GetProjectsProjectIDTimesheet.
+type GetProjectsProjectIDTimesheetParams struct {
+	// Start of the period, inclusive, with its time zone, e.g. `2026-10-01T00:00:00-03:00`.
+	From PeriodFrom `json:"from"`
+
+	// End of the period, exclusive, with its time zone.
+	To PeriodTo `json:"to"`
+}
+
+// PutProjectsProjectIDWorkspaceJSONBody defines parameters for PutProjectsProjectIDWorkspace.
+type PutProjectsProjectIDWorkspaceJSONBody ProjectWorkspace
+
+// PostRedoParams defines parameters for PostRedo.
+type PostRedoParams struct {
+	// Identifies the client session whose operations are undone and redone.
+	XSessionID string `json:"X-Session-Id"`
+}
+
+// GetTasksParams defines parameters for GetTasks.
+type GetTasksParams struct {
+	// The field to sort the tasks by, prefixed by `-` for descending order.
+	Sort *GetTasksParamsSort `json:"sort,omitempty"`
+
+	// Only list the tasks with this status, `pending` or `completed`.
+	Status *TaskStatusFilter `json:"status,omitempty"`
+
+	// Only list the direct subtasks of this task.
+	ParentTaskID *ParentTaskIDFilter `json:"parentTaskID,omitempty"`
+
+	// Only list the root tasks in this section.
+	SectionID *SectionIDFilter `json:"sectionID,omitempty"`
+
+	// Only list the tasks at the root of their project.
+	Root *RootFilter `json:"root,omitempty"`
+
+	// Where the page starts, as given in the `Link` header of the previous page. Omit it to get the first page. A cursor is only valid with the sort it was made with.
+	Cursor *Cursor `json:"cursor,omitempty"`
+
+	// Maximum number of items in the page.
+	Limit *Limit `json:"limit,omitempty"`
+}
+
+// GetTasksParamsSort defines parameters for GetTasks.
+type GetTasksParamsSort string
+
+// PostTasksJSONBody defines parameters for PostTasks.
+type PostTasksJSONBody Task
+
+// PostTasksParams defines parameters for PostTasks.
+type PostTasksParams struct {
+	// A unique key, such as a UUID, with which the request can be safely retried: retries get the response to the original request instead of creating the task again. Keys are kept for 24 hours by default.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTasksBatchJSONBody defines parameters for PostTasksBatch.
+type PostTasksBatchJSONBody TaskBatch
+
+// PostTasksBatchParams defines parameters for PostTasksBatch.
+type PostTasksBatchParams struct {
+	// A unique key, such as a UUID, with which the request can be safely retried: retries get the response to the original request instead of running the batch again.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetTasksCompletedParams defines parameters for GetTasksCompleted.
+type GetTasksCompletedParams struct {
+	// Start of the period, inclusive, with its time zone, e.g. `2026-10-01T00:00:00-03:00`.
+	From PeriodFrom `json:"from"`
+
+	// End of the period, exclusive, with its time zone.
+	To PeriodTo `json:"to"`
+}
+
+// DeleteTasksTaskIDParams defines parameters for DeleteTasksTaskID.
+type DeleteTasksTaskIDParams struct {
+	// The ETag of the task as last fetched, so that it is only changed if no one else changed it in the meantime. `*` matches any version.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksTaskIDParams defines parameters for GetTasksTaskID.
+type GetTasksTaskIDParams struct {
+	WithSubtasks *bool `json:"withSubtasks,omitempty"`
+
+	// The ETag of a previously fetched task, to only fetch it again if it changed.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PatchTasksTaskIDJSONBody defines parameters for PatchTasksTaskID.
+type PatchTasksTaskIDJSONBody struct {
+	// The new estimate of the task, in the estimate unit of its project.
+	Estimate *int `json:"estimate"`
+
+	// The new name for the task.
+	Name *string `json:"name,omitempty"`
+
+	// The new position of the task among its siblings, starting at 0.
+	Order *int `json:"order,omitempty"`
+}
+
+// PatchTasksTaskIDParams defines parameters for PatchTasksTaskID.
+type PatchTasksTaskIDParams struct {
+	// The ETag of the task as last fetched, so that it is only changed if no one else changed it in the meantime. `*` matches any version.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksTaskIDActivityParams defines parameters for GetTasksTaskIDActivity.
+type GetTasksTaskIDActivityParams struct {
+	// The `nextCursor` of the previous page. Omit it to get the first page.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Maximum number of events in the page.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// PutTasksTaskIDIterationJSONBody defines parameters for PutTasksTaskIDIteration.
+type PutTasksTaskIDIterationJSONBody TaskIteration
+
+// PutTasksTaskIDSectionJSONBody defines parameters for PutTasksTaskIDSection.
+type PutTasksTaskIDSectionJSONBody TaskSection
+
+// PatchTasksTaskIDStatusJSONBody defines parameters for PatchTasksTaskIDStatus.
+type PatchTasksTaskIDStatusJSONBody struct {
+	// The current status of the task.
+	Status *TaskStatus `json:"status,omitempty"`
+}
+
+// PatchTasksTaskIDStatusParams defines parameters for PatchTasksTaskIDStatus.
+type PatchTasksTaskIDStatusParams struct {
+	// The ETag of the task as last fetched, so that it is only changed if no one else changed it in the meantime. `*` matches any version.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostTasksTaskIDTimeEntriesJSONBody defines parameters for PostTasksTaskIDTimeEntries.
+type PostTasksTaskIDTimeEntriesJSONBody NewTimeEntry
+
+// PostTasksTaskIDTimerJSONBody defines parameters for PostTasksTaskIDTimer.
+type PostTasksTaskIDTimerJSONBody TimerStart
+
+// PostTemplatesJSONBody defines parameters for PostTemplates.
+type PostTemplatesJSONBody struct {
+	// Name of the template.
+	Name  *string        `json:"name,omitempty"`
+	Tasks []TemplateTask `json:"tasks,omitempty"`
+}
+
+// PostTemplatesTemplateIDInstantiateJSONBody defines parameters for PostTemplatesTemplateIDInstantiate.
+type PostTemplatesTemplateIDInstantiateJSONBody TemplateInstantiation
+
+// PostUndoParams defines parameters for PostUndo.
+type PostUndoParams struct {
+	// Identifies the client session whose operations are undone and redone.
+	XSessionID string `json:"X-Session-Id"`
+}
+
+// PostWorkspacesJSONBody defines parameters for PostWorkspaces.
+type PostWorkspacesJSONBody NewWorkspace
+
+// PatchWorkspacesWorkspaceIDJSONBody defines parameters for PatchWorkspacesWorkspaceID.
+type PatchWorkspacesWorkspaceIDJSONBody WorkspaceUpdate
+
+// GetWorkspacesWorkspaceIDProjectsParams defines parameters for GetWorkspacesWorkspaceIDProjects.
+type GetWorkspacesWorkspaceIDProjectsParams struct {
+	// Whether to include the archived projects.
+	Archived *bool `json:"archived,omitempty"`
+
+	// The field to sort the projects by, prefixed by `-` for descending order.
+	Sort *GetWorkspacesWorkspaceIDProjectsParamsSort `json:"sort,omitempty"`
+
+	// Where the page starts, as given in the `Link` header of the previous page. Omit it to get the first page. A cursor is only valid with the sort it was made with.
+	Cursor *Cursor `json:"cursor,omitempty"`
+
+	// Maximum number of items in the page.
+	Limit *Limit `json:"limit,omitempty"`
+}
+
+// GetWorkspacesWorkspaceIDProjectsParamsSort defines parameters for GetWorkspacesWorkspaceIDProjects.
+type GetWorkspacesWorkspaceIDProjectsParamsSort string
+
+// PostAuthRevokeJSONRequestBody defines body for PostAuthRevoke for application/json ContentType.
+type PostAuthRevokeJSONRequestBody PostAuthRevokeJSONBody
+
+// Bind implements render.Binder.
+func (PostAuthRevokeJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostAuthTokenJSONRequestBody defines body for PostAuthToken for application/json ContentType.
+type PostAuthTokenJSONRequestBody PostAuthTokenJSONBody
+
+// Bind implements render.Binder.
+func (PostAuthTokenJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostIterationsJSONRequestBody defines body for PostIterations for application/json ContentType.
+type PostIterationsJSONRequestBody PostIterationsJSONBody
+
+// Bind implements render.Binder.
+func (PostIterationsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchIterationsIterationIDJSONRequestBody defines body for PatchIterationsIterationID for application/json ContentType.
+type PatchIterationsIterationIDJSONRequestBody PatchIterationsIterationIDJSONBody
+
+// Bind implements render.Binder.
+func (PatchIterationsIterationIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostIterationsIterationIDRollOverJSONRequestBody defines body for PostIterationsIterationIDRollOver for application/json ContentType.
+type PostIterationsIterationIDRollOverJSONRequestBody PostIterationsIterationIDRollOverJSONBody
+
+// Bind implements render.Binder.
+func (PostIterationsIterationIDRollOverJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostMeTokensJSONRequestBody defines body for PostMeTokens for application/json ContentType.
+type PostMeTokensJSONRequestBody PostMeTokensJSONBody
+
+// Bind implements render.Binder.
+func (PostMeTokensJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostProjectsJSONRequestBody defines body for PostProjects for application/json ContentType.
+type PostProjectsJSONRequestBody PostProjectsJSONBody
+
+// Bind implements render.Binder.
+func (PostProjectsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchProjectsProjectIDJSONRequestBody defines body for PatchProjectsProjectID for application/json ContentType.
+type PatchProjectsProjectIDJSONRequestBody PatchProjectsProjectIDJSONBody
+
+// Bind implements render.Binder.
+func (PatchProjectsProjectIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutProjectsProjectIDParentJSONRequestBody defines body for PutProjectsProjectIDParent for application/json ContentType.
+type PutProjectsProjectIDParentJSONRequestBody PutProjectsProjectIDParentJSONBody
+
+// Bind implements render.Binder.
+func (PutProjectsProjectIDParentJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutProjectsProjectIDPositionJSONRequestBody defines body for PutProjectsProjectIDPosition for application/json ContentType.
+type PutProjectsProjectIDPositionJSONRequestBody PutProjectsProjectIDPositionJSONBody
+
+// Bind implements render.Binder.
+func (PutProjectsProjectIDPositionJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostProjectsProjectIDSectionsJSONRequestBody defines body for PostProjectsProjectIDSections for application/json ContentType.
+type PostProjectsProjectIDSectionsJSONRequestBody PostProjectsProjectIDSectionsJSONBody
+
+// Bind implements render.Binder.
+func (PostProjectsProjectIDSectionsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchProjectsProjectIDSectionsSectionIDJSONRequestBody defines body for PatchProjectsProjectIDSectionsSectionID for application/json ContentType.
+type PatchProjectsProjectIDSectionsSectionIDJSONRequestBody PatchProjectsProjectIDSectionsSectionIDJSONBody
+
+// Bind implements render.Binder.
+func (PatchProjectsProjectIDSectionsSectionIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutProjectsProjectIDSectionsSectionIDPositionJSONRequestBody defines body for PutProjectsProjectIDSectionsSectionIDPosition for application/json ContentType.
+type PutProjectsProjectIDSectionsSectionIDPositionJSONRequestBody PutProjectsProjectIDSectionsSectionIDPositionJSONBody
+
+// Bind implements render.Binder.
+func (PutProjectsProjectIDSectionsSectionIDPositionJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostProjectsProjectIDTemplateJSONRequestBody defines body for PostProjectsProjectIDTemplate for application/json ContentType.
+type PostProjectsProjectIDTemplateJSONRequestBody PostProjectsProjectIDTemplateJSONBody
+
+// Bind implements render.Binder.
+func (PostProjectsProjectIDTemplateJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutProjectsProjectIDWorkspaceJSONRequestBody defines body for PutProjectsProjectIDWorkspace for application/json ContentType.
+type PutProjectsProjectIDWorkspaceJSONRequestBody PutProjectsProjectIDWorkspaceJSONBody
+
+// Bind implements render.Binder.
+func (PutProjectsProjectIDWorkspaceJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
+type PostTasksJSONRequestBody PostTasksJSONBody
+
+// Bind implements render.Binder.
+func (PostTasksJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTasksBatchJSONRequestBody defines body for PostTasksBatch for application/json ContentType.
+type PostTasksBatchJSONRequestBody PostTasksBatchJSONBody
+
+// Bind implements render.Binder.
+func (PostTasksBatchJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchTasksTaskIDJSONRequestBody defines body for PatchTasksTaskID for application/json ContentType.
+type PatchTasksTaskIDJSONRequestBody PatchTasksTaskIDJSONBody
+
+// Bind implements render.Binder.
+func (PatchTasksTaskIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTasksTaskIDIterationJSONRequestBody defines body for PutTasksTaskIDIteration for application/json ContentType.
+type PutTasksTaskIDIterationJSONRequestBody PutTasksTaskIDIterationJSONBody
+
+// Bind implements render.Binder.
+func (PutTasksTaskIDIterationJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTasksTaskIDSectionJSONRequestBody defines body for PutTasksTaskIDSection for application/json ContentType.
+type PutTasksTaskIDSectionJSONRequestBody PutTasksTaskIDSectionJSONBody
+
+// Bind implements render.Binder.
+func (PutTasksTaskIDSectionJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchTasksTaskIDStatusJSONRequestBody defines body for PatchTasksTaskIDStatus for application/json ContentType.
+type PatchTasksTaskIDStatusJSONRequestBody PatchTasksTaskIDStatusJSONBody
+
+// Bind implements render.Binder.
+func (PatchTasksTaskIDStatusJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTasksTaskIDTimeEntriesJSONRequestBody defines body for PostTasksTaskIDTimeEntries for application/json ContentType.
+type PostTasksTaskIDTimeEntriesJSONRequestBody PostTasksTaskIDTimeEntriesJSONBody
+
+// Bind implements render.Binder.
+func (PostTasksTaskIDTimeEntriesJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTasksTaskIDTimerJSONRequestBody defines body for PostTasksTaskIDTimer for application/json ContentType.
+type PostTasksTaskIDTimerJSONRequestBody PostTasksTaskIDTimerJSONBody
+
+// Bind implements render.Binder.
+func (PostTasksTaskIDTimerJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTemplatesJSONRequestBody defines body for PostTemplates for application/json ContentType.
+type PostTemplatesJSONRequestBody PostTemplatesJSONBody
+
+// Bind implements render.Binder.
+func (PostTemplatesJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTemplatesTemplateIDInstantiateJSONRequestBody defines body for PostTemplatesTemplateIDInstantiate for application/json ContentType.
+type PostTemplatesTemplateIDInstantiateJSONRequestBody PostTemplatesTemplateIDInstantiateJSONBody
+
+// Bind implements render.Binder.
+func (PostTemplatesTemplateIDInstantiateJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostWorkspacesJSONRequestBody defines body for PostWorkspaces for application/json ContentType.
+type PostWorkspacesJSONRequestBody PostWorkspacesJSONBody
+
+// Bind implements render.Binder.
+func (PostWorkspacesJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchWorkspacesWorkspaceIDJSONRequestBody defines body for PatchWorkspacesWorkspaceID for application/json ContentType.
+type PatchWorkspacesWorkspaceIDJSONRequestBody PatchWorkspacesWorkspaceIDJSONBody
+
+// Bind implements render.Binder.
+func (PatchWorkspacesWorkspaceIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// Response is a common response struct for all the API calls.
+// A Response object may be instantiated via functions for specific operation responses.
+// It may also be instantiated directly, for the purpose of responding with a single status code.
+type Response struct {
+	body        interface{}
+	Code        int
+	contentType string
+}
+
+// Render implements the render.Renderer interface. It sets the Content-Type header
+// and status code based on the response definition.
+func (resp *Response) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Content-Type", resp.contentType)
+	render.Status(r, resp.Code)
+	return nil
+}
+
+// Status is a builder method to override the default status code for a response.
+func (resp *Response) Status(code int) *Response {
+	resp.Code = code
+	return resp
+}
+
+// ContentType is a builder method to override the default content type for a response.
+func (resp *Response) ContentType(contentType string) *Response {
+	resp.contentType = contentType
+	return resp
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// This is used to only marshal the body of the response.
+func (resp *Response) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resp.body)
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+// This is used to only marshal the body of the response.
+func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(resp.body)
+}
+
+// GetWellKnownJwksJSONJSON200Response is a constructor method for a GetWellKnownJwksJSON response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWellKnownJwksJSONJSON200Response(body JWKS) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostAuthTokenJSON200Response is a constructor method for a PostAuthToken response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostAuthTokenJSON200Response(body TokenResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetIterationsJSON200Response is a constructor method for a GetIterations response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetIterationsJSON200Response(body []Iteration) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostIterationsJSON201Response is a constructor method for a PostIterations response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostIterationsJSON201Response(body Iteration) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteIterationsIterationIDJSON204Response is a constructor method for a DeleteIterationsIterationID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteIterationsIterationIDJSON204Response(body Iteration) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetIterationsIterationIDJSON200Response is a constructor method for a GetIterationsIterationID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetIterationsIterationIDJSON200Response(body Iteration) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PatchIterationsIterationIDJSON200Response is a constructor method for a PatchIterationsIterationID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchIterationsIterationIDJSON200Response(body Iteration) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostIterationsIterationIDCloseJSON200Response is a constructor method for a PostIterationsIterationIDClose response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostIterationsIterationIDCloseJSON200Response(body Iteration) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostIterationsIterationIDRollOverJSON200Response is a constructor method for a PostIterationsIterationIDRollOver response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostIterationsIterationIDRollOverJSON200Response(body RollOver) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetIterationsIterationIDTasksJSON200Response is a constructor method for a GetIterationsIterationIDTasks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetIterationsIterationIDTasksJSON200Response(body []Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetMeJSON200Response is a constructor method for a GetMe response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetMeJSON200Response(body User) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetMeTokensJSON200Response is a constructor method for a GetMeTokens response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetMeTokensJSON200Response(body []APIToken) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostMeTokensJSON201Response is a constructor method for a PostMeTokens response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostMeTokensJSON201Response(body CreatedAPIToken) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteMeTokensTokenIDJSON200Response is a constructor method for a DeleteMeTokensTokenID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteMeTokensTokenIDJSON200Response(body APIToken) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsJSON200Response is a constructor method for a GetProjects response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsJSON200Response(body []Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostProjectsJSON201Response is a constructor method for a PostProjects response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostProjectsJSON201Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteProjectsProjectIDJSON204Response is a constructor method for a DeleteProjectsProjectID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteProjectsProjectIDJSON204Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDJSON200Response is a constructor method for a GetProjectsProjectID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PatchProjectsProjectIDJSON200Response is a constructor method for a PatchProjectsProjectID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchProjectsProjectIDJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDActivityJSON200Response is a constructor method for a GetProjectsProjectIDActivity response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDActivityJSON200Response(body ActivityPage) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostProjectsProjectIDArchiveJSON200Response is a constructor method for a PostProjectsProjectIDArchive response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostProjectsProjectIDArchiveJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutProjectsProjectIDParentJSON200Response is a constructor method for a PutProjectsProjectIDParent response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutProjectsProjectIDParentJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutProjectsProjectIDPositionJSON200Response is a constructor method for a PutProjectsProjectIDPosition response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutProjectsProjectIDPositionJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDSectionsJSON200Response is a constructor method for a GetProjectsProjectIDSections response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDSectionsJSON200Response(body []Section) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostProjectsProjectIDSectionsJSON201Response is a constructor method for a PostProjectsProjectIDSections response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostProjectsProjectIDSectionsJSON201Response(body Section) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteProjectsProjectIDSectionsSectionIDJSON204Response is a constructor method for a DeleteProjectsProjectIDSectionsSectionID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteProjectsProjectIDSectionsSectionIDJSON204Response(body Section) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDSectionsSectionIDJSON200Response is a constructor method for a GetProjectsProjectIDSectionsSectionID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDSectionsSectionIDJSON200Response(body Section) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PatchProjectsProjectIDSectionsSectionIDJSON200Response is a constructor method for a PatchProjectsProjectIDSectionsSectionID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchProjectsProjectIDSectionsSectionIDJSON200Response(body Section) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutProjectsProjectIDSectionsSectionIDPositionJSON200Response is a constructor method for a PutProjectsProjectIDSectionsSectionIDPosition response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutProjectsProjectIDSectionsSectionIDPositionJSON200Response(body Section) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDStatsJSON200Response is a constructor method for a GetProjectsProjectIDStats response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDStatsJSON200Response(body ProjectStats) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDTasksJSON200Response is a constructor method for a GetProjectsProjectIDTasks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDTasksJSON200Response(body []Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDTasksCompletedJSON200Response is a constructor method for a GetProjectsProjectIDTasksCompleted response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDTasksCompletedJSON200Response(body []Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostProjectsProjectIDTemplateJSON201Response is a constructor method for a PostProjectsProjectIDTemplate response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostProjectsProjectIDTemplateJSON201Response(body Template) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// GetProjectsProjectIDTimesheetJSON200Response is a constructor method for a GetProjectsProjectIDTimesheet response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetProjectsProjectIDTimesheetJSON200Response(body Timesheet) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostProjectsProjectIDUnarchiveJSON200Response is a constructor method for a PostProjectsProjectIDUnarchive response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostProjectsProjectIDUnarchiveJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutProjectsProjectIDWorkspaceJSON200Response is a constructor method for a PutProjectsProjectIDWorkspace response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutProjectsProjectIDWorkspaceJSON200Response(body Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostRedoJSON200Response is a constructor method for a PostRedo response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostRedoJSON200Response(body UndoStep) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksJSON200Response is a constructor method for a GetTasks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksJSON200Response(body []Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTasksJSON201Response is a constructor method for a PostTasks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTasksJSON201Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTasksBatchJSON200Response is a constructor method for a PostTasksBatch response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTasksBatchJSON200Response(body TaskBatchResult) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksCompletedJSON200Response is a constructor method for a GetTasksCompleted response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksCompletedJSON200Response(body []Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTasksTaskIDJSON204Response is a constructor method for a DeleteTasksTaskID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTasksTaskIDJSON204Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksTaskIDJSON200Response is a constructor method for a GetTasksTaskID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksTaskIDJSON200Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PatchTasksTaskIDJSON200Response is a constructor method for a PatchTasksTaskID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTasksTaskIDJSON200Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksTaskIDActivityJSON200Response is a constructor method for a GetTasksTaskIDActivity response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksTaskIDActivityJSON200Response(body ActivityPage) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutTasksTaskIDIterationJSON200Response is a constructor method for a PutTasksTaskIDIteration response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTasksTaskIDIterationJSON200Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksTaskIDRevisionsJSON200Response is a constructor method for a GetTasksTaskIDRevisions response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksTaskIDRevisionsJSON200Response(body []TaskRevision) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksTaskIDRevisionsRevisionJSON200Response is a constructor method for a GetTasksTaskIDRevisionsRevision response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksTaskIDRevisionsRevisionJSON200Response(body TaskRevision) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTasksTaskIDRevisionsRevisionRestoreJSON200Response is a constructor method for a PostTasksTaskIDRevisionsRevisionRestore response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTasksTaskIDRevisionsRevisionRestoreJSON200Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutTasksTaskIDSectionJSON200Response is a constructor method for a PutTasksTaskIDSection response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTasksTaskIDSectionJSON200Response(body Task) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksTaskIDTimeEntriesJSON200Response is a constructor method for a GetTasksTaskIDTimeEntries response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksTaskIDTimeEntriesJSON200Response(body []TimeEntry) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTasksTaskIDTimeEntriesJSON201Response is a constructor method for a PostTasksTaskIDTimeEntries response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTasksTaskIDTimeEntriesJSON201Response(body TimeEntry) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// GetTasksTaskIDTimeTotalsJSON200Response is a constructor method for a GetTasksTaskIDTimeTotals response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTasksTaskIDTimeTotalsJSON200Response(body TimeTotals) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTasksTaskIDTimerJSON201Response is a constructor method for a PostTasksTaskIDTimer response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTasksTaskIDTimerJSON201Response(body TimeEntry) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// GetTemplatesJSON200Response is a constructor method for a GetTemplates response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTemplatesJSON200Response(body []Template) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTemplatesJSON201Response is a constructor method for a PostTemplates response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTemplatesJSON201Response(body Template) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTemplatesTemplateIDJSON204Response is a constructor method for a DeleteTemplatesTemplateID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTemplatesTemplateIDJSON204Response(body Template) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTemplatesTemplateIDJSON200Response is a constructor method for a GetTemplatesTemplateID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTemplatesTemplateIDJSON200Response(body Template) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTemplatesTemplateIDInstantiateJSON201Response is a constructor method for a PostTemplatesTemplateIDInstantiate response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTemplatesTemplateIDInstantiateJSON201Response(body TemplateInstance) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTimeEntriesEntryIDJSON204Response is a constructor method for a DeleteTimeEntriesEntryID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTimeEntriesEntryIDJSON204Response(body TimeEntry) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTimerJSON200Response is a constructor method for a GetTimer response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTimerJSON200Response(body TimeEntry) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTimerStopJSON200Response is a constructor method for a PostTimerStop response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTimerStopJSON200Response(body TimeEntry) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTrashJSON200Response is a constructor method for a GetTrash response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTrashJSON200Response(body []TrashItem) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTrashItemIDRestoreJSON200Response is a constructor method for a PostTrashItemIDRestore response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTrashItemIDRestoreJSON200Response(body TrashItem) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostUndoJSON200Response is a constructor method for a PostUndo response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostUndoJSON200Response(body UndoStep) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetWorkspacesJSON200Response is a constructor method for a GetWorkspaces response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorkspacesJSON200Response(body []Workspace) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostWorkspacesJSON201Response is a constructor method for a PostWorkspaces response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostWorkspacesJSON201Response(body Workspace) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteWorkspacesWorkspaceIDJSON204Response is a constructor method for a DeleteWorkspacesWorkspaceID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteWorkspacesWorkspaceIDJSON204Response(body Workspace) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetWorkspacesWorkspaceIDJSON200Response is a constructor method for a GetWorkspacesWorkspaceID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorkspacesWorkspaceIDJSON200Response(body Workspace) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PatchWorkspacesWorkspaceIDJSON200Response is a constructor method for a PatchWorkspacesWorkspaceID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchWorkspacesWorkspaceIDJSON200Response(body Workspace) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetWorkspacesWorkspaceIDProjectsJSON200Response is a constructor method for a GetWorkspacesWorkspaceIDProjects response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorkspacesWorkspaceIDProjectsJSON200Response(body []Project) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetWorkspacesWorkspaceIDStatsJSON200Response is a constructor method for a GetWorkspacesWorkspaceIDStats response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorkspacesWorkspaceIDStatsJSON200Response(body WorkspaceStats) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// Getter for additional properties for TemplateInstantiation_Variables. Returns the specified
+// element and whether it was found
+func (a TemplateInstantiation_Variables) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for TemplateInstantiation_Variables
+func (a *TemplateInstantiation_Variables) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for TemplateInstantiation_Variables to handle AdditionalProperties
+func (a *TemplateInstantiation_Variables) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for TemplateInstantiation_Variables to handle AdditionalProperties
+func (a TemplateInstantiation_Variables) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the keys JWT access tokens are signed with.
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJSON(w http.ResponseWriter, r *http.Request) *Response
+	// Revoke a refresh token.
+	// (POST /auth/revoke)
+	PostAuthRevoke(w http.ResponseWriter, r *http.Request) *Response
+	// Get a JWT access token.
+	// (POST /auth/token)
+	PostAuthToken(w http.ResponseWriter, r *http.Request) *Response
+	// Get all iterations.
+	// (GET /iterations)
+	GetIterations(w http.ResponseWriter, r *http.Request) *Response
+	// Create an iteration.
+	// (POST /iterations)
+	PostIterations(w http.ResponseWriter, r *http.Request) *Response
+	// Delete an iteration.
+	// (DELETE /iterations/{iterationID})
+	DeleteIterationsIterationID(w http.ResponseWriter, r *http.Request, iterationID string) *Response
+	// Get a single iteration.
+	// (GET /iterations/{iterationID})
+	GetIterationsIterationID(w http.ResponseWriter, r *http.Request, iterationID string) *Response
+	// Update an iteration.
+	// (PATCH /iterations/{iterationID})
+	PatchIterationsIterationID(w http.ResponseWriter, r *http.Request, iterationID string) *Response
+	// Close an iteration.
+	// (POST /iterations/{iterationID}/close)
+	PostIterationsIterationIDClose(w http.ResponseWriter, r *http.Request, iterationID string) *Response
+	// Roll the incomplete tasks of an iteration over.
+	// (POST /iterations/{iterationID}/roll-over)
+	PostIterationsIterationIDRollOver(w http.ResponseWriter, r *http.Request, iterationID string) *Response
+	// Get the tasks of an iteration.
+	// (GET /iterations/{iterationID}/tasks)
+	GetIterationsIterationIDTasks(w http.ResponseWriter, r *http.Request, iterationID string) *Response
+	// Get the authenticated user.
+	// (GET /me)
+	GetMe(w http.ResponseWriter, r *http.Request) *Response
+	// Get the API tokens of the authenticated user.
+	// (GET /me/tokens)
+	GetMeTokens(w http.ResponseWriter, r *http.Request) *Response
+	// Create an API token.
+	// (POST /me/tokens)
+	PostMeTokens(w http.ResponseWriter, r *http.Request) *Response
+	// Revoke an API token.
+	// (DELETE /me/tokens/{tokenID})
+	DeleteMeTokensTokenID(w http.ResponseWriter, r *http.Request, tokenID string) *Response
+	// Get all projects
+	// (GET /projects)
+	GetProjects(w http.ResponseWriter, r *http.Request, params GetProjectsParams) *Response
+	// Create a project.
+	// (POST /projects)
+	PostProjects(w http.ResponseWriter, r *http.Request, params PostProjectsParams) *Response
+	// Delete a project. Also deletes the project's tasks.
+	// (DELETE /projects/{projectID})
+	DeleteProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string, params DeleteProjectsProjectIDParams) *Response
+	// Get a single project
+	// (GET /projects/{projectID})
+	GetProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string, params GetProjectsProjectIDParams) *Response
+	// Update a project
+	// (PATCH /projects/{projectID})
+	PatchProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string, params PatchProjectsProjectIDParams) *Response
+	// Get a project's activity history.
+	// (GET /projects/{projectID}/activity)
+	GetProjectsProjectIDActivity(w http.ResponseWriter, r *http.Request, projectID string, params GetProjectsProjectIDActivityParams) *Response
+	// Archive a project.
+	// (POST /projects/{projectID}/archive)
+	PostProjectsProjectIDArchive(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Make a project a sub-project of another one.
+	// (PUT /projects/{projectID}/parent)
+	PutProjectsProjectIDParent(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Move a project in the list of projects.
+	// (PUT /projects/{projectID}/position)
+	PutProjectsProjectIDPosition(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Get the sections of a project.
+	// (GET /projects/{projectID}/sections)
+	GetProjectsProjectIDSections(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Create a section.
+	// (POST /projects/{projectID}/sections)
+	PostProjectsProjectIDSections(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Delete a section.
+	// (DELETE /projects/{projectID}/sections/{sectionID})
+	DeleteProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) *Response
+	// Get a single section.
+	// (GET /projects/{projectID}/sections/{sectionID})
+	GetProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) *Response
+	// Rename a section.
+	// (PATCH /projects/{projectID}/sections/{sectionID})
+	PatchProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) *Response
+	// Move a section in the list of sections of its project.
+	// (PUT /projects/{projectID}/sections/{sectionID}/position)
+	PutProjectsProjectIDSectionsSectionIDPosition(w http.ResponseWriter, r *http.Request, projectID string, sectionID string) *Response
+	// Get the statistics of a project.
+	// (GET /projects/{projectID}/stats)
+	GetProjectsProjectIDStats(w http.ResponseWriter, r *http.Request, projectID string, params GetProjectsProjectIDStatsParams) *Response
+	// Get all project's tasks.
+	// (GET /projects/{projectID}/tasks)
+	GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Request, projectID string, params GetProjectsProjectIDTasksParams) *Response
+	// Get the tasks of a project completed in a period.
+	// (GET /projects/{projectID}/tasks/completed)
+	GetProjectsProjectIDTasksCompleted(w http.ResponseWriter, r *http.Request, projectID string, params GetProjectsProjectIDTasksCompletedParams) *Response
+	// Create a template from a project.
+	// (POST /projects/{projectID}/template)
+	PostProjectsProjectIDTemplate(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Get the timesheet of a project.
+	// (GET /projects/{projectID}/timesheet)
+	GetProjectsProjectIDTimesheet(w http.ResponseWriter, r *http.Request, projectID string, params GetProjectsProjectIDTimesheetParams) *Response
+	// Unarchive a project.
+	// (POST /projects/{projectID}/unarchive)
+	PostProjectsProjectIDUnarchive(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Move a project to a workspace.
+	// (PUT /projects/{projectID}/workspace)
+	PutProjectsProjectIDWorkspace(w http.ResponseWriter, r *http.Request, projectID string) *Response
+	// Redo the last undone operation of a client session.
+	// (POST /redo)
+	PostRedo(w http.ResponseWriter, r *http.Request, params PostRedoParams) *Response
+	// Get all tasks
+	// (GET /tasks)
+	GetTasks(w http.ResponseWriter, r *http.Request, params GetTasksParams) *Response
+	// Create a new task.
+	// (POST /tasks)
+	PostTasks(w http.ResponseWriter, r *http.Request, params PostTasksParams) *Response
+	// Run several task operations at once.
+	// (POST /tasks/batch)
+	PostTasksBatch(w http.ResponseWriter, r *http.Request, params PostTasksBatchParams) *Response
+	// Get the tasks completed in a period.
+	// (GET /tasks/completed)
+	GetTasksCompleted(w http.ResponseWriter, r *http.Request, params GetTasksCompletedParams) *Response
+	// Delete a task.
+	// (DELETE /tasks/{taskID})
+	DeleteTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params DeleteTasksTaskIDParams) *Response
+	// Get a single task.
+	// (GET /tasks/{taskID})
+	GetTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params GetTasksTaskIDParams) *Response
+	// Rename, reorder or estimate a task.
+	// (PATCH /tasks/{taskID})
+	PatchTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params PatchTasksTaskIDParams) *Response
+	// Get a task's activity history.
+	// (GET /tasks/{taskID}/activity)
+	GetTasksTaskIDActivity(w http.ResponseWriter, r *http.Request, taskID string, params GetTasksTaskIDActivityParams) *Response
+	// Plan a task in an iteration.
+	// (PUT /tasks/{taskID}/iteration)
+	PutTasksTaskIDIteration(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Get a task's revisions.
+	// (GET /tasks/{taskID}/revisions)
+	GetTasksTaskIDRevisions(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Get a single revision of a task.
+	// (GET /tasks/{taskID}/revisions/{revision})
+	GetTasksTaskIDRevisionsRevision(w http.ResponseWriter, r *http.Request, taskID string, revision int) *Response
+	// Restore a revision of a task.
+	// (POST /tasks/{taskID}/revisions/{revision}/restore)
+	PostTasksTaskIDRevisionsRevisionRestore(w http.ResponseWriter, r *http.Request, taskID string, revision int) *Response
+	// Move a task to a section.
+	// (PUT /tasks/{taskID}/section)
+	PutTasksTaskIDSection(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Update a task's status.
+	// (PATCH /tasks/{taskID}/status)
+	PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request, taskID string, params PatchTasksTaskIDStatusParams) *Response
+	// Get the time entries of a task.
+	// (GET /tasks/{taskID}/time-entries)
+	GetTasksTaskIDTimeEntries(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Add a time entry to a task.
+	// (POST /tasks/{taskID}/time-entries)
+	PostTasksTaskIDTimeEntries(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Get the time tracked on a task.
+	// (GET /tasks/{taskID}/time-totals)
+	GetTasksTaskIDTimeTotals(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Start a timer on a task.
+	// (POST /tasks/{taskID}/timer)
+	PostTasksTaskIDTimer(w http.ResponseWriter, r *http.Request, taskID string) *Response
+	// Get all templates
+	// (GET /templates)
+	GetTemplates(w http.ResponseWriter, r *http.Request) *Response
+	// Create a template.
+	// (POST /templates)
+	PostTemplates(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a template.
+	// (DELETE /templates/{templateID})
+	DeleteTemplatesTemplateID(w http.ResponseWriter, r *http.Request, templateID string) *Response
+	// Get a single template.
+	// (GET /templates/{templateID})
+	GetTemplatesTemplateID(w http.ResponseWriter, r *http.Request, templateID string) *Response
+	// Instantiate a template.
+	// (POST /templates/{templateID}/instantiate)
+	PostTemplatesTemplateIDInstantiate(w http.ResponseWriter, r *http.Request, templateID string) *Response
+	// Delete a time entry.
+	// (DELETE /time-entries/{entryID})
+	DeleteTimeEntriesEntryID(w http.ResponseWriter, r *http.Request, entryID string) *Response
+	// Get the running timer of the user.
+	// (GET /timer)
+	GetTimer(w http.ResponseWriter, r *http.Request) *Response
+	// Stop the running timer of the user.
+	// (POST /timer/stop)
+	PostTimerStop(w http.ResponseWriter, r *http.Request) *Response
+	// Get the items in the trash.
+	// (GET /trash)
+	GetTrash(w http.ResponseWriter, r *http.Request) *Response
+	// Restore an item from the trash.
+	// (POST /trash/{itemID}/restore)
+	PostTrashItemIDRestore(w http.ResponseWriter, r *http.Request, itemID string) *Response
+	// Undo the last operation of a client session.
+	// (POST /undo)
+	PostUndo(w http.ResponseWriter, r *http.Request, params PostUndoParams) *Response
+	// Get all workspaces.
+	// (GET /workspaces)
+	GetWorkspaces(w http.ResponseWriter, r *http.Request) *Response
+	// Create a workspace.
+	// (POST /workspaces)
+	PostWorkspaces(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a workspace.
+	// (DELETE /workspaces/{workspaceID})
+	DeleteWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request, workspaceID string) *Response
+	// Get a single workspace.
+	// (GET /workspaces/{workspaceID})
+	GetWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request, workspaceID string) *Response
+	// Rename a workspace.
+	// (PATCH /workspaces/{workspaceID})
+	PatchWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request, workspaceID string) *Response
+	// Get the projects of a workspace.
+	// (GET /workspaces/{workspaceID}/projects)
+	GetWorkspacesWorkspaceIDProjects(w http.ResponseWriter, r *http.Request, workspaceID string, params GetWorkspacesWorkspaceIDProjectsParams) *Response
+	// Get the statistics of a workspace.
+	// (GET /workspaces/{workspaceID}/stats)
+	GetWorkspacesWorkspaceIDStats(w http.ResponseWriter, r *http.Request, workspaceID string) *Response
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler          ServerInterface
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// GetWellKnownJwksJSON operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownJwksJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetWellKnownJwksJSON(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAuthRevoke operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRevoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAuthRevoke(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAuthToken operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAuthToken(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetIterations operation middleware
+func (siw *ServerInterfaceWrapper) GetIterations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetIterations(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostIterations operation middleware
+func (siw *ServerInterfaceWrapper) PostIterations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostIterations(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteIterationsIterationID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteIterationsIterationID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "iterationID" -------------
+	var iterationID string
+
+	if err := runtime.BindStyledParameter("simple", false, "iterationID", chi.URLParam(r, "iterationID"), &iterationID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "iterationID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteIterationsIterationID(w, r, iterationID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetIterationsIterationID operation middleware
+func (siw *ServerInterfaceWrapper) GetIterationsIterationID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "iterationID" -------------
+	var iterationID string
+
+	if err := runtime.BindStyledParameter("simple", false, "iterationID", chi.URLParam(r, "iterationID"), &iterationID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "iterationID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetIterationsIterationID(w, r, iterationID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchIterationsIterationID operation middleware
+func (siw *ServerInterfaceWrapper) PatchIterationsIterationID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "iterationID" -------------
+	var iterationID string
+
+	if err := runtime.BindStyledParameter("simple", false, "iterationID", chi.URLParam(r, "iterationID"), &iterationID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "iterationID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchIterationsIterationID(w, r, iterationID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostIterationsIterationIDClose operation middleware
+func (siw *ServerInterfaceWrapper) PostIterationsIterationIDClose(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "iterationID" -------------
+	var iterationID string
+
+	if err := runtime.BindStyledParameter("simple", false, "iterationID", chi.URLParam(r, "iterationID"), &iterationID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "iterationID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostIterationsIterationIDClose(w, r, iterationID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostIterationsIterationIDRollOver operation middleware
+func (siw *ServerInterfaceWrapper) PostIterationsIterationIDRollOver(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "iterationID" -------------
+	var iterationID string
+
+	if err := runtime.BindStyledParameter("simple", false, "iterationID", chi.URLParam(r, "iterationID"), &iterationID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "iterationID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostIterationsIterationIDRollOver(w, r, iterationID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetIterationsIterationIDTasks operation middleware
+func (siw *ServerInterfaceWrapper) GetIterationsIterationIDTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "iterationID" -------------
+	var iterationID string
+
+	if err := runtime.BindStyledParameter("simple", false, "iterationID", chi.URLParam(r, "iterationID"), &iterationID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "iterationID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetIterationsIterationIDTasks(w, r, iterationID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetMe operation middleware
+func (siw *ServerInterfaceWrapper) GetMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetMe(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetMeTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetMeTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetMeTokens(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostMeTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostMeTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostMeTokens(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteMeTokensTokenID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMeTokensTokenID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tokenID" -------------
+	var tokenID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tokenID", chi.URLParam(r, "tokenID"), &tokenID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tokenID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteMeTokensTokenID(w, r, tokenID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjects operation middleware
+func (siw *ServerInterfaceWrapper) GetProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsParams
+
+	// ------------- Optional query parameter "archived" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "archived", r.URL.Query(), &params.Archived); err != nil {
+		err = fmt.Errorf("invalid format for parameter archived: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "archived"})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort); err != nil {
+		err = fmt.Errorf("invalid format for parameter sort: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sort"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjects(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostProjects operation middleware
+func (siw *ServerInterfaceWrapper) PostProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostProjectsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "Idempotency-Key"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, valueList[0], &IdempotencyKey); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "Idempotency-Key"})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostProjects(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteProjectsProjectID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProjectsProjectID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteProjectsProjectIDParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-Match"})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteProjectsProjectID(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectID operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsProjectIDParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-None-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-None-Match"})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectID(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchProjectsProjectID operation middleware
+func (siw *ServerInterfaceWrapper) PatchProjectsProjectID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchProjectsProjectIDParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-Match"})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchProjectsProjectID(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDActivity operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsProjectIDActivityParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDActivity(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostProjectsProjectIDArchive operation middleware
+func (siw *ServerInterfaceWrapper) PostProjectsProjectIDArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostProjectsProjectIDArchive(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutProjectsProjectIDParent operation middleware
+func (siw *ServerInterfaceWrapper) PutProjectsProjectIDParent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutProjectsProjectIDParent(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutProjectsProjectIDPosition operation middleware
+func (siw *ServerInterfaceWrapper) PutProjectsProjectIDPosition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutProjectsProjectIDPosition(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDSections operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDSections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDSections(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostProjectsProjectIDSections operation middleware
+func (siw *ServerInterfaceWrapper) PostProjectsProjectIDSections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostProjectsProjectIDSections(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteProjectsProjectIDSectionsSectionID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	// ------------- Path parameter "sectionID" -------------
+	var sectionID string
+
+	if err := runtime.BindStyledParameter("simple", false, "sectionID", chi.URLParam(r, "sectionID"), &sectionID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sectionID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteProjectsProjectIDSectionsSectionID(w, r, projectID, sectionID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDSectionsSectionID operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	// ------------- Path parameter "sectionID" -------------
+	var sectionID string
+
+	if err := runtime.BindStyledParameter("simple", false, "sectionID", chi.URLParam(r, "sectionID"), &sectionID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sectionID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDSectionsSectionID(w, r, projectID, sectionID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchProjectsProjectIDSectionsSectionID operation middleware
+func (siw *ServerInterfaceWrapper) PatchProjectsProjectIDSectionsSectionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	// ------------- Path parameter "sectionID" -------------
+	var sectionID string
+
+	if err := runtime.BindStyledParameter("simple", false, "sectionID", chi.URLParam(r, "sectionID"), &sectionID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sectionID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchProjectsProjectIDSectionsSectionID(w, r, projectID, sectionID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutProjectsProjectIDSectionsSectionIDPosition operation middleware
+func (siw *ServerInterfaceWrapper) PutProjectsProjectIDSectionsSectionIDPosition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	// ------------- Path parameter "sectionID" -------------
+	var sectionID string
+
+	if err := runtime.BindStyledParameter("simple", false, "sectionID", chi.URLParam(r, "sectionID"), &sectionID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sectionID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutProjectsProjectIDSectionsSectionIDPosition(w, r, projectID, sectionID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDStats operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsProjectIDStatsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From); err != nil {
+		err = fmt.Errorf("invalid format for parameter from: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "from"})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To); err != nil {
+		err = fmt.Errorf("invalid format for parameter to: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "to"})
+		return
+	}
+
+	// ------------- Optional query parameter "bucket" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "bucket", r.URL.Query(), &params.Bucket); err != nil {
+		err = fmt.Errorf("invalid format for parameter bucket: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "bucket"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDStats(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDTasks operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsProjectIDTasksParams
+
+	// ------------- Optional query parameter "sort" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort); err != nil {
+		err = fmt.Errorf("invalid format for parameter sort: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sort"})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status); err != nil {
+		err = fmt.Errorf("invalid format for parameter status: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "status"})
+		return
+	}
+
+	// ------------- Optional query parameter "parentTaskID" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "parentTaskID", r.URL.Query(), &params.ParentTaskID); err != nil {
+		err = fmt.Errorf("invalid format for parameter parentTaskID: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "parentTaskID"})
+		return
+	}
+
+	// ------------- Optional query parameter "sectionID" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sectionID", r.URL.Query(), &params.SectionID); err != nil {
+		err = fmt.Errorf("invalid format for parameter sectionID: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sectionID"})
+		return
+	}
+
+	// ------------- Optional query parameter "root" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "root", r.URL.Query(), &params.Root); err != nil {
+		err = fmt.Errorf("invalid format for parameter root: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "root"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDTasks(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDTasksCompleted operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDTasksCompleted(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsProjectIDTasksCompletedParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From); err != nil {
+		err = fmt.Errorf("invalid format for parameter from: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "from"})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To); err != nil {
+		err = fmt.Errorf("invalid format for parameter to: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "to"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDTasksCompleted(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostProjectsProjectIDTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PostProjectsProjectIDTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostProjectsProjectIDTemplate(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetProjectsProjectIDTimesheet operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsProjectIDTimesheet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsProjectIDTimesheetParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From); err != nil {
+		err = fmt.Errorf("invalid format for parameter from: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "from"})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To); err != nil {
+		err = fmt.Errorf("invalid format for parameter to: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "to"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetProjectsProjectIDTimesheet(w, r, projectID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostProjectsProjectIDUnarchive operation middleware
+func (siw *ServerInterfaceWrapper) PostProjectsProjectIDUnarchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostProjectsProjectIDUnarchive(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutProjectsProjectIDWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) PutProjectsProjectIDWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "projectID" -------------
+	var projectID string
+
+	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutProjectsProjectIDWorkspace(w, r, projectID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostRedo operation middleware
+func (siw *ServerInterfaceWrapper) PostRedo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRedoParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Session-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Session-Id")]; found {
+		var XSessionID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "X-Session-Id"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "X-Session-Id", runtime.ParamLocationHeader, valueList[0], &XSessionID); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "X-Session-Id"})
+			return
+		}
+
+		params.XSessionID = XSessionID
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{"X-Session-Id"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostRedo(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasks operation middleware
+func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksParams
+
+	// ------------- Optional query parameter "sort" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort); err != nil {
+		err = fmt.Errorf("invalid format for parameter sort: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sort"})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status); err != nil {
+		err = fmt.Errorf("invalid format for parameter status: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "status"})
+		return
+	}
+
+	// ------------- Optional query parameter "parentTaskID" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "parentTaskID", r.URL.Query(), &params.ParentTaskID); err != nil {
+		err = fmt.Errorf("invalid format for parameter parentTaskID: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "parentTaskID"})
+		return
+	}
+
+	// ------------- Optional query parameter "sectionID" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sectionID", r.URL.Query(), &params.SectionID); err != nil {
+		err = fmt.Errorf("invalid format for parameter sectionID: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sectionID"})
+		return
+	}
+
+	// ------------- Optional query parameter "root" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "root", r.URL.Query(), &params.Root); err != nil {
+		err = fmt.Errorf("invalid format for parameter root: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "root"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasks(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTasks operation middleware
+func (siw *ServerInterfaceWrapper) PostTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTasksParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "Idempotency-Key"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, valueList[0], &IdempotencyKey); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "Idempotency-Key"})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTasks(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTasksBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTasksBatchParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "Idempotency-Key"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, valueList[0], &IdempotencyKey); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "Idempotency-Key"})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTasksBatch(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksCompleted operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksCompleted(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksCompletedParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From); err != nil {
+		err = fmt.Errorf("invalid format for parameter from: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "from"})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To); err != nil {
+		err = fmt.Errorf("invalid format for parameter to: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "to"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksCompleted(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTasksTaskID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTasksTaskID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTasksTaskIDParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-Match"})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTasksTaskID(w, r, taskID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksTaskID operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksTaskID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksTaskIDParams
+
+	// ------------- Optional query parameter "withSubtasks" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "withSubtasks", r.URL.Query(), &params.WithSubtasks); err != nil {
+		err = fmt.Errorf("invalid format for parameter withSubtasks: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "withSubtasks"})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-None-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-None-Match"})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksTaskID(w, r, taskID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchTasksTaskID operation middleware
+func (siw *ServerInterfaceWrapper) PatchTasksTaskID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchTasksTaskIDParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-Match"})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchTasksTaskID(w, r, taskID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksTaskIDActivity operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksTaskIDActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksTaskIDActivityParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksTaskIDActivity(w, r, taskID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTasksTaskIDIteration operation middleware
+func (siw *ServerInterfaceWrapper) PutTasksTaskIDIteration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTasksTaskIDIteration(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksTaskIDRevisions operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksTaskIDRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksTaskIDRevisions(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksTaskIDRevisionsRevision operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksTaskIDRevisionsRevision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	if err := runtime.BindStyledParameter("simple", false, "revision", chi.URLParam(r, "revision"), &revision); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "revision"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksTaskIDRevisionsRevision(w, r, taskID, revision)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTasksTaskIDRevisionsRevisionRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksTaskIDRevisionsRevisionRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	if err := runtime.BindStyledParameter("simple", false, "revision", chi.URLParam(r, "revision"), &revision); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "revision"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTasksTaskIDRevisionsRevisionRestore(w, r, taskID, revision)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTasksTaskIDSection operation middleware
+func (siw *ServerInterfaceWrapper) PutTasksTaskIDSection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTasksTaskIDSection(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchTasksTaskIDStatus operation middleware
+func (siw *ServerInterfaceWrapper) PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchTasksTaskIDStatusParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "If-Match"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "If-Match"})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchTasksTaskIDStatus(w, r, taskID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksTaskIDTimeEntries operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksTaskIDTimeEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksTaskIDTimeEntries(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTasksTaskIDTimeEntries operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksTaskIDTimeEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTasksTaskIDTimeEntries(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTasksTaskIDTimeTotals operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksTaskIDTimeTotals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTasksTaskIDTimeTotals(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTasksTaskIDTimer operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksTaskIDTimer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "taskID" -------------
+	var taskID string
+
+	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTasksTaskIDTimer(w, r, taskID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTemplates operation middleware
+func (siw *ServerInterfaceWrapper) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTemplates(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// GetTasksJSON200Response is a constructor method for a GetTasks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTasksJSON200Response(body []Task) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
-	}
+// PostTemplates operation middleware
+func (siw *ServerInterfaceWrapper) PostTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTemplates(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// PostTasksJSON201Response is a constructor method for a PostTasks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTasksJSON201Response(body Task) *Response {
-	return &Response{
-		body:        body,
-		Code:        201,
-		contentType: "application/json",
+// DeleteTemplatesTemplateID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTemplatesTemplateID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "templateID" -------------
+	var templateID string
+
+	if err := runtime.BindStyledParameter("simple", false, "templateID", chi.URLParam(r, "templateID"), &templateID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "templateID"})
+		return
 	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTemplatesTemplateID(w, r, templateID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// PostTasksJSON404Response is a constructor method for a PostTasks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTasksJSON404Response(body Project) *Response {
-	return &Response{
-		body:        body,
-		Code:        404,
-		contentType: "application/json",
+// GetTemplatesTemplateID operation middleware
+func (siw *ServerInterfaceWrapper) GetTemplatesTemplateID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "templateID" -------------
+	var templateID string
+
+	if err := runtime.BindStyledParameter("simple", false, "templateID", chi.URLParam(r, "templateID"), &templateID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "templateID"})
+		return
 	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTemplatesTemplateID(w, r, templateID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// DeleteTasksTaskIDJSON204Response is a constructor method for a DeleteTasksTaskID response.
-// A *Response is returned with the configured status code and content type from the spec.
-func DeleteTasksTaskIDJSON204Response(body Task) *Response {
-	return &Response{
-		body:        body,
-		Code:        204,
-		contentType: "application/json",
+// PostTemplatesTemplateIDInstantiate operation middleware
+func (siw *ServerInterfaceWrapper) PostTemplatesTemplateIDInstantiate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "templateID" -------------
+	var templateID string
+
+	if err := runtime.BindStyledParameter("simple", false, "templateID", chi.URLParam(r, "templateID"), &templateID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "templateID"})
+		return
 	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTemplatesTemplateIDInstantiate(w, r, templateID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// GetTasksTaskIDJSON200Response is a constructor method for a GetTasksTaskID response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTasksTaskIDJSON200Response(body Task) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+// DeleteTimeEntriesEntryID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTimeEntriesEntryID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "entryID" -------------
+	var entryID string
+
+	if err := runtime.BindStyledParameter("simple", false, "entryID", chi.URLParam(r, "entryID"), &entryID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "entryID"})
+		return
 	}
-}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get all projects
-	// (GET /projects)
-	GetProjects(w http.ResponseWriter, r *http.Request) *Response
-	// Create a project.
-	// (POST /projects)
-	PostProjects(w http.ResponseWriter, r *http.Request) *Response
-	// Delete a project. Also deletes the project's tasks.
-	// (DELETE /projects/{projectID})
-	DeleteProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string) *Response
-	// Get a single project
-	// (GET /projects/{projectID})
-	GetProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string) *Response
-	// Rename a project
-	// (PATCH /projects/{projectID})
-	PatchProjectsProjectID(w http.ResponseWriter, r *http.Request, projectID string) *Response
-	// Get all project's tasks.
-	// (GET /projects/{projectID}/tasks)
-	GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Request, projectID string) *Response
-	// Get all tasks
-	// (GET /tasks)
-	GetTasks(w http.ResponseWriter, r *http.Request) *Response
-	// Create a new task.
-	// (POST /tasks)
-	PostTasks(w http.ResponseWriter, r *http.Request) *Response
-	// Delete a task.
-	// (DELETE /tasks/{taskID})
-	DeleteTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string) *Response
-	// Get a single task.
-	// (GET /tasks/{taskID})
-	GetTasksTaskID(w http.ResponseWriter, r *http.Request, taskID string, params GetTasksTaskIDParams) *Response
-	// Update a task's status.
-	// (PATCH /tasks/{taskID}/status)
-	PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request, taskID string) *Response
-}
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler          ServerInterface
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTimeEntriesEntryID(w, r, entryID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// GetProjects operation middleware
-func (siw *ServerInterfaceWrapper) GetProjects(w http.ResponseWriter, r *http.Request) {
+// GetTimer operation middleware
+func (siw *ServerInterfaceWrapper) GetTimer(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetProjects(w, r)
+		resp := siw.Handler.GetTimer(w, r)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -371,12 +5058,14 @@ func (siw *ServerInterfaceWrapper) GetProjects(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
-// PostProjects operation middleware
-func (siw *ServerInterfaceWrapper) PostProjects(w http.ResponseWriter, r *http.Request) {
+// PostTimerStop operation middleware
+func (siw *ServerInterfaceWrapper) PostTimerStop(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostProjects(w, r)
+		resp := siw.Handler.PostTimerStop(w, r)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -389,20 +5078,14 @@ func (siw *ServerInterfaceWrapper) PostProjects(w http.ResponseWriter, r *http.R
 	handler(w, r.WithContext(ctx))
 }
 
-// DeleteProjectsProjectID operation middleware
-func (siw *ServerInterfaceWrapper) DeleteProjectsProjectID(w http.ResponseWriter, r *http.Request) {
+// GetTrash operation middleware
+func (siw *ServerInterfaceWrapper) GetTrash(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "projectID" -------------
-	var projectID string
-
-	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
-		return
-	}
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.DeleteProjectsProjectID(w, r, projectID)
+		resp := siw.Handler.GetTrash(w, r)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -415,20 +5098,22 @@ func (siw *ServerInterfaceWrapper) DeleteProjectsProjectID(w http.ResponseWriter
 	handler(w, r.WithContext(ctx))
 }
 
-// GetProjectsProjectID operation middleware
-func (siw *ServerInterfaceWrapper) GetProjectsProjectID(w http.ResponseWriter, r *http.Request) {
+// PostTrashItemIDRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostTrashItemIDRestore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "projectID" -------------
-	var projectID string
+	// ------------- Path parameter "itemID" -------------
+	var itemID string
 
-	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+	if err := runtime.BindStyledParameter("simple", false, "itemID", chi.URLParam(r, "itemID"), &itemID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "itemID"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetProjectsProjectID(w, r, projectID)
+		resp := siw.Handler.PostTrashItemIDRestore(w, r, itemID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -441,20 +5126,40 @@ func (siw *ServerInterfaceWrapper) GetProjectsProjectID(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
-// PatchProjectsProjectID operation middleware
-func (siw *ServerInterfaceWrapper) PatchProjectsProjectID(w http.ResponseWriter, r *http.Request) {
+// PostUndo operation middleware
+func (siw *ServerInterfaceWrapper) PostUndo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "projectID" -------------
-	var projectID string
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
 
-	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUndoParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Session-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Session-Id")]; found {
+		var XSessionID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "X-Session-Id"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "X-Session-Id", runtime.ParamLocationHeader, valueList[0], &XSessionID); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "X-Session-Id"})
+			return
+		}
+
+		params.XSessionID = XSessionID
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{"X-Session-Id"})
 		return
 	}
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PatchProjectsProjectID(w, r, projectID)
+		resp := siw.Handler.PostUndo(w, r, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -467,20 +5172,34 @@ func (siw *ServerInterfaceWrapper) PatchProjectsProjectID(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// GetProjectsProjectIDTasks operation middleware
-func (siw *ServerInterfaceWrapper) GetProjectsProjectIDTasks(w http.ResponseWriter, r *http.Request) {
+// GetWorkspaces operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspaces(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "projectID" -------------
-	var projectID string
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
 
-	if err := runtime.BindStyledParameter("simple", false, "projectID", chi.URLParam(r, "projectID"), &projectID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "projectID"})
-		return
-	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetWorkspaces(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostWorkspaces operation middleware
+func (siw *ServerInterfaceWrapper) PostWorkspaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetProjectsProjectIDTasks(w, r, projectID)
+		resp := siw.Handler.PostWorkspaces(w, r)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -493,12 +5212,22 @@ func (siw *ServerInterfaceWrapper) GetProjectsProjectIDTasks(w http.ResponseWrit
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTasks operation middleware
-func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Request) {
+// DeleteWorkspacesWorkspaceID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// ------------- Path parameter "workspaceID" -------------
+	var workspaceID string
+
+	if err := runtime.BindStyledParameter("simple", false, "workspaceID", chi.URLParam(r, "workspaceID"), &workspaceID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "workspaceID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTasks(w, r)
+		resp := siw.Handler.DeleteWorkspacesWorkspaceID(w, r, workspaceID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -511,12 +5240,22 @@ func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Reque
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTasks operation middleware
-func (siw *ServerInterfaceWrapper) PostTasks(w http.ResponseWriter, r *http.Request) {
+// GetWorkspacesWorkspaceID operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// ------------- Path parameter "workspaceID" -------------
+	var workspaceID string
+
+	if err := runtime.BindStyledParameter("simple", false, "workspaceID", chi.URLParam(r, "workspaceID"), &workspaceID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "workspaceID"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTasks(w, r)
+		resp := siw.Handler.GetWorkspacesWorkspaceID(w, r, workspaceID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -529,20 +5268,22 @@ func (siw *ServerInterfaceWrapper) PostTasks(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
-// DeleteTasksTaskID operation middleware
-func (siw *ServerInterfaceWrapper) DeleteTasksTaskID(w http.ResponseWriter, r *http.Request) {
+// PatchWorkspacesWorkspaceID operation middleware
+func (siw *ServerInterfaceWrapper) PatchWorkspacesWorkspaceID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "taskID" -------------
-	var taskID string
+	// ------------- Path parameter "workspaceID" -------------
+	var workspaceID string
 
-	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+	if err := runtime.BindStyledParameter("simple", false, "workspaceID", chi.URLParam(r, "workspaceID"), &workspaceID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "workspaceID"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.DeleteTasksTaskID(w, r, taskID)
+		resp := siw.Handler.PatchWorkspacesWorkspaceID(w, r, workspaceID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -555,31 +5296,57 @@ func (siw *ServerInterfaceWrapper) DeleteTasksTaskID(w http.ResponseWriter, r *h
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTasksTaskID operation middleware
-func (siw *ServerInterfaceWrapper) GetTasksTaskID(w http.ResponseWriter, r *http.Request) {
+// GetWorkspacesWorkspaceIDProjects operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspacesWorkspaceIDProjects(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "taskID" -------------
-	var taskID string
+	// ------------- Path parameter "workspaceID" -------------
+	var workspaceID string
 
-	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+	if err := runtime.BindStyledParameter("simple", false, "workspaceID", chi.URLParam(r, "workspaceID"), &workspaceID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "workspaceID"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTasksTaskIDParams
+	var params GetWorkspacesWorkspaceIDProjectsParams
 
-	// ------------- Optional query parameter "withSubtasks" -------------
+	// ------------- Optional query parameter "archived" -------------
 
-	if err := runtime.BindQueryParameter("form", true, false, "withSubtasks", r.URL.Query(), &params.WithSubtasks); err != nil {
-		err = fmt.Errorf("invalid format for parameter withSubtasks: %w", err)
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "withSubtasks"})
+	if err := runtime.BindQueryParameter("form", true, false, "archived", r.URL.Query(), &params.Archived); err != nil {
+		err = fmt.Errorf("invalid format for parameter archived: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "archived"})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort); err != nil {
+		err = fmt.Errorf("invalid format for parameter sort: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sort"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
 		return
 	}
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTasksTaskID(w, r, taskID, params)
+		resp := siw.Handler.GetWorkspacesWorkspaceIDProjects(w, r, workspaceID, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -592,20 +5359,22 @@ func (siw *ServerInterfaceWrapper) GetTasksTaskID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// PatchTasksTaskIDStatus operation middleware
-func (siw *ServerInterfaceWrapper) PatchTasksTaskIDStatus(w http.ResponseWriter, r *http.Request) {
+// GetWorkspacesWorkspaceIDStats operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspacesWorkspaceIDStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "taskID" -------------
-	var taskID string
+	// ------------- Path parameter "workspaceID" -------------
+	var workspaceID string
 
-	if err := runtime.BindStyledParameter("simple", false, "taskID", chi.URLParam(r, "taskID"), &taskID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "taskID"})
+	if err := runtime.BindStyledParameter("simple", false, "workspaceID", chi.URLParam(r, "workspaceID"), &workspaceID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "workspaceID"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, APITokenScopes, []string{""})
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PatchTasksTaskIDStatus(w, r, taskID)
+		resp := siw.Handler.GetWorkspacesWorkspaceIDStats(w, r, workspaceID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -733,17 +5502,80 @@ func Handler(si ServerInterface, opts ...ServerOption) http.Handler {
 	}
 
 	r.Route(options.BaseURL, func(r chi.Router) {
+		r.Get("/.well-known/jwks.json", wrapper.GetWellKnownJwksJSON)
+		r.Post("/auth/revoke", wrapper.PostAuthRevoke)
+		r.Post("/auth/token", wrapper.PostAuthToken)
+		r.Get("/iterations", wrapper.GetIterations)
+		r.Post("/iterations", wrapper.PostIterations)
+		r.Delete("/iterations/{iterationID}", wrapper.DeleteIterationsIterationID)
+		r.Get("/iterations/{iterationID}", wrapper.GetIterationsIterationID)
+		r.Patch("/iterations/{iterationID}", wrapper.PatchIterationsIterationID)
+		r.Post("/iterations/{iterationID}/close", wrapper.PostIterationsIterationIDClose)
+		r.Post("/iterations/{iterationID}/roll-over", wrapper.PostIterationsIterationIDRollOver)
+		r.Get("/iterations/{iterationID}/tasks", wrapper.GetIterationsIterationIDTasks)
+		r.Get("/me", wrapper.GetMe)
+		r.Get("/me/tokens", wrapper.GetMeTokens)
+		r.Post("/me/tokens", wrapper.PostMeTokens)
+		r.Delete("/me/tokens/{tokenID}", wrapper.DeleteMeTokensTokenID)
 		r.Get("/projects", wrapper.GetProjects)
 		r.Post("/projects", wrapper.PostProjects)
 		r.Delete("/projects/{projectID}", wrapper.DeleteProjectsProjectID)
 		r.Get("/projects/{projectID}", wrapper.GetProjectsProjectID)
 		r.Patch("/projects/{projectID}", wrapper.PatchProjectsProjectID)
+		r.Get("/projects/{projectID}/activity", wrapper.GetProjectsProjectIDActivity)
+		r.Post("/projects/{projectID}/archive", wrapper.PostProjectsProjectIDArchive)
+		r.Put("/projects/{projectID}/parent", wrapper.PutProjectsProjectIDParent)
+		r.Put("/projects/{projectID}/position", wrapper.PutProjectsProjectIDPosition)
+		r.Get("/projects/{projectID}/sections", wrapper.GetProjectsProjectIDSections)
+		r.Post("/projects/{projectID}/sections", wrapper.PostProjectsProjectIDSections)
+		r.Delete("/projects/{projectID}/sections/{sectionID}", wrapper.DeleteProjectsProjectIDSectionsSectionID)
+		r.Get("/projects/{projectID}/sections/{sectionID}", wrapper.GetProjectsProjectIDSectionsSectionID)
+		r.Patch("/projects/{projectID}/sections/{sectionID}", wrapper.PatchProjectsProjectIDSectionsSectionID)
+		r.Put("/projects/{projectID}/sections/{sectionID}/position", wrapper.PutProjectsProjectIDSectionsSectionIDPosition)
+		r.Get("/projects/{projectID}/stats", wrapper.GetProjectsProjectIDStats)
 		r.Get("/projects/{projectID}/tasks", wrapper.GetProjectsProjectIDTasks)
+		r.Get("/projects/{projectID}/tasks/completed", wrapper.GetProjectsProjectIDTasksCompleted)
+		r.Post("/projects/{projectID}/template", wrapper.PostProjectsProjectIDTemplate)
+		r.Get("/projects/{projectID}/timesheet", wrapper.GetProjectsProjectIDTimesheet)
+		r.Post("/projects/{projectID}/unarchive", wrapper.PostProjectsProjectIDUnarchive)
+		r.Put("/projects/{projectID}/workspace", wrapper.PutProjectsProjectIDWorkspace)
+		r.Post("/redo", wrapper.PostRedo)
 		r.Get("/tasks", wrapper.GetTasks)
 		r.Post("/tasks", wrapper.PostTasks)
+		r.Post("/tasks/batch", wrapper.PostTasksBatch)
+		r.Get("/tasks/completed", wrapper.GetTasksCompleted)
 		r.Delete("/tasks/{taskID}", wrapper.DeleteTasksTaskID)
 		r.Get("/tasks/{taskID}", wrapper.GetTasksTaskID)
+		r.Patch("/tasks/{taskID}", wrapper.PatchTasksTaskID)
+		r.Get("/tasks/{taskID}/activity", wrapper.GetTasksTaskIDActivity)
+		r.Put("/tasks/{taskID}/iteration", wrapper.PutTasksTaskIDIteration)
+		r.Get("/tasks/{taskID}/revisions", wrapper.GetTasksTaskIDRevisions)
+		r.Get("/tasks/{taskID}/revisions/{revision}", wrapper.GetTasksTaskIDRevisionsRevision)
+		r.Post("/tasks/{taskID}/revisions/{revision}/restore", wrapper.PostTasksTaskIDRevisionsRevisionRestore)
+		r.Put("/tasks/{taskID}/section", wrapper.PutTasksTaskIDSection)
 		r.Patch("/tasks/{taskID}/status", wrapper.PatchTasksTaskIDStatus)
+		r.Get("/tasks/{taskID}/time-entries", wrapper.GetTasksTaskIDTimeEntries)
+		r.Post("/tasks/{taskID}/time-entries", wrapper.PostTasksTaskIDTimeEntries)
+		r.Get("/tasks/{taskID}/time-totals", wrapper.GetTasksTaskIDTimeTotals)
+		r.Post("/tasks/{taskID}/timer", wrapper.PostTasksTaskIDTimer)
+		r.Get("/templates", wrapper.GetTemplates)
+		r.Post("/templates", wrapper.PostTemplates)
+		r.Delete("/templates/{templateID}", wrapper.DeleteTemplatesTemplateID)
+		r.Get("/templates/{templateID}", wrapper.GetTemplatesTemplateID)
+		r.Post("/templates/{templateID}/instantiate", wrapper.PostTemplatesTemplateIDInstantiate)
+		r.Delete("/time-entries/{entryID}", wrapper.DeleteTimeEntriesEntryID)
+		r.Get("/timer", wrapper.GetTimer)
+		r.Post("/timer/stop", wrapper.PostTimerStop)
+		r.Get("/trash", wrapper.GetTrash)
+		r.Post("/trash/{itemID}/restore", wrapper.PostTrashItemIDRestore)
+		r.Post("/undo", wrapper.PostUndo)
+		r.Get("/workspaces", wrapper.GetWorkspaces)
+		r.Post("/workspaces", wrapper.PostWorkspaces)
+		r.Delete("/workspaces/{workspaceID}", wrapper.DeleteWorkspacesWorkspaceID)
+		r.Get("/workspaces/{workspaceID}", wrapper.GetWorkspacesWorkspaceID)
+		r.Patch("/workspaces/{workspaceID}", wrapper.PatchWorkspacesWorkspaceID)
+		r.Get("/workspaces/{workspaceID}/projects", wrapper.GetWorkspacesWorkspaceIDProjects)
+		r.Get("/workspaces/{workspaceID}/stats", wrapper.GetWorkspacesWorkspaceIDStats)
 	})
 	return r
 }
@@ -768,25 +5600,251 @@ func WithErrorHandler(handler func(w http.ResponseWriter, r *http.Request, err e
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
-	"H4sIAAAAAAAC/8xYwW7jNhD9FYItsBfV9nZzqW5uAxQB2iLYpKfFHhhpZHMjkQo5SmoE+veCI1FSLMmW",
-	"Hdu7N9skZ4bz3sw885VHOsu1AoWWh6/cRmvIBH28NfobROg+5kbnYFACLUQGBEK8pKUYbGRkjlIrHvL7",
-	"NTBallqxWCAwnTBcA8srYzMe8ESbTCAPuVv/BWUGPOC4yYGH3KKRasXLgMu4b/1fJZ8KYDIGhTKRYFii",
-	"zaj5opDxkGUlMujb/kdkQ8FunS6bX/QDJacM+L2wj6dJEgr7eK4M9Wwfnx5vqnc0FwYUunzcXPdN3Fw3",
-	"+aV9ZCdgMmESGfwnLdpJAdbo7HFRbWriZQ+QarWyDPUkJxYFFgTkzwYSHvKf5m2hzOsqmbur3lU73Zni",
-	"wbmiUxIhm3Sct5QSxojNOMfumpAG6FQYSmkV9jZQoIqMh194Dip29wuo6FNAiPnXPscdt1Si+56WDHWs",
-	"WSotsuXtDcO1QJYJJVZgfcYtEyomz5a9SFwznxVijMTUubrXsY5QCsUD/gzGVuY/zhazhbutzkGJXPKQ",
-	"f5otZp+4Yxau6epz78Z9WcFAdX0GNBKegYkqUJ0wkaZNeC4MV6dUfTcxD/mfgLfeaMAN2FwrWxXxr4sF",
-	"1bJWCIp8iTxPZUSH59+sc+h75mTUfV/tA18GW3f5a/ACJXEty4TZVOG/WacS0XYgM8s4ZoIpeGmrQ1c8",
-	"8aj2k3Or7dvsPBVg8Xcdbw5KzNveeNoO7H5ycUkDMQ/RFFD2YPx4ULST0OujVS+xuvUzW0QRWJsUabqZ",
-	"OViuFr/1r+1PuaQwaZlIDYh4w1A8gtoG+w8yzUSbILfeFMX8tWmOZeXJFflQjWT6uWOGalaires2MTrb",
-	"R4xrMu2pcdv0ZJoCIgMEY3n45ZVL59DVL/ezpdPBt5ELOijs6dHl1x7KV5dEucrtIMpXO1DWyBJdqHgb",
-	"2SqfHWTZMrW69mK7RfGhhomc7WuBHuCHDeF7c72z/+2AsT9zesPWmb4g2otLoL1kVqpV2rnj0QhTo94y",
-	"V6kmjNYDUi4naShUpY2kWnUI4FI70Kydpe9ekuebEI51bnxRqxyQ/icZFYtLNpGCQH5PEznFVPkMtE20",
-	"rBwbKvNG4B6ivaqpUilwx2PUTLAIDAqpuvjt70v35P57zpgzCMKRvwGjanBsFryjLbX6sWPRkeB4vAcB",
-	"9fj9OEldDkU+nKEqFxPkNf3hJJL78SvVFKHdZue4Hro/H5fVyq3PrT7u8rNDJV9Ux40WRqO1PaTdkpi/",
-	"Ij1zTJPZRIjpqpp4UD+jTOl16Lf+mGJ6Jw0Ok9F0ZL+GrtHaL4+rp6Gd2ngnGLtUsX99OQ9gQc2EpwLM",
-	"prX8InF959+huvbq8w9apyDUmQX1GOKNmm4AOgLlNzp6tC7n7QveiMD+W5hHTwFhWfMkxrRx3+vHshGV",
-	"3WFF/S53yUI9hcQ+/IXzHXp6AN/6qfIwBbybGf4vE2H6wdYu3K6y/D8AAP//LiMOLekYAAA=",
+	"H4sIAAAAAAAC/+y973IbN7Yg/ioo3l/VJL9tUXTi5GZ0az9obGeuZpzEZcmTWxW7hhAbFBE1AQ6Alszr",
+	"0kvs1/2wr7iPsIWD/93oZlOWKCpWVSqmyG7gADjn4Pw/n0YzvlxxRpiSo6NPowXBJRHw8TVll/rfksiZ",
+	"oCtFORsdjabv68nk21ktKvhA/gMJUv3P9yNGPqr3oym6pmqB1IKgd29fIz6Hj/o3tMIXpECcVWskiUIU",
+	"fhIEUYlweGL8no2KkZwtyBLrydV6RUZHI6kEZRejm5ubYrTCAi+JslC+qIXkog3nrzC4nl0Pi6TCQskC",
+	"YYku6BVhiDL4capXOUVm2Q7elSBXlNfSQIR+WVKFqEKKowui4Ik5FdICjI7RDGDQK4HlXeGKlmEjJBfw",
+	"+jWWaIlLAr+YdVIN6b9qItajYsTwUi/VDNa7CcXoNV1S1V70T/gjXdZLxOrluVkOVWQp3WIB3o5pKxgx",
+	"nrUkc1xXanT0bDIpRksz9OjoO/iLMvPXs8JBR5kiF0QAeG+wIEydYXl58vJHWimSOaBf9FZVVJoNLakg",
+	"M4Vkfa6wvJTmJKhE+q8ukFfRLAnkcy6WWI2ORnVNy1GR2b83RFBe/ij4sg3YqUYVjwrwYIEom1W1pFek",
+	"MAdLlUSKLgn6b85Igcj4Yoym30y++f7g2eRg8uxsMjmC/w4m3x5NJtOuJcw1BMVIkH/VVJBydKRETbJL",
+	"KbEiB3rGnvWc8fZqXrGyuRbysWctXZAqfhdwvuVcDUMIgwbY/CE4dydCBVoJ/juZqS5I9cM58jnnvCKY",
+	"ARynZKZnHYqdML+BCEiJSiTNCF1ASDfBloipsfmUiwxtnwHbIVWp+RDwlLBN5+sCrQSZ04+kROdrND2Y",
+	"ojkXSI9AWEnZBeKiJKITWj1jlvZHM0GwIuWx/p0wTfK/Jd8dxH/AcMXowP4Lc+q/3QepsKql/sZ++tC5",
+	"B/D7Nqhi+a0+Gni3QNOVWfsUcYGm+p6riCJlJzF66HqvH/Mj3D3Hb07O+CVh+vNK8BURihL4JWzKQNoo",
+	"RrQcgCHFqMJSvZNu6NadZxi90lDBhaMfR7UkgDW4VgvCFJ1hRQrE6qrS1zBViJErIvTzemuy4OqH8XlF",
+	"HOW34DJ72EJaUlUygAR8HWt4BMIrLJTlnPqBklzRGdHQUGkgNtTVmkmQK345eAPs08lypaJVleyGBCao",
+	"4brtDtzE3PG3ERyeJYOADAHd+blmYXo5xzNFr6hav7oiTLUxCc/M2nLs4JIa5j5bYGZu9gaFAsvWUJhP",
+	"QIbwecmv4F+D8v80A+gvypr8E6voCyIVXdqxVhVmzDxGgJRgVKm4GRSL2YKacWsW/7EqYYAPmcPEM5WT",
+	"3vTqNNzu6orPqjT4c73gRpzSvxt4CyB0zDhbL3ktDQ80P1nR65zMuSAwgETkI5WKlEYUa0M2z7Kef+Cq",
+	"JtLBZffJMGaJ4J0EIMA6w4phx4xANu7Gp4AYBtYtIbALzIJgkWILEBI21kFqZhov3XbSTwfDa0grmggk",
+	"woJYGVaDa+YB5NWf1uiaCIIEmemvymRCytT3z0dtmbQYWaHh5GUe2ezPSC2wkdTtvgJKqegBPvd3Tuvh",
+	"BJQuBq65BJFZQE5euuHtQ2aGFpZ7JeZ8bZSY/zp4a144OCmn/mWn1Ah0QRgRcPb2DUnEFRFj9ALLGS5J",
+	"6alELrBFHw8MFW7EDkpRRgLP7mt2n2KctPPyebLLVElSzXP7ufEi0rTdBc1QNtICbwPz2BLIm55r4A2+",
+	"IO1bgFw5BR2IV3/4/wSZj45G/3YYFPhDK5scpndKmA4Lgdf6b61vd2nO5vtEd/+KVyURX1sdHnaHG6oE",
+	"+cLplbdY9gvLYSJJClfVL/PR0W8blujeuCmamyXJTJAOCdr8BiI0YZ63TI9rteCC/jfWjx6hvxAsiHBm",
+	"gTE6UV65N4S3IIJkqaEhBlhQ2vf+h5ti9Mpere8YVanQveJUH3fR4rlWH3KXsozZkRWuHAnNeM1UYQga",
+	"6B5XlX18GYsKfq4lZbUiMntN/6hvl1dCGHxJtxtunvZu/xxd35QZmwg8CvzLbrxjVee8XANQH7GW0kdH",
+	"TnZqQbIkUloSyWwOlehacHYRrC8wZTr0spYKMa7QOUEVZxdwrWCGvplMNMELPFNEyI1na9YdIMoJdyeK",
+	"COwEuIaKUHFJypx+Wtjfeq9d6kY2jBVeKKx8S90Xtxfnb6HAEFbKgSDrRyM7xLYyw8Y71ikjrR+MGXCb",
+	"VV2Ris+oWm9iuf9wz/VpAn56v1tFokxbnOjFpHcgTWeuCFZutbA726McW//br39vI8IxenPwzXffo1V9",
+	"XtEZuiRra4C6XtDZAr061T9aPRELgq6IoHNqsLihFFUXWdBn4ir7/SUt89+rdfb7Wua35mP223XeRhsj",
+	"gZ7IgKcH0a8YoApYipnwQ34fT9tHfUnWw2UBfRQtCaAJnh4wN//P5LrbyNGBQY2x4amOsXvY40Oicwv8",
+	"HOV2LMlaFrt3K6WJd4z+qzYuAWekD9bN22/sGV2SV0yJdRuOsjZbfkpmnJWyDdJ/8mu4FgGaay4ukeL8",
+	"UpvApSIYDA5TwkrNssCU5n0Bk5zeZZ/suRZgCnhsjF5RtSAijA8KfQPiqb7f3EYMvzoYN1xziT++JuxC",
+	"LcCxMSk6MGabu68p9Pn3O07nVy4u5QrPyJZYgpfxscAI8jPw5I3g5xXJeECOGSJCcAGSmvnp3MiQb398",
+	"gf78/Lt/R1/Zl9FLojCtJGhL/3l29gYdvzmRX4/Rqysi1mYYJIhccSYJWmAtRTu/A0jeeLWqtD5GOTtc",
+	"mTH/x++SsymacaYIU0jDbWTtBiLDzB2C4LV+04iCzmLPZ7NaCMJmXiwF6FLZ8I2Vnt+P/ir4jAhK5PsR",
+	"wpUguFwbpU9mhR89lMzrHIn0KxsqfgE7N60FO1K85DNFMTuyG3EE78HeTA2wEglMZdDjwwN6WH3SEmFW",
+	"WrVbECKto3HITREJ+RmV0VrHsyuEgzcPoBkvSVijOfhkj59P/pxjFIqqKoP6pwsuFJL1conF2o3rDJ8W",
+	"S/VXUqsbeitJhHjei7hepTCM3hLJazEjA47WfNGy1ZSEKS2eyBxExh+YP1TG1cGc16ycgmJZciJBG7GG",
+	"tHOirglhSJCKYElkgSRHs4rqo0IzDD+stfpNFRJYLZz2YhVyewrWamVoxOBAWHweLrsTB34ngpgt6EYu",
+	"A7+6U/TY0sF24GPb1G1Nxr23hVNvtdbjXoj0HvfVZ2g+vMpaRfTXDTMV8EeMFuQjgrdSFPu3+fyHHyaT",
+	"rbWtNnnBz5rG9UIaMAy/AK0FevDugo8AKQ4/KIHlItpnysLXt9/rBIomUD8KQpDS5id8zmuVbjxZrtQ6",
+	"CSJh1nndmoQ0zCx9LDAxyWh9c5aD7NWS/041iUlgTgCjXPBrZqxldsti/0V0WBs3hZadAgB1TEcAq+tC",
+	"g01qcbehplv4dN7c1utvuKTuAkpMuNZASCUYze3Xmp0pLJR2SevgBzTRc2nGo326jT2JzfcQ6/Gm24gf",
+	"bOfBlA82OxthJOvzg2DDB0zGbD1Gp0QZaWT65t0ZOnRgHn7yDoObQzP5dJitt2Mtqbn81Nxnm9DxLHoU",
+	"bBFCZknlhM0EWRKmwGtqr0B7n7RPdvNue/Gyf6f9Y8m+nxOtPUik+Ha77Ee7m42+6b56THhS+wLaiGSJ",
+	"p0j7DS7TpbfRjIvgTHAcantvQXzRNoHsuWJ1EIXM3LNXROAL8mI9q4hWFDs1wWPzYBROJs2jhnRVfDVZ",
+	"pmejLCJ+YEzT9vvgzjPBSGP0s3XLMw5PGmume7iBCCWvz6uei8XACb7TenZpXADOzF3i9agYXRNymTVw",
+	"+ykz/NGvvn8pWbfj3IaYDbuitZtFql9WhGnK7xC2n02QDWxBUaxc5KFcEeavB02K4EikS1IgM76JXhwP",
+	"VQs0KFmFALSjbrefdA7nItoyrZwAgJQhgmcLZE6qHW4XBSwNARJQ/Y12ZeRAVfyWqryNz4PgN4tTfuEx",
+	"0hSdRNU+1B6C7TEM9LLks5QZc5Dd0suQB16kSVVzLqqQlqsgSlSG9z+fRcWw5lb7lswFkQswK74lV3zW",
+	"YQMU0XObDY3J09lpeVX9ckUyLiwa2yH70CwYLO1F3ov/xu9NBDGy9GdSXGO5AWYHSW7NnebI+4tQ67TE",
+	"dgiQcKk2hEgbPqn5gMZNL/ZnZMcNoR6bQy5bzprwvnfcuADG/lAuu9ldHpqOEDlwsl8n6oJdfoHqezAP",
+	"R6yyjRWfcQ0a/pg/ErtvA0Z1MUqbxySs7A13Nu/eys2ob6jNsOqnXIQyac6bhxnwd0PIuYPbh5wPhTtn",
+	"hjb+ioC5jVsLFppDEmBBbfRY0Kp8oQML+nYnG8tveOIYQeguLK20scp/fXWGDuHJw08mlOhmWngBRn8D",
+	"UoPmA25IIxdu1mP8UvuDRVtyZ+xId98NtnJsVABvY+1xeRB3Z+rxq96Fnacmm2GhEpU1cXMvwLRW1gS2",
+	"4fZTk/lcM9Mr4kw7+U13RqJ4vxEXMTQMPBf6R1kv3XN++DQmJ0HWoOMQau21dgLGRfKoERbcSOWdUstA",
+	"NI0ZbOeOaRfhsp4trHvQTaylSlk4nPFbWjPqpUx3ffk9SVCRcRWv/q8Qa6U4wvYRbUFwqypAfr0iQtDS",
+	"GOGpCIdg1hy7JjswJVrvdoa3FkV2yUNeUOs3pvjHEoqwIdeIsk5jSooMh36cu7JXbbYauq1ovZpkaPWs",
+	"3TwH4ziKM16IQRu8EvxCEClzJmQTPu/myV1Jhb7CMVujkqzUojBCOxYk4vwGWeHgfaqJ1pwChd8hnfrl",
+	"ziuO1SjKvHvWj9AdBxmMIqvtrKcWymDOGxjevMSUUXahddkOYT8Sdtr80xwANcyg5IygNVFHKUeJmfT1",
+	"glYubWTlk53yjNsDZxhXi1E/KLsNKWM9x2MfarDThGXErAIWFGWvabymzI0iN/ES+9xdcZLgQt5o9DZP",
+	"6nfsrg6Od+oyV9kslGEyEYQ156L6Y9lj43o/x17veOomvLnpEN7/gtVs0Zbg+creDtttKIz2yyoyfizx",
+	"xxPzdhJFk7dVRLN+6IM3zJAJWxogQipuFUgrTN55OlvDrZdO6nNFmuq8e8zkYuWda6v8bH7nnL+hneXl",
+	"k7xGxUgS9U+fxqgFe5+rlbV699/PZ+Fmzq5XL1fPUWhOqVmUQIosV1xgof8eo7OIK+HAhlqeQqdsLKmU",
+	"lF3kGWju2h/ip8mCPkYvTbC9dIpP4/FIIskzvzthbpszZxzSFMjwrwJ2HPmEsiO4Bk5eetyLT8AkBMBA",
+	"zq5CsKgoEd6+oqmuK62HLFdZ4Boz5AghCuutsCIi4LGXvWBqG9AyJyYuiyp7ZYV0tejNIQkXfDWQw7wl",
+	"EnIt2gxyIyG6bCaX7pb18A6+prp2OXOW3uFGykRWXmB9jZDxsNhsvxdR/N+wnBv3QjvlhrKSfNwcG9DY",
+	"xDmmVWTr0zBFJl6sugy8A8/IDD9oVz7E+9KFGgK+/5yL046cM+1nj6knPrpXqzxL9UkOamQkJLJNziD/",
+	"8uc7g2JAu0jzLbmiMrvMvvRnKzDBWa8EL+sZKa2Yb4aLM42wTYlDCyoVF+ss4d5rvquIFpmO7ZbfSHwF",
+	"H8cz4IXgKm2o2RFBDGc4XZjW6SdynplhfhujEPkw5UTZ9k6NphOndRdHxnT7UsL6h+lMZ+HtxCNqr6pN",
+	"FBDV9/g8/A8gdmH/aU9krQlZVj6sM1UOfA6f0XsT435O3jtLQ4+aEY41Uz0ZhQWqCL4C9bqOC28kJuKG",
+	"Cn2tqca+b7B7TtRsQWx5JKNeu9+noEqn38URQtNcFPggj5V/yICcJ6Juc+exxhKfqWAfK5sheIXzViaG",
+	"z6MtLMZBUe/Cd3fQPWtNYkPGHaOIGWHqJWeZxb4xP+JUFZUZu1iBhA5jJiUq+TUbo0mIx9TPMR5gCDas",
+	"pHpUNmNkWwtSn92oQHRMxu7RdGvQcWRV1tuvAzj9aDNDDXNrob9e8MrYDwXRB6to1XIQdZyZPtWOcJ6m",
+	"R7Fd7ihCLr0QZ3nEiYKSnVZxhavNU7amAdLVu+TtWaOs1aFpmEiCwWHuIsuXUvSLtyciwSYWZFknWa6q",
+	"rKP9Vg42O9rn1a/ocRfkxr99oG48XFb/2EJMtUN1Gc6usKD6upN5iAIKVXhGFlApwBbwOV8nsP5JBobg",
+	"IetIt+iVjO14J0wqzHLBUquQYrBBn3Fj3oWNcTOsinZI80McJZgZp4jjYOAYK8u4DBip+PUYvTWUKNHU",
+	"353ToY6UfgdBDEIUB5tCMdBRYN//eWhMemy8+QmvIT0MU5ag3Rj9VKsaV9U6RH1YSSPairy5I0FzXJYg",
+	"1+LqTXJO/RkMjdI8Ed4nQI5aeNKHOflIjLImv8znkqiXeN17sZR4LX1OkanFEGFiE1STPLYg3vGeBm+c",
+	"Je4vrF/XMKAFvoL73r3Va8G7vcfVATnY9TrQdZng0/TTJ4cKNzfTrpMLc2zvoOjlt1lU6M7mHVg/y5e6",
+	"kSuQGhbERQQnBbOyC9yYMHxmRzODF0Z0IqWVkhi/hhMUNQPPm35SyHE2rrvlstwuexjctMYf6FYIVVnt",
+	"1Lf3BgyNfOSqqzCJNheUXRlKFr7sKn3ACNEIYCzpZmHeDqkFXqn4akVKtE4iz6KSIlsnNcfW6Y0r7yu6",
+	"1Il7PjFCL432VllCr7QBBEYSNQNBecmlgvQesxtaOYGPt63I1AoDVa6wrF2cK1YX72XA0DadhHO1mPGh",
+	"g7TPtLScydPg1z00p+lNCTy7NC7EYNtrV87qJi+Q07efQ18StppQ4vgeMmnTXn8d7VcCTtduiVMXQ5nu",
+	"1tD8/i4GKxeEZIbdLoFjm9DjYiT49UZ2qoVosP4B8ulj8LbAAsrAWjlbn4vzLg67htya3/Lrz8uZaOPR",
+	"tlgQR1zHKRewQQPxwq9lmzvSmdead2Vw5eofHPulxEboGUaVtyHLLbZhKx6rH/25K7b+vhnwZ7NUz039",
+	"MnKMte+MTbYIFE3InPGK+jSR9g4cvzmxJWEV15qBLfPnBMspXtF/wu9TdCEwy+e/wi9n8G1IK/NvjgqX",
+	"gGL/ztlfmwktbUjtE73QJvN0Q9w4gAB+z+6acg05EpoRKbsScXTW+4oKIk96o+bt6Vp3jB7QLpNKWzN/",
+	"bnLoczbBvo179dFXQeUCoi9gXGnMz63pLLBQfUH7n8+Jqzc867Cn6NeaJ29qBI4+bNr3eOsaC4lHjvcw",
+	"e0DavK4jbjK64IBob30ldER7f57FK3imLRghkkLcpaKWGfXOwk39BrlM8cFwf3ZIpfGXSKJCeOmgifNF",
+	"SWJ9ISwo2jrcdh2ZHy1jzqNzCxnfsZKfKrLazl/r3fNb+2U3Zto5+rcWx3QmqBlsPBLXn5+D194MmUsp",
+	"3Hl+3a0LkP8jqnDYobkmuV6YhfCAAi0JlrVwrj7b5sRWn0SvvIdLb78u4qP5AxHgGSuQpCytHdDwPi7x",
+	"OnbvkCUq6RxihVS17nUGen9L+ypxZU7bSViRj65ZX7XhQIwLWkQOQcqQHb3D72ZKrd7PzGbwTmdNwIzG",
+	"JhV9FWADlvSkJj8eVPeL6KqMYKsHWcdABwatol87vbDujChLS2XEHjb9g5sScdaFNp77DS4Vklev5Kho",
+	"r7Avedhv1+dntCbZ5bdKXDWqVS2oWp/qNW+S+4/RigipTfhB/C98+JyNef/l9AwdLskh/Cqh3GAoTWeK",
+	"pFtFyLyoq9Itl5iZuvAY/e3Xs6YoK2ubOmAG1yXHzfDTqLcVGOeMAOkXulBqZVqMUDbnuQUpXnJTUAdW",
+	"ZGrDM3xBZMA3V/1NptlS4/fsPXtlSshhQZAgK2M7wPAulPIrbSm/r1yVv69vUagPgn6n+uPUR4/A6LSn",
+	"YBpAbZqQmJtcHml4D4bUxfvq+WTy9VFSTZrqq6PS3EXTlXAF+Ip2LWqzGRXouJpUpwCOnHrNwQgOl4xf",
+	"98FTs6SyPAD17OujTG1x32oshDsDKlElIy2VSg8mF66FSc/8oaScnvm53w5T487iqvtTX9I+5FYWoQAd",
+	"6Po9syyJWvDyQE+Gq4pf24V+15guDCjr1co1KjIv94yelp+Dkf9sRwZGokVZ8wiEa/Udx4yzeUVnKhkk",
+	"BIfOMLPVv8HeYTl0HGkVFzCENcmoUY0PWw/V5npgWQnQesGbeGAiUjVYz75p7lrUH8EKRlaYshFTPXPQ",
+	"kixXXBE2Wx9ckvWBILU003zjpokeQZe6hQYO26kfBvUDB/nK0dLQlTkGDpP+4FD/ZH7wkw6AzWB933KY",
+	"IoLhaoq++g5oGzNUM/JxRWaaTG2JxesFl8TzLCBjfgE6QG2qu5dUGjn0PfOFCY9GZ266UZSfM3o2nown",
+	"LvMfr+joaPTteDL+dqSVSrWAq+ZwfE2q6gB4weHv15dyrNmf/uWiq9tAqHQdRUHqS8VWDvzb6S8/o1/J",
+	"Ofo7WetULMs9L2kZtyTEzhoEzZRMVL0+Q4sfkl4we6P9h5kq1B0RXMF1B+7awOmMZ86zN0GMVSBUdeJs",
+	"Ti+sP8NcW558TsrR0eivRP1Kqurvei/+dn0p9UJMMyAwIMF+fTOZGJkcbgdzWYcrxO1d6LW1oYj1qbkb",
+	"ezd5bFzyto1C59zx9TUcBh/93gbjXQM7x4m4Mjr67UMxki7uUm+euQL1WTUlCKsqhTM1Yxk5wtwENiw3",
+	"g3MvCVsDp4+MiJZzwYWGbSFRGF9qrIDqoiZyzhjDGHc9Ec6JNz6WBjFTOK2ogyvfbwFaa2HXADNCMmPa",
+	"yiHSGy6VviDfmpX59jh/4eX6zrCno97QTSp5KlGTmxYOP8+Fa0fbGxqMceE5qv0O0PH55NkuUbFtRE6F",
+	"CXMU5eOgFIMVTYyOKUL50vRZgjjROIqwKdR5UIGi1ZLZwZ3ZJBrKPPabu5FF8tlXU29Nn8beg6/1Ds+5",
+	"aA7XeCE14H9tvdutU/PUB5bpIxtvWhJGNdnNFRHXWJRSB3xFbxoGgivJkSC/m63mzs4SlgB0aftDGsZP",
+	"fbO8NrXrHyVhaljDHFTRS5LsWAGbbI3trq+D1pI42Cv1pUhnRAZ1w12ZJho8e/NO+/hJsLffPTtJPFGD",
+	"mMjkruc2o2fpP2bOlgFNds2ADM5YiQcwP07vfCCumAfK64aWMUYK1+ORJtqGCMsjvaFWdsqpr10b1fAs",
+	"JPRCkIsvvtMS/07CyJ+J7oPM8WnhvdQmf1PklqQFZ11ixsO5Z8eZHmAb0vyFdlyWEHcJJTSDFV7qYjyg",
+	"UsiVoMzkohhNwRiDgMNjtg7twQwvtvVlulhp45TvnpcmTWAG8dJndzZ3Y+KG5KC8ucCYDB+Cl55Yuc1k",
+	"gHOR0mlkuWFWILAiuFB7i+2m4V/iRGrxqsNPUdLoTXBx5xSeijRHQydKRuVOwBRAbMKSV3l/Nz3gnK7j",
+	"yKBFBGaCQAYnATCwDQi8JIoICbnT0FRa2wtCT2maPD+gf3pHmcoPecVkl3RgN/Eh6OAnb809eWnnf75T",
+	"OvSboLEJLK37Sl85ioDoJXP399zj+4Pak92g9jHS4mhFGjv1hNt7LSnlD23lyh01TTXgO2hJTMj39gXP",
+	"SwklA9EvS6oUSV1DZK7iGJL2DfFGz7wnVHT38lmz7eSO1d2BV5Ot7fXQ5Ft4Q1uHwHbN66rsldj2hPif",
+	"T/68a/U87FLUvnY/+ZChhW1k2ENYULed8oX+ORkQDGY2uAp4lGtEa12iPtKEemk3l6Hv8vlCAYK4SmqI",
+	"G4IHo/glsAC6KSNB2fdrdxbFzLGZWV1Dj1TRNDUStCa65N0egSw7hT36MiSTSPkMhPAkl+wFa3I+nv1m",
+	"UW2GsolDCV5VB9y1x8hyqZ98V5Fm/5l4IkSZ4ggzY9pvWqrO18hul23PLmwaX6j7Za/FUD/QTOEC5ZJy",
+	"MiCfXS+IIEFE24ap+J4gj0RWS+PvFD/ZqgxYUgTJdugKXX31D9DArqNP1cbAcCsc3hPH9EeVo1JYj0Zh",
+	"UkJR8IcXBaMSwdIKfuckBtHQie9d2lRCH5rZuhW47opNDcqT6YMz5cw2+yyXvZclNVbbuEQntnWwVYvW",
+	"vUzchwr3u3rMBJFIlsb0N50FRSiszYi07cMi7hxFFs0rrAqToup9uScvnZzq++Sxsl1hdZhpygetPxop",
+	"8HPSTfKOzLhw05Pt6vHYrtK6em3ZbEk6Sdfn8dpjTwI50sDnkti4sQxJ/UTuM0oPMqE6kDYJkIal7PtJ",
+	"ZSE2x2TzBQYwWnjOHVp7xMLFGkA1Sq4KnTgRWjRqHksFkmQmiAruLMNrO7jmT8SEgOzELX/85gRm28Yr",
+	"n6CvjLPu9x0j2lB3YHWP7z6TleIzuzOjIVvGVIQemVFUX9Jk35hduM4nBbHtyHzW9+4Cy4V+45KsVJd6",
+	"lODNvTj6A7Ls1s//wuaEJdM3jsafxZ54+/fffe+3rMkVDz/Bvxs89z60MwnLynFIE8bSik2GrAXFk1es",
+	"UOoIRCpaVZZbRqlMUFzFBs879ouzdGGcqY4yzsyyBgmfyj+7n+bHYcSQxjE/37WKBwKP6eBjAbJnSKUT",
+	"OPdRo7OY3SaROHE0Kze8JUpQckWgvmurd3torQbtSJEkyiW8w04F09oYHWetZQtaloQlD57EJXuXXMSz",
+	"QaTva8oufWCvLoRn8vfevX2d2Iw0vB0SSZRr2iCcjuoF3BWQTjNkHWCQw69f+FdNxDrQnHtwFBNZsxLa",
+	"TZETrcHjqyeWLl/N79v5ukArQeb0o83rPJjChW1jp027KdeqOgOVHjGByKOs7yrrajG4vw/cB5vUfNBK",
+	"bi5GB7lM52Cay2N32P7DF7WQXIwGPPmaLqkafTY3GiRPxhVZN4iTx1kS0adgcBXm0sjbNad97BCeubnZ",
+	"/X0ftOcZHMa+B6h69rVBwtW531HRViOz2szlcVb2HMohjl1D5EuyjoNe373TFteo6UysFFsHpMRzUq2R",
+	"ABZbHtkPEl1Y4d4htwOZC3pBtZzuxoks5UB7LoPfLRVfYMrGOpXPMFstbAOn+OY5WvBayJjvvmeOXRg0",
+	"DPziJErm/DtZJ6wjqm33zXff3aNjYXA9nmaL6lYn+Y4iPW+GNW+yTa98Vy2/1zqx/cD9xedjdBr+luiC",
+	"t+ouBO+3AWBgV6ft2u4bnpxmCbMxcugdlW8Pr8kFNs4rKkDyj5JgjAdt1Xxbu2eGQJ9z0OxS7/LMvM1y",
+	"7E++HIOsIXdhXlfV+iEcCg6ebKa5z583fMAfj3HbNBKrjV7uWpLqc9Kr+eabnbtHbpnwve+KZ2iAn4jU",
+	"cZ+QPrUTXNg4cUREATRxVTStSq7d5SGIVFy0M6dXtYjKTgmit0qbo3/n5936pGMHb6Kql5s1yrhG5u11",
+	"yqz0++oMXzT4OnJNMG31gSRPmEpjWnLpiXQO/kFGEKkkCV/7dMElwQxq86Lp/z9FSx0xSSR4mGwKft99",
+	"aGsI5KT6nYTHD2BjrvRdho09fxA2lkbMPNs593FYtKmoRQhQAxwMBh6LIwb+b37YNfwO6dp1K/Y97t/z",
+	"R3RcSW4xU8akHdpwhLSAbjOEPcjztW00Oe7T8Hs4WmaPmwUSvQa9c8an10muKK9ltXaoGSRcxQ27gx8Q",
+	"tZK+7b0YNQnuYl8/c0ZuxcMmu+BhPgvCrzcIOAa7kijThoKtdzAvGFvO3rpXWBmP5+aAXdZaGpS8xjPd",
+	"pDBQoX4p2chx+M3cRHy5wsLieX7mcIP5GNZoWajkwDU1HhBmn9EaHlVR2a7suekt/XbyPL8LbtklNXHf",
+	"Zu4sL9yfC2PfUz9WwUrUkfdhI7Rd8agCRT8XaMYr6BA/M4kgSb+2jjY3Y/SjSQrxQdZ6yy7oFWH5PJGc",
+	"l802XX0S/u5B+LsLqwfgRXc9Q/i5xVXAALUgH83PY3TMEFmu1BoZ+GxxJakZCRR5xjrIS1PIfP7DD5NJ",
+	"tuNKPH0XNNG3mXu0NaZD8neMbuyL9Sp+9qYYaULpBoQs+e9U0xGdtUEZvB3/9//87/812q4Ruysx2ejD",
+	"OL4rS8hklyqETWFqqBCfdddue6duvOUeoJLIttw7VPTYI/1rj2xZTwrhl6IQuiS1IC112csOXQ32zX7p",
+	"0ADbRhoq3rCmKR6E6kw0myvzDq3prNVEv2YNaQ48qCMnEb7CFHqMbPAse2Hq2K3kgYWqqfaJGxfrNHBl",
+	"o2IaVzkkG2vkV9y7oGCbzM8dzmTjKOxl1C14fjK9d6OUQVBxfPxY33wV+H6z3uvvJp1tfZ9lapDfazCL",
+	"PfU3+IJsdhW3Ow48bOA05KLvvwM4Mh9ldrCbt5igjO6ksv+kZWxo8jVI3RcVdawjDp1uBoVERX2tT6nw",
+	"9wwXntV4831cxbdmbjQXNwNpaJlJXMGdheb7HXGUbZ5kd2B3LOnDw4qyftv20xy+hwRmUWSQd+vQ+K+B",
+	"oOpckia+TDxcDU+5T8yMvvLOzKhLgWkWw1cHFbkiVZDftG0Qm85h04Yrf2pKL+k26jL2xkPWYVImNks7",
+	"dZt03pil7phy7j742S7ILmf/lE99QntQPSPqzWTc6A5XU7w0al7CxKHpJYL+iCRqS3kQwsIejvtwF3KC",
+	"Vo+FHw3lIZAo3MOpuKS+33mtNnrjrf/dWFXdy05MrWz6SAhP9RnjIDNMjJAQN15xba+xIEgu6Nz2ytRQ",
+	"o+MwwQrbbB3CfB40zGYsVY0e5PbBwSzM7cGjYGKNBrSiJD32UL+BnebHrhZEZuAP+2+VW/InOWYbvpHS",
+	"cwfl9rEMSWYDy9W6J32XGWPypCyEY2+2F5y66R6PcD4ojtqua9u0vHhPE4r+8lKLH5VntJMeNmYj2rdC",
+	"MPX70V/w7LLiF+9HiAv0fnS2oBJdE3L5flQg3Loo89MiR1joQvB6BU8KzlWaux58aKw0NQNAfNPkKyPX",
+	"PDdhxfyaDda5H4qu7yVv0hPzbsN3k2kbDQvNTw+ZLNm0oNFm8uSX6Po5duTYCFI2RTCtH8g02PLWX0eD",
+	"qRM17m619yHBdtFD5IrDT/bTwMLSfuzuqtJHJujjgvv0PNhzYGNFpDE4rtfJB0NsCYVcVzf18EBix/ZO",
+	"3Rp36wfJjC0jSPaz+vUANvcFV76OjBiOtTx4Ob5HxKJabKSvFPcXTM6TXZCzj3SND+OJnh9X7Gd8dj74",
+	"c0ic5R+amO5e77Db9DBlvwfcyQIquz+pHnt4R39ZWohrMXBbLWSwl8Rtat5Lgpfc5qPHFpmo6GKHv8Q/",
+	"faf+kgas2l+CWqUL3UOXhKxsArQx3Q50rbQ4+gP4WvaUs3+WH8cj8iPx4wy4LTr9OE/C35Myt6V3y21d",
+	"w7vVwXZ7rwOFe4pBveDLVa0IWvBrX8oCaU4fgnEIswFzU/3/KfoK6iZJekW+1qx3qvgUfUU+uu+Oogqk",
+	"rvIC9GV1/SL0msAOX+K1xhJt9vdGKiIoL4toCFNeDcpCp34BGMK8tjQv4Csi8EUovaY4v9QQupljwGJg",
+	"4oldYLh5DKZ1mR8QIy4VMmlGGq3O69klMXHEQmnwzA4VyNYoAUD+mzMSxoWMJ8hjYqWEVxSfjpEpLU5Z",
+	"KA0QEr54rYZGJp/CWe/0YtpQ1+kN7OuPgi9Hg58+46N8wHMFZWkcrrjdD8en6VuuKsgKUxwcSUsuFfr2",
+	"++8dpnTFIJvBOkpolXgdFdAyf2msHX3ouE/vN5bAnHIHq9PkTqWis33zrxbukLiwR/fkcR3icU3Oc1Ds",
+	"Zn999o7yf/ASOieay0HRKY4wmhGhsKk047KbhZXXTXUqbbfGimwo9mfzNO640p/nesOrte+K62mITrlQ",
+	"o6HPKqxq+SOtFBlULc/EeOo3T14Of8urMMNfecu5Gv70fpb7G1r1Plvr70/5igSPpOSf5rpzOL5G/scT",
+	"1x1UhTCc/gZue+gFymHpbSHLJOSWuBG2EbYLI+AIMiNMVet4EMiG24aDvvBL+CMIkPvdUKN96Ikesg8G",
+	"5wSSJ24xrN1G/mBx2MxuNkKWqwqrnhy2UxwxD6QEIemUUCNCm9rcUONhYWxnbuZHGDy+uYRoc0P2rHyl",
+	"3/wc17C/9RWw3HmtBLeXPvM/zlD/EsPhzpob0i6FsM9hbf48TT+uQfolXRK5IER1yjqn9TI0QZArKA2y",
+	"NhY7PFOQQWX+AlaWcLEthJ8xOtPjE2ZqLOvWZRVerXzJZGtuMKW7aqacJc+U51XGSmdMc6JmDN6jSyKk",
+	"fdzk8TJ+vdk+h04JK9H0eDYjK3WEFPmoDmfyahon3vttQ1iiF6f/sDXQONPmXWYqzIRN0VDBXg2W4fyp",
+	"PIlvG3iu3ylg//as9FuhUpA+g3/SsoB/9Z4VcBiFJDPOSllAre33bHL+7+R7/JwcfDt7hg+e4+++Pfhz",
+	"+cP3B9/OJ+fnz+aT+Tf4z8WvglrjsyArLlSBKzojxXfPJ5Pi2fi7yabSb1k27JFpv6x8T5LjdpJjfIyD",
+	"mK+vI9DTQRebziCtygJQUoHYGvLDhMN3frovprKA3+G9LbW740gb5hAnJKjyy5jp/EnudTspj8PDKMzn",
+	"gQ/MKC7isgPaeJRJDS+MPT1NMee1zXNepxUPonYA0yTfGFUEm7J2spHtDVZ3m5aeNJa54ES6yuHMqkW4",
+	"WZYhAouFQZYg3UPS/sBYmV/9zv1RiimEFT3VU9jHCBOPuQ8djJiULhkSjBj1L3kUadYp/7L8U5CSb5JD",
+	"LrBdcWqprlkJrGblO0+7uDAJ5VrRL+4XCf0RQI2DJgnwXqixZF+AFi7OAuabl7QlnLca5A2Fw09KwhSd",
+	"UxtnOKsoYcpPdL3gMgLcpGrZ5YAuCRB21ur+r4NTM9DBSdnL+XYpFr1jJT9VZNWlboRjgvQx09SwTCJb",
+	"jBE0Llb+MAYqd0z5Goq7Dz5zre2hnpcmI71xjc79vp4x00SG12aXbZlw6frf72VccslDXFOOpnGDfCzn",
+	"uH2Mwn4EIXTEHDzFBfzR4wIecTRANhJgj53whkcM6ANoHGK8XRRmQ0fADiJ+BO0AYcWPuxfgZtLcsSfO",
+	"z9m4wvVW93jgvkCPVz6i/qmD3T759RxfjOWtw3OXy5rnqG9rhrDPNTC+uaDmfGWIoLBZkQWSRP1TgoRS",
+	"2PKbrLRlDL72JbIgGN4n1iqBmcQgbhwhQqG+nzWZgcUJksKwQyYwRlGp88LM3AEc4LA6Oy1ih+DL5AKL",
+	"NWqw5grDxRfW4kLI9X4gQeBsTZl4ytBUgQA1hfXY+qNGprLmuHQgbIeJ1EDFL6DLdJcSqkeTf7E9Rx7F",
+	"7eMdpH7XzO3z6K4Vs+s7tub5id8SqRlGTsqsjDkjwq1ronUXXNpsEwHvSle7JbRo5/PGm/emevt1DJI3",
+	"7REWiIwvxgiziHq1Sg7WGncGpjl6EWhR6hdqdsl0pd+UsLmIdDvFOVpqE3q0fPSz1bY1Nwd24i6YyfMH",
+	"2ZNgH+TCMCu/TtBjk73RQIf7PbOW+7ryB62EpTzYlkPXWGrPuSOz2tZ61mu/5nVVwluQMKlzDq5tdd2e",
+	"o3uSDT7bWFMzJIlOkqtalztWiDNv1/28mGY9xfoh4po3hTF/WaHGexlbHMGw9wG9fTG8hkI+GUlxSDNo",
+	"H9RlXcM7bQYNhGFE2EGuWeUevcc2gGZDnhpADzE/7EvrZwDmwW0PGnP+JDuT+h+g8ZgRa566ju264J01",
+	"cGzuMA0H1N9eupdH9jWWNkAU98dHc/nqWvM/tbdIjmedc14RzG7Vk1oP+odoSN3FU32NPrNS2y9GBnMk",
+	"8nvkLiFJmELXC8Jsz+rztEqrlfzhBnOk7/o4oZmOTDfVB+ACuk23TS8+8HkC6j22tDab09HP2u/APbW0",
+	"hvXuaT/rzC247wUNPaMc1srasAaDAIBtaQEw/ZWk5xVlF9I84PqjFqbsj9ExXckN5EpmSRfyKOA8z0Xi",
+	"zrKy8Dm5oMbWyEVS2uvYhEe6qaImvwFL4WH3QEw6VKKSCDBEgBqa4HBkyswUeXwSnvergbY7355m0RkM",
+	"8HWzW43YkzpKUYPJSTHSCKc7g7oTbFYq26pztJMTWt2nt6ycZs4+Q4i+9B5WaNJRWO1Ba6j16jh32Zva",
+	"SzCPuDH1z849ZttTY+tQQ4wL/YfHY30bQp1GH1Tmf6ISMXKBdfD+k7r4pC5+oeriW+uvFsT6y6J+7rjt",
+	"JHdGvc/uWG24UNqd+oV9aoblDJdOGjGN7lx4o3XywqDGBOljKa3IYjSRKA2ERtbKwnoIZ1Vd5nM3Um13",
+	"q3bW9yXcPPWyfupl/UfrZW0vno5G1g1mQ5Wl0M6sszcVZnZU07bUlKf0L5rrH1/CRWIVLc0i/ANplpn/",
+	"2oe1eH0oETQFQasKM0ZKxJlNn+fXLA38t630bd3LuFW/9+5aVtWRSxbxoxO/EztiSPcYbRLWsk+itj3P",
+	"LzF3DNYP7X0dUTy4fBpA0bd4xaWNfosDKRqmjH0vYtxmVX6Ree6nb3rZ25IzkbWkwgrCh4wwRJgWBwSv",
+	"LxYF4lUZyVvHoEK74RG1QSUIzxURNlLBmhdjc/5GoemtB3iXTGoXQQZuZUODDfzRJRv4ZJYdIhr4vdtA",
+	"FYef3MebLQgkWHGRoBcLZZE+6l5udZbxUGR3Hx5UVQhiuYrQr8MDJwLE3WA8mBye0luPoyqs8uGuTM9E",
+	"H5frw4PtqWE4sR3aKJzukPm/gA/DOZi1lVHxlAIjcvNTHMW6uzUTgImirAnkMoIxV9XSuPygXwmI6M7I",
+	"gAVB0A8olNd3BoWKQvWXtTUqmDdsbKZZjvO6hPgmO1ngF7iSoW6+TLwlXvBfh2h9hGVyz8psxaxlLVUS",
+	"2gS2j/B7Z2ifD5nvYEdv7Sk9caV71R3cdocD3It4pG7W9ADCvIckxEa73Yrio7tN0l7wD7yhiVORKdiU",
+	"lLAR0ntpeYWlIxyA38CFbYOTTaV3fHtbY2m1b7WaUYUiO/rrpGGYM4P4LkpT33i8s+lVrrFU3HLKyFdw",
+	"fP7lTJerX6laaLCwfyw0PDEFe9r9fHva9KbWE9en6PHbTrbqSL4jy8le1NxJikcA83CX80Py4L1r9vTI",
+	"bCZxnHjM1Dr4JEhswCbzET0/YXHphsNxLDsX+u8VYSW47DaFvJgSFE+BL3sQ+BLOfCPrNE9+TsBHhsjN",
+	"/B2BGk/RBU/RBV9kdIENnfQ2PUMlea6t+cyBrVfdach7TWUoDouUwLNL43vDcbyyrVTNhRYrF5iVScqc",
+	"1aU32PV0IeRXFpo/lBnbrms9OGEuqSPeMmN/eR6yR1VAOT65KCeko8YFmXFROqJpVqmPqMxkq0JMJSmP",
+	"FZRytyF5U8JK8x0XySOrqpZoWtaG4k5NrXDrXddAQt0TrJSg57VemtXzcK0WhCm9mTrhT24oHPGwxHv3",
+	"yt7P5Dqi2B1X/UknbpCCQ641wmW5X33gAawvV+x6pEqeqVmmAl75QMEegUFxhSs5rNlGRlwAFwIL29RZ",
+	"rRrmHqO3/b0wNrrGNdWcGZAfiVCxiUHY1fQJD9G+PwkPj0p4aFFMNyWKnuZc0AIaxvJJTTB69noHaaOU",
+	"MZWGgsYwE0SqKL5akdKQ6vTNL6dnyIBxqH+Z6uAWGEzUpoAHyP+cuRGwsqxmoDAhHrvNWK8BzsEmbDyc",
+	"0CCQFQr3SWJgXH3BWSC2CrGr32NoxlDKY4+2M7zHLqbNx2yDr0HVll3tPyi/6l4sfC1HKkLzwY6oIT/b",
+	"ThR9O9sQPf91bml7XwLXb+eQMrj2YRN8ARElEi2xzvJm0MZ7+unTFRZUZzTe3GhtFc+Irn5NhCxsGUHw",
+	"V9bnUlEFGqpPeXeDm8qdUmGmqL7POi+XBBF20+uxp89jMfIVx7fCrI6yS09dI2/ZNZIn8YdwJmiBJWI8",
+	"tBB6auB4mwaOTXZ/+Ml93FCg6i1ZWtejGwm5zj4eh2zOvK93gedzgG3cVXDKQXHmYRgmXcaP36VC+Hy3",
+	"tLc3BZs8bu+7ThZq+gR8HlDXxz4cFXHxAkqxqeDPvuHoZCc4GorfxBv9hJeDyqgM4bWHkXjUbTCw/DvT",
+	"z9sNFVA6FtRi6WyMXvkaAbpQRPBBO7791dR+qcWk6deNtjOam4NhG8yQzIQTavOFG8m/fvISXgbgcVWt",
+	"bVpf/A6s4qu0XPbXG6XDQHsn0a7tmgzvwRjhoPOrGhzK9uyegJj1X1mxUP/gMmNnEWnkFJgoMkAjNXOu",
+	"uRjfoVRGhMKhVMY91oMewPWKuBx0HOm7b03sfFmzXCe7ltkkNpXsbUhyxGYywnMUn3H4SX9YbxCeg9ji",
+	"fToWVSHkjCkiTOTvkkqtT8CmlVTOsNBqe9J3vFOYDp7eVwakQQyS+Gf3VJAe6Hq1svQX6U4Ju/Dkcf1c",
+	"vcLvZUTsIjKKthUE6xS5V99iNwUsSMoe3M5DfMhDIKOd29jOGXdMa799fH1b6LEAHGqxrJ6RWI13ia8e",
+	"FCGcSzBs/RMS9HpG+GoYFggsF8NSq51px7fYhjAykxmZNi1wT9pSBKe+ZKr93nUJit0rSU6oH7n/Fd+G",
+	"3BrntBPHh/36RE2ftBfFf4SatG3mBzuyEyeOnulE6RMe7sVRpnDGMk0x3XdmlIXY49/hJ/27yUXekHYc",
+	"Eusa6JjawaTtyeYeMqHh7okQ8nMc5Z65JGb9hO8I1VMAtlPFd8d68tJCu11Z7ebCbOecjoxas3P7a5AL",
+	"ON7GHv39HuXUhpbFphSd3to2lT1MoZxlf15tJmvWyalS0apKllFsSMU1tMNMGr37XXF+GSu9f5LGb7X/",
+	"KbjM7F+ac2/Zj25b18dsrohQzR7ujRZCpu5h2vSx0Yrbtw7XB2g75a2JOkLYpRbZSjxfpVeU/do0F3dl",
+	"DhSPqyZ+XUCOsa2U6io96o/g2wpMsNlaHqA+XweYXe+7Lbr56ebpTy3l76ClvFnxU0v5rVvK6437o7SU",
+	"13gTWsoP6SV/zcWlXOHZkJyq8Cy4B4F358TfX8OYu5CB/XTbRjKF9ex7KFMD0r5gJv8oFG0P/U29ymX9",
+	"ZHjpumVdCF6vSOkjF2zwk2Gj0D9VXyaUpYNLngwZGvkFWCF+6oInV4S78HN3QQNv7iVZJkKW3fqTGhOn",
+	"R+d/dM7Hh+DWJy7W9YFiiY4D6jRkoYwDZe9Di/xaWoz28JP/PNRBkm4Mr5WnvTE6CUVbpC8TZbqDkkqS",
+	"a7jytOMktqj8B2wuZ6RRZkoTvXswEiSp6u6PF6j217CsQU6W6+T5/XS0DCTbL9jPEjbhwd0sgUyMxrrA",
+	"gTT23scSM4yi06+yj9Q22Q21+eCrdKeeyO1RhH+lh+YL72QK6OwJit+9+OmXY8pe7Lo418CrzFiC9iv7",
+	"KkikX+LV9ocQjE0jmS0E40N3cw/JvYpbbqSabqSypntnAjIlwhJN//rqDPn5prrRo35OEJCHl1yEQY3h",
+	"e/qassups2np6FJjmnz39rWDQvdkAbA6fIRZLudyB3bK7Vp1w35dGD+p4q4TDqzIl9kMIlW+j4t78Ba9",
+	"VeeUVGCfltxazf1pnq916B+Z048mKGx6MIX2cHoQU5DNFvvtgEqPmG8uY5vIFSPC6uXo6Df/94H7AEMU",
+	"owP7r1XSj/V4B+GPD9m93dAK3/TtGdI0/zW0x9lNFSCLiEMsasedxJfYDBvt6DQBdQFhHzuEZ25uHv4e",
+	"ihrmPEl7m/z0Hex3E7eXCvew+hdQSKOXu8dVgBlXxhMi6yWqV1GrHQDX1TuqQp/VLZj0qcI75tA7UbnM",
+	"sjIHr3+gUtFZjqifFLBHQJIyOcEGUeo3yKwW0KzvNw0xPeOXhI2Ofvtw8+Hm/w0AQoImJICsAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
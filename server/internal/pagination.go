@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Number of items in a page of a list when no limit is given
+	DefaultPageSize = 100
+	// Maximum number of items in a page of a list
+	MaxPageSize = 500
+)
+
+var ErrInvalidCursor = NewValidationError("invalid pagination cursor")
+
+// PageCursor points right after the last item of a page, so that the next page can be fetched
+// with a keyset query. It is only valid for the sort it was made with.
+type PageCursor struct {
+	// The sort key of the last item, formatted as text
+	Key        string    `json:"k"`
+	SortBy     string    `json:"s"`
+	ID         uuid.UUID `json:"id"`
+	Descending bool      `json:"d,omitempty"`
+}
+
+// Encode makes the cursor opaque to clients.
+func (c PageCursor) Encode() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodePageCursor decodes a cursor made by Encode for the given sort.
+func DecodePageCursor(cursor string, sortBy string, descending bool) (PageCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return PageCursor{}, ErrInvalidCursor
+	}
+
+	pageCursor := PageCursor{}
+	err = json.Unmarshal(decoded, &pageCursor)
+	if err != nil || pageCursor.SortBy != sortBy || pageCursor.Descending != descending {
+		return PageCursor{}, ErrInvalidCursor
+	}
+
+	return pageCursor, nil
+}
+
+// PageSize bounds the number of items requested for a page.
+func PageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+
+	return min(limit, MaxPageSize)
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits bound the size of what clients can create, so that a runaway client cannot make the
+// task trees too large to be handled.
+type Limits struct {
+	// Maximum number of characters in a project or task name
+	MaxNameLength int
+	// Maximum number of characters in a project description
+	MaxDescriptionLength int
+	// Maximum number of levels of a task tree, root tasks being the first level
+	MaxTaskDepth int
+	// Maximum number of direct subtasks of a task
+	MaxSubtasks int
+}
+
+var DefaultLimits = Limits{
+	MaxNameLength:        200,
+	MaxDescriptionLength: 5000,
+	MaxTaskDepth:         20,
+	MaxSubtasks:          500,
+}
+
+// FieldError tells what is wrong with one field of the input of an operation. Field is the name
+// of the field in the API.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// FieldErrors is a validation error made of the errors of each invalid field.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e FieldErrors) Unwrap() error {
+	return ErrValidation
+}
+
+// Err returns the errors as an error, or nil if there are none.
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// NormalizeName trims the whitespace around a name.
+func NormalizeName(name string) string {
+	return strings.TrimSpace(name)
+}
+
+// ValidateName checks a normalized name: it must not be empty, must not be longer than
+// maxLength characters and must not contain control characters, e.g. line breaks.
+func ValidateName(field string, name string, maxLength int) *FieldError {
+	switch {
+	case name == "":
+		return &FieldError{Field: field, Message: "must not be empty"}
+	case utf8.RuneCountInString(name) > maxLength:
+		return &FieldError{Field: field, Message: fmt.Sprintf("must not be longer than %d characters", maxLength)}
+	case strings.ContainsFunc(name, unicode.IsControl):
+		return &FieldError{Field: field, Message: "must not contain control characters"}
+	}
+
+	return nil
+}
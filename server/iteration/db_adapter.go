@@ -0,0 +1,38 @@
+package iteration
+
+import (
+	"time"
+
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// Transforms an iteration as seen by the db package to an iteration as seen by the iteration
+// package
+func IterationDBToIterationModel(iterationDB db.Iteration) (Iteration, error) {
+	iterationID, err := internal.EncodeUUID(iterationDB.ID.Bytes)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	var closedAt *time.Time = nil
+	var velocity *Velocity = nil
+	if iterationDB.ClosedAt.Valid {
+		closedAt = &iterationDB.ClosedAt.Time
+		velocity = &Velocity{
+			CompletedTasks: int(iterationDB.CompletedTasks.Int32),
+			Points:         int(iterationDB.CompletedPoints.Int32),
+			Minutes:        int(iterationDB.CompletedMinutes.Int32),
+		}
+	}
+
+	return Iteration{
+		ID:        iterationID,
+		Name:      iterationDB.Name,
+		StartsAt:  iterationDB.StartsAt.Time,
+		EndsAt:    iterationDB.EndsAt.Time,
+		CreatedAt: iterationDB.CreatedAt.Time,
+		ClosedAt:  closedAt,
+		Velocity:  velocity,
+	}, nil
+}
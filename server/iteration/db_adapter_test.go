@@ -0,0 +1,39 @@
+package iteration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterationDBToIterationModel(t *testing.T) {
+	startsAt := time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC)
+	iterationDB := db.Iteration{
+		ID:               pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:             "Sprint 1",
+		StartsAt:         pgtype.Timestamptz{Time: startsAt, Valid: true},
+		EndsAt:           pgtype.Timestamptz{Time: startsAt.AddDate(0, 0, 14), Valid: true},
+		CreatedAt:        pgtype.Timestamptz{Time: startsAt, Valid: true},
+		CompletedTasks:   pgtype.Int4{Int32: 4, Valid: true},
+		CompletedPoints:  pgtype.Int4{Int32: 8, Valid: true},
+		CompletedMinutes: pgtype.Int4{Int32: 90, Valid: true},
+	}
+
+	// The velocity of open iterations is not measured yet
+	it, err := IterationDBToIterationModel(iterationDB)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.UUID(iterationDB.ID.Bytes), it.ID)
+	assert.False(t, it.IsClosed())
+	assert.Nil(t, it.Velocity)
+
+	iterationDB.ClosedAt = pgtype.Timestamptz{Time: startsAt.AddDate(0, 0, 14), Valid: true}
+	it, err = IterationDBToIterationModel(iterationDB)
+	require.NoError(t, err)
+	assert.True(t, it.IsClosed())
+	assert.Equal(t, &Velocity{CompletedTasks: 4, Points: 8, Minutes: 90}, it.Velocity)
+}
@@ -0,0 +1,58 @@
+package iteration
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// An Iteration is a period of time, e.g. two weeks, in which tasks from any project are planned
+// to be done. Planning a task in an iteration leaves it in its project and under its parent task.
+type Iteration struct {
+	ID   uuid.UUID
+	Name string
+	// When the iteration starts, inclusive
+	StartsAt time.Time
+	// When the iteration ends, exclusive
+	EndsAt    time.Time
+	CreatedAt time.Time
+	// When the iteration was closed, nil while it is open. Tasks cannot be planned in closed
+	// iterations
+	ClosedAt *time.Time
+	// The work done in the iteration, measured when it was closed. It is nil for open iterations
+	Velocity *Velocity
+}
+
+// Velocity is the work done in an iteration: its completed tasks and their estimates. Projects
+// estimate their tasks either in points or in minutes, which are summed up separately.
+type Velocity struct {
+	CompletedTasks int
+	Points         int
+	Minutes        int
+}
+
+// NewIteration returns a new open iteration from startsAt to endsAt.
+func NewIteration(name string, startsAt time.Time, endsAt time.Time) Iteration {
+	return Iteration{
+		ID:        uuid.New(),
+		Name:      name,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func (i Iteration) IsClosed() bool {
+	return i.ClosedAt != nil
+}
+
+func (i Iteration) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ID", i.ID.String()),
+		slog.String("Name", i.Name),
+		slog.Time("StartsAt", i.StartsAt),
+		slog.Time("EndsAt", i.EndsAt),
+		slog.Any("ClosedAt", i.ClosedAt),
+	)
+}
@@ -0,0 +1,36 @@
+package iteration
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+type IterationRepository interface {
+	// Run fn with a repository whose changes are only saved if fn succeeds, along with a task
+	// repository whose changes are made in the same transaction
+	InTransaction(fn func(repository IterationRepository, taskRepository task.TaskRepository) error) error
+
+	Create(iteration Iteration) error
+	Get(id uuid.UUID) (Iteration, error)
+	// List every iteration, by start date
+	List() ([]Iteration, error)
+	// Get the first open iteration starting after the given one
+	GetNext(iteration Iteration) (Iteration, error)
+	// Update the name and the dates of an iteration
+	Update(iteration Iteration) (Iteration, error)
+	Delete(id uuid.UUID) error
+	// Close an open iteration, measuring and saving the work done in it
+	Close(id uuid.UUID, closedAt time.Time) (Iteration, error)
+
+	// List the tasks planned in an iteration, pending ones first, leaving out the ones in the trash
+	ListTasks(id uuid.UUID) ([]task.Task, error)
+	// Plan a task in an open iteration or, if iterationID is nil, in none. Fails with
+	// internal.ErrNotFound if the task does not exist or the iteration is closed
+	SetTaskIteration(taskID uuid.UUID, iterationID *uuid.UUID) (task.Task, error)
+	// Move the pending tasks of an iteration to another one, and return them
+	RollOverTasks(fromID uuid.UUID, toID uuid.UUID) ([]task.Task, error)
+	// Take every task out of an iteration, and return them
+	ClearTasks(id uuid.UUID) ([]task.Task, error)
+}
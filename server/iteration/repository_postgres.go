@@ -0,0 +1,291 @@
+package iteration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+type IterationRepositoryPostgres struct {
+	Queries *db.Queries
+	// Where transactions are started: the pool, or the current transaction for nested ones
+	db     beginner
+	ctx    context.Context
+	logger slog.Logger
+}
+
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+func NewIterationRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *IterationRepositoryPostgres {
+	return &IterationRepositoryPostgres{
+		Queries: db.New(pool),
+		db:      pool,
+		ctx:     ctx,
+		logger:  *internal.NewLogger("IterationRepositoryPostgres"),
+	}
+}
+
+// Run fn with a repository whose changes are only saved if fn succeeds, along with a task
+// repository whose changes are made in the same transaction
+func (r *IterationRepositoryPostgres) InTransaction(fn func(repository IterationRepository, taskRepository task.TaskRepository) error) error {
+	tx, err := r.db.Begin(r.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(r.ctx)
+
+	txRepository := *r
+	txRepository.Queries = r.Queries.WithTx(tx)
+	txRepository.db = tx
+
+	err = fn(&txRepository, task.NewTaskRepositoryPostgresInTx(r.ctx, tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(r.ctx)
+}
+
+func (r *IterationRepositoryPostgres) Create(iteration Iteration) error {
+	pgID, err := internal.ScanUUID(iteration.ID)
+	if err != nil {
+		return err
+	}
+
+	err = r.Queries.CreateIteration(r.ctx, db.CreateIterationParams{
+		ID:        pgID,
+		Name:      iteration.Name,
+		StartsAt:  pgtype.Timestamptz{Time: iteration.StartsAt, Valid: true},
+		EndsAt:    pgtype.Timestamptz{Time: iteration.EndsAt, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: iteration.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to create iteration", slog.Any("iteration", iteration), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *IterationRepositoryPostgres) Get(id uuid.UUID) (Iteration, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	iterationDB, err := r.Queries.GetIteration(r.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Iteration{}, internal.NewNotFoundError(fmt.Sprintf("Iteration with id %s", id))
+		}
+
+		return Iteration{}, err
+	}
+
+	return IterationDBToIterationModel(iterationDB)
+}
+
+func (r *IterationRepositoryPostgres) List() ([]Iteration, error) {
+	iterationsDB, err := r.Queries.ListIterations(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := []Iteration{}
+	for _, iterationDB := range iterationsDB {
+		iteration, err := IterationDBToIterationModel(iterationDB)
+		if err != nil {
+			return nil, err
+		}
+
+		iterations = append(iterations, iteration)
+	}
+
+	return iterations, nil
+}
+
+func (r *IterationRepositoryPostgres) GetNext(iteration Iteration) (Iteration, error) {
+	pgID, err := internal.ScanUUID(iteration.ID)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	iterationDB, err := r.Queries.GetNextIteration(r.ctx, db.GetNextIterationParams{
+		ID:       pgID,
+		StartsAt: pgtype.Timestamptz{Time: iteration.StartsAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Iteration{}, internal.NewNotFoundError(fmt.Sprintf("Open iteration after %s", iteration.ID))
+		}
+
+		return Iteration{}, err
+	}
+
+	return IterationDBToIterationModel(iterationDB)
+}
+
+func (r *IterationRepositoryPostgres) Update(iteration Iteration) (Iteration, error) {
+	pgID, err := internal.ScanUUID(iteration.ID)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	iterationDB, err := r.Queries.UpdateIteration(r.ctx, db.UpdateIterationParams{
+		ID:       pgID,
+		Name:     iteration.Name,
+		StartsAt: pgtype.Timestamptz{Time: iteration.StartsAt, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: iteration.EndsAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Iteration{}, internal.NewNotFoundError(fmt.Sprintf("Iteration with id %s", iteration.ID))
+		}
+
+		return Iteration{}, err
+	}
+
+	return IterationDBToIterationModel(iterationDB)
+}
+
+func (r *IterationRepositoryPostgres) Delete(id uuid.UUID) error {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.Queries.DeleteIteration(r.ctx, pgID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return internal.NewNotFoundError(fmt.Sprintf("Iteration with id %s", id))
+	}
+
+	return nil
+}
+
+func (r *IterationRepositoryPostgres) Close(id uuid.UUID, closedAt time.Time) (Iteration, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	iterationDB, err := r.Queries.CloseIteration(r.ctx, db.CloseIterationParams{
+		ID:       pgID,
+		ClosedAt: pgtype.Timestamptz{Time: closedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Iteration{}, internal.NewNotFoundError(fmt.Sprintf("Open iteration with id %s", id))
+		}
+
+		r.logger.Error("failed to close iteration", slog.String("err", err.Error()))
+		return Iteration{}, err
+	}
+
+	return IterationDBToIterationModel(iterationDB)
+}
+
+func (r *IterationRepositoryPostgres) ListTasks(id uuid.UUID) ([]task.Task, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksDB, err := r.Queries.ListIterationTasks(r.ctx, pgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasksDBToTaskModels(tasksDB)
+}
+
+func (r *IterationRepositoryPostgres) SetTaskIteration(taskID uuid.UUID, iterationID *uuid.UUID) (task.Task, error) {
+	pgTaskID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	pgIterationID := pgtype.UUID{}
+	if iterationID != nil {
+		pgIterationID, err = internal.ScanUUID(*iterationID)
+		if err != nil {
+			return task.Task{}, err
+		}
+	}
+
+	taskDB, err := r.Queries.SetTaskIteration(r.ctx, db.SetTaskIterationParams{ID: pgTaskID, IterationID: pgIterationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return task.Task{}, internal.NewNotFoundError(fmt.Sprintf("Task with id %s", taskID))
+		}
+
+		return task.Task{}, err
+	}
+
+	return task.TaskDBToTaskModel(taskDB)
+}
+
+func (r *IterationRepositoryPostgres) RollOverTasks(fromID uuid.UUID, toID uuid.UUID) ([]task.Task, error) {
+	pgFromID, err := internal.ScanUUID(fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgToID, err := internal.ScanUUID(toID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksDB, err := r.Queries.RollOverIterationTasks(r.ctx, db.RollOverIterationTasksParams{
+		FromIterationID: pgFromID,
+		ToIterationID:   pgToID,
+	})
+	if err != nil {
+		r.logger.Error("failed to roll over the tasks of an iteration", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return tasksDBToTaskModels(tasksDB)
+}
+
+func (r *IterationRepositoryPostgres) ClearTasks(id uuid.UUID) ([]task.Task, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksDB, err := r.Queries.ClearIterationTasks(r.ctx, pgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasksDBToTaskModels(tasksDB)
+}
+
+func tasksDBToTaskModels(tasksDB []db.Task) ([]task.Task, error) {
+	tasks := []task.Task{}
+	for _, taskDB := range tasksDB {
+		t, err := task.TaskDBToTaskModel(taskDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,398 @@
+package iteration
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+var (
+	ErrIterationClosed = internal.NewConflictError("iteration is closed")
+	ErrNoNextIteration = internal.NewError(internal.ErrNotFound, "there is no open iteration after this one")
+	ErrSameIteration   = internal.NewValidationError("tasks cannot be rolled over into the iteration they are in")
+)
+
+// IterationService plans tasks from any project in iterations, closes iterations to measure
+// their velocity and rolls the tasks left over into the next ones.
+type IterationService struct {
+	repository        IterationRepository
+	taskRepository    task.TaskRepository
+	projectRepository project.ProjectRepository
+	logger            slog.Logger
+	// Where the changes of the tasks are recorded, nil if they are not
+	activity activity.Recorder
+	// Who is making the changes, see WithOrigin
+	origin activity.Origin
+	// Where the changes made in a transaction are kept until it is saved, nil outside of one
+	pending *[]activity.Event
+	limits  internal.Limits
+}
+
+type IterationServiceOption func(*IterationService)
+
+// WithActivityRecorder records every task planned by the service in the activity history.
+func WithActivityRecorder(recorder activity.Recorder) IterationServiceOption {
+	return func(s *IterationService) {
+		s.activity = recorder
+	}
+}
+
+// WithLimits sets the limits the names of iterations are validated against, instead of
+// internal.DefaultLimits.
+func WithLimits(limits internal.Limits) IterationServiceOption {
+	return func(s *IterationService) {
+		s.limits = limits
+	}
+}
+
+func NewIterationService(repository IterationRepository, taskRepository task.TaskRepository, projectRepository project.ProjectRepository, opts ...IterationServiceOption) *IterationService {
+	s := &IterationService{
+		repository:        repository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		logger:            *internal.NewLogger("IterationService"),
+		limits:            internal.DefaultLimits,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithOrigin returns a copy of the service that records its changes as made by origin.
+func (s IterationService) WithOrigin(origin activity.Origin) *IterationService {
+	s.origin = origin
+	return &s
+}
+
+// CreateIteration creates an open iteration from startsAt (inclusive) to endsAt (exclusive).
+func (s *IterationService) CreateIteration(name string, startsAt time.Time, endsAt time.Time) (Iteration, error) {
+	name = internal.NormalizeName(name)
+	err := s.validate(name, startsAt, endsAt)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	iteration := NewIteration(name, startsAt, endsAt)
+	err = s.repository.Create(iteration)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	return iteration, nil
+}
+
+func (s *IterationService) GetIteration(id uuid.UUID) (Iteration, error) {
+	return s.repository.Get(id)
+}
+
+// ListIterations lists every iteration, by start date.
+func (s *IterationService) ListIterations() ([]Iteration, error) {
+	return s.repository.List()
+}
+
+// IterationUpdate holds the changes made to an iteration by UpdateIteration. Nil fields are left
+// as they are.
+type IterationUpdate struct {
+	Name     *string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// UpdateIteration renames an open iteration or changes its dates.
+func (s *IterationService) UpdateIteration(id uuid.UUID, update IterationUpdate) (Iteration, error) {
+	iteration, err := s.repository.Get(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+	if iteration.IsClosed() {
+		return Iteration{}, fmt.Errorf("Cannot change iteration %s: %w", id, ErrIterationClosed)
+	}
+
+	if update.Name != nil {
+		iteration.Name = internal.NormalizeName(*update.Name)
+	}
+	if update.StartsAt != nil {
+		iteration.StartsAt = *update.StartsAt
+	}
+	if update.EndsAt != nil {
+		iteration.EndsAt = *update.EndsAt
+	}
+
+	err = s.validate(iteration.Name, iteration.StartsAt, iteration.EndsAt)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	return s.repository.Update(iteration)
+}
+
+// DeleteIteration deletes an iteration, along with its velocity. Its tasks are not deleted, they
+// are just no longer planned.
+func (s *IterationService) DeleteIteration(id uuid.UUID) (Iteration, error) {
+	var iteration Iteration
+	err := s.inTransaction(func(txService *IterationService) (err error) {
+		iteration, err = txService.deleteIteration(id)
+		return err
+	})
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	return iteration, nil
+}
+
+func (s *IterationService) deleteIteration(id uuid.UUID) (Iteration, error) {
+	iteration, err := s.repository.Get(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	tasks, err := s.repository.ClearTasks(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+	for _, t := range tasks {
+		s.record(t, &id)
+	}
+
+	err = s.repository.Delete(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+
+	return iteration, nil
+}
+
+// ListIterationTasks lists the tasks planned in an iteration, pending ones first.
+func (s *IterationService) ListIterationTasks(id uuid.UUID) ([]task.Task, error) {
+	_, err := s.repository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListTasks(id)
+}
+
+// PlanTask plans a task in an open iteration or, if iterationID is nil, takes it out of its
+// iteration. Subtasks are planned on their own, so neither the parent task nor the subtasks of
+// the task are planned along with it.
+func (s *IterationService) PlanTask(taskID uuid.UUID, iterationID *uuid.UUID) (task.Task, error) {
+	t, err := s.taskRepository.Get(taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	err = s.ensureProjectIsActive(t.ProjectID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	if iterationID != nil {
+		iteration, err := s.repository.Get(*iterationID)
+		if err != nil {
+			return task.Task{}, err
+		}
+		if iteration.IsClosed() {
+			return task.Task{}, fmt.Errorf("Cannot plan task %s in iteration %s: %w", taskID, iteration.ID, ErrIterationClosed)
+		}
+	}
+
+	if sameIteration(t.IterationID, iterationID) {
+		return t, nil
+	}
+
+	updatedTask, err := s.repository.SetTaskIteration(taskID, iterationID)
+	if errors.Is(err, internal.ErrNotFound) && iterationID != nil {
+		// Unless the task was deleted, the iteration was closed in the meantime
+		iteration, getErr := s.repository.Get(*iterationID)
+		if getErr == nil && iteration.IsClosed() {
+			return task.Task{}, fmt.Errorf("Cannot plan task %s in iteration %s: %w", taskID, iteration.ID, ErrIterationClosed)
+		}
+	}
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.record(updatedTask, t.IterationID)
+	return updatedTask, nil
+}
+
+// CloseIteration closes an open iteration, which measures its velocity. Once closed, its tasks
+// can still be completed, but the velocity no longer changes and no task can be planned in it.
+func (s *IterationService) CloseIteration(id uuid.UUID) (Iteration, error) {
+	iteration, err := s.repository.Get(id)
+	if err != nil {
+		return Iteration{}, err
+	}
+	if iteration.IsClosed() {
+		return Iteration{}, fmt.Errorf("Cannot close iteration %s: %w", id, ErrIterationClosed)
+	}
+
+	iteration, err = s.repository.Close(id, time.Now().UTC())
+	if errors.Is(err, internal.ErrNotFound) {
+		// Closed in the meantime
+		return Iteration{}, fmt.Errorf("Cannot close iteration %s: %w", id, ErrIterationClosed)
+	}
+
+	return iteration, err
+}
+
+// RollOverTasks moves the pending tasks of an iteration into another one, by default the first
+// open iteration starting after it. The tasks of archived projects are left where they are. It
+// returns the iteration the tasks were moved to, and the tasks.
+func (s *IterationService) RollOverTasks(id uuid.UUID, toID *uuid.UUID) (Iteration, []task.Task, error) {
+	var next Iteration
+	var tasks []task.Task
+	err := s.inTransaction(func(txService *IterationService) (err error) {
+		next, tasks, err = txService.rollOverTasks(id, toID)
+		return err
+	})
+	if err != nil {
+		return Iteration{}, nil, err
+	}
+
+	return next, tasks, nil
+}
+
+func (s *IterationService) rollOverTasks(id uuid.UUID, toID *uuid.UUID) (Iteration, []task.Task, error) {
+	iteration, err := s.repository.Get(id)
+	if err != nil {
+		return Iteration{}, nil, err
+	}
+
+	var next Iteration
+	if toID == nil {
+		next, err = s.repository.GetNext(iteration)
+		if errors.Is(err, internal.ErrNotFound) {
+			return Iteration{}, nil, ErrNoNextIteration
+		}
+	} else {
+		next, err = s.repository.Get(*toID)
+	}
+	if err != nil {
+		return Iteration{}, nil, err
+	}
+
+	if next.ID == iteration.ID {
+		return Iteration{}, nil, ErrSameIteration
+	}
+	if next.IsClosed() {
+		return Iteration{}, nil, fmt.Errorf("Cannot roll tasks over into iteration %s: %w", next.ID, ErrIterationClosed)
+	}
+
+	tasks, err := s.repository.RollOverTasks(id, next.ID)
+	if err != nil {
+		return Iteration{}, nil, err
+	}
+	for _, t := range tasks {
+		s.record(t, &id)
+	}
+
+	return next, tasks, nil
+}
+
+func (s *IterationService) validate(name string, startsAt time.Time, endsAt time.Time) error {
+	fieldErrs := internal.FieldErrors{}
+	if fieldErr := internal.ValidateName("name", name, s.limits.MaxNameLength); fieldErr != nil {
+		fieldErrs = append(fieldErrs, *fieldErr)
+	}
+	if !endsAt.After(startsAt) {
+		fieldErrs = append(fieldErrs, internal.FieldError{Field: "endsAt", Message: "must be after startsAt"})
+	}
+
+	return fieldErrs.Err()
+}
+
+// ensureProjectIsActive checks that a project is not archived, since the tasks of archived
+// projects are frozen.
+func (s *IterationService) ensureProjectIsActive(projectID uuid.UUID) error {
+	proj, err := s.projectRepository.Get(projectID)
+	if err != nil {
+		return err
+	}
+	if proj.IsArchived() {
+		return fmt.Errorf("Cannot plan the tasks of project %s: %w", projectID, project.ErrProjectArchived)
+	}
+
+	return nil
+}
+
+// inTransaction runs fn with a copy of the service whose changes are only saved if fn succeeds.
+// The changes are recorded in the activity history once they are saved.
+func (s *IterationService) inTransaction(fn func(txService *IterationService) error) error {
+	pending := []activity.Event{}
+	err := s.repository.InTransaction(func(repository IterationRepository, taskRepository task.TaskRepository) error {
+		txService := *s
+		txService.repository = repository
+		txService.taskRepository = taskRepository
+		txService.pending = &pending
+		return fn(&txService)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range pending {
+		s.recordEvent(event)
+	}
+
+	return nil
+}
+
+// record appends the planning of a task to the activity history and saves the task as a new
+// revision, like the changes made by the task service. The change itself was already made, so
+// failing to record it is logged rather than returned. In a transaction, the planning is only
+// appended to the history once the transaction is saved.
+func (s *IterationService) record(t task.Task, previousIterationID *uuid.UUID) {
+	_, err := s.taskRepository.CreateRevision(t, activity.ActionPlanned, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("failed to save task revision", slog.Any("task", t), slog.String("err", err.Error()))
+	}
+
+	if s.activity == nil {
+		return
+	}
+
+	event := activity.NewEvent(
+		s.origin,
+		activity.ActionPlanned,
+		t.ProjectID,
+		&t.ID,
+		map[string]any{"iterationID": previousIterationID},
+		map[string]any{"iterationID": t.IterationID},
+	)
+	if s.pending != nil {
+		*s.pending = append(*s.pending, event)
+		return
+	}
+
+	s.recordEvent(event)
+}
+
+func (s *IterationService) recordEvent(event activity.Event) {
+	if s.activity == nil {
+		return
+	}
+
+	_, err := s.activity.Record(event)
+	if err != nil {
+		s.logger.Error("failed to record task activity", slog.Any("event", event), slog.String("err", err.Error()))
+	}
+}
+
+func sameIteration(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
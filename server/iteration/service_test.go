@@ -0,0 +1,302 @@
+package iteration
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type IterationServiceTestSuite struct {
+	suite.Suite
+	ctx               context.Context
+	pgContainer       *testhelpers.PostgresContainer
+	iterationService  *IterationService
+	taskService       *task.TaskService
+	taskRepository    task.TaskRepository
+	projectRepository project.ProjectRepository
+	projectID         uuid.UUID
+}
+
+func (suite *IterationServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewIterationRepositoryPostgres(suite.ctx, pgPool)
+	suite.taskRepository = task.NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	suite.projectRepository = project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = task.NewTaskService(suite.taskRepository, suite.projectRepository)
+	suite.iterationService = NewIterationService(repository, suite.taskRepository, suite.projectRepository)
+}
+
+// Setup database before each test
+func (suite *IterationServiceTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupIterationsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	testProject := project.NewProject("Test project")
+	require.NoError(t, suite.projectRepository.Create(testProject))
+	suite.projectID = testProject.ID
+}
+
+func (suite *IterationServiceTestSuite) TearDownSuite() {
+	if err := suite.pgContainer.Terminate(suite.ctx); err != nil {
+		log.Fatalf("error terminating postgres container: %s", err)
+	}
+}
+
+var sprintStart = time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+func (suite *IterationServiceTestSuite) createSprint(name string, week int) Iteration {
+	startsAt := sprintStart.AddDate(0, 0, 14*week)
+	it, err := suite.iterationService.CreateIteration(name, startsAt, startsAt.AddDate(0, 0, 14))
+	require.NoError(suite.T(), err)
+
+	return it
+}
+
+func (suite *IterationServiceTestSuite) TestCreateIteration() {
+	t := suite.T()
+
+	it, err := suite.iterationService.CreateIteration("  Sprint 1 ", sprintStart, sprintStart.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	assert.Equal(t, "Sprint 1", it.Name)
+	assert.False(t, it.IsClosed())
+
+	fetched, err := suite.iterationService.GetIteration(it.ID)
+	require.NoError(t, err)
+	assert.Equal(t, it.ID, fetched.ID)
+	assert.Nil(t, fetched.Velocity)
+
+	_, err = suite.iterationService.CreateIteration("Backwards", sprintStart, sprintStart)
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.iterationService.CreateIteration(" ", sprintStart, sprintStart.AddDate(0, 0, 14))
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.iterationService.GetIteration(uuid.New())
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *IterationServiceTestSuite) TestUpdateIteration() {
+	t := suite.T()
+
+	it := suite.createSprint("Sprint 1", 0)
+
+	name := "First sprint"
+	endsAt := it.EndsAt.AddDate(0, 0, 7)
+	updated, err := suite.iterationService.UpdateIteration(it.ID, IterationUpdate{Name: &name, EndsAt: &endsAt})
+	require.NoError(t, err)
+	assert.Equal(t, name, updated.Name)
+	assert.True(t, endsAt.Equal(updated.EndsAt))
+	assert.True(t, it.StartsAt.Equal(updated.StartsAt))
+
+	startsAt := endsAt
+	_, err = suite.iterationService.UpdateIteration(it.ID, IterationUpdate{StartsAt: &startsAt})
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.iterationService.CloseIteration(it.ID)
+	require.NoError(t, err)
+	_, err = suite.iterationService.UpdateIteration(it.ID, IterationUpdate{Name: &name})
+	assert.ErrorIs(t, err, ErrIterationClosed)
+}
+
+func (suite *IterationServiceTestSuite) TestPlanTask() {
+	t := suite.T()
+
+	it := suite.createSprint("Sprint 1", 0)
+	parent, err := suite.taskService.CreateTask("Parent", suite.projectID, nil)
+	require.NoError(t, err)
+	child, err := suite.taskService.CreateTask("Child", suite.projectID, &parent.ID)
+	require.NoError(t, err)
+
+	planned, err := suite.iterationService.PlanTask(child.ID, &it.ID)
+	require.NoError(t, err)
+	require.NotNil(t, planned.IterationID)
+	assert.Equal(t, it.ID, *planned.IterationID)
+	assert.Greater(t, planned.Version, child.Version)
+
+	// The hierarchy of the task is left as it is
+	assert.Equal(t, &parent.ID, planned.ParentTaskID)
+	assert.Equal(t, suite.projectID, planned.ProjectID)
+
+	tasks, err := suite.iterationService.ListIterationTasks(it.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, child.ID, tasks[0].ID)
+
+	revisions, err := suite.taskService.ListRevisions(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, activity.ActionPlanned, revisions[len(revisions)-1].Action)
+
+	unplanned, err := suite.iterationService.PlanTask(child.ID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, unplanned.IterationID)
+
+	_, err = suite.iterationService.PlanTask(child.ID, &[]uuid.UUID{uuid.New()}[0])
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	_, err = suite.iterationService.CloseIteration(it.ID)
+	require.NoError(t, err)
+	_, err = suite.iterationService.PlanTask(child.ID, &it.ID)
+	assert.ErrorIs(t, err, ErrIterationClosed)
+	// even if the iteration is closed after the service checked it
+	_, err = suite.iterationService.repository.SetTaskIteration(child.ID, &it.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	// The tasks of archived projects are frozen
+	other := suite.createSprint("Sprint 2", 1)
+	_, err = suite.projectRepository.Archive(suite.projectID, time.Now().UTC())
+	require.NoError(t, err)
+	_, err = suite.iterationService.PlanTask(child.ID, &other.ID)
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+}
+
+func (suite *IterationServiceTestSuite) TestCloseIteration() {
+	t := suite.T()
+
+	minutesProject := project.NewProject("Minutes project")
+	minutesProject.EstimateUnit = project.EstimateUnitMinutes
+	require.NoError(t, suite.projectRepository.Create(minutesProject))
+
+	it := suite.createSprint("Sprint 1", 0)
+	plan := func(name string, projectID uuid.UUID, estimate *int, completed bool) task.Task {
+		tsk, err := suite.taskService.CreateTask(name, projectID, nil)
+		require.NoError(t, err)
+		if estimate != nil {
+			_, err = suite.taskService.UpdateTaskEstimate(tsk.ID, estimate)
+			require.NoError(t, err)
+		}
+		if completed {
+			require.NoError(t, suite.taskService.UpdateTaskStatus(tsk.ID, task.TaskStatusCompleted.String()))
+		}
+		tsk, err = suite.iterationService.PlanTask(tsk.ID, &it.ID)
+		require.NoError(t, err)
+
+		return tsk
+	}
+	plan("Points", suite.projectID, intPtr(3), true)
+	plan("More points", suite.projectID, intPtr(5), true)
+	plan("Unestimated", suite.projectID, nil, true)
+	plan("Pending", suite.projectID, intPtr(8), false)
+	plan("Minutes", minutesProject.ID, intPtr(90), true)
+
+	closed, err := suite.iterationService.CloseIteration(it.ID)
+	require.NoError(t, err)
+	assert.True(t, closed.IsClosed())
+	assert.Equal(t, &Velocity{CompletedTasks: 4, Points: 8, Minutes: 90}, closed.Velocity)
+
+	// The velocity is frozen once the iteration is closed
+	pending, err := suite.taskService.SearchTaskName("Pending", suite.projectID)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(pending[0].ID, task.TaskStatusCompleted.String()))
+	fetched, err := suite.iterationService.GetIteration(it.ID)
+	require.NoError(t, err)
+	assert.Equal(t, closed.Velocity, fetched.Velocity)
+
+	_, err = suite.iterationService.CloseIteration(it.ID)
+	assert.ErrorIs(t, err, ErrIterationClosed)
+}
+
+func (suite *IterationServiceTestSuite) TestRollOverTasks() {
+	t := suite.T()
+
+	first := suite.createSprint("Sprint 1", 0)
+	second := suite.createSprint("Sprint 2", 1)
+	third := suite.createSprint("Sprint 3", 2)
+
+	done, err := suite.taskService.CreateTask("Done", suite.projectID, nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(done.ID, task.TaskStatusCompleted.String()))
+	left, err := suite.taskService.CreateTask("Left", suite.projectID, nil)
+	require.NoError(t, err)
+	for _, id := range []uuid.UUID{done.ID, left.ID} {
+		_, err := suite.iterationService.PlanTask(id, &first.ID)
+		require.NoError(t, err)
+	}
+
+	_, err = suite.iterationService.CloseIteration(first.ID)
+	require.NoError(t, err)
+
+	// By default, to the next iteration
+	next, moved, err := suite.iterationService.RollOverTasks(first.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, next.ID)
+	require.Len(t, moved, 1)
+	assert.Equal(t, left.ID, moved[0].ID)
+	assert.Equal(t, &second.ID, moved[0].IterationID)
+
+	// Completed tasks stay in the closed iteration
+	tasks, err := suite.iterationService.ListIterationTasks(first.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, done.ID, tasks[0].ID)
+
+	next, moved, err = suite.iterationService.RollOverTasks(second.ID, &third.ID)
+	require.NoError(t, err)
+	assert.Equal(t, third.ID, next.ID)
+	assert.Len(t, moved, 1)
+
+	_, _, err = suite.iterationService.RollOverTasks(third.ID, nil)
+	assert.ErrorIs(t, err, ErrNoNextIteration)
+
+	_, _, err = suite.iterationService.RollOverTasks(third.ID, &third.ID)
+	assert.ErrorIs(t, err, ErrSameIteration)
+
+	_, _, err = suite.iterationService.RollOverTasks(third.ID, &first.ID)
+	assert.ErrorIs(t, err, ErrIterationClosed)
+}
+
+func (suite *IterationServiceTestSuite) TestDeleteIteration() {
+	t := suite.T()
+
+	it := suite.createSprint("Sprint 1", 0)
+	tsk, err := suite.taskService.CreateTask("Task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.iterationService.PlanTask(tsk.ID, &it.ID)
+	require.NoError(t, err)
+
+	deleted, err := suite.iterationService.DeleteIteration(it.ID)
+	require.NoError(t, err)
+	assert.Equal(t, it.ID, deleted.ID)
+
+	// The task is kept, only no longer planned
+	tsk, err = suite.taskRepository.Get(tsk.ID)
+	require.NoError(t, err)
+	assert.Nil(t, tsk.IterationID)
+
+	_, err = suite.iterationService.DeleteIteration(it.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func TestIterationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IterationServiceTestSuite))
+}
+
+func intPtr(i int) *int {
+	return &i
+}
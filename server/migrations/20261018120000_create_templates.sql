@@ -0,0 +1,22 @@
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "due_at" timestamp NULL;
+-- Create "templates" table
+CREATE TABLE "public"."templates" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamp NOT NULL DEFAULT now(),
+  "name" text NOT NULL UNIQUE,
+  PRIMARY KEY ("id")
+);
+-- Create "template_tasks" table
+CREATE TABLE "public"."template_tasks" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "template_id" uuid NOT NULL,
+  "parent_template_task_id" uuid NULL,
+  "name" text NOT NULL,
+  "order" integer NOT NULL DEFAULT 0,
+  "due_offset_days" integer NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "template_tasks_template_id_fkey" FOREIGN KEY ("template_id") REFERENCES "public"."templates" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "template_tasks_parent_template_task_id_fkey" FOREIGN KEY ("parent_template_task_id") REFERENCES "public"."template_tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "template_tasks_order_check" CHECK ("order" >= 0)
+);
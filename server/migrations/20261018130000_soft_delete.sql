@@ -0,0 +1,10 @@
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ADD COLUMN "deleted_at" timestamp NULL, DROP CONSTRAINT "projects_name_key";
+-- Create index "projects_name_key" to table: "projects"
+CREATE UNIQUE INDEX "projects_name_key" ON "public"."projects" ("name") WHERE (deleted_at IS NULL);
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "deleted_at" timestamp NULL, DROP CONSTRAINT "tasks_project_id_parent_task_id_order_key";
+-- Create index "tasks_project_id_parent_task_id_order_key" to table: "tasks"
+CREATE UNIQUE INDEX "tasks_project_id_parent_task_id_order_key" ON "public"."tasks" ("project_id", "parent_task_id", "order") WHERE (deleted_at IS NULL);
+-- Create index "tasks_deleted_at" to table: "tasks"
+CREATE INDEX "tasks_deleted_at" ON "public"."tasks" ("deleted_at") WHERE (deleted_at IS NOT NULL);
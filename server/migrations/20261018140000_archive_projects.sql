@@ -0,0 +1,6 @@
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ADD COLUMN "archived_at" timestamp NULL;
+-- Drop index "projects_name_key" from table: "projects"
+DROP INDEX "public"."projects_name_key";
+-- Create index "projects_name_key" to table: "projects"
+CREATE UNIQUE INDEX "projects_name_key" ON "public"."projects" ("name") WHERE ((deleted_at IS NULL) AND (archived_at IS NULL));
@@ -0,0 +1,17 @@
+-- Create "activity_events" table
+CREATE TABLE "public"."activity_events" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "created_at" timestamp NOT NULL DEFAULT now(),
+  "project_id" uuid NOT NULL,
+  "task_id" uuid NULL,
+  "action" text NOT NULL,
+  "actor" text NOT NULL,
+  "request_id" text NOT NULL DEFAULT '',
+  "before" jsonb NULL,
+  "after" jsonb NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "activity_events_project_id_id" to table: "activity_events"
+CREATE INDEX "activity_events_project_id_id" ON "public"."activity_events" ("project_id", "id");
+-- Create index "activity_events_task_id_id" to table: "activity_events"
+CREATE INDEX "activity_events_task_id_id" ON "public"."activity_events" ("task_id", "id") WHERE (task_id IS NOT NULL);
@@ -0,0 +1,10 @@
+-- Create "task_revisions" table
+CREATE TABLE "public"."task_revisions" (
+  "task_id" uuid NOT NULL,
+  "revision" integer NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT now(),
+  "action" text NOT NULL,
+  "state" jsonb NOT NULL,
+  PRIMARY KEY ("task_id", "revision"),
+  CONSTRAINT "task_revisions_task_id_fkey" FOREIGN KEY ("task_id") REFERENCES "public"."tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
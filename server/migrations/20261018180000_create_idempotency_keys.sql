@@ -0,0 +1,14 @@
+-- Create "idempotency_keys" table
+CREATE TABLE "public"."idempotency_keys" (
+  "key" text NOT NULL,
+  "fingerprint" text NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT now(),
+  "expires_at" timestamp NOT NULL,
+  "status_code" integer NULL,
+  "headers" jsonb NULL,
+  "body" bytea NULL,
+  PRIMARY KEY ("key")
+);
+
+-- Create index "idempotency_keys_expires_at" to table: "idempotency_keys"
+CREATE INDEX "idempotency_keys_expires_at" ON "public"."idempotency_keys" ("expires_at");
@@ -0,0 +1,10 @@
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ADD COLUMN "description" text NOT NULL DEFAULT '', ADD COLUMN "color" text NULL, ADD COLUMN "icon" text NULL, ADD COLUMN "order" integer NOT NULL DEFAULT 0, ADD CONSTRAINT "projects_order_check" CHECK ("order" >= 0);
+-- Existing projects keep the order they were listed in, by name
+UPDATE "public"."projects" SET "order" = "ranked"."position"
+FROM (
+  SELECT "id", row_number() OVER (ORDER BY "name", "id") - 1 AS "position"
+  FROM "public"."projects"
+  WHERE "deleted_at" IS NULL
+) AS "ranked"
+WHERE "projects"."id" = "ranked"."id";
@@ -0,0 +1,30 @@
+-- The timestamps were stored in UTC, without their time zone
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC', ALTER COLUMN "deleted_at" TYPE timestamptz USING "deleted_at" AT TIME ZONE 'UTC', ALTER COLUMN "archived_at" TYPE timestamptz USING "archived_at" AT TIME ZONE 'UTC';
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC', ALTER COLUMN "due_at" TYPE timestamptz USING "due_at" AT TIME ZONE 'UTC', ALTER COLUMN "deleted_at" TYPE timestamptz USING "deleted_at" AT TIME ZONE 'UTC', ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT now(), ADD COLUMN "completed_at" timestamptz NULL;
+-- Modify "task_revisions" table
+ALTER TABLE "public"."task_revisions" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC';
+-- Modify "templates" table
+ALTER TABLE "public"."templates" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC';
+-- Modify "activity_events" table
+ALTER TABLE "public"."activity_events" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC';
+-- Modify "idempotency_keys" table
+ALTER TABLE "public"."idempotency_keys" ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC', ALTER COLUMN "expires_at" TYPE timestamptz USING "expires_at" AT TIME ZONE 'UTC';
+-- Existing tasks were last updated by their last recorded change, if any
+UPDATE "public"."tasks" SET "updated_at" = coalesce((
+  SELECT max("e"."created_at") FROM "public"."activity_events" AS "e"
+  WHERE "e"."task_id" = "tasks"."id"
+), "created_at");
+-- Completed tasks were completed by their last status change to "completed", if it was recorded
+UPDATE "public"."tasks" SET "completed_at" = coalesce((
+  SELECT max("e"."created_at") FROM "public"."activity_events" AS "e"
+  WHERE "e"."task_id" = "tasks"."id"
+    AND "e"."action" = 'status_changed'
+    AND "e"."after" ->> 'status' = 'completed'
+), "updated_at")
+WHERE "status" = 'completed';
+-- Create index "tasks_completed_at" to table: "tasks"
+CREATE INDEX "tasks_completed_at" ON "public"."tasks" ("completed_at") WHERE (completed_at IS NOT NULL);
+-- Create index "tasks_project_id_completed_at" to table: "tasks"
+CREATE INDEX "tasks_project_id_completed_at" ON "public"."tasks" ("project_id", "completed_at") WHERE (completed_at IS NOT NULL);
@@ -0,0 +1,17 @@
+-- Create "time_entries" table
+CREATE TABLE "public"."time_entries" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "task_id" uuid NOT NULL,
+  "actor" text NOT NULL,
+  "started_at" timestamptz NOT NULL,
+  "ended_at" timestamptz NULL,
+  "note" text NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "time_entries_task_id_fkey" FOREIGN KEY ("task_id") REFERENCES "public"."tasks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "time_entries_check" CHECK ((ended_at IS NULL) OR (ended_at >= started_at))
+);
+-- Create index "time_entries_task_id_started_at" to table: "time_entries"
+CREATE INDEX "time_entries_task_id_started_at" ON "public"."time_entries" ("task_id", "started_at");
+-- Create index "time_entries_running_actor_key" to table: "time_entries"
+CREATE UNIQUE INDEX "time_entries_running_actor_key" ON "public"."time_entries" ("actor") WHERE (ended_at IS NULL);
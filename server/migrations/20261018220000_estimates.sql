@@ -0,0 +1,4 @@
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ADD COLUMN "estimate_unit" text NOT NULL DEFAULT 'points', ADD CONSTRAINT "projects_estimate_unit_check" CHECK (estimate_unit = ANY (ARRAY['points'::text, 'minutes'::text]));
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "estimate" integer NULL, ADD CONSTRAINT "tasks_estimate_check" CHECK (estimate >= 0);
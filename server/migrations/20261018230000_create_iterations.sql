@@ -0,0 +1,20 @@
+-- Create "iterations" table
+CREATE TABLE "public"."iterations" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "closed_at" timestamptz NULL,
+  "completed_tasks" integer NULL,
+  "completed_points" integer NULL,
+  "completed_minutes" integer NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "iterations_check" CHECK (ends_at > starts_at)
+);
+-- Create index "iterations_starts_at" to table: "iterations"
+CREATE INDEX "iterations_starts_at" ON "public"."iterations" ("starts_at");
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "iteration_id" uuid NULL, ADD CONSTRAINT "tasks_iteration_id_fkey" FOREIGN KEY ("iteration_id") REFERENCES "public"."iterations" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Create index "tasks_iteration_id" to table: "tasks"
+CREATE INDEX "tasks_iteration_id" ON "public"."tasks" ("iteration_id") WHERE (iteration_id IS NOT NULL);
@@ -0,0 +1,21 @@
+-- Create "sections" table
+CREATE TABLE "public"."sections" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "project_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "order" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "sections_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "public"."projects" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "sections_order_check" CHECK ("order" >= 0)
+);
+-- Create index "sections_project_id_name_key" to table: "sections"
+CREATE UNIQUE INDEX "sections_project_id_name_key" ON "public"."sections" ("project_id", "name");
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "section_id" uuid NULL, ADD CONSTRAINT "tasks_section_id_fkey" FOREIGN KEY ("section_id") REFERENCES "public"."sections" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Drop index "tasks_project_id_parent_task_id_order_key" from table: "tasks"
+DROP INDEX "public"."tasks_project_id_parent_task_id_order_key";
+-- Create index "tasks_project_id_parent_task_id_section_id_order_key" to table: "tasks"
+CREATE UNIQUE INDEX "tasks_project_id_parent_task_id_section_id_order_key" ON "public"."tasks" ("project_id", "parent_task_id", "section_id", "order") WHERE (deleted_at IS NULL);
+-- Create index "tasks_section_id" to table: "tasks"
+CREATE INDEX "tasks_section_id" ON "public"."tasks" ("section_id") WHERE (section_id IS NOT NULL);
@@ -0,0 +1,19 @@
+-- Create "workspaces" table
+CREATE TABLE "public"."workspaces" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "workspaces_name_key" to table: "workspaces"
+CREATE UNIQUE INDEX "workspaces_name_key" ON "public"."workspaces" ("name");
+-- Modify "projects" table
+ALTER TABLE "public"."projects" ADD COLUMN "workspace_id" uuid NULL, ADD COLUMN "parent_project_id" uuid NULL, ADD CONSTRAINT "projects_workspace_id_fkey" FOREIGN KEY ("workspace_id") REFERENCES "public"."workspaces" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, ADD CONSTRAINT "projects_parent_project_id_fkey" FOREIGN KEY ("parent_project_id") REFERENCES "public"."projects" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Drop index "projects_name_key" from table: "projects"
+DROP INDEX "public"."projects_name_key";
+-- Create index "projects_workspace_id_name_key" to table: "projects"
+CREATE UNIQUE INDEX "projects_workspace_id_name_key" ON "public"."projects" ("workspace_id", "name") NULLS NOT DISTINCT WHERE ((deleted_at IS NULL) AND (archived_at IS NULL));
+-- Create index "projects_workspace_id" to table: "projects"
+CREATE INDEX "projects_workspace_id" ON "public"."projects" ("workspace_id") WHERE (workspace_id IS NOT NULL);
+-- Create index "projects_parent_project_id" to table: "projects"
+CREATE INDEX "projects_parent_project_id" ON "public"."projects" ("parent_project_id") WHERE (parent_project_id IS NOT NULL);
@@ -0,0 +1,27 @@
+-- Create "users" table
+CREATE TABLE "public"."users" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Create index "users_name_key" to table: "users"
+CREATE UNIQUE INDEX "users_name_key" ON "public"."users" ("name");
+-- Create "api_tokens" table
+CREATE TABLE "public"."api_tokens" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "token_hash" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "last_used_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "api_tokens_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "api_tokens_token_hash_key" to table: "api_tokens"
+CREATE UNIQUE INDEX "api_tokens_token_hash_key" ON "public"."api_tokens" ("token_hash");
+-- Create index "api_tokens_user_id" to table: "api_tokens"
+CREATE INDEX "api_tokens_user_id" ON "public"."api_tokens" ("user_id");
+-- Modify "activity_events" table
+ALTER TABLE "public"."activity_events" ADD COLUMN "user_id" uuid NULL;
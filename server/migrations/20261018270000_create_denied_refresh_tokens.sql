@@ -0,0 +1,10 @@
+-- Create "denied_refresh_tokens" table
+CREATE TABLE "public"."denied_refresh_tokens" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "denied_at" timestamptz NOT NULL DEFAULT now(),
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "denied_refresh_tokens_expires_at" to table: "denied_refresh_tokens"
+CREATE INDEX "denied_refresh_tokens_expires_at" ON "public"."denied_refresh_tokens" ("expires_at");
@@ -0,0 +1,13 @@
+-- Drop index "tasks_project_id_parent_task_id_section_id_order_key" from table: "tasks"
+DROP INDEX "public"."tasks_project_id_parent_task_id_section_id_order_key";
+-- Root tasks and tasks outside of sections could share an order, so each level is renumbered in
+-- the order its tasks were listed in
+UPDATE "public"."tasks" SET "order" = "ranked"."position"
+FROM (
+  SELECT "id", row_number() OVER (PARTITION BY "project_id", "parent_task_id", "section_id" ORDER BY "order", "id") - 1 AS "position"
+  FROM "public"."tasks"
+  WHERE "deleted_at" IS NULL
+) AS "ranked"
+WHERE "tasks"."id" = "ranked"."id" AND "tasks"."order" <> "ranked"."position";
+-- Create index "tasks_project_id_parent_task_id_section_id_order_key" to table: "tasks"
+CREATE UNIQUE INDEX "tasks_project_id_parent_task_id_section_id_order_key" ON "public"."tasks" ("project_id", "parent_task_id", "section_id", "order") NULLS NOT DISTINCT WHERE (deleted_at IS NULL);
@@ -0,0 +1,10 @@
+-- Modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "revision_count" integer NOT NULL DEFAULT 0;
+-- Tasks continue counting from their latest revision
+UPDATE "public"."tasks" SET "revision_count" = "latest"."revision"
+FROM (
+  SELECT "task_id", max("revision") AS "revision"
+  FROM "public"."task_revisions"
+  GROUP BY "task_id"
+) AS "latest"
+WHERE "tasks"."id" = "latest"."task_id";
@@ -0,0 +1,2 @@
+-- Modify "projects" table
+ALTER TABLE "public"."projects" DROP CONSTRAINT "projects_workspace_id_fkey", ADD CONSTRAINT "projects_workspace_id_fkey" FOREIGN KEY ("workspace_id") REFERENCES "public"."workspaces" ("id") ON UPDATE NO ACTION ON DELETE RESTRICT;
@@ -0,0 +1,10 @@
+-- Modify "time_entries" table
+ALTER TABLE "public"."time_entries" ADD COLUMN "user_id" uuid NULL;
+-- Attribute the existing entries to the users with the name of their actor
+UPDATE "public"."time_entries" e SET "user_id" = u."id" FROM "public"."users" u WHERE u."name" = e."actor";
+-- The running timers of no user can no longer be stopped, so stop them now
+UPDATE "public"."time_entries" SET "ended_at" = greatest(now(), "started_at") WHERE "user_id" IS NULL AND "ended_at" IS NULL;
+-- Drop index "time_entries_running_actor_key" from table: "time_entries"
+DROP INDEX "public"."time_entries_running_actor_key";
+-- Create index "time_entries_running_user_id_key" to table: "time_entries"
+CREATE UNIQUE INDEX "time_entries_running_user_id_key" ON "public"."time_entries" ("user_id") WHERE (ended_at IS NULL);
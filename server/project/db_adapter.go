@@ -1,6 +1,9 @@
 package project
 
 import (
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/murasakiwano/todoctian/server/db"
 	"github.com/murasakiwano/todoctian/server/internal"
 )
@@ -13,9 +16,77 @@ func ProjectDBToProjectModel(projectDB db.Project) (Project, error) {
 
 	createdAt := projectDB.CreatedAt.Time
 
+	var deletedAt *time.Time = nil
+	if projectDB.DeletedAt.Valid {
+		deletedAt = &projectDB.DeletedAt.Time
+	}
+
+	var archivedAt *time.Time = nil
+	if projectDB.ArchivedAt.Valid {
+		archivedAt = &projectDB.ArchivedAt.Time
+	}
+
+	var color *string = nil
+	if projectDB.Color.Valid {
+		color = &projectDB.Color.String
+	}
+
+	var icon *string = nil
+	if projectDB.Icon.Valid {
+		icon = &projectDB.Icon.String
+	}
+
+	var workspaceID *uuid.UUID = nil
+	if projectDB.WorkspaceID.Valid {
+		id, err := internal.EncodeUUID(projectDB.WorkspaceID.Bytes)
+		if err != nil {
+			return Project{}, err
+		}
+		workspaceID = &id
+	}
+
+	var parentProjectID *uuid.UUID = nil
+	if projectDB.ParentProjectID.Valid {
+		id, err := internal.EncodeUUID(projectDB.ParentProjectID.Bytes)
+		if err != nil {
+			return Project{}, err
+		}
+		parentProjectID = &id
+	}
+
 	return Project{
-		ID:        projectID,
-		CreatedAt: createdAt,
-		Name:      projectDB.Name,
+		ID:              projectID,
+		CreatedAt:       createdAt,
+		Name:            projectDB.Name,
+		Description:     projectDB.Description,
+		Color:           color,
+		Icon:            icon,
+		Order:           int(projectDB.Order),
+		DeletedAt:       deletedAt,
+		ArchivedAt:      archivedAt,
+		Version:         int(projectDB.Version),
+		EstimateUnit:    EstimateUnit(projectDB.EstimateUnit),
+		WorkspaceID:     workspaceID,
+		ParentProjectID: parentProjectID,
+	}, nil
+}
+
+func SectionDBToSectionModel(sectionDB db.Section) (Section, error) {
+	sectionID, err := internal.EncodeUUID(sectionDB.ID.Bytes)
+	if err != nil {
+		return Section{}, err
+	}
+
+	projectID, err := internal.EncodeUUID(sectionDB.ProjectID.Bytes)
+	if err != nil {
+		return Section{}, err
+	}
+
+	return Section{
+		ID:        sectionID,
+		ProjectID: projectID,
+		Name:      sectionDB.Name,
+		Order:     int(sectionDB.Order),
+		CreatedAt: sectionDB.CreatedAt.Time,
 	}, nil
 }
@@ -0,0 +1,131 @@
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// Maximum number of characters of an icon, enough for emojis made of several code points.
+const maxIconLength = 16
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ProjectUpdate holds the changes made to a project by UpdateProject. Nil fields are left as they
+// are, and an empty color or icon removes it.
+type ProjectUpdate struct {
+	Name         *string
+	Description  *string
+	Color        *string
+	Icon         *string
+	EstimateUnit *EstimateUnit
+}
+
+// UpdateProject changes the name, the description, the color, the icon and the estimate unit of a
+// project. Every field is validated before any change is made, and the invalid ones are reported
+// together. Changing the estimate unit leaves the estimates of the tasks as they are.
+func (p *ProjectService) UpdateProject(id uuid.UUID, update ProjectUpdate) (Project, error) {
+	fieldErrs := internal.FieldErrors{}
+	if update.Name != nil {
+		name := internal.NormalizeName(*update.Name)
+		if fieldErr := internal.ValidateName("name", name, p.limits.MaxNameLength); fieldErr != nil {
+			fieldErrs = append(fieldErrs, *fieldErr)
+		}
+	}
+	if update.Description != nil && utf8.RuneCountInString(*update.Description) > p.limits.MaxDescriptionLength {
+		fieldErrs = append(fieldErrs, internal.FieldError{
+			Field:   "description",
+			Message: fmt.Sprintf("must not be longer than %d characters", p.limits.MaxDescriptionLength),
+		})
+	}
+	if update.Color != nil && *update.Color != "" && !colorPattern.MatchString(*update.Color) {
+		fieldErrs = append(fieldErrs, internal.FieldError{
+			Field:   "color",
+			Message: "must be a hex color, e.g. #ff8800",
+		})
+	}
+	if update.Icon != nil && *update.Icon != "" {
+		if fieldErr := internal.ValidateName("icon", internal.NormalizeName(*update.Icon), maxIconLength); fieldErr != nil {
+			fieldErrs = append(fieldErrs, *fieldErr)
+		}
+	}
+	if update.EstimateUnit != nil && !update.EstimateUnit.IsValid() {
+		fieldErrs = append(fieldErrs, internal.FieldError{
+			Field:   "estimateUnit",
+			Message: fmt.Sprintf("must be %q or %q", EstimateUnitPoints, EstimateUnitMinutes),
+		})
+	}
+	if err := fieldErrs.Err(); err != nil {
+		return Project{}, err
+	}
+
+	project, err := p.repository.Get(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	if update.Name != nil {
+		project, err = p.RenameProject(id, *update.Name)
+		if err != nil {
+			return Project{}, err
+		}
+	}
+
+	description, color, icon, estimateUnit := project.Description, project.Color, project.Icon, project.EstimateUnit
+	if update.Description != nil {
+		description = *update.Description
+	}
+	if update.Color != nil {
+		color = optionalString(*update.Color)
+	}
+	if update.Icon != nil {
+		icon = optionalString(internal.NormalizeName(*update.Icon))
+	}
+	if update.EstimateUnit != nil {
+		estimateUnit = *update.EstimateUnit
+	}
+	if description == project.Description && sameString(color, project.Color) && sameString(icon, project.Icon) &&
+		estimateUnit == project.EstimateUnit {
+		return project, nil
+	}
+
+	before := map[string]any{
+		"description":  project.Description,
+		"color":        project.Color,
+		"icon":         project.Icon,
+		"estimateUnit": project.EstimateUnit,
+	}
+	project, err = p.repository.UpdateDetails(id, description, color, icon, estimateUnit)
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(project, activity.ActionUpdated, before, map[string]any{
+		"description":  project.Description,
+		"color":        project.Color,
+		"icon":         project.Icon,
+		"estimateUnit": project.EstimateUnit,
+	})
+	return project, nil
+}
+
+// optionalString returns nil for an empty string.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
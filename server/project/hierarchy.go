@@ -0,0 +1,148 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var (
+	ErrProjectCycle           = internal.NewValidationError("a project cannot be a sub-project of itself or of one of its sub-projects")
+	ErrParentInOtherWorkspace = internal.NewValidationError("a sub-project must be in the same workspace as its parent")
+)
+
+// SetParentProject makes a project a sub-project of another project of the same workspace, or a
+// top-level project if parentID is nil. Its own sub-projects move along with it.
+func (p *ProjectService) SetParentProject(id uuid.UUID, parentID *uuid.UUID) (Project, error) {
+	var project Project
+	err := p.inTransaction(func(txService *ProjectService) (err error) {
+		project, err = txService.setParentProject(id, parentID)
+		return err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+func (p *ProjectService) setParentProject(id uuid.UUID, parentID *uuid.UUID) (Project, error) {
+	// The ancestors of the new parent are checked under the lock, so that no other change of the
+	// hierarchy can make the project one of them in the meantime
+	err := p.repository.LockHierarchy()
+	if err != nil {
+		return Project{}, err
+	}
+
+	project, err := p.repository.Get(id)
+	if err != nil {
+		return Project{}, err
+	}
+	if sameUUID(project.ParentProjectID, parentID) {
+		return project, nil
+	}
+
+	if parentID != nil {
+		// Walk up from the new parent: meeting the project means the parent is one of its
+		// sub-projects
+		ancestor, err := p.repository.Get(*parentID)
+		if err != nil {
+			return Project{}, err
+		}
+		if !sameUUID(ancestor.WorkspaceID, project.WorkspaceID) {
+			return Project{}, ErrParentInOtherWorkspace
+		}
+		for {
+			if ancestor.ID == id {
+				return Project{}, ErrProjectCycle
+			}
+			if ancestor.ParentProjectID == nil {
+				break
+			}
+			ancestor, err = p.repository.Get(*ancestor.ParentProjectID)
+			if err != nil {
+				return Project{}, fmt.Errorf("Failed to fetch the ancestors of project %s: %w", *parentID, err)
+			}
+		}
+	}
+
+	movedProject, err := p.repository.SetParent(id, parentID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(movedProject, activity.ActionMoved, map[string]any{"parentProjectID": project.ParentProjectID}, map[string]any{"parentProjectID": movedProject.ParentProjectID})
+	return movedProject, nil
+}
+
+// MoveProjectToWorkspace moves a project, along with all of its sub-projects, to a workspace, or
+// out of any if workspaceID is nil. The project leaves its parent project, which stays behind.
+// Fails without moving any project if one of them goes by the name of a project of the workspace.
+func (p *ProjectService) MoveProjectToWorkspace(id uuid.UUID, workspaceID *uuid.UUID) (Project, error) {
+	var project Project
+	err := p.inTransaction(func(txService *ProjectService) (err error) {
+		project, err = txService.moveProjectToWorkspace(id, workspaceID)
+		return err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+func (p *ProjectService) moveProjectToWorkspace(id uuid.UUID, workspaceID *uuid.UUID) (Project, error) {
+	// Keeps a project from being made a sub-project of one of the moved ones, which would leave it
+	// with a parent in another workspace
+	err := p.repository.LockHierarchy()
+	if err != nil {
+		return Project{}, err
+	}
+
+	project, err := p.repository.Get(id)
+	if err != nil {
+		return Project{}, err
+	}
+	if sameUUID(project.WorkspaceID, workspaceID) {
+		return project, nil
+	}
+
+	tree, err := p.repository.ListTree(id)
+	if err != nil {
+		return Project{}, err
+	}
+	for _, pr := range tree {
+		taken, err := p.nameTaken(workspaceID, pr.Name, pr.IsArchived(), pr.ID)
+		if err != nil {
+			return Project{}, err
+		}
+		if taken {
+			return Project{}, internal.NewAlreadyExistsError(fmt.Sprintf("Project with name \"%s\" in the workspace", pr.Name))
+		}
+	}
+
+	movedProjects, err := p.repository.MoveToWorkspace(id, workspaceID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	movedProject := project
+	for _, moved := range movedProjects {
+		if moved.ID == id {
+			movedProject = moved
+		}
+		p.record(moved, activity.ActionMoved, map[string]any{"workspaceID": project.WorkspaceID}, map[string]any{"workspaceID": moved.WorkspaceID})
+	}
+
+	return movedProject, nil
+}
+
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
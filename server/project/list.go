@@ -0,0 +1,121 @@
+package project
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrInvalidSort = internal.NewValidationError("invalid sort field")
+
+// The fields projects can be sorted by. Ties are broken by project ID.
+type SortField string
+
+const (
+	SortByName      SortField = "name"
+	SortByCreatedAt SortField = "created_at"
+	// The order set by the user, see ReorderProject
+	SortByOrder SortField = "order"
+)
+
+// ListOptions filters and sorts the projects listed by ListProjectsPage.
+type ListOptions struct {
+	// Defaults to SortByOrder
+	SortBy SortField
+	// Cursor of the page to list, empty for the first page
+	Cursor string
+	// Number of projects in the page, see internal.PageSize
+	Limit int
+	// Only list the projects of this workspace
+	WorkspaceID *uuid.UUID
+	// List the archived projects too
+	IncludeArchived bool
+	Descending      bool
+}
+
+// A Page is a slice of a list of projects.
+type Page struct {
+	Projects []Project
+	// Cursor of the next page, empty on the last page
+	NextCursor string
+}
+
+// ListProjectsPage lists a page of the projects that are not in the trash, with keyset queries.
+func (p *ProjectService) ListProjectsPage(opts ListOptions) (Page, error) {
+	switch opts.SortBy {
+	case "":
+		opts.SortBy = SortByOrder
+	case SortByName, SortByCreatedAt, SortByOrder:
+	default:
+		return Page{}, fmt.Errorf("%w: %s", ErrInvalidSort, opts.SortBy)
+	}
+
+	var after *Project
+	if opts.Cursor != "" {
+		project, err := decodeProjectCursor(opts.Cursor, opts.SortBy, opts.Descending)
+		if err != nil {
+			return Page{}, err
+		}
+		after = &project
+	}
+
+	limit := internal.PageSize(opts.Limit)
+
+	// One more project than requested is fetched to know whether there is a next page
+	projects, err := p.repository.ListPage(opts, after, limit+1)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Projects: projects}
+	if len(projects) > limit {
+		page.Projects = projects[:limit]
+		page.NextCursor = projectCursor(page.Projects[limit-1], opts.SortBy, opts.Descending).Encode()
+	}
+
+	return page, nil
+}
+
+func projectCursor(project Project, sortBy SortField, descending bool) internal.PageCursor {
+	cursor := internal.PageCursor{SortBy: string(sortBy), Descending: descending, ID: project.ID}
+	switch sortBy {
+	case SortByCreatedAt:
+		cursor.Key = project.CreatedAt.Format(time.RFC3339Nano)
+	case SortByOrder:
+		cursor.Key = strconv.Itoa(project.Order)
+	default:
+		cursor.Key = project.Name
+	}
+
+	return cursor
+}
+
+// Decodes a cursor into the last project of the previous page, of which only the ID and the sort
+// key are set.
+func decodeProjectCursor(encoded string, sortBy SortField, descending bool) (Project, error) {
+	cursor, err := internal.DecodePageCursor(encoded, string(sortBy), descending)
+	if err != nil {
+		return Project{}, err
+	}
+
+	project := Project{ID: cursor.ID}
+	switch sortBy {
+	case SortByCreatedAt:
+		project.CreatedAt, err = time.Parse(time.RFC3339Nano, cursor.Key)
+		if err != nil {
+			return Project{}, internal.ErrInvalidCursor
+		}
+	case SortByOrder:
+		project.Order, err = strconv.Atoi(cursor.Key)
+		if err != nil {
+			return Project{}, internal.ErrInvalidCursor
+		}
+	default:
+		project.Name = cursor.Key
+	}
+
+	return project, nil
+}
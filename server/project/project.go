@@ -7,31 +7,99 @@ import (
 	"github.com/google/uuid"
 )
 
-// A Project is a collection of tasks. The only attribute releant to the user is
-// its name.
+// A Project is a collection of tasks. Besides its name, it has a few attributes that only help the
+// user tell projects apart, e.g. its color.
 type Project struct {
 	CreatedAt time.Time
-	Name      string      // Name of the project
-	Tasks     []uuid.UUID // IDs of the project's tasks
-	ID        uuid.UUID   // ID of the project
+	Name      string     // Name of the project
+	ID        uuid.UUID  // ID of the project
+	DeletedAt *time.Time // When the project was moved to the trash, nil if it is not in the trash
+	// When the project was archived, nil for active projects. Tasks of archived projects cannot
+	// be changed.
+	ArchivedAt *time.Time
+	// Incremented by the repository on every change of the project, so that concurrent changes
+	// can be detected
+	Version int
+	// Free text about the project, empty if there is none
+	Description string
+	// Color of the project, as a hex color (e.g. #ff8800), nil if it has none
+	Color *string
+	// Emoji or short text shown next to the project name, nil if it has none
+	Icon *string
+	// The position of the project in the list of projects, starting from 0 (first). Restored
+	// projects go to the end of the list.
+	Order int
+	// The unit of the estimates of the tasks of the project
+	EstimateUnit EstimateUnit
+	// The workspace the project belongs to, nil if it is in none. Project names are unique within
+	// a workspace, and among the projects without one.
+	WorkspaceID *uuid.UUID
+	// The project this one is a sub-project of, nil for top-level projects. Both are always in
+	// the same workspace.
+	ParentProjectID *uuid.UUID
+	// Counts of the tasks of the project. It is only set by ProjectService.SummarizeTasks
+	TaskSummary *TaskSummary
+}
+
+// EstimateUnit is what the estimates of the tasks of a project count. All the tasks of a project
+// share the same unit, so that their estimates can be added up.
+type EstimateUnit string
+
+const (
+	EstimateUnitPoints  EstimateUnit = "points"
+	EstimateUnitMinutes EstimateUnit = "minutes"
+)
+
+func (u EstimateUnit) IsValid() bool {
+	return u == EstimateUnitPoints || u == EstimateUnitMinutes
+}
+
+// TaskSummary counts the tasks of a project that are not in the trash.
+type TaskSummary struct {
+	Total     int
+	Pending   int
+	Completed int
+	// Number of tasks at the root of the project, i.e. without a parent task
+	Root int
+	// Sum of the effective estimates of the root tasks, i.e. of all the work estimated
+	Estimate int
+	// Sum of the effective estimates of the pending tasks, the work left to do. An estimated task
+	// stands for its whole subtree, so its subtasks are not counted on their own
+	RemainingWork int
+}
+
+// PercentDone is the percentage of the tasks that are completed, rounded down. It is 0 for
+// projects without tasks.
+func (s TaskSummary) PercentDone() int {
+	if s.Total == 0 {
+		return 0
+	}
+
+	return s.Completed * 100 / s.Total
 }
 
 // Create a new instance of a project.
 func NewProject(name string) Project {
 	now := time.Now().UTC()
 	return Project{
-		ID:        uuid.New(),
-		Name:      name,
-		Tasks:     []uuid.UUID{},
-		CreatedAt: now,
+		ID:           uuid.New(),
+		Name:         name,
+		CreatedAt:    now,
+		Version:      1,
+		EstimateUnit: EstimateUnitPoints,
 	}
 }
 
-// Will not log tasks in order to save log space.
+func (p Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// Only the main attributes of the project are logged, to save log space.
 func (p Project) LogValue() slog.Value {
 	return slog.GroupValue(
 		slog.String("ID", p.ID.String()),
 		slog.String("Name", p.Name),
 		slog.Time("CreatedAt", p.CreatedAt),
+		slog.Any("ArchivedAt", p.ArchivedAt),
 	)
 }
@@ -0,0 +1,52 @@
+package project
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+)
+
+// ReorderProject moves a project to the given position in the list of projects, with the same
+// semantics as reordering a task: a position less than 0 brings the project to the beginning, a
+// position past the end brings it to the end, and the projects in between are shifted by one.
+//
+// Archived projects keep their position, so the list of projects is ordered with them.
+func (p *ProjectService) ReorderProject(id uuid.UUID, newOrder int) (Project, error) {
+	project, err := p.repository.Get(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projects, err := p.repository.ListAllProjects()
+	if err != nil {
+		return Project{}, err
+	}
+
+	newOrder = max(0, min(newOrder, len(projects)-1))
+	// The projects are listed in order, so their positions are their indexes. Renumbering all
+	// of them also fixes any gap or duplicate left by projects created outside of the service.
+	index := slices.IndexFunc(projects, func(pr Project) bool { return pr.ID == id })
+	if index == newOrder && project.Order == newOrder {
+		return project, nil
+	}
+
+	projects = slices.Delete(projects, index, index+1)
+	projects = slices.Insert(projects, newOrder, project)
+	for i := range projects {
+		projects[i].Order = i
+	}
+
+	err = p.repository.BatchUpdateOrder(projects)
+	if err != nil {
+		return Project{}, err
+	}
+
+	reorderedProject, err := p.repository.Get(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(reorderedProject, activity.ActionReordered, map[string]any{"order": project.Order}, map[string]any{"order": reorderedProject.Order})
+	return reorderedProject, nil
+}
@@ -1,18 +1,80 @@
 package project
 
 import (
-	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
 )
 
-var ErrProjectDoesNotExist = errors.New("Project does not exist")
+var ErrProjectDoesNotExist = internal.NewError(internal.ErrNotFound, "Project does not exist")
 
 type ProjectRepository interface {
 	Create(project Project) error
 	Get(id uuid.UUID) (Project, error)
-	GetByName(name string) (Project, error)
+	// Get the project with the given name in a workspace, or among the projects without one if
+	// workspaceID is nil
+	GetByName(workspaceID *uuid.UUID, name string) (Project, error)
+	// Lock a project name of a workspace, or among the projects without one if workspaceID is nil,
+	// until the end of the transaction
+	LockName(workspaceID *uuid.UUID, name string) error
+	// Lock the hierarchy of the projects, i.e. their parents and workspaces, until the end of the
+	// transaction
+	LockHierarchy() error
+	// Lock the order of the projects until the end of the transaction
+	LockOrder() error
+	// Get the position after the last project of the list
+	GetNextOrder() (int, error)
+	// Count the tasks of each of the given projects and sum up their estimates. Projects without
+	// tasks are left out
+	GetTaskSummaries(ids []uuid.UUID) (map[uuid.UUID]TaskSummary, error)
+	// List the projects that are not archived
 	ListProjects() ([]Project, error)
+	// List both the active and the archived projects
+	ListAllProjects() ([]Project, error)
+	// List up to limit projects, starting after the given project if it is not nil
+	ListPage(opts ListOptions, after *Project, limit int) ([]Project, error)
+	// List the projects of a workspace, archived or not
+	ListInWorkspace(workspaceID uuid.UUID) ([]Project, error)
+	// List a project and all of its sub-projects, at any depth
+	ListTree(id uuid.UUID) ([]Project, error)
+	// Make a project a sub-project of another one or, if parentID is nil, a top-level project
+	SetParent(id uuid.UUID, parentID *uuid.UUID) (Project, error)
+	// Move a project and all of its sub-projects to a workspace, or out of any if workspaceID is
+	// nil. The project leaves its parent. Returns the moved projects
+	MoveToWorkspace(id uuid.UUID, workspaceID *uuid.UUID) ([]Project, error)
 	Rename(id uuid.UUID, newName string) (Project, error)
+	// Set the description, color, icon and estimate unit of a project, a nil color or icon removes
+	// it
+	UpdateDetails(id uuid.UUID, description string, color, icon *string, estimateUnit EstimateUnit) (Project, error)
+	// Update the order of a collection of projects
+	BatchUpdateOrder(projects []Project) error
+	Archive(id uuid.UUID, archivedAt time.Time) (Project, error)
+	Unarchive(id uuid.UUID) (Project, error)
 	Delete(id uuid.UUID) (Project, error)
+	// Move a project and its tasks to the trash. Its sub-projects move up to its parent
+	SoftDelete(id uuid.UUID, deletedAt time.Time) (Project, error)
+	GetDeleted(id uuid.UUID) (Project, error)
+	ListDeleted() ([]Project, error)
+	// Take a project and the tasks deleted along with it out of the trash, at the end of the list
+	Restore(project Project) (Project, error)
+	// Permanently delete the projects moved to the trash before the given time
+	Purge(deletedBefore time.Time) (int64, error)
+
+	CreateSection(section Section) error
+	GetSection(id uuid.UUID) (Section, error)
+	// List the sections of a project, in order
+	ListSections(projectID uuid.UUID) ([]Section, error)
+	RenameSection(id uuid.UUID, newName string) (Section, error)
+	// Update the order of a collection of sections
+	BatchUpdateSectionOrder(sections []Section) error
+	// Delete a section, moving its tasks to the end of the root tasks without a section and the
+	// sections after it one position up
+	DeleteSection(id uuid.UUID) (Section, error)
+
+	// Lock a project until the end of the transaction, failing with ErrVersionChanged if it does not
+	// have the given version
+	LockVersion(id uuid.UUID, version int) error
+	// Run fn with a repository whose changes are only saved if fn succeeds
+	InTransaction(fn func(repository ProjectRepository) error) error
 }
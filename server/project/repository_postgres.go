@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -17,40 +18,120 @@ import (
 
 type ProjectRepositoryPostgres struct {
 	Queries *db.Queries
-	ctx     context.Context
-	logger  slog.Logger
+	// Where transactions are started: the pool, or the current transaction for nested ones
+	db     beginner
+	ctx    context.Context
+	logger slog.Logger
+}
+
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 func NewProjectRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *ProjectRepositoryPostgres {
 	return &ProjectRepositoryPostgres{
 		Queries: db.New(pool),
+		db:      pool,
+		ctx:     ctx,
+		logger:  *internal.NewLogger("ProjectRepositoryPostgres"),
+	}
+}
+
+// NewProjectRepositoryPostgresInTx returns a repository whose changes are made in a transaction
+// begun by another repository, so that they are saved or rolled back along with its changes.
+func NewProjectRepositoryPostgresInTx(ctx context.Context, tx pgx.Tx) *ProjectRepositoryPostgres {
+	return &ProjectRepositoryPostgres{
+		Queries: db.New(tx),
+		db:      tx,
 		ctx:     ctx,
 		logger:  *internal.NewLogger("ProjectRepositoryPostgres"),
 	}
 }
 
+// Run fn with a repository whose changes are only saved if fn succeeds
+func (p *ProjectRepositoryPostgres) InTransaction(fn func(repository ProjectRepository) error) error {
+	tx, err := p.db.Begin(p.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(p.ctx)
+
+	txRepository := *p
+	txRepository.Queries = p.Queries.WithTx(tx)
+	txRepository.db = tx
+
+	err = fn(&txRepository)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(p.ctx)
+}
+
+// LockVersion locks a project until the end of the transaction. Fails with ErrVersionChanged if the
+// project does not have the expected version, e.g. because it was changed in the meantime.
+func (p *ProjectRepositoryPostgres) LockVersion(id uuid.UUID, version int) error {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return err
+	}
+
+	locked, err := p.Queries.LockProjectVersion(p.ctx, db.LockProjectVersionParams{
+		ID:              pgUUID,
+		ExpectedVersion: int32(version),
+	})
+	if err != nil {
+		return err
+	}
+	if locked == 0 {
+		return ErrVersionChanged
+	}
+
+	return nil
+}
+
 func (p *ProjectRepositoryPostgres) Create(project Project) error {
 	pgUUID, err := internal.ScanUUID(project.ID)
 	if err != nil {
 		return err
 	}
 
-	pgCreatedAt := pgtype.Timestamp{}
+	pgCreatedAt := pgtype.Timestamptz{}
 	err = pgCreatedAt.Scan(project.CreatedAt)
 	if err != nil {
 		return err
 	}
 
+	pgWorkspaceUUID, err := optionalUUID(project.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	pgParentUUID, err := optionalUUID(project.ParentProjectID)
+	if err != nil {
+		return err
+	}
+
 	p.logger.Info("Creating project", slog.Any("project", project))
 	err = p.Queries.CreateProject(p.ctx, db.CreateProjectParams{
-		ID:        pgUUID,
-		Name:      project.Name,
-		CreatedAt: pgCreatedAt,
+		ID:              pgUUID,
+		Name:            project.Name,
+		CreatedAt:       pgCreatedAt,
+		Description:     project.Description,
+		Color:           optionalText(project.Color),
+		Icon:            optionalText(project.Icon),
+		Order:           int32(project.Order),
+		EstimateUnit:    string(project.EstimateUnit),
+		WorkspaceID:     pgWorkspaceUUID,
+		ParentProjectID: pgParentUUID,
 	})
 	if err != nil {
 		p.logger.Error("failed to insert project in the database", slog.String("err", err.Error()))
-		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == ErrPgDuplicate {
-			err = internal.NewAlreadyExistsError(fmt.Sprintf("Project \"%s\"", project.ID))
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Project \"%s\"", project.Name))
+		}
+		if isForeignKeyViolation(err) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Workspace with id %s", project.WorkspaceID))
 		}
 	}
 	return err
@@ -76,8 +157,45 @@ func (p *ProjectRepositoryPostgres) Get(id uuid.UUID) (Project, error) {
 	return ProjectDBToProjectModel(projectDB)
 }
 
-func (p *ProjectRepositoryPostgres) GetByName(name string) (Project, error) {
-	projectDB, err := p.Queries.GetProjectByName(p.ctx, name)
+func (p *ProjectRepositoryPostgres) LockName(workspaceID *uuid.UUID, name string) error {
+	pgWorkspaceUUID, err := optionalUUID(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	return p.Queries.LockProjectName(p.ctx, db.LockProjectNameParams{
+		WorkspaceID: pgWorkspaceUUID,
+		Name:        name,
+	})
+}
+
+func (p *ProjectRepositoryPostgres) LockHierarchy() error {
+	return p.Queries.LockProjectHierarchy(p.ctx)
+}
+
+func (p *ProjectRepositoryPostgres) LockOrder() error {
+	return p.Queries.LockProjectOrder(p.ctx)
+}
+
+func (p *ProjectRepositoryPostgres) GetNextOrder() (int, error) {
+	nextOrder, err := p.Queries.GetNextProjectOrder(p.ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(nextOrder), nil
+}
+
+func (p *ProjectRepositoryPostgres) GetByName(workspaceID *uuid.UUID, name string) (Project, error) {
+	pgWorkspaceUUID, err := optionalUUID(workspaceID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projectDB, err := p.Queries.GetProjectByName(p.ctx, db.GetProjectByNameParams{
+		Name:        name,
+		WorkspaceID: pgWorkspaceUUID,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			err = internal.NewNotFoundError(fmt.Sprintf("Project %s", name))
@@ -94,19 +212,229 @@ func (p *ProjectRepositoryPostgres) GetByName(name string) (Project, error) {
 	return ProjectDBToProjectModel(projectDB)
 }
 
+// GetTaskSummaries counts the tasks of the projects, and sums up their estimates, with two grouped
+// queries.
+func (p *ProjectRepositoryPostgres) GetTaskSummaries(ids []uuid.UUID) (map[uuid.UUID]TaskSummary, error) {
+	pgUUIDs := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		pgUUID, err := internal.ScanUUID(id)
+		if err != nil {
+			return nil, err
+		}
+		pgUUIDs = append(pgUUIDs, pgUUID)
+	}
+
+	rows, err := p.Queries.GetProjectTaskSummaries(p.ctx, pgUUIDs)
+	if err != nil {
+		p.logger.Error("failed to count the tasks of projects", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	summaries := map[uuid.UUID]TaskSummary{}
+	for _, row := range rows {
+		projectID, err := internal.EncodeUUID(row.ProjectID.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries[projectID] = TaskSummary{
+			Total:     int(row.Total),
+			Pending:   int(row.Pending),
+			Completed: int(row.Completed),
+			Root:      int(row.Root),
+		}
+	}
+
+	estimateRows, err := p.Queries.GetProjectEstimateSummaries(p.ctx, pgUUIDs)
+	if err != nil {
+		p.logger.Error("failed to sum up the estimates of projects", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	for _, row := range estimateRows {
+		projectID, err := internal.EncodeUUID(row.ProjectID.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := summaries[projectID]
+		summary.Estimate = int(row.Estimate)
+		summary.RemainingWork = int(row.Remaining)
+		summaries[projectID] = summary
+	}
+
+	return summaries, nil
+}
+
+// ListProjects lists the projects that are not archived.
 func (prepo *ProjectRepositoryPostgres) ListProjects() ([]Project, error) {
-	projectsDB, err := prepo.Queries.ListProjects(prepo.ctx)
+	return prepo.listProjects(false)
+}
+
+// ListAllProjects lists both the active and the archived projects.
+func (prepo *ProjectRepositoryPostgres) ListAllProjects() ([]Project, error) {
+	return prepo.listProjects(true)
+}
+
+func (prepo *ProjectRepositoryPostgres) ListPage(opts ListOptions, after *Project, limit int) ([]Project, error) {
+	params := db.ListProjectsPageParams{
+		IncludeArchived: opts.IncludeArchived,
+		SortBy:          string(opts.SortBy),
+		Descending:      opts.Descending,
+		MaxProjects:     int32(limit),
+	}
+
+	if opts.WorkspaceID != nil {
+		pgUUID, err := internal.ScanUUID(*opts.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		params.FilterWorkspace = true
+		params.WorkspaceID = pgUUID
+	}
+
+	if after != nil {
+		pgUUID, err := internal.ScanUUID(after.ID)
+		if err != nil {
+			return nil, err
+		}
+		params.HasCursor = true
+		params.AfterID = pgUUID
+		params.AfterText = after.Name
+		params.AfterOrder = int32(after.Order)
+		params.AfterCreatedAt = pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}
+	}
+
+	projectsDB, err := prepo.Queries.ListProjectsPage(prepo.ctx, params)
+	if err != nil {
+		prepo.logger.Error("failed to list projects from the database", slog.String("err", err.Error()))
+
+		return nil, err
+	}
+
+	projects := []Project{}
+	for _, pDB := range projectsDB {
+		p, err := ProjectDBToProjectModel(pDB)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, p)
+	}
+
+	return projects, nil
+}
+
+func (prepo *ProjectRepositoryPostgres) listProjects(includeArchived bool) ([]Project, error) {
+	projectsDB, err := prepo.Queries.ListProjects(prepo.ctx, includeArchived)
 	if err != nil {
 		prepo.logger.Error("failed to list projects from the database", slog.String("err", err.Error()))
 
 		return nil, err
 	}
 
+	return projectDBsToProjectModels(projectsDB)
+}
+
+func (prepo *ProjectRepositoryPostgres) ListInWorkspace(workspaceID uuid.UUID) ([]Project, error) {
+	pgUUID, err := internal.ScanUUID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectsDB, err := prepo.Queries.ListWorkspaceProjects(prepo.ctx, pgUUID)
+	if err != nil {
+		prepo.logger.Error("failed to list the projects of a workspace", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return projectDBsToProjectModels(projectsDB)
+}
+
+func (prepo *ProjectRepositoryPostgres) ListTree(id uuid.UUID) ([]Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	projectsDB, err := prepo.Queries.ListProjectTree(prepo.ctx, pgUUID)
+	if err != nil {
+		prepo.logger.Error("failed to list the sub-projects of a project", slog.String("err", err.Error()))
+		return nil, err
+	}
+	if len(projectsDB) == 0 {
+		return nil, internal.NewNotFoundError(fmt.Sprintf("Project with id %s", id))
+	}
+
+	return projectDBsToProjectModels(projectsDB)
+}
+
+func (prepo *ProjectRepositoryPostgres) SetParent(id uuid.UUID, parentID *uuid.UUID) (Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	pgParentUUID, err := optionalUUID(parentID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projectDB, err := prepo.Queries.SetProjectParent(prepo.ctx, db.SetProjectParentParams{
+		ID:              pgUUID,
+		ParentProjectID: pgParentUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Project{}, internal.NewNotFoundError(fmt.Sprintf("project %s", id))
+		}
+
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
+// MoveToWorkspace moves a project and its sub-projects with a single query, so that either all of
+// them move or, if one of them takes a name already used in the workspace, none does.
+func (prepo *ProjectRepositoryPostgres) MoveToWorkspace(id uuid.UUID, workspaceID *uuid.UUID) ([]Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pgWorkspaceUUID, err := optionalUUID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectsDB, err := prepo.Queries.MoveProjectTreeToWorkspace(prepo.ctx, db.MoveProjectTreeToWorkspaceParams{
+		ID:          pgUUID,
+		WorkspaceID: pgWorkspaceUUID,
+	})
+	if err != nil {
+		prepo.logger.Error("failed to move project to workspace", slog.String("err", err.Error()))
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError("A project with the same name in the workspace")
+		}
+		if isForeignKeyViolation(err) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Workspace with id %s", workspaceID))
+		}
+
+		return nil, err
+	}
+	if len(projectsDB) == 0 {
+		return nil, internal.NewNotFoundError(fmt.Sprintf("Project with id %s", id))
+	}
+
+	return projectDBsToProjectModels(projectsDB)
+}
+
+func projectDBsToProjectModels(projectsDB []db.Project) ([]Project, error) {
 	projects := []Project{}
 	for _, pDB := range projectsDB {
 		p, err := ProjectDBToProjectModel(pDB)
 		if err != nil {
-			prepo.logger.Error("could not adapt DB project to the project model", slog.String("err", err.Error()))
 			return nil, err
 		}
 
@@ -126,6 +454,33 @@ func (p *ProjectRepositoryPostgres) Rename(id uuid.UUID, newName string) (Projec
 		ID:   pgUUID,
 		Name: newName,
 	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Project{}, internal.NewNotFoundError(fmt.Sprintf("project %s", id))
+		}
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Project \"%s\"", newName))
+		}
+
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
+func (p *ProjectRepositoryPostgres) UpdateDetails(id uuid.UUID, description string, color, icon *string, estimateUnit EstimateUnit) (Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projectDB, err := p.Queries.UpdateProjectDetails(p.ctx, db.UpdateProjectDetailsParams{
+		ID:           pgUUID,
+		Description:  description,
+		Color:        optionalText(color),
+		Icon:         optionalText(icon),
+		EstimateUnit: string(estimateUnit),
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return Project{}, internal.NewNotFoundError(fmt.Sprintf("project %s", id))
@@ -137,6 +492,82 @@ func (p *ProjectRepositoryPostgres) Rename(id uuid.UUID, newName string) (Projec
 	return ProjectDBToProjectModel(projectDB)
 }
 
+func (p *ProjectRepositoryPostgres) BatchUpdateOrder(projects []Project) error {
+	params := []db.BatchUpdateProjectOrdersParams{}
+	for _, project := range projects {
+		pgUUID, err := internal.ScanUUID(project.ID)
+		if err != nil {
+			return err
+		}
+
+		params = append(params, db.BatchUpdateProjectOrdersParams{ID: pgUUID, Order: int32(project.Order)})
+	}
+
+	errs := []error{}
+	br := p.Queries.BatchUpdateProjectOrders(p.ctx, params)
+	br.Exec(func(i int, err error) {
+		if err != nil {
+			p.logger.Error("failed to execute query in batch", slog.Int("queryNumber", i), slog.String("err", err.Error()))
+			errs = append(errs, err)
+			br.Close()
+		}
+	})
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs[len(errs)-1]
+}
+
+func (p *ProjectRepositoryPostgres) Archive(id uuid.UUID, archivedAt time.Time) (Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	pgArchivedAt := pgtype.Timestamptz{}
+	err = pgArchivedAt.Scan(archivedAt)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projectDB, err := p.Queries.ArchiveProject(p.ctx, db.ArchiveProjectParams{
+		ID:         pgUUID,
+		ArchivedAt: pgArchivedAt,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Project{}, internal.NewNotFoundError(fmt.Sprintf("project %s", id))
+		}
+
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
+func (p *ProjectRepositoryPostgres) Unarchive(id uuid.UUID) (Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projectDB, err := p.Queries.UnarchiveProject(p.ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Project{}, internal.NewNotFoundError(fmt.Sprintf("project %s", id))
+		}
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Project with id %s", id))
+		}
+
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
 func (p *ProjectRepositoryPostgres) Delete(id uuid.UUID) (Project, error) {
 	pgUUID, err := internal.ScanUUID(id)
 	if err != nil {
@@ -150,3 +581,376 @@ func (p *ProjectRepositoryPostgres) Delete(id uuid.UUID) (Project, error) {
 
 	return ProjectDBToProjectModel(project)
 }
+
+// SoftDelete moves a project and all of its tasks to the trash in a single transaction. The
+// tasks get the same deletion date as the project, which is how they are found when the
+// project is restored. The projects after it move one position up, and its sub-projects move
+// up to its parent.
+func (p *ProjectRepositoryPostgres) SoftDelete(id uuid.UUID, deletedAt time.Time) (Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	pgDeletedAt := pgtype.Timestamptz{}
+	err = pgDeletedAt.Scan(deletedAt)
+	if err != nil {
+		return Project{}, err
+	}
+
+	tx, err := p.db.Begin(p.ctx)
+	if err != nil {
+		return Project{}, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	qtx := p.Queries.WithTx(tx)
+	storedProjectDB, err := qtx.GetProject(p.ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Project with id %s", id.String()))
+		}
+		return Project{}, err
+	}
+
+	err = qtx.ReleaseSubprojects(p.ctx, db.ReleaseSubprojectsParams{
+		ParentProjectID:    pgUUID,
+		NewParentProjectID: storedProjectDB.ParentProjectID,
+	})
+	if err != nil {
+		p.logger.Error("failed to move the sub-projects of project up", slog.String("err", err.Error()))
+		return Project{}, err
+	}
+
+	projectDB, err := qtx.SoftDeleteProject(p.ctx, db.SoftDeleteProjectParams{
+		ID:        pgUUID,
+		DeletedAt: pgDeletedAt,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Project with id %s", id.String()))
+		}
+		return Project{}, err
+	}
+
+	err = qtx.SoftDeleteProjectTasks(p.ctx, db.SoftDeleteProjectTasksParams{
+		ProjectID: pgUUID,
+		DeletedAt: pgDeletedAt,
+	})
+	if err != nil {
+		p.logger.Error("failed to move project tasks to the trash", slog.String("err", err.Error()))
+		return Project{}, err
+	}
+
+	err = qtx.CloseProjectOrderGap(p.ctx, projectDB.Order)
+	if err != nil {
+		return Project{}, err
+	}
+
+	err = tx.Commit(p.ctx)
+	if err != nil {
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
+func (p *ProjectRepositoryPostgres) GetDeleted(id uuid.UUID) (Project, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	projectDB, err := p.Queries.GetDeletedProject(p.ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Deleted project with id %s", id.String()))
+		}
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
+func (p *ProjectRepositoryPostgres) ListDeleted() ([]Project, error) {
+	projectsDB, err := p.Queries.ListDeletedProjects(p.ctx)
+	if err != nil {
+		p.logger.Error("failed to list deleted projects from the database", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	projects := []Project{}
+	for _, pDB := range projectsDB {
+		project, err := ProjectDBToProjectModel(pDB)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// Restore takes a project out of the trash, along with the tasks that were deleted with it, in a
+// single transaction. The project goes to the end of the list of projects.
+func (p *ProjectRepositoryPostgres) Restore(project Project) (Project, error) {
+	if project.DeletedAt == nil {
+		return Project{}, fmt.Errorf("project %s is not in the trash", project.ID)
+	}
+
+	pgUUID, err := internal.ScanUUID(project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	pgDeletedAt := pgtype.Timestamptz{}
+	err = pgDeletedAt.Scan(*project.DeletedAt)
+	if err != nil {
+		return Project{}, err
+	}
+
+	tx, err := p.db.Begin(p.ctx)
+	if err != nil {
+		return Project{}, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	qtx := p.Queries.WithTx(tx)
+	projectDB, err := qtx.RestoreProject(p.ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Deleted project with id %s", project.ID.String()))
+		}
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Project \"%s\"", project.Name))
+		}
+		return Project{}, err
+	}
+
+	err = qtx.RestoreProjectTasks(p.ctx, db.RestoreProjectTasksParams{
+		ProjectID: pgUUID,
+		DeletedAt: pgDeletedAt,
+	})
+	if err != nil {
+		p.logger.Error("failed to restore project tasks", slog.String("err", err.Error()))
+		return Project{}, err
+	}
+
+	err = tx.Commit(p.ctx)
+	if err != nil {
+		return Project{}, err
+	}
+
+	return ProjectDBToProjectModel(projectDB)
+}
+
+// Purge permanently deletes the projects moved to the trash before the given time. Their tasks
+// are deleted in cascade.
+func (p *ProjectRepositoryPostgres) Purge(deletedBefore time.Time) (int64, error) {
+	pgDeletedBefore := pgtype.Timestamptz{}
+	err := pgDeletedBefore.Scan(deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return p.Queries.PurgeDeletedProjects(p.ctx, pgDeletedBefore)
+}
+
+func (p *ProjectRepositoryPostgres) CreateSection(section Section) error {
+	pgID, err := internal.ScanUUID(section.ID)
+	if err != nil {
+		return err
+	}
+
+	pgProjectID, err := internal.ScanUUID(section.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	err = p.Queries.CreateSection(p.ctx, db.CreateSectionParams{
+		ID:        pgID,
+		ProjectID: pgProjectID,
+		Name:      section.Name,
+		Order:     int32(section.Order),
+		CreatedAt: pgtype.Timestamptz{Time: section.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		p.logger.Error("failed to insert section in the database", slog.String("err", err.Error()))
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Section \"%s\"", section.Name))
+		}
+	}
+	return err
+}
+
+func (p *ProjectRepositoryPostgres) GetSection(id uuid.UUID) (Section, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Section{}, err
+	}
+
+	sectionDB, err := p.Queries.GetSection(p.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Section with id %s", id))
+		}
+		return Section{}, err
+	}
+
+	return SectionDBToSectionModel(sectionDB)
+}
+
+func (p *ProjectRepositoryPostgres) ListSections(projectID uuid.UUID) ([]Section, error) {
+	pgProjectID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionsDB, err := p.Queries.ListSections(p.ctx, pgProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []Section{}
+	for _, sectionDB := range sectionsDB {
+		section, err := SectionDBToSectionModel(sectionDB)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section)
+	}
+
+	return sections, nil
+}
+
+func (p *ProjectRepositoryPostgres) RenameSection(id uuid.UUID, newName string) (Section, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Section{}, err
+	}
+
+	sectionDB, err := p.Queries.RenameSection(p.ctx, db.RenameSectionParams{ID: pgID, Name: newName})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Section{}, internal.NewNotFoundError(fmt.Sprintf("Section with id %s", id))
+		}
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Section \"%s\"", newName))
+		}
+
+		return Section{}, err
+	}
+
+	return SectionDBToSectionModel(sectionDB)
+}
+
+func (p *ProjectRepositoryPostgres) BatchUpdateSectionOrder(sections []Section) error {
+	params := []db.BatchUpdateSectionOrdersParams{}
+	for _, section := range sections {
+		pgID, err := internal.ScanUUID(section.ID)
+		if err != nil {
+			return err
+		}
+
+		params = append(params, db.BatchUpdateSectionOrdersParams{ID: pgID, Order: int32(section.Order)})
+	}
+
+	errs := []error{}
+	br := p.Queries.BatchUpdateSectionOrders(p.ctx, params)
+	br.Exec(func(i int, err error) {
+		if err != nil {
+			p.logger.Error("failed to execute query in batch", slog.Int("queryNumber", i), slog.String("err", err.Error()))
+			errs = append(errs, err)
+			br.Close()
+		}
+	})
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs[len(errs)-1]
+}
+
+func (p *ProjectRepositoryPostgres) DeleteSection(id uuid.UUID) (Section, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Section{}, err
+	}
+
+	tx, err := p.db.Begin(p.ctx)
+	if err != nil {
+		return Section{}, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	qtx := p.Queries.WithTx(tx)
+	sectionDB, err := qtx.GetSection(p.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Section with id %s", id))
+		}
+		return Section{}, err
+	}
+
+	_, err = qtx.ReleaseSectionTasks(p.ctx, db.ReleaseSectionTasksParams{
+		ProjectID: sectionDB.ProjectID,
+		SectionID: pgID,
+	})
+	if err != nil {
+		p.logger.Error("failed to move the tasks out of a section", slog.String("err", err.Error()))
+		return Section{}, err
+	}
+
+	_, err = qtx.DeleteSection(p.ctx, pgID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	err = qtx.CloseSectionOrderGap(p.ctx, db.CloseSectionOrderGapParams{
+		ProjectID:    sectionDB.ProjectID,
+		RemovedOrder: sectionDB.Order,
+	})
+	if err != nil {
+		return Section{}, err
+	}
+
+	err = tx.Commit(p.ctx)
+	if err != nil {
+		return Section{}, err
+	}
+
+	return SectionDBToSectionModel(sectionDB)
+}
+
+func optionalText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{String: *s, Valid: true}
+}
+
+func optionalUUID(id *uuid.UUID) (pgtype.UUID, error) {
+	if id == nil {
+		return pgtype.UUID{}, nil
+	}
+
+	return internal.ScanUUID(*id)
+}
+
+// isUniqueViolation tells if the query failed because another project, or another section of the
+// same project, has the same name.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == ErrPgDuplicate
+}
+
+// isForeignKeyViolation tells if the query failed because the workspace of a project does not
+// exist.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == errPgForeignKey
+}
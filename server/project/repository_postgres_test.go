@@ -91,7 +91,7 @@ func (suite *ProjectRepoPostgresTestSuite) TestGetProjectByName() {
 
 	projectID := project.ID
 
-	project, err := suite.repository.GetByName(project.Name)
+	project, err := suite.repository.GetByName(nil, project.Name)
 	if assert.NoError(t, err) {
 		assert.Equal(t, projectName, project.Name)
 		assert.Equal(t, projectID.String(), project.ID.String())
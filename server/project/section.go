@@ -0,0 +1,202 @@
+package project
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// A Section groups root tasks of a project, e.g. "Backlog", "This week" and "Done". Each section
+// orders its tasks on its own, and the root tasks without a section form a list of their own.
+type Section struct {
+	CreatedAt time.Time
+	Name      string
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	// The position of the section in its project, starting from 0 (first)
+	Order int
+}
+
+func NewSection(name string, projectID uuid.UUID) Section {
+	return Section{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func (s Section) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ID", s.ID.String()),
+		slog.String("ProjectID", s.ProjectID.String()),
+		slog.String("Name", s.Name),
+		slog.Int("Order", s.Order),
+	)
+}
+
+// CreateSection adds a section at the end of the sections of a project. Section names are unique
+// within a project, and the whitespace around them is trimmed.
+func (p *ProjectService) CreateSection(projectID uuid.UUID, name string) (Section, error) {
+	name, err := p.validateName(name)
+	if err != nil {
+		return Section{}, err
+	}
+
+	project, err := p.activeProject(projectID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	sections, err := p.repository.ListSections(projectID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	section := NewSection(name, projectID)
+	section.Order = len(sections)
+	err = p.repository.CreateSection(section)
+	if err != nil {
+		return Section{}, err
+	}
+
+	p.record(project, activity.ActionUpdated, map[string]any{"section": nil}, map[string]any{"section": sectionValue(section)})
+	return section, nil
+}
+
+// ListSections lists the sections of a project, in order.
+func (p *ProjectService) ListSections(projectID uuid.UUID) ([]Section, error) {
+	_, err := p.repository.Get(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.repository.ListSections(projectID)
+}
+
+// GetSection retrieves a section of a project. Sections of other projects are not found.
+func (p *ProjectService) GetSection(projectID uuid.UUID, id uuid.UUID) (Section, error) {
+	section, err := p.repository.GetSection(id)
+	if err != nil {
+		return Section{}, err
+	}
+	if section.ProjectID != projectID {
+		return Section{}, internal.NewNotFoundError(fmt.Sprintf("Section with id %s in project %s", id, projectID))
+	}
+
+	return section, nil
+}
+
+// RenameSection gives a section a new name. The whitespace around the name is trimmed.
+func (p *ProjectService) RenameSection(projectID uuid.UUID, id uuid.UUID, newName string) (Section, error) {
+	newName, err := p.validateName(newName)
+	if err != nil {
+		return Section{}, err
+	}
+
+	project, err := p.activeProject(projectID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	section, err := p.GetSection(projectID, id)
+	if err != nil {
+		return Section{}, err
+	}
+	if section.Name == newName {
+		return section, nil
+	}
+
+	renamedSection, err := p.repository.RenameSection(id, newName)
+	if err != nil {
+		return Section{}, err
+	}
+
+	p.record(project, activity.ActionUpdated, map[string]any{"section": sectionValue(section)}, map[string]any{"section": sectionValue(renamedSection)})
+	return renamedSection, nil
+}
+
+// ReorderSection moves a section to the given position among the sections of its project, with
+// the same semantics as ReorderProject. The tasks of the section keep their order.
+func (p *ProjectService) ReorderSection(projectID uuid.UUID, id uuid.UUID, newOrder int) (Section, error) {
+	project, err := p.activeProject(projectID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	section, err := p.GetSection(projectID, id)
+	if err != nil {
+		return Section{}, err
+	}
+
+	sections, err := p.repository.ListSections(projectID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	newOrder = max(0, min(newOrder, len(sections)-1))
+	index := slices.IndexFunc(sections, func(s Section) bool { return s.ID == id })
+	if index == newOrder && section.Order == newOrder {
+		return section, nil
+	}
+
+	sections = slices.Delete(sections, index, index+1)
+	sections = slices.Insert(sections, newOrder, section)
+	for i := range sections {
+		sections[i].Order = i
+	}
+
+	err = p.repository.BatchUpdateSectionOrder(sections)
+	if err != nil {
+		return Section{}, err
+	}
+
+	reorderedSection := sections[newOrder]
+	p.record(project, activity.ActionUpdated, map[string]any{"section": sectionValue(section)}, map[string]any{"section": sectionValue(reorderedSection)})
+	return reorderedSection, nil
+}
+
+// DeleteSection deletes a section. Its tasks are not deleted: they go, in the same order, to the
+// end of the root tasks without a section.
+func (p *ProjectService) DeleteSection(projectID uuid.UUID, id uuid.UUID) (Section, error) {
+	project, err := p.activeProject(projectID)
+	if err != nil {
+		return Section{}, err
+	}
+
+	_, err = p.GetSection(projectID, id)
+	if err != nil {
+		return Section{}, err
+	}
+
+	section, err := p.repository.DeleteSection(id)
+	if err != nil {
+		return Section{}, err
+	}
+
+	p.record(project, activity.ActionUpdated, map[string]any{"section": sectionValue(section)}, map[string]any{"section": nil})
+	return section, nil
+}
+
+// activeProject retrieves a project whose sections may be changed, i.e. that is not archived.
+func (p *ProjectService) activeProject(id uuid.UUID) (Project, error) {
+	project, err := p.repository.Get(id)
+	if err != nil {
+		return Project{}, err
+	}
+	if project.IsArchived() {
+		return Project{}, fmt.Errorf("Cannot change the sections of project %s: %w", id, ErrProjectArchived)
+	}
+
+	return project, nil
+}
+
+// sectionValue is how a section is recorded in the activity history of its project.
+func sectionValue(section Section) map[string]any {
+	return map[string]any{"id": section.ID, "name": section.Name, "order": section.Order}
+}
@@ -4,48 +4,198 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/murasakiwano/todoctian/server/activity"
 	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/template"
 )
 
 // This code indicates that a duplicate constraint was violated by the query
 var ErrPgDuplicate = "23505"
 
+// This code indicates that a foreign key constraint was violated by the query
+const errPgForeignKey = "23503"
+
+// ErrProjectArchived is returned when trying to change the tasks of an archived project.
+var ErrProjectArchived = internal.NewConflictError("project is archived")
+
 type ProjectService struct {
 	repository ProjectRepository
+	templates  template.TemplateRepository
 	logger     slog.Logger
+	// Whether a new or renamed project may take the name of an archived project
+	reuseArchivedNames bool
+	// Where changes are recorded, nil if they are not
+	activity activity.Recorder
+	// Who is making the changes, see WithOrigin
+	origin activity.Origin
+	// The changes made within a transaction, which are only recorded once it is saved
+	pending *[]activity.Event
+	limits  internal.Limits
+}
+
+type ProjectServiceOption func(*ProjectService)
+
+// WithArchivedNameReuse lets projects take the names of archived projects. By default, archived
+// projects keep their names reserved, so that unarchiving them never fails.
+func WithArchivedNameReuse() ProjectServiceOption {
+	return func(p *ProjectService) {
+		p.reuseArchivedNames = true
+	}
+}
+
+// WithActivityRecorder records every change made by the service in the activity history.
+func WithActivityRecorder(recorder activity.Recorder) ProjectServiceOption {
+	return func(p *ProjectService) {
+		p.activity = recorder
+	}
+}
+
+// WithLimits sets the limits the names of projects are validated against, instead of
+// internal.DefaultLimits.
+func WithLimits(limits internal.Limits) ProjectServiceOption {
+	return func(p *ProjectService) {
+		p.limits = limits
+	}
 }
 
-func NewProjectService(db ProjectRepository) *ProjectService {
-	return &ProjectService{repository: db, logger: *internal.NewLogger("ProjectService")}
+func NewProjectService(db ProjectRepository, templates template.TemplateRepository, opts ...ProjectServiceOption) *ProjectService {
+	p := &ProjectService{
+		repository: db,
+		templates:  templates,
+		logger:     *internal.NewLogger("ProjectService"),
+		limits:     internal.DefaultLimits,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
-// Creates and persists a project to the repository.
+// Creates and persists a project to the repository, in no workspace. The whitespace around the
+// name is trimmed.
 func (p *ProjectService) CreateProject(name string) (Project, error) {
-	project, err := p.repository.GetByName(name)
-	if err != nil && !errors.Is(err, internal.ErrNotFound) {
-		p.logger.Error("failed to create project", slog.String("err", err.Error()))
+	return p.CreateProjectIn(name, nil, nil)
+}
+
+// CreateProjectIn creates a project in a workspace, or in none if workspaceID is nil, and makes it
+// a sub-project of parentProjectID if it is not nil. A sub-project goes in the workspace of its
+// parent, so workspaceID may be left out for them.
+func (p *ProjectService) CreateProjectIn(name string, workspaceID *uuid.UUID, parentProjectID *uuid.UUID) (Project, error) {
+	var project Project
+	err := p.inTransaction(func(txService *ProjectService) (err error) {
+		project, err = txService.createProjectIn(name, workspaceID, parentProjectID)
+		return err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+func (p *ProjectService) createProjectIn(name string, workspaceID *uuid.UUID, parentProjectID *uuid.UUID) (Project, error) {
+	name, err := p.validateName(name)
+	if err != nil {
+		return Project{}, err
+	}
 
-		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == ErrPgDuplicate {
-			err = internal.NewAlreadyExistsError(fmt.Sprintf("Project \"%s\"", name))
+	if parentProjectID != nil {
+		parent, err := p.repository.Get(*parentProjectID)
+		if err != nil {
+			return Project{}, err
 		}
+		if workspaceID != nil && !sameUUID(workspaceID, parent.WorkspaceID) {
+			return Project{}, ErrParentInOtherWorkspace
+		}
+		workspaceID = parent.WorkspaceID
+	}
 
+	taken, err := p.nameTaken(workspaceID, name, false, uuid.Nil)
+	if err != nil {
+		p.logger.Error("failed to create project", slog.String("err", err.Error()))
 		return Project{}, err
 	}
+	if taken {
+		return Project{}, internal.NewAlreadyExistsError(fmt.Sprintf("Project \"%s\"", name))
+	}
 
-	project = NewProject(name)
+	project := NewProject(name)
+	project.WorkspaceID = workspaceID
+	project.ParentProjectID = parentProjectID
+	// New projects go to the end of the list, which is locked so that no other project takes the
+	// same position in the meantime
+	err = p.repository.LockOrder()
+	if err != nil {
+		return Project{}, err
+	}
+	project.Order, err = p.repository.GetNextOrder()
+	if err != nil {
+		return Project{}, err
+	}
 
 	err = p.repository.Create(project)
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(project, activity.ActionCreated, nil, map[string]any{"name": project.Name})
+	return project, nil
+}
+
+// validateName normalizes the name of a project and checks it against the limits of the service.
+func (p *ProjectService) validateName(name string) (string, error) {
+	name = internal.NormalizeName(name)
+	if fieldErr := internal.ValidateName("name", name, p.limits.MaxNameLength); fieldErr != nil {
+		return "", internal.FieldErrors{*fieldErr}
+	}
 
-	return project, err
+	return name, nil
+}
+
+// nameTaken reports whether a project of the workspace other than except goes by the given name.
+// archived tells whether the project that wants the name is archived: when archived names may be
+// reused, only two active projects conflict.
+//
+// The name is locked until the end of the transaction of the service, so it must be called in one,
+// see inTransaction. Otherwise, two projects could both find the name free and take it: the
+// database only keeps active projects from sharing a name, since archived names are not always
+// reserved.
+func (p *ProjectService) nameTaken(workspaceID *uuid.UUID, name string, archived bool, except uuid.UUID) (bool, error) {
+	err := p.repository.LockName(workspaceID, name)
+	if err != nil {
+		return false, err
+	}
+
+	// The active project with the name, if any, comes first
+	existing, err := p.repository.GetByName(workspaceID, name)
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+	if existing.ID == except {
+		return false, nil
+	}
+	if p.reuseArchivedNames {
+		return !existing.IsArchived() && !archived, nil
+	}
+
+	return true, nil
 }
 
 func (p *ProjectService) GetProject(id uuid.UUID) (Project, error) {
 	return p.repository.Get(id)
 }
 
+// Moves a project and its tasks to the trash. They can be restored with RestoreProject until they
+// are purged.
 func (p *ProjectService) DeleteProject(id uuid.UUID) (Project, error) {
 	_, err := p.repository.Get(id)
 	if err != nil {
@@ -53,10 +203,93 @@ func (p *ProjectService) DeleteProject(id uuid.UUID) (Project, error) {
 		return Project{}, err
 	}
 
-	return p.repository.Delete(id)
+	project, err := p.repository.SoftDelete(id, time.Now().UTC())
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(project, activity.ActionDeleted, map[string]any{"name": project.Name}, nil)
+	return project, nil
+}
+
+// Lists the projects in the trash, most recently deleted first.
+func (p *ProjectService) ListDeletedProjects() ([]Project, error) {
+	return p.repository.ListDeleted()
+}
+
+// Takes a project out of the trash, along with the tasks that were deleted with it. Fails if
+// another project took its name in the meantime.
+func (p *ProjectService) RestoreProject(id uuid.UUID) (Project, error) {
+	var project Project
+	err := p.inTransaction(func(txService *ProjectService) (err error) {
+		project, err = txService.restoreProject(id)
+		return err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
 }
 
+func (p *ProjectService) restoreProject(id uuid.UUID) (Project, error) {
+	project, err := p.repository.GetDeleted(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	taken, err := p.nameTaken(project.WorkspaceID, project.Name, project.IsArchived(), project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+	if taken {
+		p.logger.Error(
+			"could not restore project - project with the same name already exists",
+			slog.String("name", project.Name),
+		)
+		return Project{}, internal.NewAlreadyExistsError(fmt.Sprintf("Project with name \"%s\"", project.Name))
+	}
+
+	// Restored projects go to the end of the list, see createProjectIn
+	err = p.repository.LockOrder()
+	if err != nil {
+		return Project{}, err
+	}
+	project, err = p.repository.Restore(project)
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(project, activity.ActionRestored, nil, map[string]any{"name": project.Name})
+	return project, nil
+}
+
+// Permanently deletes the projects moved to the trash before the given time, along with their
+// tasks. Returns the number of purged projects.
+func (p *ProjectService) PurgeDeletedProjects(deletedBefore time.Time) (int64, error) {
+	return p.repository.Purge(deletedBefore)
+}
+
+// RenameProject gives a project a new name. The whitespace around the name is trimmed.
 func (p *ProjectService) RenameProject(id uuid.UUID, newName string) (Project, error) {
+	var project Project
+	err := p.inTransaction(func(txService *ProjectService) (err error) {
+		project, err = txService.renameProject(id, newName)
+		return err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+func (p *ProjectService) renameProject(id uuid.UUID, newName string) (Project, error) {
+	newName, err := p.validateName(newName)
+	if err != nil {
+		return Project{}, err
+	}
+
 	project, err := p.repository.Get(id)
 	if err != nil {
 		p.logger.Error("failed to rename project", slog.String("err", err.Error()))
@@ -67,21 +300,209 @@ func (p *ProjectService) RenameProject(id uuid.UUID, newName string) (Project, e
 	}
 
 	// Check if another project with the new name already exists
-	_, err = p.repository.GetByName(newName)
-	if err == nil {
+	taken, err := p.nameTaken(project.WorkspaceID, newName, project.IsArchived(), project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+	if taken {
 		p.logger.Error(
 			"could not rename project - project with requested name already exists",
 			slog.String("name", newName),
 		)
 		return Project{}, internal.NewAlreadyExistsError(fmt.Sprintf("Project with name \"%s\"", newName))
 	}
-	if !errors.Is(err, internal.ErrNotFound) {
+
+	oldName := project.Name
+	project, err = p.repository.Rename(project.ID, newName)
+	if err != nil {
 		return Project{}, err
 	}
 
-	return p.repository.Rename(project.ID, newName)
+	p.record(project, activity.ActionRenamed, map[string]any{"name": oldName}, map[string]any{"name": project.Name})
+	return project, nil
+}
+
+// SummarizeTasks sets the task summary of each project, counting the tasks of all of them at once.
+func (p *ProjectService) SummarizeTasks(projects []Project) ([]Project, error) {
+	ids := make([]uuid.UUID, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+
+	summaries, err := p.repository.GetTaskSummaries(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, project := range projects {
+		summary := summaries[project.ID]
+		projects[i].TaskSummary = &summary
+	}
+
+	return projects, nil
 }
 
+// Lists the projects that are not archived.
 func (p *ProjectService) ListProjects() ([]Project, error) {
 	return p.repository.ListProjects()
 }
+
+// Lists every project, archived or not.
+func (p *ProjectService) ListAllProjects() ([]Project, error) {
+	return p.repository.ListAllProjects()
+}
+
+// Archives a project. Archived projects are hidden from ListProjects and their tasks cannot be
+// changed until the project is unarchived. Archiving an archived project is a no-op.
+func (p *ProjectService) ArchiveProject(id uuid.UUID) (Project, error) {
+	project, err := p.repository.Get(id)
+	if err != nil {
+		p.logger.Error("failed to archive project", slog.String("err", err.Error()))
+		return Project{}, err
+	}
+	if project.IsArchived() {
+		return project, nil
+	}
+
+	project, err = p.repository.Archive(id, time.Now().UTC())
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(project, activity.ActionArchived, map[string]any{"archivedAt": nil}, map[string]any{"archivedAt": project.ArchivedAt})
+	return project, nil
+}
+
+// Makes an archived project active again. Fails if an active project took its name in the
+// meantime.
+func (p *ProjectService) UnarchiveProject(id uuid.UUID) (Project, error) {
+	var project Project
+	err := p.inTransaction(func(txService *ProjectService) (err error) {
+		project, err = txService.unarchiveProject(id)
+		return err
+	})
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+func (p *ProjectService) unarchiveProject(id uuid.UUID) (Project, error) {
+	project, err := p.repository.Get(id)
+	if err != nil {
+		p.logger.Error("failed to unarchive project", slog.String("err", err.Error()))
+		return Project{}, err
+	}
+	if !project.IsArchived() {
+		return project, nil
+	}
+
+	taken, err := p.nameTaken(project.WorkspaceID, project.Name, false, project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+	if taken {
+		p.logger.Error(
+			"could not unarchive project - project with the same name already exists",
+			slog.String("name", project.Name),
+		)
+		return Project{}, internal.NewAlreadyExistsError(fmt.Sprintf("Project with name \"%s\"", project.Name))
+	}
+
+	archivedAt := project.ArchivedAt
+	project, err = p.repository.Unarchive(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	p.record(project, activity.ActionUnarchived, map[string]any{"archivedAt": archivedAt}, map[string]any{"archivedAt": nil})
+	return project, nil
+}
+
+// CreateTemplate saves the task tree of a project as a new template. Due dates of the tasks are
+// stored relative to the creation date of the project.
+func (p *ProjectService) CreateTemplate(id uuid.UUID, templateName string) (template.Template, error) {
+	if strings.TrimSpace(templateName) == "" {
+		return template.Template{}, template.ErrEmptyName
+	}
+
+	project, err := p.repository.Get(id)
+	if err != nil {
+		p.logger.Error("failed to create template from project", slog.String("err", err.Error()))
+		return template.Template{}, err
+	}
+
+	tmpl := template.NewTemplate(templateName, nil)
+	err = p.templates.CreateFromProject(tmpl, project.ID, project.CreatedAt)
+	if err != nil {
+		return template.Template{}, err
+	}
+
+	return p.templates.Get(tmpl.ID)
+}
+
+// WithOrigin returns a copy of the service that records its changes as made by origin.
+func (p ProjectService) WithOrigin(origin activity.Origin) *ProjectService {
+	p.origin = origin
+	return &p
+}
+
+// InTransactionOf returns a copy of the service that makes its changes with the given repository,
+// bound to the transaction of another service, and leaves the changes it records in pending, to be
+// recorded by that service once its transaction is saved.
+func (p ProjectService) InTransactionOf(repository ProjectRepository, pending *[]activity.Event) *ProjectService {
+	p.repository = repository
+	p.pending = pending
+	return &p
+}
+
+// inTransaction runs fn with a copy of the service whose changes are only saved if fn succeeds.
+// The changes are recorded once they are saved, or along with the enclosing transaction if the
+// service already runs in one.
+func (p *ProjectService) inTransaction(fn func(txService *ProjectService) error) error {
+	pending := p.pending
+	if pending == nil {
+		pending = &[]activity.Event{}
+	}
+
+	err := p.repository.InTransaction(func(repository ProjectRepository) error {
+		txService := *p
+		txService.repository = repository
+		txService.pending = pending
+		return fn(&txService)
+	})
+	if err != nil {
+		return err
+	}
+
+	if p.pending == nil && p.activity != nil {
+		for _, event := range *pending {
+			_, err := p.activity.Record(event)
+			if err != nil {
+				p.logger.Error("failed to record project activity", slog.Any("event", event), slog.String("err", err.Error()))
+			}
+		}
+	}
+
+	return nil
+}
+
+// record appends a change of the project to the activity history. The change itself was already
+// made, so failing to record it is logged rather than returned.
+func (p *ProjectService) record(project Project, action activity.Action, before, after map[string]any) {
+	if p.activity == nil {
+		return
+	}
+
+	event := activity.NewEvent(p.origin, action, project.ID, nil, before, after)
+	if p.pending != nil {
+		*p.pending = append(*p.pending, event)
+		return
+	}
+
+	_, err := p.activity.Record(event)
+	if err != nil {
+		p.logger.Error("failed to record project activity", slog.Any("event", event), slog.String("err", err.Error()))
+	}
+}
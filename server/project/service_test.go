@@ -2,13 +2,18 @@ package project
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/template"
 	"github.com/murasakiwano/todoctian/server/testhelpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,6 +24,7 @@ type ProjectServiceTestSuite struct {
 	suite.Suite
 	pgContainer *testhelpers.PostgresContainer
 	service     *ProjectService
+	pool        *pgxpool.Pool
 	ctx         context.Context
 }
 
@@ -36,8 +42,10 @@ func (suite *ProjectServiceTestSuite) SetupSuite() {
 	}
 
 	repository := NewProjectRepositoryPostgres(suite.ctx, pgPool)
+	templateRepository := template.NewTemplateRepositoryPostgres(suite.ctx, pgPool)
 
-	suite.service = NewProjectService(repository)
+	suite.service = NewProjectService(repository, templateRepository)
+	suite.pool = pgPool
 }
 
 func (suite *ProjectServiceTestSuite) SetupTest() {
@@ -49,6 +57,7 @@ func (suite *ProjectServiceTestSuite) SetupTest() {
 	}
 	conn.Query(suite.ctx, "DELETE FROM projects;") // Cleanup everything before each test
 	conn.Close(suite.ctx)
+	testhelpers.CleanupTemplatesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
 }
 
 func (suite *ProjectServiceTestSuite) TestCreateProject_Success() {
@@ -68,6 +77,48 @@ func (suite *ProjectServiceTestSuite) TestCreateProject_AlreadyExists() {
 	assert.Error(t, err)
 }
 
+func (suite *ProjectServiceTestSuite) TestCreateProject_TrimsName() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("  My test project\t")
+	require.NoError(t, err)
+	assert.Equal(t, "My test project", project.Name)
+
+	_, err = suite.service.CreateProject("My test project ")
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+}
+
+func (suite *ProjectServiceTestSuite) TestCreateProject_InvalidName() {
+	t := suite.T()
+
+	limits := internal.DefaultLimits
+	limits.MaxNameLength = 10
+	service := NewProjectService(suite.service.repository, suite.service.templates, WithLimits(limits))
+
+	for _, name := range []string{"", "   ", "A project name that is too long", "Line\nbreak"} {
+		_, err := service.CreateProject(name)
+		var fieldErrs internal.FieldErrors
+		if assert.ErrorAs(t, err, &fieldErrs, name) {
+			assert.ErrorIs(t, err, internal.ErrValidation)
+			assert.Equal(t, "name", fieldErrs[0].Field)
+		}
+	}
+}
+
+func (suite *ProjectServiceTestSuite) TestRenameProject_InvalidName() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+
+	_, err = suite.service.RenameProject(project.ID, " \x00 ")
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	project, err = suite.service.RenameProject(project.ID, " Renamed project ")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed project", project.Name)
+}
+
 func (suite *ProjectServiceTestSuite) TestDeleteProject_Success() {
 	t := suite.T()
 
@@ -86,6 +137,352 @@ func (suite *ProjectServiceTestSuite) TestDeleteProject_NonExistentProject() {
 	assert.Error(t, err)
 }
 
+func (suite *ProjectServiceTestSuite) TestDeleteProject_MovesProjectToTrash() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+
+	deletedProject, err := suite.service.DeleteProject(project.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, deletedProject.DeletedAt)
+
+	_, err = suite.service.GetProject(project.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	deletedProjects, err := suite.service.ListDeletedProjects()
+	if assert.NoError(t, err) && assert.Len(t, deletedProjects, 1) {
+		assert.Equal(t, project.ID, deletedProjects[0].ID)
+	}
+
+	restoredProject, err := suite.service.RestoreProject(project.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restoredProject.DeletedAt)
+
+	_, err = suite.service.GetProject(project.ID)
+	assert.NoError(t, err)
+}
+
+func (suite *ProjectServiceTestSuite) TestRestoreProject_NameTaken() {
+	t := suite.T()
+	name := "My test project"
+
+	project, err := suite.service.CreateProject(name)
+	require.NoError(t, err)
+	_, err = suite.service.DeleteProject(project.ID)
+	require.NoError(t, err)
+
+	// The name of a project in the trash can be reused...
+	_, err = suite.service.CreateProject(name)
+	require.NoError(t, err)
+
+	// ...but then the deleted project cannot be restored
+	_, err = suite.service.RestoreProject(project.ID)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+}
+
+func (suite *ProjectServiceTestSuite) TestPurgeDeletedProjects() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+	_, err = suite.service.DeleteProject(project.ID)
+	require.NoError(t, err)
+
+	purged, err := suite.service.PurgeDeletedProjects(time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = suite.service.RestoreProject(project.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProject() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+
+	name, description, color, icon := "Groceries", "Things to buy", "#00FF00", " 🛒 "
+	project, err = suite.service.UpdateProject(project.ID, ProjectUpdate{
+		Name:        &name,
+		Description: &description,
+		Color:       &color,
+		Icon:        &icon,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, name, project.Name)
+	assert.Equal(t, description, project.Description)
+	assert.Equal(t, &color, project.Color)
+	assert.Equal(t, "🛒", *project.Icon)
+
+	// Fields that are not given are left as they are, empty ones are removed
+	icon = ""
+	project, err = suite.service.UpdateProject(project.ID, ProjectUpdate{Icon: &icon})
+	require.NoError(t, err)
+	assert.Equal(t, name, project.Name)
+	assert.Equal(t, &color, project.Color)
+	assert.Nil(t, project.Icon)
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProject_EstimateUnit() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+	assert.Equal(t, EstimateUnitPoints, project.EstimateUnit)
+
+	minutes := EstimateUnitMinutes
+	project, err = suite.service.UpdateProject(project.ID, ProjectUpdate{EstimateUnit: &minutes})
+	require.NoError(t, err)
+	assert.Equal(t, EstimateUnitMinutes, project.EstimateUnit)
+
+	hours := EstimateUnit("hours")
+	_, err = suite.service.UpdateProject(project.ID, ProjectUpdate{EstimateUnit: &hours})
+	var fieldErrs internal.FieldErrors
+	if assert.ErrorAs(t, err, &fieldErrs) && assert.Len(t, fieldErrs, 1) {
+		assert.Equal(t, "estimateUnit", fieldErrs[0].Field)
+	}
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProject_InvalidFields() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+
+	name, color := "", "red"
+	_, err = suite.service.UpdateProject(project.ID, ProjectUpdate{Name: &name, Color: &color})
+	var fieldErrs internal.FieldErrors
+	if assert.ErrorAs(t, err, &fieldErrs) && assert.Len(t, fieldErrs, 2) {
+		assert.Equal(t, "name", fieldErrs[0].Field)
+		assert.Equal(t, "color", fieldErrs[1].Field)
+	}
+
+	// Nothing was changed
+	project, err = suite.service.GetProject(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "My test project", project.Name)
+	assert.Nil(t, project.Color)
+}
+
+func (suite *ProjectServiceTestSuite) TestReorderProject() {
+	t := suite.T()
+
+	ids := []uuid.UUID{}
+	for i, name := range []string{"First", "Second", "Third", "Fourth"} {
+		project, err := suite.service.CreateProject(name)
+		require.NoError(t, err)
+		assert.Equal(t, i, project.Order)
+		ids = append(ids, project.ID)
+	}
+
+	listedNames := func() []string {
+		projects, err := suite.service.ListProjects()
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	project, err := suite.service.ReorderProject(ids[0], 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, project.Order)
+	assert.Equal(t, []string{"Second", "Third", "First", "Fourth"}, listedNames())
+
+	// Positions out of the list are brought back to its ends
+	_, err = suite.service.ReorderProject(ids[3], -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Fourth", "Second", "Third", "First"}, listedNames())
+
+	_, err = suite.service.ReorderProject(ids[3], 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Second", "Third", "First", "Fourth"}, listedNames())
+
+	// Deleted projects leave no gap, and restored ones go to the end
+	_, err = suite.service.DeleteProject(ids[1])
+	require.NoError(t, err)
+	project, err = suite.service.GetProject(ids[2])
+	require.NoError(t, err)
+	assert.Equal(t, 0, project.Order)
+
+	project, err = suite.service.RestoreProject(ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, 3, project.Order)
+	assert.Equal(t, []string{"Third", "First", "Fourth", "Second"}, listedNames())
+}
+
+func (suite *ProjectServiceTestSuite) TestCreateProject_ConcurrentOrders() {
+	t := suite.T()
+
+	errs := make([]error, 5)
+	orders := make([]int, 5)
+	var wg sync.WaitGroup
+	wg.Add(5)
+	for i := range 5 {
+		go func() {
+			defer wg.Done()
+			project, err := suite.service.CreateProject(fmt.Sprintf("Project %d", i))
+			orders[i], errs[i] = project.Order, err
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, orders)
+}
+
+func (suite *ProjectServiceTestSuite) TestSections() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+
+	ids := []uuid.UUID{}
+	for i, name := range []string{"Backlog", "This week", "Done"} {
+		section, err := suite.service.CreateSection(project.ID, name)
+		require.NoError(t, err)
+		assert.Equal(t, i, section.Order)
+		ids = append(ids, section.ID)
+	}
+
+	listedNames := func() []string {
+		sections, err := suite.service.ListSections(project.ID)
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, s := range sections {
+			names = append(names, s.Name)
+		}
+		return names
+	}
+
+	// Names are unique within a project
+	_, err = suite.service.CreateSection(project.ID, " Backlog ")
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	section, err := suite.service.ReorderSection(project.ID, ids[2], 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, section.Order)
+	assert.Equal(t, []string{"Done", "Backlog", "This week"}, listedNames())
+
+	section, err = suite.service.RenameSection(project.ID, ids[1], "Next week")
+	require.NoError(t, err)
+	assert.Equal(t, "Next week", section.Name)
+
+	// Deleted sections leave no gap
+	_, err = suite.service.DeleteSection(project.ID, ids[2])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Backlog", "Next week"}, listedNames())
+
+	_, err = suite.service.GetSection(project.ID, ids[2])
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *ProjectServiceTestSuite) TestSections_OtherProject() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+	otherProject, err := suite.service.CreateProject("My other project")
+	require.NoError(t, err)
+
+	section, err := suite.service.CreateSection(project.ID, "Backlog")
+	require.NoError(t, err)
+
+	// The same name can be used in another project, but the section is not found there
+	_, err = suite.service.CreateSection(otherProject.ID, "Backlog")
+	assert.NoError(t, err)
+	_, err = suite.service.GetSection(otherProject.ID, section.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+	_, err = suite.service.DeleteSection(otherProject.ID, section.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	// The sections of archived projects cannot be changed
+	_, err = suite.service.ArchiveProject(project.ID)
+	require.NoError(t, err)
+	_, err = suite.service.RenameSection(project.ID, section.ID, "Later")
+	assert.ErrorIs(t, err, ErrProjectArchived)
+}
+
+func (suite *ProjectServiceTestSuite) TestSubprojects() {
+	t := suite.T()
+
+	parent, err := suite.service.CreateProject("Parent")
+	require.NoError(t, err)
+	child, err := suite.service.CreateProjectIn("Child", nil, &parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &parent.ID, child.ParentProjectID)
+	grandchild, err := suite.service.CreateProjectIn("Grandchild", nil, &child.ID)
+	require.NoError(t, err)
+
+	// A project cannot go below itself or one of its sub-projects
+	_, err = suite.service.SetParentProject(parent.ID, &grandchild.ID)
+	assert.ErrorIs(t, err, ErrProjectCycle)
+	_, err = suite.service.SetParentProject(parent.ID, &parent.ID)
+	assert.ErrorIs(t, err, ErrProjectCycle)
+
+	grandchild, err = suite.service.SetParentProject(grandchild.ID, &parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &parent.ID, grandchild.ParentProjectID)
+
+	// The sub-projects of a deleted project move up to its parent
+	_, err = suite.service.SetParentProject(grandchild.ID, &child.ID)
+	require.NoError(t, err)
+	_, err = suite.service.DeleteProject(child.ID)
+	require.NoError(t, err)
+	grandchild, err = suite.service.GetProject(grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &parent.ID, grandchild.ParentProjectID)
+
+	// and restored projects go back to the top
+	child, err = suite.service.RestoreProject(child.ID)
+	require.NoError(t, err)
+	assert.Nil(t, child.ParentProjectID)
+
+	grandchild, err = suite.service.SetParentProject(grandchild.ID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, grandchild.ParentProjectID)
+}
+
+func (suite *ProjectServiceTestSuite) TestSubprojects_ConcurrentCycle() {
+	t := suite.T()
+
+	first, err := suite.service.CreateProject("First")
+	require.NoError(t, err)
+	second, err := suite.service.CreateProject("Second")
+	require.NoError(t, err)
+
+	// Each move is fine on its own, but together they would make a cycle
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errs[0] = suite.service.SetParentProject(first.ID, &second.ID)
+	}()
+	go func() {
+		defer wg.Done()
+		_, errs[1] = suite.service.SetParentProject(second.ID, &first.ID)
+	}()
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, ErrProjectCycle)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
 func (suite *ProjectServiceTestSuite) TestRenameProject_SuccessfulRename() {
 	t := suite.T()
 	oldName := "My test project"
@@ -159,6 +556,212 @@ func (suite *ProjectServiceTestSuite) TestListProjects() {
 	}
 }
 
+func (suite *ProjectServiceTestSuite) TestListProjectsPage() {
+	t := suite.T()
+
+	for _, name := range []string{"Delta", "Alpha", "Charlie", "Bravo", "Echo"} {
+		_, err := suite.service.CreateProject(name)
+		require.NoError(t, err)
+	}
+
+	names := []string{}
+	opts := ListOptions{SortBy: SortByName, Descending: true, Limit: 2}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+
+		page, err := suite.service.ListProjectsPage(opts)
+		require.NoError(t, err)
+		for _, project := range page.Projects {
+			names = append(names, project.Name)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"Echo", "Delta", "Charlie", "Bravo", "Alpha"}, names)
+
+	// A cursor only works with the sort it was made with
+	opts.SortBy = SortByCreatedAt
+	_, err := suite.service.ListProjectsPage(opts)
+	assert.ErrorIs(t, err, internal.ErrInvalidCursor)
+}
+
+func (suite *ProjectServiceTestSuite) TestArchiveProject() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("My test project")
+	require.NoError(t, err)
+
+	archivedProject, err := suite.service.ArchiveProject(project.ID)
+	require.NoError(t, err)
+	assert.True(t, archivedProject.IsArchived())
+
+	// Archived projects are hidden from the project list...
+	projectList, err := suite.service.ListProjects()
+	require.NoError(t, err)
+	assert.Empty(t, projectList)
+
+	// ...but can still be listed and fetched
+	projectList, err = suite.service.ListAllProjects()
+	if assert.NoError(t, err) && assert.Len(t, projectList, 1) {
+		assert.Equal(t, project.ID, projectList[0].ID)
+	}
+	_, err = suite.service.GetProject(project.ID)
+	assert.NoError(t, err)
+
+	// Archiving twice keeps the original archive date
+	rearchivedProject, err := suite.service.ArchiveProject(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, archivedProject.ArchivedAt, rearchivedProject.ArchivedAt)
+
+	unarchivedProject, err := suite.service.UnarchiveProject(project.ID)
+	require.NoError(t, err)
+	assert.False(t, unarchivedProject.IsArchived())
+
+	projectList, err = suite.service.ListProjects()
+	require.NoError(t, err)
+	assert.Len(t, projectList, 1)
+}
+
+func (suite *ProjectServiceTestSuite) TestArchiveProject_NonExistentProject() {
+	t := suite.T()
+
+	_, err := suite.service.ArchiveProject(uuid.New())
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	_, err = suite.service.UnarchiveProject(uuid.New())
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *ProjectServiceTestSuite) TestArchiveProject_NameStaysReserved() {
+	t := suite.T()
+	name := "My test project"
+
+	project, err := suite.service.CreateProject(name)
+	require.NoError(t, err)
+	_, err = suite.service.ArchiveProject(project.ID)
+	require.NoError(t, err)
+
+	_, err = suite.service.CreateProject(name)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	anotherProject, err := suite.service.CreateProject("Another project")
+	require.NoError(t, err)
+	_, err = suite.service.RenameProject(anotherProject.ID, name)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+}
+
+func (suite *ProjectServiceTestSuite) TestArchiveProject_ConcurrentClaimsOfAName() {
+	t := suite.T()
+	name := "My test project"
+
+	archivedProject, err := suite.service.CreateProject("Archived project")
+	require.NoError(t, err)
+	_, err = suite.service.ArchiveProject(archivedProject.ID)
+	require.NoError(t, err)
+
+	// The database lets the archived project and an active one share the name, so only the lock
+	// taken by the service keeps both from getting it
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errs[0] = suite.service.RenameProject(archivedProject.ID, name)
+	}()
+	go func() {
+		defer wg.Done()
+		_, errs[1] = suite.service.CreateProject(name)
+	}()
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func (suite *ProjectServiceTestSuite) TestArchiveProject_NameReuse() {
+	t := suite.T()
+	name := "My test project"
+	service := NewProjectService(suite.service.repository, suite.service.templates, WithArchivedNameReuse())
+
+	project, err := service.CreateProject(name)
+	require.NoError(t, err)
+	_, err = service.ArchiveProject(project.ID)
+	require.NoError(t, err)
+
+	// The name of the archived project can be taken by a new project...
+	newProject, err := service.CreateProject(name)
+	require.NoError(t, err)
+
+	fetchedProject, err := service.repository.GetByName(nil, name)
+	if assert.NoError(t, err) {
+		assert.Equal(t, newProject.ID, fetchedProject.ID)
+	}
+
+	// ...but not by a second active project
+	_, err = service.CreateProject(name)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	// The archived project cannot be unarchived while its name is taken
+	_, err = service.UnarchiveProject(project.ID)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	_, err = service.RenameProject(newProject.ID, "Renamed project")
+	require.NoError(t, err)
+	_, err = service.UnarchiveProject(project.ID)
+	assert.NoError(t, err)
+}
+
+func (suite *ProjectServiceTestSuite) TestCreateTemplate() {
+	t := suite.T()
+
+	project, err := suite.service.CreateProject("Onboarding Alice")
+	require.NoError(t, err)
+
+	rootTaskID := uuid.New()
+	insertTask := `
+INSERT INTO tasks (
+  id, name, project_id, parent_task_id, "order", status, created_at, due_at
+) VALUES (
+  $1, $2, $3, $4, $5, 'pending', $6, $7
+)
+`
+	dueAt := project.CreatedAt.AddDate(0, 0, 3)
+	_, err = suite.pool.Exec(suite.ctx, insertTask, rootTaskID, "Send laptop", project.ID, nil, 0, project.CreatedAt, nil)
+	require.NoError(t, err)
+	_, err = suite.pool.Exec(suite.ctx, insertTask, uuid.New(), "Configure VPN", project.ID, rootTaskID, 0, project.CreatedAt, dueAt)
+	require.NoError(t, err)
+
+	tmpl, err := suite.service.CreateTemplate(project.ID, "Onboarding")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Onboarding", tmpl.Name)
+	require.Len(t, tmpl.Tasks, 1)
+	assert.Equal(t, "Send laptop", tmpl.Tasks[0].Name)
+	assert.Nil(t, tmpl.Tasks[0].DueOffsetDays)
+	require.Len(t, tmpl.Tasks[0].Subtasks, 1)
+	assert.Equal(t, "Configure VPN", tmpl.Tasks[0].Subtasks[0].Name)
+	if assert.NotNil(t, tmpl.Tasks[0].Subtasks[0].DueOffsetDays) {
+		assert.Equal(t, 3, *tmpl.Tasks[0].Subtasks[0].DueOffsetDays)
+	}
+}
+
+func (suite *ProjectServiceTestSuite) TestCreateTemplate_NonExistentProject() {
+	t := suite.T()
+
+	_, err := suite.service.CreateTemplate(uuid.New(), "Onboarding")
+	assert.Error(t, err)
+}
+
 func TestProjectService(t *testing.T) {
 	suite.Run(t, new(ProjectServiceTestSuite))
 }
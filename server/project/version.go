@@ -0,0 +1,24 @@
+package project
+
+import (
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// ErrVersionChanged is returned when a project no longer has the version a change was made for.
+var ErrVersionChanged = internal.NewError(internal.ErrPreconditionFailed, "the project was changed since it was fetched")
+
+// IfVersion runs fn with a copy of the service provided that the project still has the given
+// version, and saves the changes made by fn all at once. The project stays locked until then, so
+// that no one can change it in between. Fails with ErrVersionChanged if the project has another
+// version.
+func (p *ProjectService) IfVersion(id uuid.UUID, version int, fn func(p *ProjectService) error) error {
+	return p.inTransaction(func(txService *ProjectService) error {
+		err := txService.repository.LockVersion(id, version)
+		if err != nil {
+			return err
+		}
+
+		return fn(txService)
+	})
+}
@@ -0,0 +1,57 @@
+package todoctian
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// DefaultTrashRetentionDays is the number of days deleted projects and tasks are kept in the
+// trash when no retention is configured.
+const DefaultTrashRetentionDays = 30
+
+// How often the retention job looks for expired items in the trash.
+const trashPurgeInterval = time.Hour
+
+// PurgeTrash permanently deletes the tasks and projects that were moved to the trash more than
+// retention ago.
+func (s *Server) PurgeTrash(retention time.Duration) error {
+	deletedBefore := time.Now().UTC().Add(-retention)
+
+	purgedTasks, err := s.TaskService.PurgeDeletedTasks(deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	purgedProjects, err := s.ProjectService.PurgeDeletedProjects(deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("purged trash",
+		slog.Time("deletedBefore", deletedBefore),
+		slog.Int64("tasks", purgedTasks),
+		slog.Int64("projects", purgedProjects),
+	)
+
+	return nil
+}
+
+// runTrashRetentionJob purges the trash every interval until ctx is done.
+func (s *Server) runTrashRetentionJob(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.PurgeTrash(retention)
+		if err != nil {
+			s.logger.Error("failed to purge trash", slog.Any("err", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
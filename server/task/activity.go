@@ -0,0 +1,56 @@
+package task
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/murasakiwano/todoctian/server/activity"
+)
+
+// WithOrigin returns a copy of the service that records its changes as made by origin.
+func (ts TaskService) WithOrigin(origin activity.Origin) *TaskService {
+	ts.origin = origin
+	return &ts
+}
+
+// record appends a change of the task to the activity history and saves the state of the task
+// after the change as a new revision. The change itself was already made, so failing to record it
+// is logged rather than returned.
+func (ts *TaskService) record(task Task, action activity.Action, before, after map[string]any) {
+	_, err := ts.repository.CreateRevision(task, action, time.Now().UTC())
+	if err != nil {
+		ts.logger.Error("failed to save task revision", slog.Any("task", task), slog.String("err", err.Error()))
+	}
+
+	if ts.activity == nil {
+		return
+	}
+
+	event := activity.NewEvent(ts.origin, action, task.ProjectID, &task.ID, before, after)
+	if ts.pending != nil {
+		ts.pending.events = append(ts.pending.events, event)
+		return
+	}
+
+	_, err = ts.activity.Record(event)
+	if err != nil {
+		ts.logger.Error("failed to record task activity", slog.Any("event", event), slog.String("err", err.Error()))
+	}
+}
+
+// The fields of a task that are tracked by the activity history.
+func taskSnapshot(task Task) map[string]any {
+	snapshot := map[string]any{
+		"name":   task.Name,
+		"status": task.Status.String(),
+		"order":  task.Order,
+	}
+	if task.ParentTaskID != nil {
+		snapshot["parentTaskID"] = task.ParentTaskID.String()
+	}
+	if task.DueAt != nil {
+		snapshot["dueAt"] = task.DueAt
+	}
+
+	return snapshot
+}
@@ -0,0 +1,147 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskActivityTestSuite struct {
+	suite.Suite
+	ctx             context.Context
+	pgContainer     *testhelpers.PostgresContainer
+	taskService     *TaskService
+	activityService *activity.ActivityService
+	projectID       uuid.UUID
+}
+
+func (suite *TaskActivityTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+	suite.activityService = activity.NewActivityService(activity.NewActivityRepositoryPostgres(suite.ctx, pgPool))
+
+	suite.taskService = NewTaskService(repository, projectRepository, WithActivityRecorder(suite.activityService))
+}
+
+// Setup database before each test
+func (suite *TaskActivityTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupActivityTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *TaskActivityTestSuite) TestChangesAreRecorded() {
+	t := suite.T()
+	taskService := suite.taskService.WithOrigin(activity.Origin{Actor: "alice", RequestID: "request-1"})
+
+	task, err := taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+	_, err = taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	page, err := suite.activityService.ListTaskActivity(task.ID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 3)
+
+	actions := []activity.Action{}
+	for _, event := range page.Events {
+		actions = append(actions, event.Action)
+		assert.Equal(t, "alice", event.Actor)
+		assert.Equal(t, "request-1", event.RequestID)
+	}
+	assert.Equal(t, []activity.Action{activity.ActionDeleted, activity.ActionRenamed, activity.ActionCreated}, actions)
+	assert.Equal(t, map[string]any{"name": "Test task"}, page.Events[1].Before)
+}
+
+func (suite *TaskActivityTestSuite) TestCascadedStatusChangesAreRecorded() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &parentTask.ID)
+	require.NoError(t, err)
+
+	err = suite.taskService.UpdateTaskStatus(parentTask.ID, TaskStatusCompleted.String())
+	require.NoError(t, err)
+
+	// Marking the subtask as pending also marks its parent as pending
+	taskService := suite.taskService.WithOrigin(activity.Origin{RequestID: "reopen-subtask"})
+	err = taskService.UpdateTaskStatus(subtask.ID, TaskStatusPending.String())
+	require.NoError(t, err)
+
+	page, err := suite.activityService.ListTaskActivity(parentTask.ID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 3) // created, completed, reopened
+	reopened := page.Events[0]
+	assert.Equal(t, activity.ActionStatusChanged, reopened.Action)
+	assert.Equal(t, "reopen-subtask", reopened.RequestID)
+	assert.Equal(t, map[string]any{"status": TaskStatusPending.String()}, reopened.After)
+
+	page, err = suite.activityService.ListTaskActivity(subtask.ID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 3) // created, completed with its parent, reopened
+	assert.Equal(t, map[string]any{"status": TaskStatusCompleted.String()}, page.Events[1].After)
+}
+
+func (suite *TaskActivityTestSuite) TestReorderIsRecordedWithItsTransaction() {
+	t := suite.T()
+
+	first, err := suite.taskService.CreateTask("First", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Second", suite.projectID, nil)
+	require.NoError(t, err)
+
+	// The reorder is rolled back along with the transaction it is made in, so it is not recorded
+	errOuter := errors.New("outer failure")
+	err = suite.taskService.IfVersion(first.ID, first.Version, func(ts *TaskService) error {
+		require.NoError(t, ts.ReorderTask(first, 1))
+		return errOuter
+	})
+	require.ErrorIs(t, err, errOuter)
+
+	page, err := suite.activityService.ListTaskActivity(first.ID, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Events, 1)
+	revisions, err := suite.taskService.ListRevisions(first.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+
+	require.NoError(t, suite.taskService.ReorderTask(first, 1))
+	page, err = suite.activityService.ListTaskActivity(first.ID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 2)
+	assert.Equal(t, activity.ActionReordered, page.Events[0].Action)
+}
+
+func TestTaskActivity(t *testing.T) {
+	suite.Run(t, new(TaskActivityTestSuite))
+}
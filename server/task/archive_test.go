@@ -0,0 +1,126 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ArchivedProjectTestSuite struct {
+	suite.Suite
+	ctx            context.Context
+	pgContainer    *testhelpers.PostgresContainer
+	taskService    *TaskService
+	projectService *project.ProjectService
+	projectID      uuid.UUID
+}
+
+func (suite *ArchivedProjectTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+	suite.projectService = project.NewProjectService(projectRepository, nil)
+}
+
+// Setup database before each test
+func (suite *ArchivedProjectTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *ArchivedProjectTestSuite) TestTasksCannotBeChanged() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Other test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.projectService.ArchiveProject(suite.projectID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.CreateTask("New task", suite.projectID, nil)
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+
+	_, err = suite.taskService.CreateTask("New subtask", suite.projectID, &task.ID)
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+
+	err = suite.taskService.ReorderTask(task, 1)
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+
+	err = suite.taskService.UpdateTaskStatus(task.ID, TaskStatusCompleted.String())
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+
+	_, err = suite.taskService.DeleteTask(task.ID)
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+
+	// Nothing changed
+	fetchedTask, err := suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.Name, fetchedTask.Name)
+	assert.Equal(t, task.Order, fetchedTask.Order)
+	assert.Equal(t, TaskStatusPending, fetchedTask.Status)
+}
+
+func (suite *ArchivedProjectTestSuite) TestTasksCanBeChangedAfterUnarchiving() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.projectService.ArchiveProject(suite.projectID)
+	require.NoError(t, err)
+	_, err = suite.projectService.UnarchiveProject(suite.projectID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	assert.NoError(t, err)
+}
+
+func (suite *ArchivedProjectTestSuite) TestDeletedTaskCannotBeRestored() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	_, err = suite.projectService.ArchiveProject(suite.projectID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RestoreTask(task.ID)
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+}
+
+func TestArchivedProject(t *testing.T) {
+	suite.Run(t, new(ArchivedProjectTestSuite))
+}
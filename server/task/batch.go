@@ -0,0 +1,351 @@
+package task
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+)
+
+var (
+	ErrBatchTooLarge          = internal.NewValidationError(fmt.Sprintf("a batch can have at most %d operations", MaxBatchSize))
+	ErrInvalidBatchOperation  = internal.NewValidationError("invalid batch operation")
+	ErrUnknownTaskReference   = internal.NewValidationError("unknown task reference")
+	ErrDuplicateTemporaryTask = internal.NewValidationError("temporary ID already used in the batch")
+)
+
+// How many operations a batch can have
+const MaxBatchSize = 1000
+
+type BatchOperationKind string
+
+const (
+	BatchCreate    BatchOperationKind = "create"
+	BatchRename    BatchOperationKind = "rename"
+	BatchSetStatus BatchOperationKind = "set_status"
+	BatchMove      BatchOperationKind = "move"
+	BatchDelete    BatchOperationKind = "delete"
+)
+
+// BatchOperation is one of the operations run by RunBatch. Tasks are referenced either by their ID
+// or by the temporary ID given to them by an earlier create operation of the same batch.
+type BatchOperation struct {
+	Kind BatchOperationKind
+	// Temporary ID of the task to create, with which later operations can reference it
+	TempID string
+	// The task to rename, update, move or delete
+	TaskRef string
+	// The parent of the task to create or move. Empty for a root task of the project
+	ParentRef string
+	// The project of the task to create. If unset, it is the project of the parent task
+	ProjectID uuid.UUID
+	// The name of the task to create or the new name of the task to rename
+	Name string
+	// The new status of the task, "pending" or "completed"
+	Status string
+	// When the task to create is due
+	DueAt *time.Time
+}
+
+// BatchResult is the outcome of an operation of a batch: the task it was made to, as it was right
+// after the operation.
+type BatchResult struct {
+	Kind   BatchOperationKind
+	TempID string
+	Task   Task
+}
+
+// BatchError is returned when an operation of a batch fails. None of the operations of the batch
+// are saved then.
+type BatchError struct {
+	Err   error
+	Kind  BatchOperationKind
+	Index int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d (%s) failed: %s", e.Index, e.Kind, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// The changes made within a batch that must wait for it to succeed before being recorded.
+type pendingChanges struct {
+	events   []activity.Event
+	commands []command
+}
+
+// RunBatch runs the operations in order, all or nothing: if one of them fails, a *BatchError is
+// returned and none of the changes are saved. The changes of the batch are undone together.
+func (ts *TaskService) RunBatch(operations []BatchOperation) ([]BatchResult, error) {
+	if len(operations) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	err := validateBatch(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BatchResult
+	err = ts.inTransaction(func(txService *TaskService) error {
+		b := batch{ts: txService, tempIDs: map[string]uuid.UUID{}, nextOrders: map[taskLevel]int{}}
+		results = make([]BatchResult, 0, len(operations))
+		for i, op := range operations {
+			task, err := b.run(op)
+			if err != nil {
+				return &BatchError{Index: i, Kind: op.Kind, Err: err}
+			}
+
+			results = append(results, BatchResult{Kind: op.Kind, TempID: op.TempID, Task: task})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// inTransaction runs fn with a copy of the service whose changes are only saved if fn succeeds. The
+// changes are recorded and logged once they are saved, or along with the enclosing transaction if
+// the service already runs in one, so that they are left out if it is rolled back.
+func (ts *TaskService) inTransaction(fn func(txService *TaskService) error) error {
+	pending := ts.pending
+	if pending == nil {
+		pending = &pendingChanges{}
+	}
+	events, commands := len(pending.events), len(pending.commands)
+
+	err := ts.repository.InTransaction(func(repository TaskRepository, projectRepository project.ProjectRepository) error {
+		txService := *ts
+		txService.repository = repository
+		txService.projectDB = projectRepository
+		txService.pending = pending
+
+		return fn(&txService)
+	})
+	if err != nil {
+		// The changes of fn were rolled back, they must not be recorded with the enclosing ones
+		pending.events = pending.events[:events]
+		pending.commands = pending.commands[:commands]
+		return err
+	}
+
+	if ts.pending == nil {
+		ts.flushPendingChanges(pending)
+	}
+	return nil
+}
+
+// Records and logs the changes of a batch once it succeeded.
+func (ts *TaskService) flushPendingChanges(pending *pendingChanges) {
+	if ts.activity != nil {
+		for _, event := range pending.events {
+			_, err := ts.activity.Record(event)
+			if err != nil {
+				ts.logger.Error("failed to record task activity", slog.Any("event", event), slog.String("err", err.Error()))
+			}
+		}
+	}
+
+	if ts.session != "" && len(pending.commands) > 0 {
+		ts.commands.pushStep(ts.session, pending.commands)
+	}
+}
+
+// Checks the operations before running any of them.
+func validateBatch(operations []BatchOperation) error {
+	tempIDs := map[string]bool{}
+	for i, op := range operations {
+		invalid := func(reason string) error {
+			return &BatchError{Index: i, Kind: op.Kind, Err: fmt.Errorf("%w: %s", ErrInvalidBatchOperation, reason)}
+		}
+
+		if op.TempID != "" {
+			if op.Kind != BatchCreate {
+				return invalid("only created tasks have a temporary ID")
+			}
+			if tempIDs[op.TempID] {
+				return &BatchError{Index: i, Kind: op.Kind, Err: fmt.Errorf("%w: %s", ErrDuplicateTemporaryTask, op.TempID)}
+			}
+			tempIDs[op.TempID] = true
+		}
+
+		if op.Kind != BatchCreate && op.TaskRef == "" {
+			return invalid("the task is missing")
+		}
+
+		switch op.Kind {
+		case BatchCreate:
+			if op.ProjectID == uuid.Nil && op.ParentRef == "" {
+				return invalid("either the project or the parent task is required")
+			}
+			if op.Name == "" {
+				return invalid("the name is required")
+			}
+
+		case BatchRename:
+			if op.Name == "" {
+				return invalid("the name is required")
+			}
+
+		case BatchSetStatus:
+			status := TaskStatus{}
+			if status.FromString(op.Status) != nil {
+				return invalid(fmt.Sprintf("unknown status %q", op.Status))
+			}
+
+		case BatchMove, BatchDelete:
+
+		default:
+			return invalid(fmt.Sprintf("unknown operation %q", op.Kind))
+		}
+	}
+
+	return nil
+}
+
+// A level of the task tree: the root of a project, or the subtasks of a task.
+type taskLevel struct {
+	projectID    uuid.UUID
+	parentTaskID uuid.UUID
+}
+
+func levelOf(task Task) taskLevel {
+	level := taskLevel{projectID: task.ProjectID}
+	if task.ParentTaskID != nil {
+		level.parentTaskID = *task.ParentTaskID
+	}
+
+	return level
+}
+
+// batch is the state of a batch while it runs.
+type batch struct {
+	ts *TaskService
+	// The IDs of the tasks created so far, by their temporary ID
+	tempIDs map[string]uuid.UUID
+	// The order of the next task created in each level, so that the siblings are only fetched for
+	// the first one. Moves and deletions change the levels, so they reset it.
+	nextOrders map[taskLevel]int
+}
+
+func (b *batch) run(op BatchOperation) (Task, error) {
+	if op.Kind == BatchCreate {
+		return b.create(op)
+	}
+
+	id, err := b.resolve(op.TaskRef)
+	if err != nil {
+		return Task{}, err
+	}
+
+	switch op.Kind {
+	case BatchRename:
+		return b.ts.RenameTask(id, op.Name)
+
+	case BatchSetStatus:
+		err = b.ts.UpdateTaskStatus(id, op.Status)
+		if err != nil {
+			return Task{}, err
+		}
+
+		return b.ts.repository.Get(id)
+
+	case BatchMove:
+		parentTaskID, err := b.resolveParent(op.ParentRef)
+		if err != nil {
+			return Task{}, err
+		}
+
+		clear(b.nextOrders)
+		return b.ts.MoveTask(id, parentTaskID)
+
+	default:
+		clear(b.nextOrders)
+		return b.ts.DeleteTask(id)
+	}
+}
+
+func (b *batch) create(op BatchOperation) (Task, error) {
+	parentTaskID, err := b.resolveParent(op.ParentRef)
+	if err != nil {
+		return Task{}, err
+	}
+
+	projectID := op.ProjectID
+	if projectID == uuid.Nil {
+		parentTask, err := b.ts.repository.Get(*parentTaskID)
+		if err != nil {
+			return Task{}, err
+		}
+		projectID = parentTask.ProjectID
+	}
+
+	task := NewTask(internal.NormalizeName(op.Name), projectID, parentTaskID)
+	task.DueAt = op.DueAt
+
+	err = b.ts.ValidateTask(task)
+	if err != nil {
+		return Task{}, fmt.Errorf("Could not create task \"%s\": %w", task.Name, err)
+	}
+
+	level := levelOf(task)
+	order, ok := b.nextOrders[level]
+	if !ok {
+		task, err = b.ts.setInitialTaskOrder(task)
+		if err != nil {
+			return Task{}, err
+		}
+		order = task.Order
+	}
+	task.Order = order
+	b.nextOrders[level] = order + 1
+
+	task, err = b.ts.insertTask(task)
+	if err != nil {
+		return Task{}, err
+	}
+
+	if op.TempID != "" {
+		b.tempIDs[op.TempID] = task.ID
+	}
+
+	return task, nil
+}
+
+// resolve finds the ID of the task referenced by an operation.
+func (b *batch) resolve(ref string) (uuid.UUID, error) {
+	if id, ok := b.tempIDs[ref]; ok {
+		return id, nil
+	}
+
+	id, err := uuid.Parse(ref)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrUnknownTaskReference, ref)
+	}
+
+	return id, nil
+}
+
+func (b *batch) resolveParent(ref string) (*uuid.UUID, error) {
+	if ref == "" {
+		return nil, nil
+	}
+
+	id, err := b.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
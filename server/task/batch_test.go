@@ -0,0 +1,269 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+	ctx             context.Context
+	pgContainer     *testhelpers.PostgresContainer
+	taskService     *TaskService
+	activityService *activity.ActivityService
+	projectID       uuid.UUID
+}
+
+func (suite *BatchTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+	suite.activityService = activity.NewActivityService(activity.NewActivityRepositoryPostgres(suite.ctx, pgPool))
+
+	suite.taskService = NewTaskService(repository, projectRepository, WithActivityRecorder(suite.activityService))
+}
+
+// Setup database before each test
+func (suite *BatchTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupActivityTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *BatchTestSuite) TestCreateTreeWithTemporaryIDs() {
+	t := suite.T()
+
+	existingTask, err := suite.taskService.CreateTask("Existing task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	results, err := suite.taskService.RunBatch([]BatchOperation{
+		{Kind: BatchCreate, TempID: "checklist", Name: "Checklist", ProjectID: suite.projectID},
+		{Kind: BatchCreate, TempID: "first", Name: "First item", ParentRef: "checklist"},
+		{Kind: BatchCreate, Name: "Second item", ParentRef: "checklist"},
+		{Kind: BatchRename, TaskRef: "first", Name: "First item, renamed"},
+		{Kind: BatchSetStatus, TaskRef: "first", Status: TaskStatusCompleted.String()},
+		{Kind: BatchMove, TaskRef: existingTask.ID.String(), ParentRef: "checklist"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	checklist := results[0].Task
+	assert.Equal(t, "checklist", results[0].TempID)
+	assert.Equal(t, suite.projectID, checklist.ProjectID)
+	assert.Equal(t, 1, checklist.Order) // after the existing task
+
+	first, err := suite.taskService.FindTaskByID(results[1].Task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First item, renamed", first.Name)
+	assert.Equal(t, TaskStatusCompleted, first.Status)
+	assert.Equal(t, suite.projectID, first.ProjectID)
+
+	subtasks, err := suite.taskService.FetchSubtasksDirect(checklist.ID)
+	require.NoError(t, err)
+	orders := map[uuid.UUID]int{}
+	for _, subtask := range subtasks {
+		orders[subtask.ID] = subtask.Order
+	}
+	assert.Equal(t, map[uuid.UUID]int{first.ID: 0, results[2].Task.ID: 1, existingTask.ID: 2}, orders)
+
+	// The checklist is now the only root task
+	checklist, err = suite.taskService.FindTaskByID(checklist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, checklist.Order)
+}
+
+func (suite *BatchTestSuite) TestFailedOperationSavesNothing() {
+	t := suite.T()
+
+	existingTask, err := suite.taskService.CreateTask("Existing task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RunBatch([]BatchOperation{
+		{Kind: BatchCreate, TempID: "new", Name: "New task", ProjectID: suite.projectID},
+		{Kind: BatchRename, TaskRef: existingTask.ID.String(), Name: "Renamed task"},
+		{Kind: BatchMove, TaskRef: existingTask.ID.String(), ParentRef: uuid.NewString()},
+	})
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 2, batchErr.Index)
+	assert.Equal(t, BatchMove, batchErr.Kind)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	tasks, err := suite.taskService.ListTasks()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Existing task", tasks[0].Name)
+
+	// Neither is the activity of the failed batch recorded
+	page, err := suite.activityService.ListTaskActivity(existingTask.ID, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Events, 1)
+}
+
+func (suite *BatchTestSuite) TestBatchInRolledBackTransactionRecordsNothing() {
+	t := suite.T()
+
+	existingTask, err := suite.taskService.CreateTask("Existing task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	// The batch succeeds, but the transaction it runs in is rolled back afterwards
+	errOuter := errors.New("outer failure")
+	err = suite.taskService.IfVersion(existingTask.ID, existingTask.Version, func(ts *TaskService) error {
+		_, err := ts.RunBatch([]BatchOperation{
+			{Kind: BatchRename, TaskRef: existingTask.ID.String(), Name: "Renamed task"},
+		})
+		require.NoError(t, err)
+
+		return errOuter
+	})
+	require.ErrorIs(t, err, errOuter)
+
+	existingTask, err = suite.taskService.FindTaskByID(existingTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Existing task", existingTask.Name)
+
+	page, err := suite.activityService.ListTaskActivity(existingTask.ID, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Events, 1)
+}
+
+func (suite *BatchTestSuite) TestUnknownTemporaryID() {
+	t := suite.T()
+
+	_, err := suite.taskService.RunBatch([]BatchOperation{
+		{Kind: BatchCreate, Name: "New task", ProjectID: suite.projectID},
+		{Kind: BatchDelete, TaskRef: "missing"},
+	})
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.ErrorIs(t, err, ErrUnknownTaskReference)
+
+	tasks, err := suite.taskService.ListTasks()
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func (suite *BatchTestSuite) TestBatchIsUndoneAtOnce() {
+	t := suite.T()
+	taskService := suite.taskService.WithSession("batch-session")
+
+	first, err := taskService.CreateTask("First task", suite.projectID, nil)
+	require.NoError(t, err)
+	second, err := taskService.CreateTask("Second task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = taskService.RunBatch([]BatchOperation{
+		{Kind: BatchRename, TaskRef: first.ID.String(), Name: "First task, renamed"},
+		{Kind: BatchSetStatus, TaskRef: second.ID.String(), Status: TaskStatusCompleted.String()},
+	})
+	require.NoError(t, err)
+
+	_, err = taskService.Undo()
+	require.NoError(t, err)
+
+	first, err = taskService.FindTaskByID(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First task", first.Name)
+	second, err = taskService.FindTaskByID(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, second.Status)
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func TestValidateBatch(t *testing.T) {
+	projectID := uuid.New()
+
+	tests := []struct {
+		name       string
+		operations []BatchOperation
+		index      int
+		err        error
+	}{
+		{
+			name:       "missing name",
+			operations: []BatchOperation{{Kind: BatchCreate, ProjectID: projectID}},
+			err:        ErrInvalidBatchOperation,
+		},
+		{
+			name:       "missing project and parent",
+			operations: []BatchOperation{{Kind: BatchCreate, Name: "Task"}},
+			err:        ErrInvalidBatchOperation,
+		},
+		{
+			name: "missing task",
+			operations: []BatchOperation{
+				{Kind: BatchCreate, Name: "Task", ProjectID: projectID},
+				{Kind: BatchDelete},
+			},
+			index: 1,
+			err:   ErrInvalidBatchOperation,
+		},
+		{
+			name:       "unknown status",
+			operations: []BatchOperation{{Kind: BatchSetStatus, TaskRef: "task", Status: "done"}},
+			err:        ErrInvalidBatchOperation,
+		},
+		{
+			name:       "temporary ID on another operation",
+			operations: []BatchOperation{{Kind: BatchDelete, TaskRef: "task", TempID: "task"}},
+			err:        ErrInvalidBatchOperation,
+		},
+		{
+			name: "duplicate temporary ID",
+			operations: []BatchOperation{
+				{Kind: BatchCreate, TempID: "task", Name: "Task", ProjectID: projectID},
+				{Kind: BatchCreate, TempID: "task", Name: "Other task", ProjectID: projectID},
+			},
+			index: 1,
+			err:   ErrDuplicateTemporaryTask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBatch(tt.operations)
+			var batchErr *BatchError
+			require.True(t, errors.As(err, &batchErr))
+			assert.Equal(t, tt.index, batchErr.Index)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	assert.NoError(t, validateBatch([]BatchOperation{
+		{Kind: BatchCreate, TempID: "parent", Name: "Parent", ProjectID: projectID},
+		{Kind: BatchCreate, Name: "Subtask", ParentRef: "parent"},
+		{Kind: BatchMove, TaskRef: "parent"},
+	}))
+}
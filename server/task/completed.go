@@ -0,0 +1,26 @@
+package task
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// ListCompletedTasks lists the tasks completed from "from" (inclusive) to "to" (exclusive), most
+// recently completed first, in every project or, if projectID is not nil, in a single project.
+// The times can be in any time zone.
+func (ts TaskService) ListCompletedTasks(projectID *uuid.UUID, from time.Time, to time.Time) ([]Task, error) {
+	if !from.Before(to) {
+		return nil, internal.FieldErrors{{Field: "to", Message: "must be after from"}}
+	}
+
+	if projectID != nil {
+		_, err := ts.projectDB.Get(*projectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ts.repository.ListCompleted(projectID, from, to)
+}
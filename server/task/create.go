@@ -3,18 +3,33 @@ package task
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
 )
 
 // CreateTask instantiates a new Task and persists it to the TaskRepository, while performing
-// validations.
+// validations. The whitespace around the name is trimmed.
 func (t *TaskService) CreateTask(taskName string, projectID uuid.UUID, parentTaskID *uuid.UUID) (Task, error) {
+	return t.CreateTaskWithDueDate(taskName, projectID, parentTaskID, nil)
+}
+
+// CreateTaskWithDueDate works like CreateTask, but also sets when the task is due.
+func (t *TaskService) CreateTaskWithDueDate(taskName string, projectID uuid.UUID, parentTaskID *uuid.UUID, dueAt *time.Time) (Task, error) {
 	task := NewTask(taskName, projectID, parentTaskID)
+	task.DueAt = dueAt
+
+	return t.createTask(task)
+}
+
+func (t *TaskService) createTask(task Task) (Task, error) {
+	task.Name = internal.NormalizeName(task.Name)
 	err := t.ValidateTask(task)
 	if err != nil {
 		t.logger.Error("could not validate task", slog.Any("err", err))
-		return Task{}, fmt.Errorf("Could not create task \"%s\": %w", taskName, err)
+		return Task{}, fmt.Errorf("Could not create task \"%s\": %w", task.Name, err)
 	}
 
 	task, err = t.setInitialTaskOrder(task)
@@ -22,7 +37,18 @@ func (t *TaskService) CreateTask(taskName string, projectID uuid.UUID, parentTas
 		return Task{}, err
 	}
 
-	return task, t.repository.Create(task)
+	return t.insertTask(task)
+}
+
+// insertTask persists a validated task whose order is already set.
+func (t *TaskService) insertTask(task Task) (Task, error) {
+	err := t.repository.Create(task)
+	if err != nil {
+		return Task{}, err
+	}
+
+	t.record(task, activity.ActionCreated, nil, taskSnapshot(task))
+	return task, nil
 }
 
 // Sets the initial order of the task relative to its siblings. The order is an integer starting at 0
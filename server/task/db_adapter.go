@@ -1,8 +1,12 @@
 package task
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/murasakiwano/todoctian/server/activity"
 	"github.com/murasakiwano/todoctian/server/db"
 	"github.com/murasakiwano/todoctian/server/internal"
 )
@@ -30,6 +34,47 @@ func TaskDBToTaskModel(taskDB db.Task) (Task, error) {
 		parentTaskID = &pTaskID
 	}
 
+	var dueAt *time.Time = nil
+	if taskDB.DueAt.Valid {
+		dueAt = &taskDB.DueAt.Time
+	}
+
+	var deletedAt *time.Time = nil
+	if taskDB.DeletedAt.Valid {
+		deletedAt = &taskDB.DeletedAt.Time
+	}
+
+	var completedAt *time.Time = nil
+	if taskDB.CompletedAt.Valid {
+		completedAt = &taskDB.CompletedAt.Time
+	}
+
+	var iterationID *uuid.UUID = nil
+	if taskDB.IterationID.Valid {
+		iID, err := internal.EncodeUUID(taskDB.IterationID.Bytes)
+		if err != nil {
+			return Task{}, err
+		}
+
+		iterationID = &iID
+	}
+
+	var sectionID *uuid.UUID = nil
+	if taskDB.SectionID.Valid {
+		sID, err := internal.EncodeUUID(taskDB.SectionID.Bytes)
+		if err != nil {
+			return Task{}, err
+		}
+
+		sectionID = &sID
+	}
+
+	var estimate *int = nil
+	if taskDB.Estimate.Valid {
+		e := int(taskDB.Estimate.Int32)
+		estimate = &e
+	}
+
 	// NOTE: the database guarantees that "status" is either "pending" or "completed"
 	taskStatus := TaskStatusPending
 	if taskDB.Status == TaskStatusCompleted.String() {
@@ -44,6 +89,14 @@ func TaskDBToTaskModel(taskDB db.Task) (Task, error) {
 		Status:       taskStatus,
 		Order:        int(taskDB.Order),
 		Name:         taskDB.Name,
+		DueAt:        dueAt,
+		DeletedAt:    deletedAt,
+		UpdatedAt:    taskDB.UpdatedAt.Time,
+		CompletedAt:  completedAt,
+		Estimate:     estimate,
+		IterationID:  iterationID,
+		SectionID:    sectionID,
+		Version:      int(taskDB.Version),
 	}, nil
 }
 
@@ -68,8 +121,8 @@ func TaskModelToTaskDB(task Task) (db.Task, error) {
 		}
 	}
 
-	// Step 2: convert task.CreatedAt to pgtype.Timestamp
-	pgCreatedAt := pgtype.Timestamp{}
+	// Step 2: convert task.CreatedAt to pgtype.Timestamptz
+	pgCreatedAt := pgtype.Timestamptz{}
 	err = pgCreatedAt.Scan(task.CreatedAt)
 	if err != nil {
 		return db.Task{}, err
@@ -78,7 +131,64 @@ func TaskModelToTaskDB(task Task) (db.Task, error) {
 	// Step 3: convert task status to text
 	pgTaskStatus := task.Status.String()
 
-	// Step 3: return task data as defined by the db
+	// Step 4: convert the (optional) due date to pgtype.Timestamptz
+	pgDueAt := pgtype.Timestamptz{}
+	if task.DueAt != nil {
+		err = pgDueAt.Scan(*task.DueAt)
+		if err != nil {
+			return db.Task{}, err
+		}
+	}
+
+	// Step 5: convert the deletion date, for tasks in the trash, to pgtype.Timestamptz
+	pgDeletedAt := pgtype.Timestamptz{}
+	if task.DeletedAt != nil {
+		err = pgDeletedAt.Scan(*task.DeletedAt)
+		if err != nil {
+			return db.Task{}, err
+		}
+	}
+
+	// Step 6: convert the update and (optional) completion dates to pgtype.Timestamptz
+	pgUpdatedAt := pgtype.Timestamptz{}
+	err = pgUpdatedAt.Scan(task.UpdatedAt)
+	if err != nil {
+		return db.Task{}, err
+	}
+
+	pgCompletedAt := pgtype.Timestamptz{}
+	if task.CompletedAt != nil {
+		err = pgCompletedAt.Scan(*task.CompletedAt)
+		if err != nil {
+			return db.Task{}, err
+		}
+	}
+
+	// Step 7: convert the (optional) estimate to pgtype.Int4
+	pgEstimate := pgtype.Int4{}
+	if task.Estimate != nil {
+		pgEstimate = pgtype.Int4{Int32: int32(*task.Estimate), Valid: true}
+	}
+
+	// Step 8: convert the (optional) iteration ID to pgtype.UUID
+	pgIterationUUID := pgtype.UUID{}
+	if task.IterationID != nil {
+		pgIterationUUID, err = internal.ScanUUID(*task.IterationID)
+		if err != nil {
+			return db.Task{}, err
+		}
+	}
+
+	// Step 9: convert the (optional) section ID to pgtype.UUID
+	pgSectionUUID := pgtype.UUID{}
+	if task.SectionID != nil {
+		pgSectionUUID, err = internal.ScanUUID(*task.SectionID)
+		if err != nil {
+			return db.Task{}, err
+		}
+	}
+
+	// Step 10: return task data as defined by the db
 	return db.Task{
 		ID:           pgTaskUUID,
 		CreatedAt:    pgCreatedAt,
@@ -87,5 +197,39 @@ func TaskModelToTaskDB(task Task) (db.Task, error) {
 		Status:       pgTaskStatus,
 		Order:        int32(task.Order),
 		Name:         task.Name,
+		DueAt:        pgDueAt,
+		DeletedAt:    pgDeletedAt,
+		Version:      int32(task.Version),
+		UpdatedAt:    pgUpdatedAt,
+		CompletedAt:  pgCompletedAt,
+		Estimate:     pgEstimate,
+		IterationID:  pgIterationUUID,
+		SectionID:    pgSectionUUID,
+	}, nil
+}
+
+func TaskRevisionDBToRevisionModel(revisionDB db.TaskRevision) (Revision, error) {
+	taskID, err := internal.EncodeUUID(revisionDB.TaskID.Bytes)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	var state taskState
+	err = json.Unmarshal(revisionDB.State, &state)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	task, err := state.toTask(taskID)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	return Revision{
+		TaskID:    taskID,
+		Number:    int(revisionDB.Revision),
+		CreatedAt: revisionDB.CreatedAt.Time,
+		Action:    activity.Action(revisionDB.Action),
+		Task:      task,
 	}, nil
 }
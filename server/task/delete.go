@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
 	"github.com/murasakiwano/todoctian/server/internal"
 )
 
-// DeleteTask method    Deletes a task if it exists. If it does not, it is a no-op.
+// DeleteTask method    Moves a task and its subtasks to the trash, from which they can be restored
+// with RestoreTask. If the task does not exist, it is a no-op.
 func (ts *TaskService) DeleteTask(id uuid.UUID) (Task, error) {
 	task, err := ts.repository.Get(id)
 	if err != nil {
@@ -22,40 +25,43 @@ func (ts *TaskService) DeleteTask(id uuid.UUID) (Task, error) {
 		return Task{}, internal.NewNotFoundError(fmt.Sprintf("task %s", id))
 	}
 
-	err = ts.maybeDeleteSubtasks(task)
+	err = ts.ensureProjectIsActive(task.ProjectID)
 	if err != nil {
-		return Task{}, fmt.Errorf("Failed to delete subtasks of task %s: %w", task.ID, err)
+		return Task{}, err
 	}
 
-	err = ts.rearrangeTaskSiblings(task)
+	deletedAt := time.Now().UTC()
+	err = ts.repository.SoftDelete(id, deletedAt)
 	if err != nil {
-		return Task{}, fmt.Errorf("Failed to rearrange siblings of task %s: %w", task.ID, err)
+		return Task{}, fmt.Errorf("Failed to move task %s to the trash: %w", task.ID, err)
 	}
+	before := taskSnapshot(task)
+	task.DeletedAt = &deletedAt
+	ts.record(task, activity.ActionDeleted, before, nil)
 
-	return ts.repository.Delete(id)
-}
-
-func (ts *TaskService) maybeDeleteSubtasks(task Task) error {
-	// Does the task have any subtasks?
-	subtasks, err := ts.repository.GetSubtasksDeep(task.ID)
+	err = ts.rearrangeTaskSiblings(task)
 	if err != nil {
-		return err
-	}
-	if len(subtasks) == 0 {
-		return nil
-	}
-
-	// Delete the subtasks recursively, bottom-up
-	for _, subtask := range subtasks {
-		_, err := ts.DeleteTask(subtask.ID)
-		if err != nil {
-			return fmt.Errorf("Failed to delete subtask %s of task %s: %w", subtask, task.ID, err)
-		}
+		return Task{}, fmt.Errorf("Failed to rearrange siblings of task %s: %w", task.ID, err)
 	}
 
-	return nil
+	ts.logCommand(command{
+		action:  activity.ActionDeleted,
+		taskIDs: []uuid.UUID{id},
+		undo: func(ts *TaskService) error {
+			_, err := ts.RestoreTask(id)
+			return err
+		},
+		redo: func(ts *TaskService) error {
+			_, err := ts.DeleteTask(id)
+			return err
+		},
+	})
+
+	return task, nil
 }
 
+// Closes the gap left by a task that was moved to the trash, by moving every sibling after it
+// one position up.
 func (ts *TaskService) rearrangeTaskSiblings(task Task) error {
 	siblings, err := ts.FetchTaskSiblings(task)
 	if err != nil {
@@ -68,24 +74,26 @@ func (ts *TaskService) rearrangeTaskSiblings(task Task) error {
 		slog.Any("siblings", siblings),
 	)
 
-	if len(siblings) <= 1 {
-		return nil
-	}
+	// Siblings are updated in ascending order, so that each one moves into a free position
 	slices.SortFunc(siblings, cmpTasks)
 
-	siblingsAfterTaskToDelete := siblings[task.Order+1:]
-	for i, s := range siblingsAfterTaskToDelete {
-		s.Order -= 1
-		siblings[task.Order+1-i] = s
+	siblingsAfterDeletedTask := []Task{}
+	for _, s := range siblings {
+		if s.Order > task.Order {
+			s.Order -= 1
+			siblingsAfterDeletedTask = append(siblingsAfterDeletedTask, s)
+		}
+	}
+	if len(siblingsAfterDeletedTask) == 0 {
+		return nil
 	}
 
-	_ = slices.Delete(siblings, task.Order, task.Order+1)
-	err = ts.repository.BatchUpdateOrder(siblings[:len(siblings)-1])
+	err = ts.repository.BatchUpdateOrder(siblingsAfterDeletedTask)
 	if err != nil {
 		return fmt.Errorf("Failed to update siblings of task %s: %w", task.ID, err)
 	}
 
-	slog.Debug("task siblings after rearrangement", slog.Any("siblings", siblings))
+	slog.Debug("task siblings after rearrangement", slog.Any("siblings", siblingsAfterDeletedTask))
 
 	return nil
 }
@@ -0,0 +1,30 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+)
+
+// UpdateTaskDueDate sets when a task is due. A nil dueAt removes the due date.
+func (ts *TaskService) UpdateTaskDueDate(id uuid.UUID, dueAt *time.Time) (Task, error) {
+	task, err := ts.repository.Get(id)
+	if err != nil {
+		return Task{}, fmt.Errorf("Could not update the due date of task %s: %w", id, err)
+	}
+
+	err = ts.ensureProjectIsActive(task.ProjectID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	updatedTask, err := ts.repository.UpdateDueAt(id, dueAt)
+	if err != nil {
+		return Task{}, err
+	}
+
+	ts.record(updatedTask, activity.ActionDueAtChanged, map[string]any{"dueAt": task.DueAt}, map[string]any{"dueAt": updatedTask.DueAt})
+	return updatedTask, nil
+}
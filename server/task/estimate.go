@@ -0,0 +1,66 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// UpdateTaskEstimate sets how much work a task takes, in the estimate unit of its project. A nil
+// estimate removes it, so that the estimate of the task is derived from its subtasks again.
+func (ts *TaskService) UpdateTaskEstimate(id uuid.UUID, estimate *int) (Task, error) {
+	if estimate != nil && *estimate < 0 {
+		return Task{}, internal.FieldErrors{{Field: "estimate", Message: "must not be negative"}}
+	}
+
+	task, err := ts.repository.Get(id)
+	if err != nil {
+		return Task{}, fmt.Errorf("Could not update the estimate of task %s: %w", id, err)
+	}
+
+	err = ts.ensureProjectIsActive(task.ProjectID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	updatedTask, err := ts.repository.UpdateEstimate(id, estimate)
+	if err != nil {
+		return Task{}, err
+	}
+
+	ts.record(updatedTask, activity.ActionEstimated, map[string]any{"estimate": task.Estimate}, map[string]any{"estimate": updatedTask.Estimate})
+	return updatedTask, nil
+}
+
+// rollUpEstimate sets the effective estimate of a task, and the remaining work, from its own
+// estimate or else from the progress of its subtasks. An estimate given to a task with estimated
+// subtasks overrides theirs: it stands for the whole subtree until the task is completed.
+func rollUpEstimate(task Task, progress *Progress) {
+	estimated := false
+	estimate, remaining := 0, 0
+	for _, st := range task.Subtasks {
+		if st.Progress.EffectiveEstimate != nil {
+			estimated = true
+			estimate += *st.Progress.EffectiveEstimate
+			remaining += *st.Progress.RemainingWork
+		}
+	}
+
+	if task.Estimate != nil {
+		estimated = true
+		estimate, remaining = *task.Estimate, *task.Estimate
+	}
+	if !estimated {
+		return
+	}
+
+	// Completing a task completes its subtasks, so none of its work is left
+	if task.Status == TaskStatusCompleted {
+		remaining = 0
+	}
+
+	progress.EffectiveEstimate = &estimate
+	progress.RemainingWork = &remaining
+}
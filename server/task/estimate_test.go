@@ -0,0 +1,157 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EstimateTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *EstimateTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *EstimateTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *EstimateTestSuite) TestUpdateTaskEstimate() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	updated, err := suite.taskService.UpdateTaskEstimate(task.ID, intPtr(5))
+	require.NoError(t, err)
+	assert.Equal(t, intPtr(5), updated.Estimate)
+	assert.Equal(t, task.Version+1, updated.Version)
+
+	fetched, err := suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, intPtr(5), fetched.Estimate)
+
+	updated, err = suite.taskService.UpdateTaskEstimate(task.ID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, updated.Estimate)
+
+	_, err = suite.taskService.UpdateTaskEstimate(task.ID, intPtr(-1))
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.taskService.UpdateTaskEstimate(uuid.New(), intPtr(1))
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *EstimateTestSuite) TestFetchTaskTree_Estimates() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Root task", suite.projectID, nil)
+	require.NoError(t, err)
+	first, err := suite.taskService.CreateTask("First subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+	second, err := suite.taskService.CreateTask("Second subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Unestimated subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.UpdateTaskEstimate(first.ID, intPtr(3))
+	require.NoError(t, err)
+	_, err = suite.taskService.UpdateTaskEstimate(second.ID, intPtr(5))
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(first.ID, TaskStatusCompleted.String()))
+
+	// The estimate of the root task is derived from its subtasks
+	tree, err := suite.taskService.FetchTaskTree(root.ID)
+	require.NoError(t, err)
+	assert.Nil(t, tree.Estimate)
+	assert.Equal(t, intPtr(8), tree.Progress.EffectiveEstimate)
+	assert.Equal(t, intPtr(5), tree.Progress.RemainingWork)
+
+	// Until it is given one
+	_, err = suite.taskService.UpdateTaskEstimate(root.ID, intPtr(13))
+	require.NoError(t, err)
+	tree, err = suite.taskService.FetchTaskTree(root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, intPtr(13), tree.Progress.EffectiveEstimate)
+	assert.Equal(t, intPtr(13), tree.Progress.RemainingWork)
+}
+
+func TestBuildTaskTree_Estimates(t *testing.T) {
+	projectID := uuid.New()
+	root := NewTask("Root task", projectID, nil)
+	derived := NewTask("Derived", projectID, &root.ID)
+	leaf := NewTask("Leaf", projectID, &derived.ID)
+	leaf.Estimate = intPtr(2)
+	otherLeaf := NewTask("Other leaf", projectID, &derived.ID)
+	otherLeaf.Estimate = intPtr(3)
+	otherLeaf.Status = TaskStatusCompleted
+	overridden := NewTask("Overridden", projectID, &root.ID)
+	overridden.Estimate = intPtr(10)
+	ignored := NewTask("Ignored", projectID, &overridden.ID)
+	ignored.Estimate = intPtr(1)
+	unestimated := NewTask("Unestimated", projectID, &root.ID)
+
+	tree := buildTaskTree(root, map[uuid.UUID][]Task{
+		root.ID:       {derived, overridden, unestimated},
+		derived.ID:    {leaf, otherLeaf},
+		overridden.ID: {ignored},
+	})
+
+	assert.Equal(t, intPtr(15), tree.Progress.EffectiveEstimate)
+	assert.Equal(t, intPtr(12), tree.Progress.RemainingWork)
+
+	byName := map[string]Task{}
+	for _, st := range tree.Subtasks {
+		byName[st.Name] = st
+	}
+	assert.Equal(t, intPtr(5), byName["Derived"].Progress.EffectiveEstimate)
+	assert.Equal(t, intPtr(2), byName["Derived"].Progress.RemainingWork)
+	assert.Equal(t, intPtr(10), byName["Overridden"].Progress.EffectiveEstimate)
+	assert.Nil(t, byName["Unestimated"].Progress.EffectiveEstimate)
+	assert.Nil(t, byName["Unestimated"].Progress.RemainingWork)
+}
+
+func TestEstimates(t *testing.T) {
+	suite.Run(t, new(EstimateTestSuite))
+}
+
+func intPtr(i int) *int {
+	return &i
+}
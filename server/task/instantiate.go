@@ -0,0 +1,91 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/template"
+)
+
+// InstantiateTemplate creates the task tree of a template inside a project. If parentTaskID is
+// given, the root tasks of the template become subtasks of that task, otherwise they are created
+// at the root of the project. Due dates are computed relative to the moment of instantiation.
+//
+// The template is expected to have been rendered already (see template.Template.Render), so that
+// no placeholder is left in the task names. The tasks are all created or, if one of them cannot
+// be, none of them. Returns the created root tasks with their subtasks.
+func (ts *TaskService) InstantiateTemplate(tmpl template.Template, projectID uuid.UUID, parentTaskID *uuid.UUID) ([]Task, error) {
+	if parentTaskID != nil {
+		parentTask, err := ts.repository.Get(*parentTaskID)
+		if err != nil {
+			return nil, fmt.Errorf("Could not instantiate template %s: %w", tmpl.ID, err)
+		}
+
+		if parentTask.ProjectID != projectID {
+			return nil, fmt.Errorf("Could not instantiate template %s: parent task belongs to another project", tmpl.ID)
+		}
+	}
+
+	var created []Task
+	err := ts.inTransaction(func(txService *TaskService) (err error) {
+		created, err = txService.instantiateTemplateTasks(tmpl.Tasks, projectID, parentTaskID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// InstantiateTemplateInNewProject creates a project, with projects, and the task tree of a
+// template inside it, all in one transaction: if one of the tasks cannot be created, neither is the
+// project. Returns the project and the created root tasks with their subtasks.
+func (ts *TaskService) InstantiateTemplateInNewProject(tmpl template.Template, projects *project.ProjectService, projectName string) (project.Project, []Task, error) {
+	var proj project.Project
+	var created []Task
+	err := ts.inTransaction(func(txService *TaskService) (err error) {
+		proj, err = projects.InTransactionOf(txService.projectDB, &txService.pending.events).CreateProject(projectName)
+		if err != nil {
+			return err
+		}
+
+		created, err = txService.instantiateTemplateTasks(tmpl.Tasks, proj.ID, nil, time.Now())
+		return err
+	})
+	if err != nil {
+		return project.Project{}, nil, err
+	}
+
+	return proj, created, nil
+}
+
+func (ts *TaskService) instantiateTemplateTasks(
+	templateTasks []template.TemplateTask,
+	projectID uuid.UUID,
+	parentTaskID *uuid.UUID,
+	start time.Time,
+) ([]Task, error) {
+	created := []Task{}
+	for _, tt := range templateTasks {
+		task := NewTask(tt.Name, projectID, parentTaskID)
+		task.DueAt = tt.DueDate(start)
+
+		task, err := ts.createTask(task)
+		if err != nil {
+			return nil, err
+		}
+
+		subtasks, err := ts.instantiateTemplateTasks(tt.Subtasks, projectID, &task.ID, start)
+		if err != nil {
+			return nil, err
+		}
+
+		task.Subtasks = subtasks
+		created = append(created, task)
+	}
+
+	return created, nil
+}
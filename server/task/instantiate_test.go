@@ -0,0 +1,165 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/template"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type InstantiateTemplateTestSuite struct {
+	suite.Suite
+	ctx            context.Context
+	pgContainer    *testhelpers.PostgresContainer
+	taskService    *TaskService
+	projectID      uuid.UUID
+	otherProjectID uuid.UUID
+}
+
+func (suite *InstantiateTemplateTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *InstantiateTemplateTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+	suite.otherProjectID = projectIDs[1]
+}
+
+func (suite *InstantiateTemplateTestSuite) renderedTemplate() template.Template {
+	t := suite.T()
+
+	twoDays := 2
+	tmpl := template.NewTemplate("Release", []template.TemplateTask{
+		{
+			Name:          "Release {{version}}",
+			DueOffsetDays: &twoDays,
+			Subtasks: []template.TemplateTask{
+				{Name: "Tag {{version}}"},
+				{Name: "Write changelog"},
+			},
+		},
+		{Name: "Announce"},
+	})
+
+	rendered, err := tmpl.Render(map[string]string{"version": "v1.2.0"})
+	require.NoError(t, err)
+
+	return rendered
+}
+
+func (suite *InstantiateTemplateTestSuite) TestAtProjectRoot() {
+	t := suite.T()
+
+	before := time.Now()
+	tasks, err := suite.taskService.InstantiateTemplate(suite.renderedTemplate(), suite.projectID, nil)
+	require.NoError(t, err)
+
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "Release v1.2.0", tasks[0].Name)
+	assert.Equal(t, "Announce", tasks[1].Name)
+	assert.Nil(t, tasks[1].DueAt)
+	if assert.NotNil(t, tasks[0].DueAt) {
+		assert.False(t, tasks[0].DueAt.Before(before.AddDate(0, 0, 2)))
+	}
+
+	require.Len(t, tasks[0].Subtasks, 2)
+	assert.Equal(t, "Tag v1.2.0", tasks[0].Subtasks[0].Name)
+
+	rootTasks, err := suite.taskService.repository.GetTasksInProjectRoot(suite.projectID)
+	require.NoError(t, err)
+	assert.Len(t, rootTasks, 2)
+
+	subtasks, err := suite.taskService.FetchSubtasksDirect(tasks[0].ID)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 2)
+	assert.ElementsMatch(t, []int{0, 1}, []int{subtasks[0].Order, subtasks[1].Order})
+}
+
+func (suite *InstantiateTemplateTestSuite) TestUnderExistingTask() {
+	t := suite.T()
+
+	parent, err := suite.taskService.CreateTask("Q3 releases", suite.projectID, nil)
+	require.NoError(t, err)
+
+	tasks, err := suite.taskService.InstantiateTemplate(suite.renderedTemplate(), suite.projectID, &parent.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+
+	subtasks, err := suite.taskService.FetchSubtasksDirect(parent.ID)
+	require.NoError(t, err)
+	assert.Len(t, subtasks, 2)
+}
+
+func (suite *InstantiateTemplateTestSuite) TestParentInAnotherProject() {
+	t := suite.T()
+
+	parent, err := suite.taskService.CreateTask("Q3 releases", suite.otherProjectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.InstantiateTemplate(suite.renderedTemplate(), suite.projectID, &parent.ID)
+	assert.Error(t, err)
+}
+
+func (suite *InstantiateTemplateTestSuite) TestInNewProject() {
+	t := suite.T()
+	projectService := project.NewProjectService(suite.taskService.projectDB, nil)
+
+	proj, tasks, err := suite.taskService.InstantiateTemplateInNewProject(suite.renderedTemplate(), projectService, "Release v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Release v1.2.0", proj.Name)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, proj.ID, tasks[0].ProjectID)
+
+	rootTasks, err := suite.taskService.repository.GetTasksInProjectRoot(proj.ID)
+	require.NoError(t, err)
+	assert.Len(t, rootTasks, 2)
+}
+
+func (suite *InstantiateTemplateTestSuite) TestInNewProject_InvalidTask() {
+	t := suite.T()
+	projectService := project.NewProjectService(suite.taskService.projectDB, nil)
+
+	tmpl := template.NewTemplate("Broken", []template.TemplateTask{{Name: "Fine"}, {Name: " "}})
+	_, _, err := suite.taskService.InstantiateTemplateInNewProject(tmpl, projectService, "Broken project")
+	require.Error(t, err)
+
+	// The project is rolled back along with the tasks
+	_, err = suite.taskService.projectDB.GetByName(nil, "Broken project")
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	suite.Run(t, new(InstantiateTemplateTestSuite))
+}
@@ -0,0 +1,106 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// WithLimits sets the limits tasks are validated against, instead of internal.DefaultLimits.
+func WithLimits(limits internal.Limits) TaskServiceOption {
+	return func(ts *TaskService) {
+		ts.limits = limits
+	}
+}
+
+// validateTreeLimits checks that a task fits below its parent task: the parent task must have
+// room for one more subtask, and neither the task nor its subtasks may go deeper than the maximum
+// depth.
+func (ts TaskService) validateTreeLimits(task Task, parentTask Task) (*internal.FieldError, error) {
+	siblings, err := ts.repository.GetSubtasksDirect(parentTask.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	siblingCount := 0
+	for _, s := range siblings {
+		if s.ID != task.ID {
+			siblingCount++
+		}
+	}
+	if siblingCount >= ts.limits.MaxSubtasks {
+		return &internal.FieldError{
+			Field:   "parentTaskID",
+			Message: fmt.Sprintf("a task must not have more than %d subtasks", ts.limits.MaxSubtasks),
+		}, nil
+	}
+
+	parentDepth, err := ts.taskDepth(parentTask)
+	if err != nil {
+		return nil, err
+	}
+	height, err := ts.subtreeHeight(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if parentDepth+height > ts.limits.MaxTaskDepth {
+		return &internal.FieldError{
+			Field:   "parentTaskID",
+			Message: fmt.Sprintf("tasks must not be nested more than %d levels deep", ts.limits.MaxTaskDepth),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// taskDepth returns the level of a task in its tree, root tasks being at level 1. The ancestors
+// are only walked up to the maximum depth, past which the exact depth does not matter.
+func (ts TaskService) taskDepth(task Task) (int, error) {
+	depth := 1
+	for task.ParentTaskID != nil && depth <= ts.limits.MaxTaskDepth {
+		parentTask, err := ts.repository.Get(*task.ParentTaskID)
+		if err != nil {
+			return 0, err
+		}
+
+		task = parentTask
+		depth++
+	}
+
+	return depth, nil
+}
+
+// subtreeHeight returns the number of levels of the tree made of a task and its subtasks, which is
+// 1 for a task without subtasks.
+func (ts TaskService) subtreeHeight(id uuid.UUID) (int, error) {
+	subtasks, err := ts.repository.GetSubtasksDeep(id)
+	if err != nil {
+		return 0, err
+	}
+
+	levels := map[uuid.UUID]int{id: 1}
+	height := 1
+	// Subtasks are not sorted by level, so each pass finds the level of the subtasks whose parent
+	// task has a known level
+	for len(subtasks) > 0 {
+		remaining := subtasks[:0]
+		for _, st := range subtasks {
+			parentLevel, ok := levels[*st.ParentTaskID]
+			if !ok {
+				remaining = append(remaining, st)
+				continue
+			}
+
+			levels[st.ID] = parentLevel + 1
+			height = max(height, parentLevel+1)
+		}
+		if len(remaining) == len(subtasks) {
+			break
+		}
+
+		subtasks = remaining
+	}
+
+	return height, nil
+}
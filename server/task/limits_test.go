@@ -0,0 +1,156 @@
+package task
+
+import (
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LimitsTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *LimitsTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(
+		repository,
+		projectRepository,
+		WithLimits(internal.Limits{MaxNameLength: 20, MaxTaskDepth: 3, MaxSubtasks: 2}),
+	)
+}
+
+// Setup database before each test
+func (suite *LimitsTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *LimitsTestSuite) TestNameIsTrimmed() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("  My test task\n", suite.projectID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "My test task", task.Name)
+
+	task, err = suite.taskService.RenameTask(task.ID, "\tRenamed task ")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed task", task.Name)
+}
+
+func (suite *LimitsTestSuite) TestInvalidName() {
+	t := suite.T()
+
+	for _, name := range []string{"", " ", strings.Repeat("a", 21), "Tab\tin the name"} {
+		_, err := suite.taskService.CreateTask(name, suite.projectID, nil)
+		var fieldErrs internal.FieldErrors
+		if assert.ErrorAs(t, err, &fieldErrs, name) {
+			assert.ErrorIs(t, err, internal.ErrValidation)
+			assert.Equal(t, internal.FieldErrors{{Field: "name", Message: fieldErrs[0].Message}}, fieldErrs)
+		}
+	}
+
+	task, err := suite.taskService.CreateTask("My test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, strings.Repeat("a", 21))
+	assert.ErrorIs(t, err, internal.ErrValidation)
+}
+
+// Characters are counted rather than bytes
+func (suite *LimitsTestSuite) TestNameLengthInCharacters() {
+	t := suite.T()
+
+	_, err := suite.taskService.CreateTask(strings.Repeat("é", 20), suite.projectID, nil)
+	assert.NoError(t, err)
+}
+
+func (suite *LimitsTestSuite) TestMaxTaskDepth() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Level 1", suite.projectID, nil)
+	require.NoError(t, err)
+	level2, err := suite.taskService.CreateTask("Level 2", suite.projectID, &root.ID)
+	require.NoError(t, err)
+	level3, err := suite.taskService.CreateTask("Level 3", suite.projectID, &level2.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.CreateTask("Level 4", suite.projectID, &level3.ID)
+	var fieldErrs internal.FieldErrors
+	if assert.ErrorAs(t, err, &fieldErrs) {
+		assert.Equal(t, "parentTaskID", fieldErrs[0].Field)
+	}
+}
+
+// A task cannot be moved where its subtasks would be too deep
+func (suite *LimitsTestSuite) TestMaxTaskDepth_Move() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Root", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+
+	other, err := suite.taskService.CreateTask("Other", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Other subtask", suite.projectID, &other.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.MoveTask(other.ID, &subtask.ID)
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.taskService.MoveTask(other.ID, &root.ID)
+	assert.NoError(t, err)
+}
+
+func (suite *LimitsTestSuite) TestMaxSubtasks() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Root", suite.projectID, nil)
+	require.NoError(t, err)
+	for _, name := range []string{"Subtask 1", "Subtask 2"} {
+		_, err = suite.taskService.CreateTask(name, suite.projectID, &root.ID)
+		require.NoError(t, err)
+	}
+
+	_, err = suite.taskService.CreateTask("Subtask 3", suite.projectID, &root.ID)
+	var fieldErrs internal.FieldErrors
+	if assert.ErrorAs(t, err, &fieldErrs) {
+		assert.Equal(t, "parentTaskID", fieldErrs[0].Field)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	suite.Run(t, new(LimitsTestSuite))
+}
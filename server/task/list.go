@@ -0,0 +1,129 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrInvalidSort = internal.NewValidationError("invalid sort field")
+
+// The fields tasks can be sorted by. Ties are broken by task ID.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByName      SortField = "name"
+	SortByOrder     SortField = "order"
+	SortByStatus    SortField = "status"
+)
+
+// ListOptions filters and sorts the tasks listed by ListTasksPage.
+type ListOptions struct {
+	// Only list the tasks of this project
+	ProjectID *uuid.UUID
+	// Only list the direct subtasks of this task
+	ParentTaskID *uuid.UUID
+	// Only list the tasks in this section
+	SectionID *uuid.UUID
+	// Only list the tasks with this status
+	Status *TaskStatus
+	// Defaults to SortByCreatedAt
+	SortBy SortField
+	// Cursor of the page to list, empty for the first page
+	Cursor string
+	// Number of tasks in the page, see internal.PageSize
+	Limit int
+	// Only list the tasks at the root of their project
+	RootOnly   bool
+	Descending bool
+}
+
+// A Page is a slice of a list of tasks.
+type Page struct {
+	Tasks []Task
+	// Cursor of the next page, empty on the last page
+	NextCursor string
+}
+
+// ListTasksPage lists a page of the tasks matching the options. Pages are fetched with keyset
+// queries, so tasks created or deleted in the meantime do not shift the following pages.
+func (ts *TaskService) ListTasksPage(opts ListOptions) (Page, error) {
+	switch opts.SortBy {
+	case "":
+		opts.SortBy = SortByCreatedAt
+	case SortByCreatedAt, SortByName, SortByOrder, SortByStatus:
+	default:
+		return Page{}, fmt.Errorf("%w: %s", ErrInvalidSort, opts.SortBy)
+	}
+
+	var after *Task
+	if opts.Cursor != "" {
+		task, err := decodeTaskCursor(opts.Cursor, opts.SortBy, opts.Descending)
+		if err != nil {
+			return Page{}, err
+		}
+		after = &task
+	}
+
+	limit := internal.PageSize(opts.Limit)
+
+	// One more task than requested is fetched to know whether there is a next page
+	tasks, err := ts.repository.ListPage(opts, after, limit+1)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = taskCursor(page.Tasks[limit-1], opts.SortBy, opts.Descending).Encode()
+	}
+
+	return page, nil
+}
+
+func taskCursor(task Task, sortBy SortField, descending bool) internal.PageCursor {
+	cursor := internal.PageCursor{SortBy: string(sortBy), Descending: descending, ID: task.ID}
+	switch sortBy {
+	case SortByName:
+		cursor.Key = task.Name
+	case SortByStatus:
+		cursor.Key = task.Status.String()
+	case SortByOrder:
+		cursor.Key = strconv.Itoa(task.Order)
+	default:
+		cursor.Key = task.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return cursor
+}
+
+// Decodes a cursor into the last task of the previous page, of which only the ID and the sort
+// key are set.
+func decodeTaskCursor(encoded string, sortBy SortField, descending bool) (Task, error) {
+	cursor, err := internal.DecodePageCursor(encoded, string(sortBy), descending)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task := Task{ID: cursor.ID}
+	switch sortBy {
+	case SortByName:
+		task.Name = cursor.Key
+	case SortByStatus:
+		err = task.Status.FromString(cursor.Key)
+	case SortByOrder:
+		task.Order, err = strconv.Atoi(cursor.Key)
+	default:
+		task.CreatedAt, err = time.Parse(time.RFC3339Nano, cursor.Key)
+	}
+	if err != nil {
+		return Task{}, internal.ErrInvalidCursor
+	}
+
+	return task, nil
+}
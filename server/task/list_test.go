@@ -0,0 +1,151 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListTasksTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectIDs  []uuid.UUID
+}
+
+func (suite *ListTasksTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *ListTasksTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	suite.projectIDs = insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+}
+
+// Fetches every page of the list, checking that none is larger than the limit.
+func (suite *ListTasksTestSuite) listAllPages(opts ListOptions) []string {
+	t := suite.T()
+
+	names := []string{}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 10)
+
+		page, err := suite.taskService.ListTasksPage(opts)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(page.Tasks), opts.Limit)
+		for _, task := range page.Tasks {
+			names = append(names, task.Name)
+		}
+
+		if page.NextCursor == "" {
+			return names
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+func (suite *ListTasksTestSuite) TestPagesAreSorted() {
+	t := suite.T()
+
+	for _, name := range []string{"c", "a", "e", "b", "d"} {
+		_, err := suite.taskService.CreateTask(name, suite.projectIDs[0], nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"c", "a", "e", "b", "d"}, suite.listAllPages(ListOptions{Limit: 2}))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, suite.listAllPages(ListOptions{SortBy: SortByName, Limit: 2}))
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, suite.listAllPages(ListOptions{SortBy: SortByName, Descending: true, Limit: 3}))
+	assert.Equal(t, []string{"d", "b", "e", "a", "c"}, suite.listAllPages(ListOptions{SortBy: SortByOrder, Descending: true, Limit: 2}))
+}
+
+func (suite *ListTasksTestSuite) TestFilters() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("parent", suite.projectIDs[0], nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("subtask", suite.projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("other subtask", suite.projectIDs[0], &parentTask.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("other project", suite.projectIDs[1], nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(subtask.ID, TaskStatusCompleted.String()))
+
+	assert.Equal(t,
+		[]string{"parent", "other project"},
+		suite.listAllPages(ListOptions{RootOnly: true, Limit: 10}),
+	)
+	assert.Equal(t,
+		[]string{"other subtask", "subtask"},
+		suite.listAllPages(ListOptions{ParentTaskID: &parentTask.ID, SortBy: SortByName, Limit: 10}),
+	)
+	assert.Equal(t,
+		[]string{"subtask"},
+		suite.listAllPages(ListOptions{Status: &TaskStatusCompleted, Limit: 10}),
+	)
+	assert.Equal(t,
+		[]string{"parent"},
+		suite.listAllPages(ListOptions{ProjectID: &suite.projectIDs[0], RootOnly: true, Limit: 10}),
+	)
+
+	unknownProjectID := uuid.New()
+	_, err = suite.taskService.ListTasksPage(ListOptions{ProjectID: &unknownProjectID})
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func TestListTasks(t *testing.T) {
+	suite.Run(t, new(ListTasksTestSuite))
+}
+
+func TestTaskCursor(t *testing.T) {
+	task := NewTask("Test task", uuid.New(), nil)
+	task.Order = 3
+
+	for _, sortBy := range []SortField{SortByCreatedAt, SortByName, SortByOrder, SortByStatus} {
+		encoded := taskCursor(task, sortBy, true).Encode()
+
+		decoded, err := decodeTaskCursor(encoded, sortBy, true)
+		require.NoError(t, err)
+		assert.Equal(t, task.ID, decoded.ID)
+
+		_, err = decodeTaskCursor(encoded, sortBy, false)
+		assert.ErrorIs(t, err, internal.ErrInvalidCursor)
+	}
+
+	_, err := decodeTaskCursor("not a cursor", SortByName, false)
+	assert.ErrorIs(t, err, internal.ErrInvalidCursor)
+
+	decoded, err := decodeTaskCursor(taskCursor(task, SortByCreatedAt, false).Encode(), SortByCreatedAt, false)
+	require.NoError(t, err)
+	assert.True(t, task.CreatedAt.Equal(decoded.CreatedAt))
+}
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrInvalidMove = internal.NewConflictError("a task cannot be moved below itself or one of its subtasks")
+
+// MoveTask makes a task a subtask of another task of the same project, or a root task of its
+// project if newParentTaskID is nil. The task goes to the end of its new siblings and its subtasks
+// follow it. Moving a pending task below a completed task marks the new parent as pending, as
+// would creating it there. Root tasks moved below another task leave their section, and subtasks
+// moved to the root of their project are in no section, see MoveTaskToSection.
+func (ts *TaskService) MoveTask(id uuid.UUID, newParentTaskID *uuid.UUID) (Task, error) {
+	task, err := ts.repository.Get(id)
+	if err != nil {
+		return Task{}, fmt.Errorf("Could not move task %s: %w", id, err)
+	}
+	if sameTaskID(task.ParentTaskID, newParentTaskID) {
+		return task, nil
+	}
+
+	movedTask := task
+	movedTask.ParentTaskID = newParentTaskID
+	movedTask.SectionID = nil
+	err = ts.ValidateTask(movedTask)
+	if err != nil {
+		return Task{}, fmt.Errorf("Could not move task %s: %w", id, err)
+	}
+
+	if newParentTaskID != nil {
+		subtasks, err := ts.repository.GetSubtasksDeep(id)
+		if err != nil {
+			return Task{}, err
+		}
+
+		isSubtask := slices.ContainsFunc(subtasks, func(t Task) bool { return t.ID == *newParentTaskID })
+		if *newParentTaskID == id || isSubtask {
+			return Task{}, ErrInvalidMove
+		}
+	}
+
+	newSiblings, err := ts.FetchTaskSiblings(movedTask)
+	if err != nil {
+		return Task{}, fmt.Errorf("Failed to fetch the new siblings of task %s: %w", id, err)
+	}
+
+	movedTask, err = ts.repository.Move(id, newParentTaskID, len(newSiblings))
+	if err != nil {
+		return Task{}, err
+	}
+
+	err = ts.rearrangeTaskSiblings(task)
+	if err != nil {
+		return Task{}, fmt.Errorf("Failed to rearrange the former siblings of task %s: %w", id, err)
+	}
+
+	ts.record(
+		movedTask,
+		activity.ActionMoved,
+		map[string]any{"parentTaskID": task.ParentTaskID, "order": task.Order},
+		map[string]any{"parentTaskID": movedTask.ParentTaskID, "order": movedTask.Order},
+	)
+
+	tracked, changes := ts.trackStatusChanges()
+	if movedTask.Status == TaskStatusPending && newParentTaskID != nil {
+		newParentTask, err := ts.repository.Get(*newParentTaskID)
+		if err != nil {
+			return Task{}, err
+		}
+
+		err = tracked.markTaskAsPending(newParentTask)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	ts.logCommand(command{
+		action:  activity.ActionMoved,
+		taskIDs: changedTaskIDs(id, *changes),
+		undo: func(ts *TaskService) error {
+			err := ts.setStatuses(*changes, false)
+			if err != nil {
+				return err
+			}
+
+			_, err = ts.MoveTask(id, task.ParentTaskID)
+			if err != nil {
+				return err
+			}
+			if task.SectionID != nil {
+				_, err = ts.MoveTaskToSection(id, task.SectionID, task.Order)
+				return err
+			}
+
+			return reorderTo(id, task.Order)(ts)
+		},
+		redo: func(ts *TaskService) error {
+			_, err := ts.MoveTask(id, newParentTaskID)
+			if err != nil {
+				return err
+			}
+
+			err = reorderTo(id, movedTask.Order)(ts)
+			if err != nil {
+				return err
+			}
+
+			return ts.setStatuses(*changes, true)
+		},
+	})
+
+	return movedTask, nil
+}
+
+func sameTaskID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MoveTaskTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *MoveTaskTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *MoveTaskTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *MoveTaskTestSuite) TestMoveBelowAnotherTask() {
+	t := suite.T()
+
+	firstTask, err := suite.taskService.CreateTask("First task", suite.projectID, nil)
+	require.NoError(t, err)
+	secondTask, err := suite.taskService.CreateTask("Second task", suite.projectID, nil)
+	require.NoError(t, err)
+	thirdTask, err := suite.taskService.CreateTask("Third task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Subtask", suite.projectID, &thirdTask.ID)
+	require.NoError(t, err)
+
+	movedTask, err := suite.taskService.MoveTask(firstTask.ID, &thirdTask.ID)
+	require.NoError(t, err)
+	if assert.NotNil(t, movedTask.ParentTaskID) {
+		assert.Equal(t, thirdTask.ID, *movedTask.ParentTaskID)
+	}
+	assert.Equal(t, 1, movedTask.Order) // after the existing subtask
+
+	// The gap left at the root of the project is closed
+	secondTask, err = suite.taskService.FindTaskByID(secondTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, secondTask.Order)
+	thirdTask, err = suite.taskService.FindTaskByID(thirdTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, thirdTask.Order)
+}
+
+func (suite *MoveTaskTestSuite) TestMoveToProjectRoot() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &parentTask.ID)
+	require.NoError(t, err)
+
+	movedTask, err := suite.taskService.MoveTask(subtask.ID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, movedTask.ParentTaskID)
+	assert.Equal(t, 1, movedTask.Order)
+}
+
+func (suite *MoveTaskTestSuite) TestMovePendingTaskBelowCompletedTask() {
+	t := suite.T()
+
+	completedTask, err := suite.taskService.CreateTask("Completed task", suite.projectID, nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(completedTask.ID, TaskStatusCompleted.String()))
+	pendingTask, err := suite.taskService.CreateTask("Pending task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.MoveTask(pendingTask.ID, &completedTask.ID)
+	require.NoError(t, err)
+
+	completedTask, err = suite.taskService.FindTaskByID(completedTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, completedTask.Status)
+}
+
+func (suite *MoveTaskTestSuite) TestCannotMoveBelowItself() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &parentTask.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.MoveTask(parentTask.ID, &parentTask.ID)
+	assert.ErrorIs(t, err, ErrInvalidMove)
+
+	_, err = suite.taskService.MoveTask(parentTask.ID, &subtask.ID)
+	assert.ErrorIs(t, err, ErrInvalidMove)
+}
+
+func (suite *MoveTaskTestSuite) TestCannotMoveToAnotherProject() {
+	t := suite.T()
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	task, err := suite.taskService.CreateTask("Task", suite.projectID, nil)
+	require.NoError(t, err)
+	otherTask, err := suite.taskService.CreateTask("Other task", projectIDs[1], nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.MoveTask(task.ID, &otherTask.ID)
+	assert.Error(t, err)
+}
+
+func TestMoveTask(t *testing.T) {
+	suite.Run(t, new(MoveTaskTestSuite))
+}
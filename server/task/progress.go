@@ -0,0 +1,96 @@
+package task
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Progress tells how much of the subtree of a task is done, so that clients can show it without
+// walking the tree.
+type Progress struct {
+	// Number of direct subtasks of the task
+	ChildCount int
+	// Number of subtasks of the task, their subtasks and so on
+	Descendants int
+	// Number of completed descendants
+	CompletedDescendants int
+	// The estimate of the task if it has one, or else the sum of the effective estimates of its
+	// subtasks. It is nil if neither the task nor any of its subtasks was estimated
+	EffectiveEstimate *int
+	// The part of the effective estimate that is not done yet: the estimate of the task while it
+	// is pending, or else the remaining work of its subtasks. It is nil along with
+	// EffectiveEstimate
+	RemainingWork *int
+}
+
+// Ratio is the fraction of the descendants of the task that are completed, from 0 to 1. It is nil
+// for tasks without subtasks, whose progress is their status.
+func (p Progress) Ratio() *float64 {
+	if p.Descendants == 0 {
+		return nil
+	}
+
+	ratio := float64(p.CompletedDescendants) / float64(p.Descendants)
+	return &ratio
+}
+
+// FetchTaskTree returns a task along with all of its subtasks, each one with its progress. The
+// whole tree is fetched with a single query.
+func (ts TaskService) FetchTaskTree(id uuid.UUID) (Task, error) {
+	task, err := ts.repository.Get(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	descendants, err := ts.repository.GetSubtasksDeep(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	children := map[uuid.UUID][]Task{}
+	for _, d := range descendants {
+		children[*d.ParentTaskID] = append(children[*d.ParentTaskID], d)
+	}
+
+	return buildTaskTree(task, children), nil
+}
+
+// FetchTaskWithProgress returns a task with its progress, but without its subtasks.
+func (ts TaskService) FetchTaskWithProgress(id uuid.UUID) (Task, error) {
+	task, err := ts.FetchTaskTree(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task.Subtasks = []Task{}
+	return task, nil
+}
+
+// buildTaskTree attaches to a task its subtasks, sorted by order, and sums up their progress and
+// estimates.
+func buildTaskTree(task Task, children map[uuid.UUID][]Task) Task {
+	subtasks := children[task.ID]
+	slices.SortFunc(subtasks, cmpTasks)
+
+	progress := Progress{ChildCount: len(subtasks)}
+	for i, st := range subtasks {
+		st = buildTaskTree(st, children)
+		subtasks[i] = st
+
+		progress.Descendants += 1 + st.Progress.Descendants
+		progress.CompletedDescendants += st.Progress.CompletedDescendants
+		if st.Status == TaskStatusCompleted {
+			progress.CompletedDescendants++
+		}
+	}
+
+	task.Subtasks = subtasks
+	if task.Subtasks == nil {
+		task.Subtasks = []Task{}
+	}
+	rollUpEstimate(task, &progress)
+	task.Progress = &progress
+
+	return task
+}
@@ -0,0 +1,113 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProgressTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *ProgressTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *ProgressTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *ProgressTestSuite) TestFetchTaskTree() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Root task", suite.projectID, nil)
+	require.NoError(t, err)
+	first, err := suite.taskService.CreateTask("First subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Second subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+	nested, err := suite.taskService.CreateTask("Nested subtask", suite.projectID, &first.ID)
+	require.NoError(t, err)
+
+	// Completing the only subtask of the first subtask completes it as well
+	err = suite.taskService.UpdateTaskStatus(nested.ID, TaskStatusCompleted.String())
+	require.NoError(t, err)
+
+	tree, err := suite.taskService.FetchTaskTree(root.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Progress{ChildCount: 2, Descendants: 3, CompletedDescendants: 2}, tree.Progress)
+	if assert.NotNil(t, tree.Progress.Ratio()) {
+		assert.InDelta(t, 2.0/3.0, *tree.Progress.Ratio(), 1e-9)
+	}
+
+	require.Len(t, tree.Subtasks, 2)
+	assert.Equal(t, "First subtask", tree.Subtasks[0].Name)
+	assert.Equal(t, &Progress{ChildCount: 1, Descendants: 1, CompletedDescendants: 1}, tree.Subtasks[0].Progress)
+	require.Len(t, tree.Subtasks[0].Subtasks, 1)
+	assert.Equal(t, nested.ID, tree.Subtasks[0].Subtasks[0].ID)
+
+	assert.Equal(t, "Second subtask", tree.Subtasks[1].Name)
+	assert.Equal(t, &Progress{}, tree.Subtasks[1].Progress)
+	assert.Nil(t, tree.Subtasks[1].Progress.Ratio())
+	assert.Empty(t, tree.Subtasks[1].Subtasks)
+}
+
+func (suite *ProgressTestSuite) TestFetchTaskWithProgress() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Root task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Subtask", suite.projectID, &root.ID)
+	require.NoError(t, err)
+
+	task, err := suite.taskService.FetchTaskWithProgress(root.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Progress{ChildCount: 1, Descendants: 1}, task.Progress)
+	assert.Empty(t, task.Subtasks)
+}
+
+func (suite *ProgressTestSuite) TestFetchTaskTree_NotFound() {
+	_, err := suite.taskService.FetchTaskTree(uuid.New())
+	assert.Error(suite.T(), err)
+}
+
+func TestProgress(t *testing.T) {
+	suite.Run(t, new(ProgressTestSuite))
+}
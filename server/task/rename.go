@@ -4,20 +4,48 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
 )
 
-// RenameTask changes the name of a previously existing task.
+// RenameTask changes the name of a previously existing task. The whitespace around the name is
+// trimmed.
 func (ts *TaskService) RenameTask(id uuid.UUID, newTaskName string) (Task, error) {
+	newTaskName = internal.NormalizeName(newTaskName)
+	if fieldErr := internal.ValidateName("name", newTaskName, ts.limits.MaxNameLength); fieldErr != nil {
+		return Task{}, internal.FieldErrors{*fieldErr}
+	}
+
 	// The task must exist first
 	task, err := ts.repository.Get(id)
 	if err != nil {
 		return Task{}, fmt.Errorf("Could not rename task %s: %w", id, err)
 	}
 
+	err = ts.ensureProjectIsActive(task.ProjectID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	oldTaskName := task.Name
 	task, err = ts.repository.Rename(task.ID, newTaskName)
 	if err != nil {
 		return Task{}, err
 	}
 
+	ts.record(task, activity.ActionRenamed, map[string]any{"name": oldTaskName}, map[string]any{"name": task.Name})
+	ts.logCommand(command{
+		action:  activity.ActionRenamed,
+		taskIDs: []uuid.UUID{id},
+		undo: func(ts *TaskService) error {
+			_, err := ts.RenameTask(id, oldTaskName)
+			return err
+		},
+		redo: func(ts *TaskService) error {
+			_, err := ts.RenameTask(id, newTaskName)
+			return err
+		},
+	})
+
 	return task, nil
 }
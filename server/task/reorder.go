@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
 )
 
 // ReorderTask changes the order of the task accordingly with the given number.
@@ -18,9 +21,22 @@ import (
 // - Update the order of each task accordingly:
 //   - If the current order is less than the new order, then we need to subtract 1 from all other siblings
 //   - If the current order is greater than the new order, then we need to add 1 to all other siblings
+//
+// The siblings are reordered in one transaction, in which the change is recorded.
 func (ts *TaskService) ReorderTask(task Task, newOrder int) error {
+	return ts.inTransaction(func(txService *TaskService) error {
+		return txService.reorderTask(task, newOrder)
+	})
+}
+
+func (ts *TaskService) reorderTask(task Task, newOrder int) error {
 	// Check if the task exists
-	_, err := ts.repository.Get(task.ID)
+	storedTask, err := ts.repository.Get(task.ID)
+	if err != nil {
+		return err
+	}
+
+	err = ts.ensureProjectIsActive(task.ProjectID)
 	if err != nil {
 		return err
 	}
@@ -77,10 +93,37 @@ func (ts *TaskService) ReorderTask(task Task, newOrder int) error {
 	slices.SortFunc(siblings, cmpTasks)
 	ts.logger.Debug("Siblings are now like this", slog.Any("siblings", siblings))
 
-	ts.repository.BatchUpdateOrder(siblings)
+	err = ts.repository.BatchUpdateOrder(siblings)
+	if err != nil {
+		return fmt.Errorf("Failed to update the order of the siblings of task %s: %w", task.ID, err)
+	}
+
+	reorderedTask := storedTask
+	reorderedTask.Order = newOrder
+	ts.record(reorderedTask, activity.ActionReordered, map[string]any{"order": task.Order}, map[string]any{"order": newOrder})
+	ts.logCommand(command{
+		action:  activity.ActionReordered,
+		taskIDs: []uuid.UUID{task.ID},
+		undo:    reorderTo(task.ID, task.Order),
+		redo:    reorderTo(task.ID, newOrder),
+	})
+
 	return nil
 }
 
+// reorderTo reorders a task based on its current order, which may have changed since the command
+// was logged.
+func reorderTo(id uuid.UUID, order int) func(ts *TaskService) error {
+	return func(ts *TaskService) error {
+		task, err := ts.repository.Get(id)
+		if err != nil {
+			return err
+		}
+
+		return ts.ReorderTask(task, order)
+	}
+}
+
 func cmpTasks(taskA, taskB Task) int {
 	if taskA.Order < taskB.Order {
 		return -1
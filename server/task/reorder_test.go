@@ -121,6 +121,31 @@ func (suite *ReorderTaskTestSuite) TestDecreaseOrder() {
 	}
 }
 
+func (suite *ReorderTaskTestSuite) TestReorderSubtasks() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	subtasks := []Task{}
+	for _, name := range []string{"First subtask", "Second subtask", "Third subtask"} {
+		subtask, err := suite.taskService.CreateTask(name, suite.projectID, &parentTask.ID)
+		require.NoError(t, err)
+		subtasks = append(subtasks, subtask)
+	}
+
+	// Every sibling takes the order another one had, and each order stays unique
+	err = suite.taskService.ReorderTask(subtasks[2], 0)
+	require.NoError(t, err)
+
+	for i, expectedOrder := range []int{1, 2, 0} {
+		subtask, err := suite.taskService.repository.Get(subtasks[i].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expectedOrder, subtask.Order, "wrong order for %q", subtask.Name)
+		}
+	}
+}
+
 func (suite *ReorderTaskTestSuite) TestOrderOutOfBounds() {
 	t := suite.T()
 
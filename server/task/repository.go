@@ -1,7 +1,11 @@
 package task
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/project"
 )
 
 type TaskRepository interface {
@@ -23,11 +27,17 @@ type TaskRepository interface {
 	// Retrieve all tasks in a project
 	GetTasksInProjectRoot(projectID uuid.UUID) ([]Task, error)
 
+	// Retrieve the root tasks of a project in a section or, if sectionID is nil, in no section
+	GetTasksInSection(projectID uuid.UUID, sectionID *uuid.UUID) ([]Task, error)
+
 	// Filter tasks in a project by their status
 	GetTasksByStatus(projectID uuid.UUID, status TaskStatus) ([]Task, error)
 
 	// List all tasks in the repository
 	List() ([]Task, error)
+	// List up to limit tasks matching the options, starting after the given task if it is not nil.
+	// Fails with internal.ErrNotFound if the options filter on a project that does not exist
+	ListPage(opts ListOptions, after *Task, limit int) ([]Task, error)
 
 	// Rename a single task
 	Rename(taskID uuid.UUID, newName string) (Task, error)
@@ -35,12 +45,73 @@ type TaskRepository interface {
 	// Update a single task's order
 	UpdateOrder(taskID uuid.UUID, newTaskOrder int) error
 
+	// Give a task a new parent task, or none, and a new order among its new siblings. The task is
+	// taken out of its section
+	Move(taskID uuid.UUID, newParentTaskID *uuid.UUID, newTaskOrder int) (Task, error)
+
+	// Put a root task in a section, or in none, at the given order among the tasks of the section
+	SetSection(taskID uuid.UUID, sectionID *uuid.UUID, newTaskOrder int) (Task, error)
+
+	// Set or, if dueAt is nil, unset the due date of a task
+	UpdateDueAt(taskID uuid.UUID, dueAt *time.Time) (Task, error)
+
+	// Set or, if estimate is nil, unset the estimate of a task
+	UpdateEstimate(taskID uuid.UUID, estimate *int) (Task, error)
+
 	// Batch update the order a collection of tasks
 	BatchUpdateOrder(tasks []Task) error
 
-	// Update task status to Pending or Completed
-	UpdateTaskStatus(id uuid.UUID, newStatus TaskStatus) error
+	// Update task status to Pending or Completed. completedAt is the time a completed task was
+	// completed at, and must be nil for pending tasks
+	UpdateTaskStatus(id uuid.UUID, newStatus TaskStatus, completedAt *time.Time) error
+
+	// List the tasks completed from "from" (inclusive) to "to" (exclusive), in every project or,
+	// if projectID is not nil, in a single project
+	ListCompleted(projectID *uuid.UUID, from time.Time, to time.Time) ([]Task, error)
+
+	// Count the tasks of a project created and completed in each bucket, given by its start and
+	// end, and the tasks open at the end of each bucket
+	GetStatsSeries(projectID uuid.UUID, starts []time.Time, ends []time.Time) ([]StatsPoint, error)
+
+	// Count the tasks of a project completed from "from" (inclusive) to "to" (exclusive), and
+	// average the time it took to complete them
+	GetCycleTime(projectID uuid.UUID, from time.Time, to time.Time) (int, time.Duration, error)
+
+	// List the oldest pending tasks of a project, at most limit of them
+	ListOldestOpen(projectID uuid.UUID, limit int) ([]Task, error)
 
 	// Delete the task with the specified ID
 	Delete(id uuid.UUID) (Task, error)
+
+	// Move a task and its subtasks to the trash
+	SoftDelete(id uuid.UUID, deletedAt time.Time) error
+
+	// Retrieve a task in the trash by its ID
+	GetDeleted(id uuid.UUID) (Task, error)
+
+	// List the tasks that were moved to the trash on their own, i.e. not together with their
+	// parent task or project
+	ListDeleted() ([]Task, error)
+
+	// Take a task and the subtasks deleted along with it out of the trash, at the task's order
+	Restore(task Task) error
+
+	// Permanently delete the tasks moved to the trash before the given time
+	Purge(deletedBefore time.Time) (int64, error)
+
+	// Save the state of a task as its next revision
+	CreateRevision(task Task, action activity.Action, createdAt time.Time) (Revision, error)
+
+	// List the revisions of a task, oldest first
+	ListRevisions(taskID uuid.UUID) ([]Revision, error)
+
+	// Retrieve a revision of a task by its number
+	GetRevision(taskID uuid.UUID, number int) (Revision, error)
+
+	// Lock a task until the end of the transaction, failing with ErrVersionChanged if it does not
+	// have the given version
+	LockVersion(id uuid.UUID, version int) error
+
+	// Run fn with a repository whose changes are only saved if fn succeeds
+	InTransaction(fn func(repository TaskRepository, projectRepository project.ProjectRepository) error) error
 }
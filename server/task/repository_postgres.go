@@ -2,16 +2,21 @@ package task
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
 	"github.com/murasakiwano/todoctian/server/db"
 	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
 )
 
 // This code indicates that a duplicate constraint was violated by the query
@@ -19,19 +24,80 @@ var ErrPgDuplicate = "23505"
 
 type TaskRepositoryPostgres struct {
 	Queries *db.Queries
-	ctx     context.Context
-	logger  slog.Logger
+	// Where transactions are started: the pool, or the current transaction for nested ones
+	db     beginner
+	ctx    context.Context
+	logger slog.Logger
+}
+
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 func NewTaskRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *TaskRepositoryPostgres {
 	slog.Debug("Connected to the database")
 	return &TaskRepositoryPostgres{
 		Queries: db.New(pool),
+		db:      pool,
 		ctx:     ctx,
 		logger:  *internal.NewLogger("TaskRepositoryPostgres"),
 	}
 }
 
+// NewTaskRepositoryPostgresInTx returns a repository whose changes are made in a transaction begun
+// by another repository, so that they are saved or rolled back along with its changes.
+func NewTaskRepositoryPostgresInTx(ctx context.Context, tx pgx.Tx) *TaskRepositoryPostgres {
+	return &TaskRepositoryPostgres{
+		Queries: db.New(tx),
+		db:      tx,
+		ctx:     ctx,
+		logger:  *internal.NewLogger("TaskRepositoryPostgres"),
+	}
+}
+
+// Run fn with a repository whose changes are only saved if fn succeeds, along with a project
+// repository whose changes are made in the same transaction
+func (t *TaskRepositoryPostgres) InTransaction(fn func(repository TaskRepository, projectRepository project.ProjectRepository) error) error {
+	tx, err := t.db.Begin(t.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(t.ctx)
+
+	txRepository := *t
+	txRepository.Queries = t.Queries.WithTx(tx)
+	txRepository.db = tx
+
+	err = fn(&txRepository, project.NewProjectRepositoryPostgresInTx(t.ctx, tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(t.ctx)
+}
+
+// LockVersion locks a task until the end of the transaction. Fails with ErrVersionChanged if the
+// task does not have the expected version, e.g. because it was changed in the meantime.
+func (t *TaskRepositoryPostgres) LockVersion(id uuid.UUID, version int) error {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return err
+	}
+
+	locked, err := t.Queries.LockTaskVersion(t.ctx, db.LockTaskVersionParams{
+		ID:              pgUUID,
+		ExpectedVersion: int32(version),
+	})
+	if err != nil {
+		return err
+	}
+	if locked == 0 {
+		return ErrVersionChanged
+	}
+
+	return nil
+}
+
 func (t *TaskRepositoryPostgres) Create(task Task) error {
 	taskDB, err := TaskModelToTaskDB(task)
 	if err != nil {
@@ -50,6 +116,11 @@ func (t *TaskRepositoryPostgres) Create(task Task) error {
 		Order:        taskDB.Order,
 		ParentTaskID: taskDB.ParentTaskID,
 		CreatedAt:    taskDB.CreatedAt,
+		DueAt:        taskDB.DueAt,
+		UpdatedAt:    taskDB.UpdatedAt,
+		CompletedAt:  taskDB.CompletedAt,
+		Estimate:     taskDB.Estimate,
+		IterationID:  taskDB.IterationID,
 	})
 	if err != nil {
 		t.logger.Info("failed to create task", slog.Any("task", task), slog.String("err", err.Error()))
@@ -208,6 +279,41 @@ func (t *TaskRepositoryPostgres) GetTasksInProjectRoot(projectID uuid.UUID) (_ [
 	return projectRoot, nil
 }
 
+func (t *TaskRepositoryPostgres) GetTasksInSection(projectID uuid.UUID, sectionID *uuid.UUID) ([]Task, error) {
+	pgProjectUUID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgSectionUUID := pgtype.UUID{}
+	if sectionID != nil {
+		pgSectionUUID, err = internal.ScanUUID(*sectionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tasksDB, err := t.Queries.GetTasksInSection(t.ctx, db.GetTasksInSectionParams{
+		ProjectID: pgProjectUUID,
+		SectionID: pgSectionUUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []Task{}
+	for _, taskDB := range tasksDB {
+		task, err := TaskDBToTaskModel(taskDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
 // Filter tasks in a project by their status
 func (t *TaskRepositoryPostgres) GetTasksByStatus(projectID uuid.UUID, status TaskStatus) (_ []Task, _ error) {
 	pgUUID, err := internal.ScanUUID(projectID)
@@ -256,6 +362,86 @@ func (t *TaskRepositoryPostgres) List() ([]Task, error) {
 	return tasks, nil
 }
 
+func (t *TaskRepositoryPostgres) ListPage(opts ListOptions, after *Task, limit int) ([]Task, error) {
+	params := db.ListTasksPageParams{
+		RootOnly:   opts.RootOnly,
+		SortBy:     string(opts.SortBy),
+		Descending: opts.Descending,
+		MaxTasks:   int32(limit),
+	}
+
+	if opts.ProjectID != nil {
+		pgUUID, err := internal.ScanUUID(*opts.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = t.Queries.GetProject(t.ctx, pgUUID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, internal.NewNotFoundError(fmt.Sprintf("Project %s", *opts.ProjectID))
+			}
+			return nil, err
+		}
+		params.FilterProject = true
+		params.ProjectID = pgUUID
+	}
+
+	if opts.ParentTaskID != nil {
+		pgUUID, err := internal.ScanUUID(*opts.ParentTaskID)
+		if err != nil {
+			return nil, err
+		}
+		params.FilterParent = true
+		params.ParentTaskID = pgUUID
+	}
+
+	if opts.SectionID != nil {
+		pgUUID, err := internal.ScanUUID(*opts.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		params.FilterSection = true
+		params.SectionID = pgUUID
+	}
+
+	if opts.Status != nil {
+		params.Status = opts.Status.String()
+	}
+
+	if after != nil {
+		pgUUID, err := internal.ScanUUID(after.ID)
+		if err != nil {
+			return nil, err
+		}
+		params.HasCursor = true
+		params.AfterID = pgUUID
+		params.AfterText = after.Name
+		if opts.SortBy == SortByStatus {
+			params.AfterText = after.Status.String()
+		}
+		params.AfterOrder = int32(after.Order)
+		params.AfterCreatedAt = pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}
+	}
+
+	tasksDB, err := t.Queries.ListTasksPage(t.ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []Task{}
+	for _, tDB := range tasksDB {
+		task, err := TaskDBToTaskModel(tDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
 // Rename a single task
 func (t *TaskRepositoryPostgres) Rename(taskID uuid.UUID, newName string) (_ Task, _ error) {
 	pgUUID, err := internal.ScanUUID(taskID)
@@ -287,9 +473,118 @@ func (t *TaskRepositoryPostgres) UpdateOrder(taskID uuid.UUID, newTaskOrder int)
 	})
 }
 
+// Give a task a new parent task, or none, and a new order among its new siblings
+func (t *TaskRepositoryPostgres) Move(taskID uuid.UUID, newParentTaskID *uuid.UUID, newTaskOrder int) (Task, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	pgParentUUID := pgtype.UUID{}
+	if newParentTaskID != nil {
+		pgParentUUID, err = internal.ScanUUID(*newParentTaskID)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	taskDB, err := t.Queries.MoveTask(t.ctx, db.MoveTaskParams{
+		ID:           pgUUID,
+		ParentTaskID: pgParentUUID,
+		Order:        int32(newTaskOrder),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Task{}, internal.NewNotFoundError(fmt.Sprintf("task %s", taskID))
+		}
+		return Task{}, err
+	}
+
+	return TaskDBToTaskModel(taskDB)
+}
+
+func (t *TaskRepositoryPostgres) SetSection(taskID uuid.UUID, sectionID *uuid.UUID, newTaskOrder int) (Task, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	pgSectionUUID := pgtype.UUID{}
+	if sectionID != nil {
+		pgSectionUUID, err = internal.ScanUUID(*sectionID)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	taskDB, err := t.Queries.SetTaskSection(t.ctx, db.SetTaskSectionParams{
+		ID:        pgUUID,
+		SectionID: pgSectionUUID,
+		Order:     int32(newTaskOrder),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Task{}, internal.NewNotFoundError(fmt.Sprintf("task %s", taskID))
+		}
+		return Task{}, err
+	}
+
+	return TaskDBToTaskModel(taskDB)
+}
+
+// Set or, if dueAt is nil, unset the due date of a task
+func (t *TaskRepositoryPostgres) UpdateDueAt(taskID uuid.UUID, dueAt *time.Time) (Task, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	pgDueAt := pgtype.Timestamptz{}
+	if dueAt != nil {
+		err = pgDueAt.Scan(*dueAt)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	taskDB, err := t.Queries.UpdateTaskDueAt(t.ctx, db.UpdateTaskDueAtParams{ID: pgUUID, DueAt: pgDueAt})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Task{}, internal.NewNotFoundError(fmt.Sprintf("task %s", taskID))
+		}
+		return Task{}, err
+	}
+
+	return TaskDBToTaskModel(taskDB)
+}
+
+// Set or, if estimate is nil, unset the estimate of a task
+func (t *TaskRepositoryPostgres) UpdateEstimate(taskID uuid.UUID, estimate *int) (Task, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	pgEstimate := pgtype.Int4{}
+	if estimate != nil {
+		pgEstimate = pgtype.Int4{Int32: int32(*estimate), Valid: true}
+	}
+
+	taskDB, err := t.Queries.UpdateTaskEstimate(t.ctx, db.UpdateTaskEstimateParams{ID: pgUUID, Estimate: pgEstimate})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Task{}, internal.NewNotFoundError(fmt.Sprintf("task %s", taskID))
+		}
+		return Task{}, err
+	}
+
+	return TaskDBToTaskModel(taskDB)
+}
+
 // Batch update the order a collection of tasks
 func (t *TaskRepositoryPostgres) BatchUpdateOrder(tasks []Task) (_ error) {
 	batchUpdateTaskOrderParams := []db.BatchUpdateTaskOrdersParams{}
+	batchMoveTaskOrdersAsideParams := []db.BatchMoveTaskOrdersAsideParams{}
 
 	for _, task := range tasks {
 		pgUUID, err := internal.ScanUUID(task.ID)
@@ -303,34 +598,75 @@ func (t *TaskRepositoryPostgres) BatchUpdateOrder(tasks []Task) (_ error) {
 				Order: int32(task.Order),
 			},
 		)
+		batchMoveTaskOrdersAsideParams = append(batchMoveTaskOrdersAsideParams,
+			db.BatchMoveTaskOrdersAsideParams{
+				ID:    pgUUID,
+				Order: int32(task.Order),
+			},
+		)
 	}
 
-	errs := []error{}
-	br := t.Queries.BatchUpdateTaskOrders(t.ctx, batchUpdateTaskOrderParams)
+	// The orders of a level are unique, so the tasks whose order changes are first moved out of
+	// the way of each other
+	tx, err := t.db.Begin(t.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(t.ctx)
+
+	qtx := t.Queries.WithTx(tx)
+	err = t.execBatch(qtx.BatchMoveTaskOrdersAside(t.ctx, batchMoveTaskOrdersAsideParams))
+	if err != nil {
+		return err
+	}
+
+	err = t.execBatch(qtx.BatchUpdateTaskOrders(t.ctx, batchUpdateTaskOrderParams))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(t.ctx)
+}
+
+// The results of a batch of queries.
+type batchResults interface {
+	Exec(f func(int, error))
+	Close() error
+}
+
+// execBatch runs the queries of a batch, stopping at the first one that fails, whose error is
+// returned.
+func (t *TaskRepositoryPostgres) execBatch(br batchResults) error {
+	var batchErr error
 	br.Exec(func(i int, err error) {
-		if err != nil {
+		if err != nil && batchErr == nil {
 			t.logger.Error("failed to execute query in batch", slog.Int("queryNumber", i), slog.String("err", err.Error()))
-			errs = append(errs, err)
+			batchErr = err
 			br.Close()
 		}
 	})
 
-	if len(errs) == 0 {
-		return nil
-	}
-
-	return errs[len(errs)-1]
+	return batchErr
 }
 
-// Update task status to Pending or Completed
-func (t *TaskRepositoryPostgres) UpdateTaskStatus(id uuid.UUID, newStatus TaskStatus) (_ error) {
+// Update task status to Pending or Completed, along with the time it was completed at, which is nil
+// for pending tasks
+func (t *TaskRepositoryPostgres) UpdateTaskStatus(id uuid.UUID, newStatus TaskStatus, completedAt *time.Time) (_ error) {
 	pgUUID, err := internal.ScanUUID(id)
 	if err != nil {
 		return err
 	}
 
+	pgCompletedAt := pgtype.Timestamptz{}
+	if completedAt != nil {
+		err = pgCompletedAt.Scan(*completedAt)
+		if err != nil {
+			return err
+		}
+	}
+
 	return t.Queries.UpdateTaskStatus(t.ctx, db.UpdateTaskStatusParams{
-		ID: pgUUID, Status: newStatus.String(),
+		ID: pgUUID, Status: newStatus.String(), CompletedAt: pgCompletedAt,
 	})
 }
 
@@ -355,3 +691,327 @@ func (t *TaskRepositoryPostgres) Delete(id uuid.UUID) (_ Task, _ error) {
 
 	return task, t.Queries.DeleteTask(t.ctx, pgUUID)
 }
+
+// Move a task and its subtasks to the trash
+func (t *TaskRepositoryPostgres) SoftDelete(id uuid.UUID, deletedAt time.Time) error {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return err
+	}
+
+	pgDeletedAt := pgtype.Timestamptz{}
+	err = pgDeletedAt.Scan(deletedAt)
+	if err != nil {
+		return err
+	}
+
+	err = t.Queries.SoftDeleteTaskTree(t.ctx, db.SoftDeleteTaskTreeParams{
+		ID:        pgUUID,
+		DeletedAt: pgDeletedAt,
+	})
+	if err != nil {
+		t.logger.Error("failed to move task to the trash",
+			slog.String("taskID", id.String()),
+			slog.String("err", err.Error()),
+		)
+	}
+
+	return err
+}
+
+// Retrieve a task in the trash by its ID
+func (t *TaskRepositoryPostgres) GetDeleted(id uuid.UUID) (Task, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	taskDB, err := t.Queries.GetDeletedTask(t.ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Deleted task %s", id))
+		}
+		return Task{}, err
+	}
+
+	return TaskDBToTaskModel(taskDB)
+}
+
+// List the tasks that were moved to the trash on their own
+func (t *TaskRepositoryPostgres) ListDeleted() ([]Task, error) {
+	tasksDB, err := t.Queries.ListDeletedTasks(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []Task{}
+	for _, tDB := range tasksDB {
+		task, err := TaskDBToTaskModel(tDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// List the tasks completed from "from" (inclusive) to "to" (exclusive), most recently completed
+// first, in every project or, if projectID is not nil, in a single project
+func (t *TaskRepositoryPostgres) ListCompleted(projectID *uuid.UUID, from time.Time, to time.Time) ([]Task, error) {
+	pgFrom := pgtype.Timestamptz{}
+	err := pgFrom.Scan(from)
+	if err != nil {
+		return nil, err
+	}
+
+	pgTo := pgtype.Timestamptz{}
+	err = pgTo.Scan(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasksDB []db.Task
+	if projectID == nil {
+		tasksDB, err = t.Queries.ListCompletedTasks(t.ctx, db.ListCompletedTasksParams{
+			CompletedFrom: pgFrom,
+			CompletedTo:   pgTo,
+		})
+	} else {
+		var pgProjectID pgtype.UUID
+		pgProjectID, err = internal.ScanUUID(*projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		tasksDB, err = t.Queries.ListProjectCompletedTasks(t.ctx, db.ListProjectCompletedTasksParams{
+			ProjectID:     pgProjectID,
+			CompletedFrom: pgFrom,
+			CompletedTo:   pgTo,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []Task{}
+	for _, tDB := range tasksDB {
+		task, err := TaskDBToTaskModel(tDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// Count the tasks of a project created and completed in each bucket, given by its start and end,
+// and the tasks open at the end of each bucket. The tasks in the trash are left out.
+func (t *TaskRepositoryPostgres) GetStatsSeries(projectID uuid.UUID, starts []time.Time, ends []time.Time) ([]StatsPoint, error) {
+	pgProjectID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgStarts := make([]pgtype.Timestamptz, len(starts))
+	for i, start := range starts {
+		pgStarts[i] = pgtype.Timestamptz{Time: start, Valid: true}
+	}
+	pgEnds := make([]pgtype.Timestamptz, len(ends))
+	for i, end := range ends {
+		pgEnds[i] = pgtype.Timestamptz{Time: end, Valid: true}
+	}
+
+	rows, err := t.Queries.GetProjectTaskSeries(t.ctx, db.GetProjectTaskSeriesParams{
+		BucketStarts: pgStarts,
+		BucketEnds:   pgEnds,
+		ProjectID:    pgProjectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The times are given back in the time zone of the buckets, rather than in the one of the
+	// database session
+	location := time.UTC
+	if len(starts) > 0 {
+		location = starts[0].Location()
+	}
+
+	series := []StatsPoint{}
+	for _, row := range rows {
+		series = append(series, StatsPoint{
+			Start:     row.BucketStart.Time.In(location),
+			End:       row.BucketEnd.Time.In(location),
+			Created:   int(row.Created),
+			Completed: int(row.Completed),
+			Open:      int(row.Open),
+		})
+	}
+
+	return series, nil
+}
+
+// Count the tasks of a project completed from "from" (inclusive) to "to" (exclusive), and average
+// the time it took to complete them. The tasks in the trash are left out.
+func (t *TaskRepositoryPostgres) GetCycleTime(projectID uuid.UUID, from time.Time, to time.Time) (int, time.Duration, error) {
+	pgProjectID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	row, err := t.Queries.GetProjectCycleTime(t.ctx, db.GetProjectCycleTimeParams{
+		ProjectID:     pgProjectID,
+		CompletedFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		CompletedTo:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	average := time.Duration(row.AverageSeconds * float64(time.Second))
+	return int(row.Completed), average, nil
+}
+
+// List the oldest pending tasks of a project, at most limit of them
+func (t *TaskRepositoryPostgres) ListOldestOpen(projectID uuid.UUID, limit int) ([]Task, error) {
+	pgProjectID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksDB, err := t.Queries.ListOldestOpenTasks(t.ctx, db.ListOldestOpenTasksParams{
+		ProjectID: pgProjectID,
+		MaxTasks:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []Task{}
+	for _, tDB := range tasksDB {
+		task, err := TaskDBToTaskModel(tDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// Take a task and the subtasks deleted along with it out of the trash. The order of the task is
+// updated first, so that it takes its place among its siblings again.
+func (t *TaskRepositoryPostgres) Restore(task Task) error {
+	if task.DeletedAt == nil {
+		return fmt.Errorf("task %s is not in the trash", task.ID)
+	}
+
+	taskDB, err := TaskModelToTaskDB(task)
+	if err != nil {
+		return err
+	}
+
+	err = t.Queries.UpdateTaskOrder(t.ctx, db.UpdateTaskOrderParams{
+		ID:    taskDB.ID,
+		Order: taskDB.Order,
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.Queries.RestoreTaskTree(t.ctx, db.RestoreTaskTreeParams{
+		ID:        taskDB.ID,
+		DeletedAt: taskDB.DeletedAt,
+	})
+}
+
+// Permanently delete the tasks moved to the trash before the given time
+func (t *TaskRepositoryPostgres) Purge(deletedBefore time.Time) (int64, error) {
+	pgDeletedBefore := pgtype.Timestamptz{}
+	err := pgDeletedBefore.Scan(deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Queries.PurgeDeletedTasks(t.ctx, pgDeletedBefore)
+}
+
+// Save the state of a task as its next revision
+func (t *TaskRepositoryPostgres) CreateRevision(task Task, action activity.Action, createdAt time.Time) (Revision, error) {
+	pgUUID, err := internal.ScanUUID(task.ID)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	pgCreatedAt := pgtype.Timestamptz{}
+	err = pgCreatedAt.Scan(createdAt)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	state, err := json.Marshal(newTaskState(task))
+	if err != nil {
+		return Revision{}, err
+	}
+
+	revisionDB, err := t.Queries.CreateTaskRevision(t.ctx, db.CreateTaskRevisionParams{
+		TaskID:    pgUUID,
+		CreatedAt: pgCreatedAt,
+		Action:    string(action),
+		State:     state,
+	})
+	if err != nil {
+		t.logger.Error("failed to insert task revision", slog.String("taskID", task.ID.String()), slog.String("err", err.Error()))
+		return Revision{}, err
+	}
+
+	return TaskRevisionDBToRevisionModel(revisionDB)
+}
+
+// List the revisions of a task, oldest first
+func (t *TaskRepositoryPostgres) ListRevisions(taskID uuid.UUID) ([]Revision, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisionsDB, err := t.Queries.ListTaskRevisions(t.ctx, pgUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []Revision{}
+	for _, revisionDB := range revisionsDB {
+		revision, err := TaskRevisionDBToRevisionModel(revisionDB)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// Retrieve a revision of a task by its number
+func (t *TaskRepositoryPostgres) GetRevision(taskID uuid.UUID, number int) (Revision, error) {
+	pgUUID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	revisionDB, err := t.Queries.GetTaskRevision(t.ctx, db.GetTaskRevisionParams{TaskID: pgUUID, Revision: int32(number)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Revision{}, internal.NewNotFoundError(fmt.Sprintf("revision %d of task %s", number, taskID))
+		}
+		return Revision{}, err
+	}
+
+	return TaskRevisionDBToRevisionModel(revisionDB)
+}
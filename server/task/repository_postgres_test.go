@@ -6,6 +6,7 @@ import (
 	"log"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -116,6 +117,37 @@ func (suite *TaskRepoPostgresTestSuite) TestCreateDuplicateTaskShouldReturnAlrea
 	}
 }
 
+func (suite *TaskRepoPostgresTestSuite) TestCreateSiblingsWithTheSameOrderShouldFail() {
+	t := suite.T()
+
+	// Root tasks without a section have no parent task nor section, which must not make their
+	// orders distinct
+	task := NewTask("Test task", suite.projectID, nil)
+	err := suite.repository.Create(task)
+	require.NoError(t, err)
+
+	rootSibling := NewTask("Test root sibling task", suite.projectID, nil)
+	err = suite.repository.Create(rootSibling)
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, internal.ErrAlreadyExists))
+	}
+
+	subtask := NewTask("Test subtask", suite.projectID, &task.ID)
+	err = suite.repository.Create(subtask)
+	require.NoError(t, err)
+
+	sibling := NewTask("Test sibling subtask", suite.projectID, &task.ID)
+	err = suite.repository.Create(sibling)
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, internal.ErrAlreadyExists))
+	}
+
+	// Tasks of other levels may have the same order
+	otherProjectTask := NewTask("Other project task", suite.otherProjectID, nil)
+	err = suite.repository.Create(otherProjectTask)
+	assert.NoError(t, err)
+}
+
 func (suite *TaskRepoPostgresTestSuite) TestGetTask() {
 	t := suite.T()
 
@@ -293,6 +325,7 @@ func (suite *TaskRepoPostgresTestSuite) TestListTasks() {
 	firstTask := NewTask("test task", suite.projectID, nil)
 	secondTask := NewTask("second test task", suite.otherProjectID, nil)
 	firstSubtask := NewTask("first subtask", suite.projectID, nil)
+	firstSubtask.Order = 1
 
 	err := suite.repository.Create(firstTask)
 	require.NoError(t, err)
@@ -365,15 +398,79 @@ func (suite *TaskRepoPostgresTestSuite) TestUpdateTaskStatus() {
 	err := suite.repository.Create(task)
 	require.NoError(t, err)
 
-	err = suite.repository.UpdateTaskStatus(task.ID, TaskStatusCompleted)
+	completedAt := time.Now().UTC()
+	err = suite.repository.UpdateTaskStatus(task.ID, TaskStatusCompleted, &completedAt)
 	require.NoError(t, err)
 
 	completedTask, err := suite.repository.Get(task.ID)
 	if assert.NoError(t, err) {
 		assert.Equal(t, TaskStatusCompleted, completedTask.Status)
+		if assert.NotNil(t, completedTask.CompletedAt) {
+			assert.WithinDuration(t, completedAt, *completedTask.CompletedAt, time.Millisecond)
+		}
+		assert.True(t, completedTask.UpdatedAt.After(task.UpdatedAt))
+	}
+
+	err = suite.repository.UpdateTaskStatus(task.ID, TaskStatusPending, nil)
+	require.NoError(t, err)
+
+	pendingTask, err := suite.repository.Get(task.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TaskStatusPending, pendingTask.Status)
+		assert.Nil(t, pendingTask.CompletedAt)
 	}
 }
 
+func (suite *TaskRepoPostgresTestSuite) TestListCompleted() {
+	t := suite.T()
+	start := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	completions := []struct {
+		projectID   uuid.UUID
+		completedAt time.Time
+	}{
+		{suite.projectID, start.Add(-time.Second)},
+		{suite.projectID, start},
+		{suite.otherProjectID, start.Add(time.Hour)},
+		{suite.projectID, start.Add(2 * time.Hour)},
+		{suite.projectID, start.Add(24 * time.Hour)},
+	}
+	taskIDs := []uuid.UUID{}
+	for i, c := range completions {
+		task := NewTask("Test task", c.projectID, nil)
+		task.Order = i
+		require.NoError(t, suite.repository.Create(task))
+		require.NoError(t, suite.repository.UpdateTaskStatus(task.ID, TaskStatusCompleted, &c.completedAt))
+		taskIDs = append(taskIDs, task.ID)
+	}
+	// Pending tasks are never listed
+	pendingTask := NewTask("Pending task", suite.projectID, nil)
+	pendingTask.Order = len(completions)
+	require.NoError(t, suite.repository.Create(pendingTask))
+
+	ids := func(tasks []Task) []uuid.UUID {
+		ids := []uuid.UUID{}
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	// From is inclusive, to is exclusive, and the most recently completed tasks come first
+	tasks, err := suite.repository.ListCompleted(nil, start, start.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{taskIDs[3], taskIDs[2], taskIDs[1]}, ids(tasks))
+
+	tasks, err = suite.repository.ListCompleted(&suite.projectID, start, start.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{taskIDs[3], taskIDs[1]}, ids(tasks))
+
+	// Time zones are taken into account
+	brt := time.FixedZone("BRT", -3*60*60)
+	tasks, err = suite.repository.ListCompleted(nil, start.In(brt), start.Add(time.Hour).In(brt))
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{taskIDs[1]}, ids(tasks))
+}
+
 func (suite *TaskRepoPostgresTestSuite) TestDeleteTask() {
 	t := suite.T()
 	task := NewTask("Test task", suite.projectID, nil)
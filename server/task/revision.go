@@ -0,0 +1,215 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrMissingParent = internal.NewConflictError("the parent task of the revision no longer exists")
+
+// A Revision is the state of a task right after one of its changes. Revisions are numbered from
+// 1 for each task.
+type Revision struct {
+	CreatedAt time.Time
+	// The change that produced the revision
+	Action activity.Action
+	// The state of the task, without its subtasks
+	Task   Task
+	TaskID uuid.UUID
+	Number int
+}
+
+// taskState is how the state of a task is stored in a revision. Fields added to Task should be
+// added here too, so that revisions keep track of them.
+type taskState struct {
+	CreatedAt    time.Time  `json:"createdAt"`
+	ParentTaskID *uuid.UUID `json:"parentTaskID"`
+	DueAt        *time.Time `json:"dueAt"`
+	DeletedAt    *time.Time `json:"deletedAt"`
+	Name         string     `json:"name"`
+	Status       string     `json:"status"`
+	ProjectID    uuid.UUID  `json:"projectID"`
+	Order        int        `json:"order"`
+	Estimate     *int       `json:"estimate"`
+	IterationID  *uuid.UUID `json:"iterationID"`
+	SectionID    *uuid.UUID `json:"sectionID"`
+}
+
+func newTaskState(task Task) taskState {
+	return taskState{
+		CreatedAt:    task.CreatedAt,
+		ParentTaskID: task.ParentTaskID,
+		DueAt:        task.DueAt,
+		DeletedAt:    task.DeletedAt,
+		Name:         task.Name,
+		Status:       task.Status.String(),
+		ProjectID:    task.ProjectID,
+		Order:        task.Order,
+		Estimate:     task.Estimate,
+		IterationID:  task.IterationID,
+		SectionID:    task.SectionID,
+	}
+}
+
+func (s taskState) toTask(id uuid.UUID) (Task, error) {
+	status := TaskStatus{}
+	err := status.FromString(s.Status)
+	if err != nil {
+		return Task{}, err
+	}
+
+	return Task{
+		ID:           id,
+		CreatedAt:    s.CreatedAt,
+		ParentTaskID: s.ParentTaskID,
+		DueAt:        s.DueAt,
+		DeletedAt:    s.DeletedAt,
+		Name:         s.Name,
+		Status:       status,
+		ProjectID:    s.ProjectID,
+		Order:        s.Order,
+		Estimate:     s.Estimate,
+		IterationID:  s.IterationID,
+		SectionID:    s.SectionID,
+		Subtasks:     []Task{},
+	}, nil
+}
+
+// ListRevisions lists the revisions of a task, oldest first. The revisions of tasks in the trash
+// can be listed too.
+func (ts *TaskService) ListRevisions(taskID uuid.UUID) ([]Revision, error) {
+	_, err := ts.repository.Get(taskID)
+	if errors.Is(err, internal.ErrNotFound) {
+		_, err = ts.repository.GetDeleted(taskID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.repository.ListRevisions(taskID)
+}
+
+func (ts *TaskService) GetRevision(taskID uuid.UUID, number int) (Revision, error) {
+	return ts.repository.GetRevision(taskID, number)
+}
+
+// RestoreRevision brings a task back to the state it had at one of its revisions. The state is
+// restored with the same operations as any other change, i.e. moving, renaming, updating the
+// status, the due date, the estimate and the order of the task, so that they are validated and
+// cascaded as usual. Each of them creates a new revision, and either all of them are saved or none
+// is. The iteration the task is planned in and the section it is in are left as they are, since
+// they may have been closed or deleted since.
+//
+// Tasks in the trash must be restored from the trash first, and restoring a revision never
+// moves a task to the trash.
+func (ts *TaskService) RestoreRevision(taskID uuid.UUID, number int) (Task, error) {
+	var restored Task
+	err := ts.inTransaction(func(txService *TaskService) error {
+		var err error
+		restored, err = txService.restoreRevision(taskID, number)
+		return err
+	})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return restored, nil
+}
+
+// Restores a revision with the operations of the service, which run in the transaction of
+// RestoreRevision so that a failure does not leave the task partly restored.
+func (ts *TaskService) restoreRevision(taskID uuid.UUID, number int) (Task, error) {
+	task, err := ts.repository.Get(taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	revision, err := ts.repository.GetRevision(taskID, number)
+	if err != nil {
+		return Task{}, err
+	}
+
+	target := revision.Task
+	target.ProjectID = task.ProjectID
+	err = ts.ValidateTask(target)
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			return Task{}, fmt.Errorf("Could not restore revision %d of task %s: %w", number, taskID, ErrMissingParent)
+		}
+
+		return Task{}, err
+	}
+
+	if !sameTaskID(task.ParentTaskID, target.ParentTaskID) {
+		_, err = ts.MoveTask(taskID, target.ParentTaskID)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	if task.Name != target.Name {
+		_, err = ts.RenameTask(taskID, target.Name)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	if !sameTime(task.DueAt, target.DueAt) {
+		_, err = ts.UpdateTaskDueDate(taskID, target.DueAt)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	if !sameInt(task.Estimate, target.Estimate) {
+		_, err = ts.UpdateTaskEstimate(taskID, target.Estimate)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	// Changing the status may cascade to the task from its subtasks or parent task, so the stored
+	// status is checked rather than the one read at the beginning
+	task, err = ts.repository.Get(taskID)
+	if err != nil {
+		return Task{}, err
+	}
+	if task.Status != target.Status {
+		err = ts.UpdateTaskStatus(taskID, target.Status.String())
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	if task.Order != target.Order {
+		err = ts.ReorderTask(task, target.Order)
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	return ts.repository.Get(taskID)
+}
+
+// Times are compared with the precision of the database, since revisions may hold more precise
+// times than the ones that were stored.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,191 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RevisionTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *RevisionTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *RevisionTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *RevisionTestSuite) TestChangesCreateRevisions() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(task.ID, TaskStatusCompleted.String()))
+
+	revisions, err := suite.taskService.ListRevisions(task.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	for i, revision := range revisions {
+		assert.Equal(t, i+1, revision.Number)
+	}
+	assert.Equal(t, "Test task", revisions[0].Task.Name)
+	assert.Equal(t, "Renamed task", revisions[1].Task.Name)
+	assert.Equal(t, TaskStatusPending, revisions[1].Task.Status)
+	assert.Equal(t, TaskStatusCompleted, revisions[2].Task.Status)
+
+	revision, err := suite.taskService.GetRevision(task.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed task", revision.Task.Name)
+
+	_, err = suite.taskService.GetRevision(task.ID, 4)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *RevisionTestSuite) TestConcurrentChangesCreateRevisions() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.taskService.RenameTask(task.ID, fmt.Sprintf("Task %d", i))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// No revision was lost to another one taking the same number
+	revisions, err := suite.taskService.ListRevisions(task.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 11)
+	for i, revision := range revisions {
+		assert.Equal(t, i+1, revision.Number)
+	}
+}
+
+func (suite *RevisionTestSuite) TestRestoreRevision() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &task.ID)
+	require.NoError(t, err)
+
+	// Revision 1 is the creation of the task
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+	_, err = suite.taskService.MoveTask(task.ID, &parentTask.ID)
+	require.NoError(t, err)
+
+	restoredTask, err := suite.taskService.RestoreRevision(task.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Test task", restoredTask.Name)
+	assert.Nil(t, restoredTask.ParentTaskID)
+	assert.Equal(t, 1, restoredTask.Order)
+
+	// The subtask followed its parent back
+	subtask, err = suite.taskService.FindTaskByID(subtask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, *subtask.ParentTaskID)
+
+	// Restoring creates new revisions rather than rewriting the history
+	revisions, err := suite.taskService.ListRevisions(task.ID)
+	require.NoError(t, err)
+	assert.Greater(t, len(revisions), 3)
+}
+
+func (suite *RevisionTestSuite) TestRestoreRevision_StatusIsCascaded() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &task.ID)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(task.ID, TaskStatusCompleted.String()))
+	require.NoError(t, suite.taskService.UpdateTaskStatus(task.ID, TaskStatusPending.String()))
+
+	revisions, err := suite.taskService.ListRevisions(task.ID)
+	require.NoError(t, err)
+	completedRevision := revisions[len(revisions)-2]
+	require.Equal(t, TaskStatusCompleted, completedRevision.Task.Status)
+
+	_, err = suite.taskService.RestoreRevision(task.ID, completedRevision.Number)
+	require.NoError(t, err)
+
+	subtask, err = suite.taskService.FindTaskByID(subtask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCompleted, subtask.Status)
+}
+
+func (suite *RevisionTestSuite) TestRestoreRevision_ParentIsGone() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, &parentTask.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.MoveTask(task.ID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.DeleteTask(parentTask.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RestoreRevision(task.ID, 1)
+	assert.ErrorIs(t, err, ErrMissingParent)
+}
+
+func (suite *RevisionTestSuite) TestRestoreRevision_TaskDoesNotExist() {
+	_, err := suite.taskService.RestoreRevision(uuid.New(), 1)
+	assert.ErrorIs(suite.T(), err, internal.ErrNotFound)
+}
+
+func TestRevision(t *testing.T) {
+	suite.Run(t, new(RevisionTestSuite))
+}
@@ -0,0 +1,116 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrSubtaskInSection = internal.NewValidationError("only root tasks can be put in sections")
+
+// MoveTaskToSection puts a root task in a section of its project, or in none if sectionID is nil,
+// at the given position among the tasks of the section. As with ReorderTask, a position less than
+// 0 brings the task to the beginning and a position past the end brings it to the end. The tasks
+// after the position are shifted by one, and the former siblings of the task close the gap it
+// leaves.
+func (ts *TaskService) MoveTaskToSection(id uuid.UUID, sectionID *uuid.UUID, position int) (Task, error) {
+	var movedTask Task
+	err := ts.inTransaction(func(txService *TaskService) (err error) {
+		movedTask, err = txService.moveTaskToSection(id, sectionID, position)
+		return err
+	})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return movedTask, nil
+}
+
+func (ts *TaskService) moveTaskToSection(id uuid.UUID, sectionID *uuid.UUID, position int) (Task, error) {
+	task, err := ts.repository.Get(id)
+	if err != nil {
+		return Task{}, fmt.Errorf("Could not move task %s to another section: %w", id, err)
+	}
+	if !task.IsInProjectRoot() {
+		return Task{}, ErrSubtaskInSection
+	}
+
+	err = ts.ensureProjectIsActive(task.ProjectID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	if sectionID != nil {
+		section, err := ts.projectDB.GetSection(*sectionID)
+		if err != nil {
+			return Task{}, err
+		}
+		if section.ProjectID != task.ProjectID {
+			return Task{}, internal.NewNotFoundError(fmt.Sprintf("Section with id %s in project %s", *sectionID, task.ProjectID))
+		}
+	}
+
+	if sameTaskID(task.SectionID, sectionID) {
+		err = ts.reorderTask(task, position)
+		if err != nil {
+			return Task{}, err
+		}
+
+		return ts.repository.Get(id)
+	}
+
+	newSiblings, err := ts.repository.GetTasksInSection(task.ProjectID, sectionID)
+	if err != nil {
+		return Task{}, fmt.Errorf("Failed to fetch the tasks of the new section of task %s: %w", id, err)
+	}
+
+	position = max(0, min(position, len(newSiblings)))
+	slices.SortFunc(newSiblings, cmpTasks)
+	// The tasks from the position on make room for the task, the last one first so that each one
+	// moves into a free position
+	shiftedSiblings := []Task{}
+	for i := len(newSiblings) - 1; i >= position; i-- {
+		newSiblings[i].Order = i + 1
+		shiftedSiblings = append(shiftedSiblings, newSiblings[i])
+	}
+	if len(shiftedSiblings) > 0 {
+		err = ts.repository.BatchUpdateOrder(shiftedSiblings)
+		if err != nil {
+			return Task{}, fmt.Errorf("Failed to make room for task %s in its new section: %w", id, err)
+		}
+	}
+
+	movedTask, err := ts.repository.SetSection(id, sectionID, position)
+	if err != nil {
+		return Task{}, err
+	}
+
+	err = ts.rearrangeTaskSiblings(task)
+	if err != nil {
+		return Task{}, fmt.Errorf("Failed to rearrange the former siblings of task %s: %w", id, err)
+	}
+
+	ts.record(
+		movedTask,
+		activity.ActionMoved,
+		map[string]any{"sectionID": task.SectionID, "order": task.Order},
+		map[string]any{"sectionID": movedTask.SectionID, "order": movedTask.Order},
+	)
+	ts.logCommand(command{
+		action:  activity.ActionMoved,
+		taskIDs: []uuid.UUID{id},
+		undo: func(ts *TaskService) error {
+			_, err := ts.MoveTaskToSection(id, task.SectionID, task.Order)
+			return err
+		},
+		redo: func(ts *TaskService) error {
+			_, err := ts.MoveTaskToSection(id, sectionID, movedTask.Order)
+			return err
+		},
+	})
+
+	return movedTask, nil
+}
@@ -0,0 +1,172 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SectionTestSuite struct {
+	suite.Suite
+	ctx               context.Context
+	pgContainer       *testhelpers.PostgresContainer
+	taskService       *TaskService
+	projectRepository project.ProjectRepository
+	projectIDs        []uuid.UUID
+}
+
+func (suite *SectionTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	suite.projectRepository = project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, suite.projectRepository)
+}
+
+// Setup database before each test
+func (suite *SectionTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	suite.projectIDs = insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+}
+
+func (suite *SectionTestSuite) createSection(name string, projectID uuid.UUID, order int) project.Section {
+	section := project.NewSection(name, projectID)
+	section.Order = order
+	require.NoError(suite.T(), suite.projectRepository.CreateSection(section))
+
+	return section
+}
+
+// Names of the tasks of a section, or of the root tasks without one, in order
+func (suite *SectionTestSuite) sectionTaskNames(sectionID *uuid.UUID) []string {
+	tasks, err := suite.taskService.repository.GetTasksInSection(suite.projectIDs[0], sectionID)
+	require.NoError(suite.T(), err)
+
+	names := make([]string, len(tasks))
+	for _, task := range tasks {
+		names[task.Order] = task.Name
+	}
+	return names
+}
+
+func (suite *SectionTestSuite) TestMoveTaskToSection() {
+	t := suite.T()
+	projectID := suite.projectIDs[0]
+	backlog := suite.createSection("Backlog", projectID, 0)
+
+	tasks := []Task{}
+	for _, name := range []string{"First", "Second", "Third"} {
+		task, err := suite.taskService.CreateTask(name, projectID, nil)
+		require.NoError(t, err)
+		tasks = append(tasks, task)
+	}
+
+	moved, err := suite.taskService.MoveTaskToSection(tasks[1].ID, &backlog.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, &backlog.ID, moved.SectionID)
+	assert.Equal(t, 0, moved.Order)
+	// The tasks left behind close the gap
+	assert.Equal(t, []string{"First", "Third"}, suite.sectionTaskNames(nil))
+
+	_, err = suite.taskService.MoveTaskToSection(tasks[2].ID, &backlog.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Third", "Second"}, suite.sectionTaskNames(&backlog.ID))
+
+	// Within the same section, the task is just reordered
+	_, err = suite.taskService.MoveTaskToSection(tasks[2].ID, &backlog.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Second", "Third"}, suite.sectionTaskNames(&backlog.ID))
+
+	moved, err = suite.taskService.MoveTaskToSection(tasks[1].ID, nil, 0)
+	require.NoError(t, err)
+	assert.Nil(t, moved.SectionID)
+	assert.Equal(t, []string{"Second", "First"}, suite.sectionTaskNames(nil))
+	assert.Equal(t, []string{"Third"}, suite.sectionTaskNames(&backlog.ID))
+}
+
+func (suite *SectionTestSuite) TestMoveTaskToSection_Invalid() {
+	t := suite.T()
+	projectID := suite.projectIDs[0]
+	backlog := suite.createSection("Backlog", projectID, 0)
+	otherBacklog := suite.createSection("Backlog", suite.projectIDs[1], 0)
+
+	task, err := suite.taskService.CreateTask("Task", projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", projectID, &task.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.MoveTaskToSection(subtask.ID, &backlog.ID, 0)
+	assert.ErrorIs(t, err, ErrSubtaskInSection)
+
+	_, err = suite.taskService.MoveTaskToSection(task.ID, &otherBacklog.ID, 0)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	missingSectionID := uuid.New()
+	_, err = suite.taskService.MoveTaskToSection(task.ID, &missingSectionID, 0)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *SectionTestSuite) TestDeleteSection_ReleasesTasks() {
+	t := suite.T()
+	projectID := suite.projectIDs[0]
+	backlog := suite.createSection("Backlog", projectID, 0)
+
+	_, err := suite.taskService.CreateTask("Unsectioned", projectID, nil)
+	require.NoError(t, err)
+	for _, name := range []string{"First", "Second"} {
+		task, err := suite.taskService.CreateTask(name, projectID, nil)
+		require.NoError(t, err)
+		_, err = suite.taskService.MoveTaskToSection(task.ID, &backlog.ID, 10)
+		require.NoError(t, err)
+	}
+
+	_, err = suite.projectRepository.DeleteSection(backlog.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Unsectioned", "First", "Second"}, suite.sectionTaskNames(nil))
+}
+
+func (suite *SectionTestSuite) TestMoveTask_LeavesSection() {
+	t := suite.T()
+	projectID := suite.projectIDs[0]
+	backlog := suite.createSection("Backlog", projectID, 0)
+
+	parent, err := suite.taskService.CreateTask("Parent", projectID, nil)
+	require.NoError(t, err)
+	task, err := suite.taskService.CreateTask("Task", projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.MoveTaskToSection(task.ID, &backlog.ID, 0)
+	require.NoError(t, err)
+
+	moved, err := suite.taskService.MoveTask(task.ID, &parent.ID)
+	require.NoError(t, err)
+	assert.Nil(t, moved.SectionID)
+	assert.Empty(t, suite.sectionTaskNames(&backlog.ID))
+}
+
+func TestSections(t *testing.T) {
+	suite.Run(t, new(SectionTestSuite))
+}
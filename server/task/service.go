@@ -1,11 +1,11 @@
 package task
 
 import (
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
 	"github.com/murasakiwano/todoctian/server/internal"
 	"github.com/murasakiwano/todoctian/server/project"
 )
@@ -14,24 +14,62 @@ type TaskService struct {
 	repository TaskRepository
 	projectDB  project.ProjectRepository
 	logger     slog.Logger
+	// Where changes are recorded, nil if they are not
+	activity activity.Recorder
+	// Who is making the changes, see WithOrigin
+	origin activity.Origin
+	// The client session the operations are logged under, see WithSession
+	session  string
+	commands *commandLog
+	// The operation the changes are made by, see WithOperation
+	operation string
+	// Where the status changes are collected, nil if they are not, see trackStatusChanges
+	statusChanges *[]statusChange
+	// The changes made within a batch, which are only recorded and logged once it succeeds
+	pending *pendingChanges
+	limits  internal.Limits
 }
 
-func NewTaskService(taskRepository TaskRepository, projectRepository project.ProjectRepository) *TaskService {
-	return &TaskService{
+type TaskServiceOption func(*TaskService)
+
+// WithActivityRecorder records every change made by the service in the activity history.
+func WithActivityRecorder(recorder activity.Recorder) TaskServiceOption {
+	return func(ts *TaskService) {
+		ts.activity = recorder
+	}
+}
+
+func NewTaskService(taskRepository TaskRepository, projectRepository project.ProjectRepository, opts ...TaskServiceOption) *TaskService {
+	ts := &TaskService{
 		repository: taskRepository,
 		projectDB:  projectRepository,
 		logger:     *internal.NewLogger("TaskService"),
+		commands:   newCommandLog(),
+		limits:     internal.DefaultLimits,
+	}
+	for _, opt := range opts {
+		opt(ts)
 	}
+
+	return ts
 }
 
 // ValidateTask checks if some conditions are true for a given task:
-// - The project it references must exist
-// - If there is a parent task, it must exist
+// - Its name must be valid, see internal.ValidateName
+// - The project it references must exist and must not be archived
+// - If there is a parent task, it must exist and belong to the same project, and the task must
+// stay within the limits of the tree, see validateTreeLimits
+//
+// The invalid fields of the task are all reported together, as internal.FieldErrors.
 func (ts TaskService) ValidateTask(task Task) error {
-	// Check if the project exists
-	_, err := ts.projectDB.Get(task.ProjectID)
+	err := ts.ensureProjectIsActive(task.ProjectID)
 	if err != nil {
-		return fmt.Errorf("Failed to fetch project %s from repository: %w", task.ProjectID, err)
+		return err
+	}
+
+	fieldErrs := internal.FieldErrors{}
+	if fieldErr := internal.ValidateName("name", task.Name, ts.limits.MaxNameLength); fieldErr != nil {
+		fieldErrs = append(fieldErrs, *fieldErr)
 	}
 	// Check if the task parent is valid
 	if task.ParentTaskID != nil {
@@ -41,8 +79,30 @@ func (ts TaskService) ValidateTask(task Task) error {
 		}
 
 		if parentTask.ProjectID != task.ProjectID {
-			return errors.New("task and parent task must belong to the same project")
+			return internal.NewValidationError("task and parent task must belong to the same project")
+		}
+
+		fieldErr, err := ts.validateTreeLimits(task, parentTask)
+		if err != nil {
+			return err
 		}
+		if fieldErr != nil {
+			fieldErrs = append(fieldErrs, *fieldErr)
+		}
+	}
+
+	return fieldErrs.Err()
+}
+
+// ensureProjectIsActive fails with project.ErrProjectArchived if the project is archived, since the
+// tasks of archived projects cannot be changed.
+func (ts TaskService) ensureProjectIsActive(projectID uuid.UUID) error {
+	proj, err := ts.projectDB.Get(projectID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch project %s from repository: %w", projectID, err)
+	}
+	if proj.IsArchived() {
+		return fmt.Errorf("Cannot change the tasks of project %s: %w", projectID, project.ErrProjectArchived)
 	}
 
 	return nil
@@ -52,11 +112,12 @@ func (ts TaskService) ValidateTask(task Task) error {
 //
 // The siblings of a task are the ones that are found in the same level of the task tree.
 // This means that it is a set of tasks that has the same parent task. If there is no parent task,
-// then they are the tasks in the same project with a nil parent task ID.
+// then they are the tasks in the same project and section with a nil parent task ID.
 func (ts TaskService) FetchTaskSiblings(task Task) ([]Task, error) {
 	siblings := []Task{}
 	if task.IsInProjectRoot() {
-		taskSiblings, err := ts.repository.GetTasksInProjectRoot(task.ProjectID)
+		// Each section orders its tasks on its own
+		taskSiblings, err := ts.repository.GetTasksInSection(task.ProjectID, task.SectionID)
 		if err != nil {
 			return nil, err
 		}
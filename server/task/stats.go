@@ -0,0 +1,142 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrInvalidBucket = internal.NewValidationError("invalid bucket")
+
+// The length of the buckets the period of project statistics is split into.
+type StatsBucket string
+
+const (
+	BucketDay  StatsBucket = "day"
+	BucketWeek StatsBucket = "week"
+)
+
+// The maximum number of buckets of project statistics, e.g. a year of days
+const maxStatsBuckets = 366
+
+// The number of oldest open tasks listed in project statistics
+const oldestOpenTasksCount = 10
+
+// A StatsPoint counts the tasks of a project in one bucket of the period of the statistics.
+type StatsPoint struct {
+	// Start of the bucket, inclusive
+	Start time.Time
+	// End of the bucket, exclusive
+	End time.Time
+	// Number of tasks created in the bucket
+	Created int
+	// Number of tasks completed in the bucket
+	Completed int
+	// Number of tasks open at the end of the bucket, for burndown charts
+	Open int
+}
+
+// ProjectStats tells how the work on a project went during a period. Tasks in the trash are left
+// out.
+type ProjectStats struct {
+	From   time.Time
+	To     time.Time
+	Bucket StatsBucket
+	// The tasks created, completed and open in each bucket of the period, in order
+	Series []StatsPoint
+	// Number of tasks completed during the period
+	Completed int
+	// Average time from the creation to the completion of the tasks completed during the period.
+	// It is nil if no task was completed
+	AverageCycleTime *time.Duration
+	// The tasks of the project that have been open for the longest time, oldest first
+	OldestOpenTasks []Task
+}
+
+// GetProjectStats computes the statistics of a project from "from" (inclusive) to "to"
+// (exclusive). The period is split into buckets of a day or a week, starting at "from": days are
+// counted in the time zone of "from", so that they start at the same hour even across daylight
+// saving time changes. The bucket defaults to a day.
+func (ts TaskService) GetProjectStats(projectID uuid.UUID, from time.Time, to time.Time, bucket StatsBucket) (ProjectStats, error) {
+	if bucket == "" {
+		bucket = BucketDay
+	}
+
+	starts, ends, err := statsBuckets(from, to, bucket)
+	if err != nil {
+		return ProjectStats{}, err
+	}
+
+	_, err = ts.projectDB.Get(projectID)
+	if err != nil {
+		return ProjectStats{}, err
+	}
+
+	series, err := ts.repository.GetStatsSeries(projectID, starts, ends)
+	if err != nil {
+		return ProjectStats{}, err
+	}
+
+	completed, averageCycleTime, err := ts.repository.GetCycleTime(projectID, from, to)
+	if err != nil {
+		return ProjectStats{}, err
+	}
+
+	oldestOpenTasks, err := ts.repository.ListOldestOpen(projectID, oldestOpenTasksCount)
+	if err != nil {
+		return ProjectStats{}, err
+	}
+
+	stats := ProjectStats{
+		From:            from,
+		To:              to,
+		Bucket:          bucket,
+		Series:          series,
+		Completed:       completed,
+		OldestOpenTasks: oldestOpenTasks,
+	}
+	if completed > 0 {
+		stats.AverageCycleTime = &averageCycleTime
+	}
+
+	return stats, nil
+}
+
+// statsBuckets splits a period into buckets, the last one ending at the end of the period.
+func statsBuckets(from time.Time, to time.Time, bucket StatsBucket) ([]time.Time, []time.Time, error) {
+	days := 0
+	switch bucket {
+	case BucketDay:
+		days = 1
+	case BucketWeek:
+		days = 7
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidBucket, bucket)
+	}
+
+	if !from.Before(to) {
+		return nil, nil, internal.FieldErrors{{Field: "to", Message: "must be after from"}}
+	}
+
+	starts := []time.Time{}
+	ends := []time.Time{}
+	for start := from; start.Before(to); start = start.AddDate(0, 0, days) {
+		if len(starts) == maxStatsBuckets {
+			return nil, nil, internal.FieldErrors{{
+				Field:   "to",
+				Message: fmt.Sprintf("the period must not have more than %d buckets", maxStatsBuckets),
+			}}
+		}
+
+		end := start.AddDate(0, 0, days)
+		if end.After(to) {
+			end = to
+		}
+		starts = append(starts, start)
+		ends = append(ends, end)
+	}
+
+	return starts, ends, nil
+}
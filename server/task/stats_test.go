@@ -0,0 +1,169 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *StatsTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *StatsTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+// insertTask inserts a task created at createdAt and, unless completedAt is nil, completed at
+// completedAt.
+func (suite *StatsTestSuite) insertTask(name string, createdAt time.Time, completedAt *time.Time) Task {
+	t := suite.T()
+
+	rootTasks, err := suite.taskService.repository.GetTasksInProjectRoot(suite.projectID)
+	require.NoError(t, err)
+
+	task := NewTask(name, suite.projectID, nil)
+	task.Order = len(rootTasks)
+	task.CreatedAt = createdAt
+	task.UpdatedAt = createdAt
+	require.NoError(t, suite.taskService.repository.Create(task))
+
+	if completedAt != nil {
+		err = suite.taskService.repository.UpdateTaskStatus(task.ID, TaskStatusCompleted, completedAt)
+		require.NoError(t, err)
+	}
+
+	return task
+}
+
+func (suite *StatsTestSuite) TestGetProjectStats() {
+	t := suite.T()
+
+	day := func(d int, hour int) time.Time {
+		return time.Date(2026, time.October, d, hour, 0, 0, 0, time.UTC)
+	}
+	at := func(d int, hour int) *time.Time {
+		t := day(d, hour)
+		return &t
+	}
+
+	oldest := suite.insertTask("Open before the period", day(1, 12), nil)
+	suite.insertTask("Completed in the first day", day(1, 12), at(2, 12))
+	suite.insertTask("Completed in the second day", day(2, 1), at(3, 7))
+	openTask := suite.insertTask("Open", day(3, 1), nil)
+	suite.insertTask("Created after the period", day(5, 1), at(5, 2))
+
+	from := day(2, 0)
+	to := day(4, 12)
+	stats, err := suite.taskService.GetProjectStats(suite.projectID, from, to, BucketDay)
+	require.NoError(t, err)
+
+	assert.Equal(t, BucketDay, stats.Bucket)
+	require.Len(t, stats.Series, 3)
+	assert.Equal(t, StatsPoint{Start: day(2, 0), End: day(3, 0), Created: 1, Completed: 1, Open: 2}, stats.Series[0])
+	assert.Equal(t, StatsPoint{Start: day(3, 0), End: day(4, 0), Created: 1, Completed: 1, Open: 2}, stats.Series[1])
+	// The last bucket ends with the period
+	assert.Equal(t, StatsPoint{Start: day(4, 0), End: day(4, 12), Created: 0, Completed: 0, Open: 2}, stats.Series[2])
+
+	// The tasks completed in the period took 24 and 30 hours
+	assert.Equal(t, 2, stats.Completed)
+	if assert.NotNil(t, stats.AverageCycleTime) {
+		assert.Equal(t, 27*time.Hour, *stats.AverageCycleTime)
+	}
+
+	require.Len(t, stats.OldestOpenTasks, 2)
+	assert.Equal(t, oldest.ID, stats.OldestOpenTasks[0].ID)
+	assert.Equal(t, openTask.ID, stats.OldestOpenTasks[1].ID)
+}
+
+func (suite *StatsTestSuite) TestGetProjectStats_NothingCompleted() {
+	t := suite.T()
+
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := suite.taskService.GetProjectStats(suite.projectID, from, from.AddDate(0, 0, 14), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, BucketDay, stats.Bucket)
+	assert.Len(t, stats.Series, 14)
+	assert.Zero(t, stats.Completed)
+	assert.Nil(t, stats.AverageCycleTime)
+	assert.Empty(t, stats.OldestOpenTasks)
+}
+
+func (suite *StatsTestSuite) TestGetProjectStats_ProjectDoesNotExist() {
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	_, err := suite.taskService.GetProjectStats(uuid.New(), from, from.AddDate(0, 0, 1), BucketDay)
+	assert.ErrorIs(suite.T(), err, internal.ErrNotFound)
+}
+
+func TestStats(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
+
+func TestStatsBuckets(t *testing.T) {
+	// Days start at midnight in the time zone of the period, across daylight saving time changes
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	from := time.Date(2026, time.October, 24, 0, 0, 0, 0, berlin)
+	starts, ends, err := statsBuckets(from, from.AddDate(0, 0, 3), BucketDay)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{from, from.AddDate(0, 0, 1), from.AddDate(0, 0, 2)}, starts)
+	assert.Equal(t, 25*time.Hour, ends[1].Sub(starts[1]))
+	assert.Equal(t, 0, ends[1].Hour())
+
+	// The last week is cut short at the end of the period
+	from = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	starts, ends, err = statsBuckets(from, from.AddDate(0, 0, 10), BucketWeek)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{from, from.AddDate(0, 0, 7)}, starts)
+	assert.Equal(t, []time.Time{from.AddDate(0, 0, 7), from.AddDate(0, 0, 10)}, ends)
+
+	_, _, err = statsBuckets(from, from.AddDate(1, 1, 0), BucketDay)
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, _, err = statsBuckets(from, from, BucketDay)
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, _, err = statsBuckets(from, from.AddDate(0, 0, 1), "month")
+	assert.ErrorIs(t, err, ErrInvalidBucket)
+}
@@ -29,6 +29,29 @@ type Task struct {
 	ID uuid.UUID
 	// The position of the task relative to its siblings. It starts from 1 (first), 0 means "unset".
 	Order int
+	// When the task is due. Tasks without a due date have a nil DueAt
+	DueAt *time.Time
+	// When the task was moved to the trash. It is nil for tasks that are not in the trash
+	DeletedAt *time.Time
+	// Time of the last change of the task, set by the repository
+	UpdatedAt time.Time
+	// When the task was completed. It is nil for pending tasks
+	CompletedAt *time.Time
+	// How much work the task takes, in the estimate unit of its project. It is nil for tasks that
+	// were not estimated. The estimate of a task with subtasks overrides theirs, see Progress
+	Estimate *int
+	// The iteration the task is planned in, nil if it is not planned. Tasks are planned one by
+	// one, regardless of their parent task and subtasks
+	IterationID *uuid.UUID
+	// The section of its project the task is in, nil if it is in none. Only root tasks are in
+	// sections, and each section orders its tasks on its own
+	SectionID *uuid.UUID
+	// Incremented by the repository on every change of the task, so that concurrent changes can be
+	// detected
+	Version int
+	// How much of the subtree of the task is done. It is only set by FetchTaskTree and
+	// FetchTaskWithProgress
+	Progress *Progress
 }
 
 func (t Task) String() string {
@@ -56,7 +79,9 @@ func NewTask(name string, projectID uuid.UUID, parentTaskID *uuid.UUID) Task {
 		ParentTaskID: parentTaskID,
 		Order:        0, // 0 means the order is unset
 		CreatedAt:    now,
+		UpdatedAt:    now,
 		Subtasks:     []Task{},
+		Version:      1,
 	}
 
 	return task
@@ -105,5 +130,7 @@ func (t Task) LogValue() slog.Value {
 		slog.Int("Order", t.Order),
 		slog.Any("SubtaskIDs", subtaskIDs),
 		slog.Any("ParentTaskID", t.ParentTaskID),
+		slog.Any("DueAt", t.DueAt),
+		slog.Any("DeletedAt", t.DeletedAt),
 	)
 }
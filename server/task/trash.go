@@ -0,0 +1,84 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrParentInTrash = internal.NewConflictError("the parent task or the project of the task is in the trash")
+
+// ListDeletedTasks returns the tasks in the trash, most recently deleted first. Subtasks deleted
+// together with their parent task and tasks deleted together with their project are not listed:
+// they are restored along with them.
+func (ts *TaskService) ListDeletedTasks() ([]Task, error) {
+	return ts.repository.ListDeleted()
+}
+
+// RestoreTask takes a task out of the trash, along with the subtasks that were deleted with it.
+// The task goes back to its original position among its siblings, or to the end of the list if
+// its siblings were deleted in the meantime.
+//
+// A task cannot be restored while its parent task or its project is in the trash.
+func (ts *TaskService) RestoreTask(id uuid.UUID) (Task, error) {
+	task, err := ts.repository.GetDeleted(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	err = ts.ValidateTask(task)
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			return Task{}, fmt.Errorf("Could not restore task %s: %w", id, ErrParentInTrash)
+		}
+
+		return Task{}, err
+	}
+
+	siblings, err := ts.FetchTaskSiblings(task)
+	if err != nil {
+		return Task{}, fmt.Errorf("Failed to fetch siblings for task %s: %w", id, err)
+	}
+	if task.Order > len(siblings) {
+		task.Order = len(siblings)
+	}
+
+	// Make room for the task. Siblings are updated in descending order, so that each one moves
+	// into a free position.
+	slices.SortFunc(siblings, func(a, b Task) int { return cmpTasks(b, a) })
+	siblingsAfterTask := []Task{}
+	for _, s := range siblings {
+		if s.Order >= task.Order {
+			s.Order += 1
+			siblingsAfterTask = append(siblingsAfterTask, s)
+		}
+	}
+	if len(siblingsAfterTask) > 0 {
+		err = ts.repository.BatchUpdateOrder(siblingsAfterTask)
+		if err != nil {
+			return Task{}, fmt.Errorf("Failed to update siblings of task %s: %w", id, err)
+		}
+	}
+
+	err = ts.repository.Restore(task)
+	if err != nil {
+		ts.logger.Error("failed to restore task", slog.String("taskID", id.String()), slog.String("err", err.Error()))
+		return Task{}, err
+	}
+
+	task.DeletedAt = nil
+	ts.record(task, activity.ActionRestored, nil, taskSnapshot(task))
+	return task, nil
+}
+
+// PurgeDeletedTasks permanently deletes the tasks that were moved to the trash before the given
+// time. Returns the number of purged tasks.
+func (ts *TaskService) PurgeDeletedTasks(deletedBefore time.Time) (int64, error) {
+	return ts.repository.Purge(deletedBefore)
+}
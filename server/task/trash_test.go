@@ -0,0 +1,211 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrashTestSuite struct {
+	suite.Suite
+	ctx            context.Context
+	pgContainer    *testhelpers.PostgresContainer
+	taskService    *TaskService
+	projectService *project.ProjectService
+	projectID      uuid.UUID
+}
+
+func (suite *TrashTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+	suite.projectService = project.NewProjectService(projectRepository, nil)
+}
+
+// Setup database before each test
+func (suite *TrashTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *TrashTestSuite) TestDeletedTaskIsListed() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Subtask", suite.projectID, &task.ID)
+	require.NoError(t, err)
+
+	deletedTask, err := suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, deletedTask.DeletedAt)
+
+	// The subtask was deleted together with its parent, so only the parent is listed
+	deletedTasks, err := suite.taskService.ListDeletedTasks()
+	require.NoError(t, err)
+	require.Len(t, deletedTasks, 1)
+	assert.Equal(t, task.ID, deletedTasks[0].ID)
+}
+
+func (suite *TrashTestSuite) TestRestoreToOriginalPosition() {
+	t := suite.T()
+
+	firstTask, err := suite.taskService.CreateTask("First task", suite.projectID, nil)
+	require.NoError(t, err)
+	secondTask, err := suite.taskService.CreateTask("Second task", suite.projectID, nil)
+	require.NoError(t, err)
+	thirdTask, err := suite.taskService.CreateTask("Third task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.DeleteTask(secondTask.ID)
+	require.NoError(t, err)
+
+	restoredTask, err := suite.taskService.RestoreTask(secondTask.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restoredTask.DeletedAt)
+
+	for expectedOrder, id := range []uuid.UUID{firstTask.ID, secondTask.ID, thirdTask.ID} {
+		task, err := suite.taskService.FindTaskByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, expectedOrder, task.Order)
+	}
+
+	deletedTasks, err := suite.taskService.ListDeletedTasks()
+	require.NoError(t, err)
+	assert.Empty(t, deletedTasks)
+}
+
+func (suite *TrashTestSuite) TestRestoreAlsoRestoresSubtasks() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &task.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Subsubtask", suite.projectID, &subtask.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RestoreTask(task.ID)
+	require.NoError(t, err)
+
+	subtasks, err := suite.taskService.FetchSubtasksDeep(task.ID)
+	require.NoError(t, err)
+	assert.Len(t, subtasks, 2)
+}
+
+func (suite *TrashTestSuite) TestSubtaskDeletedOnItsOwnStaysInTrash() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	subtask, err := suite.taskService.CreateTask("Subtask", suite.projectID, &task.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.DeleteTask(subtask.ID)
+	require.NoError(t, err)
+	_, err = suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	// The subtask cannot come back before its parent
+	_, err = suite.taskService.RestoreTask(subtask.ID)
+	assert.ErrorIs(t, err, ErrParentInTrash)
+
+	_, err = suite.taskService.RestoreTask(task.ID)
+	require.NoError(t, err)
+
+	subtasks, err := suite.taskService.FetchSubtasksDirect(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, subtasks)
+
+	_, err = suite.taskService.RestoreTask(subtask.ID)
+	require.NoError(t, err)
+}
+
+func (suite *TrashTestSuite) TestRestoreTaskNotInTrash() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RestoreTask(task.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *TrashTestSuite) TestProjectTasksAreRestoredWithTheProject() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.projectService.DeleteProject(suite.projectID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.FindTaskByID(task.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	// Tasks deleted together with their project are not listed on their own
+	deletedTasks, err := suite.taskService.ListDeletedTasks()
+	require.NoError(t, err)
+	assert.Empty(t, deletedTasks)
+
+	_, err = suite.projectService.RestoreProject(suite.projectID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.FindTaskByID(task.ID)
+	assert.NoError(t, err)
+}
+
+func (suite *TrashTestSuite) TestPurge() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	purged, err := suite.taskService.PurgeDeletedTasks(time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	purged, err = suite.taskService.PurgeDeletedTasks(time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = suite.taskService.RestoreTask(task.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func TestTrash(t *testing.T) {
+	suite.Run(t, new(TrashTestSuite))
+}
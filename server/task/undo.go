@@ -0,0 +1,362 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var (
+	ErrMissingSession = internal.NewValidationError("operations can only be undone and redone within a session")
+	ErrNothingToUndo  = internal.NewConflictError("there is nothing to undo")
+	ErrNothingToRedo  = internal.NewConflictError("there is nothing to redo")
+)
+
+const (
+	// How many operations of a session can be undone
+	maxUndoSteps = 50
+	// How long the operations of an inactive session are kept
+	sessionTimeout = 24 * time.Hour
+)
+
+// UndoStep is an operation that was undone or redone, along with the tasks it changed.
+type UndoStep struct {
+	Action activity.Action
+	Tasks  []Task
+}
+
+// A command is a change made by the service that can be reverted and made again. undo and redo
+// are given a copy of the service that does not log the changes they make as new commands.
+type command struct {
+	action activity.Action
+	// The task the change was made to, followed by the tasks it was cascaded to
+	taskIDs []uuid.UUID
+	// The operation the command was made by, see WithOperation
+	operation string
+	undo      func(ts *TaskService) error
+	redo      func(ts *TaskService) error
+}
+
+// A step is what gets undone and redone at once: the commands logged by a single operation, in the
+// order they were made.
+type step []command
+
+type sessionLog struct {
+	undo     []step
+	redo     []step
+	lastUsed time.Time
+}
+
+// commandLog keeps the operations of each client session in memory, so that they can be undone
+// and redone. Logging a new operation discards the operations that were undone before it.
+type commandLog struct {
+	mu       sync.Mutex
+	sessions map[string]*sessionLog
+}
+
+func newCommandLog() *commandLog {
+	return &commandLog{sessions: map[string]*sessionLog{}}
+}
+
+// Returns the log of the session, forgetting the sessions that have been inactive for too long.
+// Must be called with the lock held.
+func (cl *commandLog) session(session string) *sessionLog {
+	now := time.Now()
+	for id, s := range cl.sessions {
+		if now.Sub(s.lastUsed) > sessionTimeout {
+			delete(cl.sessions, id)
+		}
+	}
+
+	s, ok := cl.sessions[session]
+	if !ok {
+		s = &sessionLog{}
+		cl.sessions[session] = s
+	}
+	s.lastUsed = now
+
+	return s
+}
+
+// push logs a command made by the session. Commands made by the same operation are grouped in the
+// same step.
+func (cl *commandLog) push(session string, cmd command) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	s := cl.session(session)
+	s.redo = nil
+
+	last := len(s.undo) - 1
+	if last >= 0 && cmd.operation != "" && s.undo[last][0].operation == cmd.operation {
+		s.undo[last] = append(s.undo[last], cmd)
+		return
+	}
+
+	s.undo = append(s.undo, step{cmd})
+	if len(s.undo) > maxUndoSteps {
+		s.undo = s.undo[len(s.undo)-maxUndoSteps:]
+	}
+}
+
+// pushStep logs the commands made together, e.g. by a batch, as a single step.
+func (cl *commandLog) pushStep(session string, st step) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	s := cl.session(session)
+	s.redo = nil
+	s.undo = append(s.undo, st)
+	if len(s.undo) > maxUndoSteps {
+		s.undo = s.undo[len(s.undo)-maxUndoSteps:]
+	}
+}
+
+func (cl *commandLog) popUndo(session string) (step, bool) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	s := cl.session(session)
+	if len(s.undo) == 0 {
+		return nil, false
+	}
+
+	last := s.undo[len(s.undo)-1]
+	s.undo = s.undo[:len(s.undo)-1]
+	return last, true
+}
+
+func (cl *commandLog) popRedo(session string) (step, bool) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	s := cl.session(session)
+	if len(s.redo) == 0 {
+		return nil, false
+	}
+
+	last := s.redo[len(s.redo)-1]
+	s.redo = s.redo[:len(s.redo)-1]
+	return last, true
+}
+
+// pushRedo puts back a step that can be redone, e.g. once it was undone. Unlike push, it keeps
+// the steps that were undone.
+func (cl *commandLog) pushRedo(session string, st step) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	s := cl.session(session)
+	s.redo = append(s.redo, st)
+}
+
+// pushUndo puts back a step that can be undone, e.g. once it was redone. Unlike push, it keeps the
+// steps that were undone.
+func (cl *commandLog) pushUndo(session string, st step) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	s := cl.session(session)
+	s.undo = append(s.undo, st)
+}
+
+// WithSession returns a copy of the service that logs its operations under the given client
+// session, so that they can be undone with Undo and redone with Redo.
+func (ts TaskService) WithSession(session string) *TaskService {
+	ts.session = session
+	return &ts
+}
+
+// WithOperation returns a copy of the service whose operations are undone and redone together
+// with the other operations logged under the same ID, e.g. the ones made by the same request. The
+// ID must be generated by the server, since grouping operations under an ID chosen by a client
+// would merge unrelated operations.
+func (ts TaskService) WithOperation(operation string) *TaskService {
+	ts.operation = operation
+	return &ts
+}
+
+// logCommand logs an operation of the session, if there is one.
+func (ts *TaskService) logCommand(cmd command) {
+	if ts.session == "" {
+		return
+	}
+
+	cmd.operation = ts.operation
+	if ts.pending != nil {
+		ts.pending.commands = append(ts.pending.commands, cmd)
+		return
+	}
+
+	ts.commands.push(ts.session, cmd)
+}
+
+// Undo reverts the last operation of the session that was not undone yet. The commands of the
+// operation are reverted all at once: if one of them can no longer be reverted, e.g. because the
+// task was deleted in the meantime, none is, the operation stays in the log and the error is
+// returned.
+func (ts *TaskService) Undo() (UndoStep, error) {
+	if ts.session == "" {
+		return UndoStep{}, ErrMissingSession
+	}
+
+	st, ok := ts.commands.popUndo(ts.session)
+	if !ok {
+		return UndoStep{}, ErrNothingToUndo
+	}
+
+	err := ts.inTransaction(func(txService *TaskService) error {
+		replay := txService.WithSession("")
+		for i := len(st) - 1; i >= 0; i-- {
+			err := st[i].undo(replay)
+			if err != nil {
+				return fmt.Errorf("Could not undo the %s of task %s, nothing was undone: %w", st[i].action, st[i].taskIDs[0], err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		ts.commands.pushUndo(ts.session, st)
+		return UndoStep{}, err
+	}
+
+	ts.commands.pushRedo(ts.session, st)
+	return ts.undoStep(st)
+}
+
+// Redo makes again the last operation of the session that was undone. Like with Undo, the commands
+// of the operation are made all at once or not at all, in which case the operation stays in the
+// log and the error is returned.
+func (ts *TaskService) Redo() (UndoStep, error) {
+	if ts.session == "" {
+		return UndoStep{}, ErrMissingSession
+	}
+
+	st, ok := ts.commands.popRedo(ts.session)
+	if !ok {
+		return UndoStep{}, ErrNothingToRedo
+	}
+
+	err := ts.inTransaction(func(txService *TaskService) error {
+		replay := txService.WithSession("")
+		for _, cmd := range st {
+			err := cmd.redo(replay)
+			if err != nil {
+				return fmt.Errorf("Could not redo the %s of task %s, nothing was redone: %w", cmd.action, cmd.taskIDs[0], err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		ts.commands.pushRedo(ts.session, st)
+		return UndoStep{}, err
+	}
+
+	ts.commands.pushUndo(ts.session, st)
+	return ts.undoStep(st)
+}
+
+// Describes a step with the current state of the tasks it changed, including the ones it moved to
+// the trash.
+func (ts *TaskService) undoStep(st step) (UndoStep, error) {
+	result := UndoStep{Action: st[0].action, Tasks: []Task{}}
+	seen := map[uuid.UUID]bool{}
+	for _, cmd := range st {
+		for _, id := range cmd.taskIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			task, err := ts.repository.Get(id)
+			if errors.Is(err, internal.ErrNotFound) {
+				task, err = ts.repository.GetDeleted(id)
+			}
+			if err != nil {
+				return UndoStep{}, err
+			}
+
+			result.Tasks = append(result.Tasks, task)
+		}
+	}
+
+	return result, nil
+}
+
+type statusChange struct {
+	taskID uuid.UUID
+	before TaskStatus
+	after  TaskStatus
+	// When the task was completed before and after the change, nil while it was pending. They are
+	// restored as they were, so that undoing and redoing does not change when tasks were completed
+	completedBefore *time.Time
+	completedAfter  *time.Time
+}
+
+// trackStatusChanges returns a copy of the service that collects the status changes it makes,
+// including the ones cascaded to the parent task or to the subtasks, so that they can be undone.
+func (ts TaskService) trackStatusChanges() (*TaskService, *[]statusChange) {
+	changes := &[]statusChange{}
+	ts.statusChanges = changes
+	return &ts, changes
+}
+
+// setStatuses sets the tasks back to the statuses they had before the changes, or, when redoing,
+// to the statuses they had after. Unlike UpdateTaskStatus, the status of each task is set as is,
+// without being cascaded.
+func (ts *TaskService) setStatuses(changes []statusChange, redo bool) error {
+	// A task may have changed more than once, so the changes are undone from the last one
+	for i := range changes {
+		change := changes[len(changes)-1-i]
+		status, completedAt := change.before, change.completedBefore
+		if redo {
+			change = changes[i]
+			status, completedAt = change.after, change.completedAfter
+		}
+
+		task, err := ts.repository.Get(change.taskID)
+		if err != nil {
+			return err
+		}
+		if task.Status == status {
+			continue
+		}
+
+		err = ts.ensureProjectIsActive(task.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		err = ts.repository.UpdateTaskStatus(task.ID, status, completedAt)
+		if err != nil {
+			return err
+		}
+
+		previousStatus, previousCompletedAt := task.Status, task.CompletedAt
+		task.Status = status
+		task.CompletedAt = completedAt
+		ts.recordStatusChange(task, previousStatus, previousCompletedAt)
+	}
+
+	return nil
+}
+
+// The IDs of the tasks whose status changed, starting with the task the operation was made to.
+func changedTaskIDs(taskID uuid.UUID, changes []statusChange) []uuid.UUID {
+	ids := []uuid.UUID{taskID}
+	for _, change := range changes {
+		if !slices.Contains(ids, change.taskID) {
+			ids = append(ids, change.taskID)
+		}
+	}
+
+	return ids
+}
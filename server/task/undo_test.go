@@ -0,0 +1,321 @@
+package task
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UndoTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *UndoTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository).WithSession("test session")
+}
+
+// Setup database before each test
+func (suite *UndoTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *UndoTestSuite) TestUndoAndRedoRename() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+
+	step, err := suite.taskService.Undo()
+	require.NoError(t, err)
+	assert.Equal(t, activity.ActionRenamed, step.Action)
+	require.Len(t, step.Tasks, 1)
+	assert.Equal(t, "Test task", step.Tasks[0].Name)
+
+	step, err = suite.taskService.Redo()
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed task", step.Tasks[0].Name)
+
+	_, err = suite.taskService.Redo()
+	assert.ErrorIs(t, err, ErrNothingToRedo)
+}
+
+func (suite *UndoTestSuite) TestUndoStatusChangeRestoresCascadedStatuses() {
+	t := suite.T()
+
+	parentTask, err := suite.taskService.CreateTask("Parent task", suite.projectID, nil)
+	require.NoError(t, err)
+	completedSubtask, err := suite.taskService.CreateTask("Completed subtask", suite.projectID, &parentTask.ID)
+	require.NoError(t, err)
+	pendingSubtask, err := suite.taskService.CreateTask("Pending subtask", suite.projectID, &parentTask.ID)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(completedSubtask.ID, TaskStatusCompleted.String()))
+
+	// Completes the pending subtask too
+	require.NoError(t, suite.taskService.UpdateTaskStatus(parentTask.ID, TaskStatusCompleted.String()))
+
+	step, err := suite.taskService.Undo()
+	require.NoError(t, err)
+	assert.Equal(t, activity.ActionStatusChanged, step.Action)
+	assert.Len(t, step.Tasks, 2)
+
+	// Undoing does not mark the completed subtask as pending, as marking the parent task as
+	// pending would
+	expectedStatuses := map[uuid.UUID]TaskStatus{
+		parentTask.ID:       TaskStatusPending,
+		completedSubtask.ID: TaskStatusCompleted,
+		pendingSubtask.ID:   TaskStatusPending,
+	}
+	for id, status := range expectedStatuses {
+		task, err := suite.taskService.FindTaskByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, status, task.Status, task.Name)
+	}
+
+	_, err = suite.taskService.Redo()
+	require.NoError(t, err)
+	for id := range expectedStatuses {
+		task, err := suite.taskService.FindTaskByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, TaskStatusCompleted, task.Status, task.Name)
+	}
+}
+
+func (suite *UndoTestSuite) TestUndoStatusChangeKeepsCompletionTime() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(task.ID, TaskStatusCompleted.String()))
+	completedTask, err := suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, completedTask.CompletedAt)
+
+	require.NoError(t, suite.taskService.UpdateTaskStatus(task.ID, TaskStatusPending.String()))
+	time.Sleep(10 * time.Millisecond)
+
+	// The task is completed again at the time it was first completed, not at the time of the undo
+	_, err = suite.taskService.Undo()
+	require.NoError(t, err)
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, task.CompletedAt)
+	assert.True(t, completedTask.CompletedAt.Equal(*task.CompletedAt))
+
+	_, err = suite.taskService.Redo()
+	require.NoError(t, err)
+	_, err = suite.taskService.Undo()
+	require.NoError(t, err)
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, task.CompletedAt)
+	assert.True(t, completedTask.CompletedAt.Equal(*task.CompletedAt))
+}
+
+func (suite *UndoTestSuite) TestUndoReorder() {
+	t := suite.T()
+
+	firstTask, err := suite.taskService.CreateTask("First task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Second task", suite.projectID, nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.ReorderTask(firstTask, 1))
+
+	_, err = suite.taskService.Undo()
+	require.NoError(t, err)
+
+	firstTask, err = suite.taskService.FindTaskByID(firstTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, firstTask.Order)
+}
+
+func (suite *UndoTestSuite) TestUndoDeleteRestoresFromTrash() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.Undo()
+	require.NoError(t, err)
+	_, err = suite.taskService.FindTaskByID(task.ID)
+	assert.NoError(t, err)
+
+	step, err := suite.taskService.Redo()
+	require.NoError(t, err)
+	assert.NotNil(t, step.Tasks[0].DeletedAt)
+}
+
+func (suite *UndoTestSuite) TestUndoMove() {
+	t := suite.T()
+
+	completedTask, err := suite.taskService.CreateTask("Completed task", suite.projectID, nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(completedTask.ID, TaskStatusCompleted.String()))
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.CreateTask("Last task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	// Marks the completed task as pending
+	_, err = suite.taskService.MoveTask(task.ID, &completedTask.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.Undo()
+	require.NoError(t, err)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, task.ParentTaskID)
+	assert.Equal(t, 1, task.Order)
+	completedTask, err = suite.taskService.FindTaskByID(completedTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCompleted, completedTask.Status)
+}
+
+func (suite *UndoTestSuite) TestNewOperationDiscardsUndoneOperations() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+	_, err = suite.taskService.Undo()
+	require.NoError(t, err)
+
+	_, err = suite.taskService.RenameTask(task.ID, "Another name")
+	require.NoError(t, err)
+	_, err = suite.taskService.Redo()
+	assert.ErrorIs(t, err, ErrNothingToRedo)
+}
+
+func (suite *UndoTestSuite) TestSessionsAreSeparate() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+
+	_, err = suite.taskService.WithSession("other session").Undo()
+	assert.ErrorIs(t, err, ErrNothingToUndo)
+
+	_, err = suite.taskService.WithSession("").Undo()
+	assert.ErrorIs(t, err, ErrMissingSession)
+}
+
+func (suite *UndoTestSuite) TestUndoFailsPartway() {
+	t := suite.T()
+
+	first, err := suite.taskService.CreateTask("First task", suite.projectID, nil)
+	require.NoError(t, err)
+	second, err := suite.taskService.CreateTask("Second task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RunBatch([]BatchOperation{
+		{Kind: BatchRename, TaskRef: first.ID.String(), Name: "Renamed first task"},
+		{Kind: BatchRename, TaskRef: second.ID.String(), Name: "Renamed second task"},
+	})
+	require.NoError(t, err)
+	_, err = suite.taskService.WithSession("other session").DeleteTask(first.ID)
+	require.NoError(t, err)
+
+	// The second rename is undone first, but is rolled back once the first one fails
+	_, err = suite.taskService.Undo()
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	second, err = suite.taskService.FindTaskByID(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed second task", second.Name)
+}
+
+func (suite *UndoTestSuite) TestUndoFailsIfTaskWasDeleted() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("Test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed task")
+	require.NoError(t, err)
+	_, err = suite.taskService.WithSession("other session").DeleteTask(task.ID)
+	require.NoError(t, err)
+
+	_, err = suite.taskService.Undo()
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	// The rename stays in the log, and the task is left as it was
+	_, err = suite.taskService.Undo()
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func TestUndo(t *testing.T) {
+	suite.Run(t, new(UndoTestSuite))
+}
+
+func TestCommandLog(t *testing.T) {
+	cl := newCommandLog()
+	taskID := uuid.New()
+	renamed := command{action: activity.ActionRenamed, taskIDs: []uuid.UUID{taskID}}
+	reordered := command{action: activity.ActionReordered, taskIDs: []uuid.UUID{taskID}}
+
+	// Commands of the same operation are undone together
+	renamed.operation = "first operation"
+	reordered.operation = "first operation"
+	cl.push("session", renamed)
+	cl.push("session", reordered)
+
+	st, ok := cl.popUndo("session")
+	require.True(t, ok)
+	assert.Len(t, st, 2)
+	_, ok = cl.popUndo("session")
+	assert.False(t, ok)
+
+	// Commands without an operation ID are not grouped
+	renamed.operation = ""
+	for range maxUndoSteps + 1 {
+		cl.push("session", renamed)
+	}
+	for range maxUndoSteps {
+		st, ok = cl.popUndo("session")
+		require.True(t, ok)
+		assert.Len(t, st, 1)
+	}
+	_, ok = cl.popUndo("session")
+	assert.False(t, ok, "only the last steps are kept")
+}
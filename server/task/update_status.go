@@ -3,8 +3,11 @@ package task
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
 )
 
 func (ts *TaskService) UpdateTaskStatus(id uuid.UUID, status string) error {
@@ -13,15 +16,38 @@ func (ts *TaskService) UpdateTaskStatus(id uuid.UUID, status string) error {
 		return err
 	}
 
+	err = ts.ensureProjectIsActive(task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	tracked, changes := ts.trackStatusChanges()
 	switch status {
 	case TaskStatusPending.value:
-		return ts.markTaskAsPending(task)
+		err = tracked.markTaskAsPending(task)
 
 	case TaskStatusCompleted.value:
-		return ts.markTaskAsCompleted(task)
+		err = tracked.markTaskAsCompleted(task)
+
+	default:
+		return internal.NewValidationError(fmt.Sprintf("invalid task status: %s", status))
+	}
+	if err != nil {
+		return err
 	}
 
-	return fmt.Errorf("invalid task status: %s", status)
+	if len(*changes) > 0 {
+		// Undoing sets every task back to the exact status it had, rather than cascading the
+		// opposite change
+		ts.logCommand(command{
+			action:  activity.ActionStatusChanged,
+			taskIDs: changedTaskIDs(id, *changes),
+			undo:    func(ts *TaskService) error { return ts.setStatuses(*changes, false) },
+			redo:    func(ts *TaskService) error { return ts.setStatuses(*changes, true) },
+		})
+	}
+
+	return nil
 }
 
 // markTaskAsPending either does nothing (if the task is already marked "Todo") or updates the
@@ -33,11 +59,14 @@ func (ts *TaskService) markTaskAsPending(task Task) error {
 		return nil
 	}
 
+	previousCompletedAt := task.CompletedAt
 	task.Status = TaskStatusPending
-	err := ts.repository.UpdateTaskStatus(task.ID, TaskStatusPending)
+	task.CompletedAt = nil
+	err := ts.repository.UpdateTaskStatus(task.ID, TaskStatusPending, nil)
 	if err != nil {
 		return err
 	}
+	ts.recordStatusChange(task, TaskStatusCompleted, previousCompletedAt)
 
 	if task.ParentTaskID == nil {
 		return nil
@@ -81,14 +110,45 @@ func (ts *TaskService) markTaskAsCompleted(task Task) error {
 
 func (ts *TaskService) completeTask(task Task) error {
 	ts.logger.Debug("marked task as completed", slog.String("taskID", task.ID.String()))
-	err := ts.repository.UpdateTaskStatus(task.ID, TaskStatusCompleted)
+	// Already completed tasks are left untouched by the repository, so they keep their completion
+	// time
+	completedAt := time.Now().UTC()
+	err := ts.repository.UpdateTaskStatus(task.ID, TaskStatusCompleted, &completedAt)
 	if err != nil {
 		return err
 	}
 
+	if task.Status != TaskStatusCompleted {
+		previousStatus, previousCompletedAt := task.Status, task.CompletedAt
+		task.Status = TaskStatusCompleted
+		task.CompletedAt = &completedAt
+		ts.recordStatusChange(task, previousStatus, previousCompletedAt)
+	}
+
 	return nil
 }
 
+// Records the status change of a task, which may have been cascaded from its parent task or from
+// one of its subtasks.
+func (ts *TaskService) recordStatusChange(task Task, previousStatus TaskStatus, previousCompletedAt *time.Time) {
+	if ts.statusChanges != nil {
+		*ts.statusChanges = append(*ts.statusChanges, statusChange{
+			taskID:          task.ID,
+			before:          previousStatus,
+			after:           task.Status,
+			completedBefore: previousCompletedAt,
+			completedAfter:  task.CompletedAt,
+		})
+	}
+
+	ts.record(
+		task,
+		activity.ActionStatusChanged,
+		map[string]any{"status": previousStatus.String()},
+		map[string]any{"status": task.Status.String()},
+	)
+}
+
 func (ts *TaskService) completeSubtasks(task Task) error {
 	subtasks, err := ts.repository.GetSubtasksDeep(task.ID)
 	if err != nil {
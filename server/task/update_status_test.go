@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -67,6 +68,41 @@ func (suite *UpdateTaskStatusTestSuite) TestCompleteWithoutSubtasks() {
 	}
 }
 
+func (suite *UpdateTaskStatusTestSuite) TestCompletedAt() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("First task", suite.projectID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, task.CompletedAt)
+
+	before := time.Now()
+	err = suite.taskService.UpdateTaskStatus(task.ID, TaskStatusCompleted.value)
+	require.NoError(t, err)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, task.CompletedAt)
+	assert.WithinDuration(t, before, *task.CompletedAt, time.Minute)
+	completedAt := *task.CompletedAt
+
+	// Completing a completed task again does not change when it was completed
+	err = suite.taskService.UpdateTaskStatus(task.ID, TaskStatusCompleted.value)
+	require.NoError(t, err)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	if assert.NotNil(t, task.CompletedAt) {
+		assert.True(t, completedAt.Equal(*task.CompletedAt))
+	}
+
+	err = suite.taskService.UpdateTaskStatus(task.ID, TaskStatusPending.value)
+	require.NoError(t, err)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, task.CompletedAt)
+}
+
 func (suite *UpdateTaskStatusTestSuite) TestCompleteWithSubtasks() {
 	t := suite.T()
 
@@ -0,0 +1,23 @@
+package task
+
+import (
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// ErrVersionChanged is returned when a task no longer has the version a change was made for.
+var ErrVersionChanged = internal.NewError(internal.ErrPreconditionFailed, "the task was changed since it was fetched")
+
+// IfVersion runs fn with a copy of the service provided that the task still has the given version,
+// and saves the changes made by fn all at once. The task stays locked until then, so that no one
+// can change it in between. Fails with ErrVersionChanged if the task has another version.
+func (ts *TaskService) IfVersion(taskID uuid.UUID, version int, fn func(ts *TaskService) error) error {
+	return ts.inTransaction(func(txService *TaskService) error {
+		err := txService.repository.LockVersion(taskID, version)
+		if err != nil {
+			return err
+		}
+
+		return fn(txService)
+	})
+}
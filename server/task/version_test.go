@@ -0,0 +1,163 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type IfVersionTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	taskService *TaskService
+	projectID   uuid.UUID
+}
+
+func (suite *IfVersionTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = NewTaskService(repository, projectRepository)
+}
+
+// Setup database before each test
+func (suite *IfVersionTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	projectIDs := insertTestProjectsInTheDatabase(suite.ctx, t, suite.pgContainer.ConnectionString)
+	suite.projectID = projectIDs[0]
+}
+
+func (suite *IfVersionTestSuite) TestSavesChanges() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("My test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	err = suite.taskService.IfVersion(task.ID, task.Version, func(ts *TaskService) error {
+		_, err := ts.RenameTask(task.ID, "Renamed task")
+		if err != nil {
+			return err
+		}
+
+		return ts.UpdateTaskStatus(task.ID, TaskStatusCompleted.String())
+	})
+	require.NoError(t, err)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed task", task.Name)
+	assert.Equal(t, TaskStatusCompleted, task.Status)
+	assert.Equal(t, 3, task.Version)
+}
+
+func (suite *IfVersionTestSuite) TestFailsIfTheTaskChanged() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("My test task", suite.projectID, nil)
+	require.NoError(t, err)
+	_, err = suite.taskService.RenameTask(task.ID, "Renamed by someone else")
+	require.NoError(t, err)
+
+	err = suite.taskService.IfVersion(task.ID, task.Version, func(ts *TaskService) error {
+		_, err := ts.RenameTask(task.ID, "Renamed task")
+		return err
+	})
+	assert.ErrorIs(t, err, ErrVersionChanged)
+	assert.ErrorIs(t, err, internal.ErrPreconditionFailed)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed by someone else", task.Name)
+}
+
+func (suite *IfVersionTestSuite) TestSavesNothingOnError() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("My test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	failure := errors.New("failure")
+	err = suite.taskService.IfVersion(task.ID, task.Version, func(ts *TaskService) error {
+		_, err := ts.RenameTask(task.ID, "Renamed task")
+		if err != nil {
+			return err
+		}
+
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "My test task", task.Name)
+	assert.Equal(t, 1, task.Version)
+}
+
+func (suite *IfVersionTestSuite) TestConcurrentChanges() {
+	t := suite.T()
+
+	task, err := suite.taskService.CreateTask("My test task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	// Both writers checked version 1, but only the first one to lock the task may change it
+	names := []string{"First", "Second"}
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = suite.taskService.IfVersion(task.ID, task.Version, func(ts *TaskService) error {
+				_, err := ts.RenameTask(task.ID, name)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, ErrVersionChanged)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	task, err = suite.taskService.FindTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, task.Version)
+}
+
+func TestIfVersion(t *testing.T) {
+	suite.Run(t, new(IfVersionTestSuite))
+}
@@ -0,0 +1,173 @@
+package template
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// Transforms a template as seen by the db package, along with its task rows, to a template as seen
+// by the template package. The flat rows are assembled into a tree.
+func TemplateDBToTemplateModel(templateDB db.Template, tasksDB []db.TemplateTask) (Template, error) {
+	templateID, err := internal.EncodeUUID(templateDB.ID.Bytes)
+	if err != nil {
+		return Template{}, err
+	}
+
+	tasks := []TemplateTask{}
+	for _, taskDB := range tasksDB {
+		task, err := TemplateTaskDBToTemplateTaskModel(taskDB)
+		if err != nil {
+			return Template{}, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return Template{
+		ID:        templateID,
+		Name:      templateDB.Name,
+		CreatedAt: templateDB.CreatedAt.Time,
+		Tasks:     buildTemplateTaskTree(tasks, nil),
+	}, nil
+}
+
+func TemplateTaskDBToTemplateTaskModel(taskDB db.TemplateTask) (TemplateTask, error) {
+	taskID, err := internal.EncodeUUID(taskDB.ID.Bytes)
+	if err != nil {
+		return TemplateTask{}, err
+	}
+
+	var parentID *uuid.UUID = nil
+	if taskDB.ParentTemplateTaskID.Valid {
+		pID, err := internal.EncodeUUID(taskDB.ParentTemplateTaskID.Bytes)
+		if err != nil {
+			return TemplateTask{}, err
+		}
+
+		parentID = &pID
+	}
+
+	var dueOffsetDays *int = nil
+	if taskDB.DueOffsetDays.Valid {
+		offset := int(taskDB.DueOffsetDays.Int32)
+		dueOffsetDays = &offset
+	}
+
+	return TemplateTask{
+		ID:            taskID,
+		ParentID:      parentID,
+		Name:          taskDB.Name,
+		Order:         int(taskDB.Order),
+		DueOffsetDays: dueOffsetDays,
+		Subtasks:      []TemplateTask{},
+	}, nil
+}
+
+// Transforms a template task as seen by the template package to one as seen by the db package.
+func TemplateTaskModelToTemplateTaskDB(templateID uuid.UUID, task TemplateTask) (db.TemplateTask, error) {
+	pgTaskUUID, err := internal.ScanUUID(task.ID)
+	if err != nil {
+		return db.TemplateTask{}, err
+	}
+
+	pgTemplateUUID, err := internal.ScanUUID(templateID)
+	if err != nil {
+		return db.TemplateTask{}, err
+	}
+
+	pgParentUUID := pgtype.UUID{}
+	if task.ParentID != nil {
+		pgParentUUID, err = internal.ScanUUID(*task.ParentID)
+		if err != nil {
+			return db.TemplateTask{}, err
+		}
+	}
+
+	pgDueOffsetDays := pgtype.Int4{}
+	if task.DueOffsetDays != nil {
+		pgDueOffsetDays = pgtype.Int4{Int32: int32(*task.DueOffsetDays), Valid: true}
+	}
+
+	return db.TemplateTask{
+		ID:                   pgTaskUUID,
+		TemplateID:           pgTemplateUUID,
+		ParentTemplateTaskID: pgParentUUID,
+		Name:                 task.Name,
+		Order:                int32(task.Order),
+		DueOffsetDays:        pgDueOffsetDays,
+	}, nil
+}
+
+// buildTemplateTaskTree nests the tasks below their parents. The input is expected to be sorted by
+// order, which is preserved among siblings.
+func buildTemplateTaskTree(tasks []TemplateTask, parentID *uuid.UUID) []TemplateTask {
+	level := []TemplateTask{}
+	for _, t := range tasks {
+		if !sameParent(t.ParentID, parentID) {
+			continue
+		}
+
+		t.Subtasks = buildTemplateTaskTree(tasks, &t.ID)
+		level = append(level, t)
+	}
+
+	return level
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+// Transforms the tasks of a project into the task tree of a template. Due dates become offsets
+// from projectStart, rounded to the nearest whole day, so that a day made shorter or longer by a
+// change of daylight saving time still counts as one. The returned tasks still carry the IDs of the project tasks,
+// so they must go through NewTemplate (or prepareTemplateTasks) before being persisted.
+func ProjectTasksDBToTemplateTasks(tasksDB []db.Task, projectStart time.Time) ([]TemplateTask, error) {
+	slices.SortFunc(tasksDB, func(a, b db.Task) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+
+	tasks := []TemplateTask{}
+	for _, taskDB := range tasksDB {
+		taskID, err := internal.EncodeUUID(taskDB.ID.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var parentID *uuid.UUID = nil
+		if taskDB.ParentTaskID.Valid {
+			pID, err := internal.EncodeUUID(taskDB.ParentTaskID.Bytes)
+			if err != nil {
+				return nil, err
+			}
+
+			parentID = &pID
+		}
+
+		var dueOffsetDays *int = nil
+		if taskDB.DueAt.Valid {
+			offset := int(math.Round(taskDB.DueAt.Time.Sub(projectStart).Hours() / 24))
+			dueOffsetDays = &offset
+		}
+
+		tasks = append(tasks, TemplateTask{
+			ID:            taskID,
+			ParentID:      parentID,
+			Name:          taskDB.Name,
+			Order:         int(taskDB.Order),
+			DueOffsetDays: dueOffsetDays,
+		})
+	}
+
+	return buildTemplateTaskTree(tasks, nil), nil
+}
@@ -0,0 +1,25 @@
+package template
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TemplateRepository interface {
+	// Create a template and its whole task tree
+	Create(template Template) error
+
+	// Create a template from the task tree of a project. Due dates are converted to offsets
+	// relative to projectStart.
+	CreateFromProject(template Template, projectID uuid.UUID, projectStart time.Time) error
+
+	// Retrieve a template, with its task tree, by its ID
+	Get(id uuid.UUID) (Template, error)
+
+	// List all templates, with their task trees
+	List() ([]Template, error)
+
+	// Delete the template with the specified ID
+	Delete(id uuid.UUID) (Template, error)
+}
@@ -0,0 +1,190 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// This code indicates that a duplicate constraint was violated by the query
+var ErrPgDuplicate = "23505"
+
+type TemplateRepositoryPostgres struct {
+	Queries *db.Queries
+	pool    *pgxpool.Pool
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewTemplateRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *TemplateRepositoryPostgres {
+	return &TemplateRepositoryPostgres{
+		Queries: db.New(pool),
+		pool:    pool,
+		ctx:     ctx,
+		logger:  *internal.NewLogger("TemplateRepositoryPostgres"),
+	}
+}
+
+// Create inserts the template and all of its tasks in a single transaction, parents before
+// children.
+func (r *TemplateRepositoryPostgres) Create(template Template) error {
+	pgUUID, err := internal.ScanUUID(template.ID)
+	if err != nil {
+		return err
+	}
+
+	pgCreatedAt := pgtype.Timestamptz{}
+	err = pgCreatedAt.Scan(template.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(r.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(r.ctx)
+
+	qtx := r.Queries.WithTx(tx)
+	r.logger.Info("Creating template", slog.Any("template", template))
+	err = qtx.CreateTemplate(r.ctx, db.CreateTemplateParams{
+		ID:        pgUUID,
+		Name:      template.Name,
+		CreatedAt: pgCreatedAt,
+	})
+	if err != nil {
+		r.logger.Error("failed to insert template in the database", slog.String("err", err.Error()))
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == ErrPgDuplicate {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Template \"%s\"", template.Name))
+		}
+
+		return err
+	}
+
+	err = createTemplateTasks(r.ctx, qtx, template.ID, template.Tasks)
+	if err != nil {
+		r.logger.Error("failed to insert template tasks in the database", slog.String("err", err.Error()))
+		return err
+	}
+
+	return tx.Commit(r.ctx)
+}
+
+func createTemplateTasks(ctx context.Context, q *db.Queries, templateID uuid.UUID, tasks []TemplateTask) error {
+	for _, task := range tasks {
+		taskDB, err := TemplateTaskModelToTemplateTaskDB(templateID, task)
+		if err != nil {
+			return err
+		}
+
+		err = q.CreateTemplateTask(ctx, db.CreateTemplateTaskParams(taskDB))
+		if err != nil {
+			return err
+		}
+
+		err = createTemplateTasks(ctx, q, templateID, task.Subtasks)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TemplateRepositoryPostgres) CreateFromProject(template Template, projectID uuid.UUID, projectStart time.Time) error {
+	pgProjectUUID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return err
+	}
+
+	projectTasksDB, err := r.Queries.GetTasksByProject(r.ctx, pgProjectUUID)
+	if err != nil {
+		r.logger.Error("failed to retrieve project tasks", slog.String("projectID", projectID.String()), slog.String("err", err.Error()))
+		return err
+	}
+
+	tasks, err := ProjectTasksDBToTemplateTasks(projectTasksDB, projectStart)
+	if err != nil {
+		return err
+	}
+
+	template.Tasks = prepareTemplateTasks(tasks, nil)
+	return r.Create(template)
+}
+
+func (r *TemplateRepositoryPostgres) Get(id uuid.UUID) (Template, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Template{}, err
+	}
+
+	templateDB, err := r.Queries.GetTemplate(r.ctx, pgUUID)
+	if err != nil {
+		r.logger.Error("failed to retrieve template from database", slog.String("err", err.Error()))
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = internal.NewNotFoundError(fmt.Sprintf("Template with id %s", id.String()))
+		}
+		return Template{}, err
+	}
+
+	return r.withTasks(templateDB)
+}
+
+func (r *TemplateRepositoryPostgres) List() ([]Template, error) {
+	templatesDB, err := r.Queries.ListTemplates(r.ctx)
+	if err != nil {
+		r.logger.Error("failed to list templates from the database", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	templates := []Template{}
+	for _, tDB := range templatesDB {
+		t, err := r.withTasks(tDB)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, t)
+	}
+
+	return templates, nil
+}
+
+func (r *TemplateRepositoryPostgres) Delete(id uuid.UUID) (Template, error) {
+	template, err := r.Get(id)
+	if err != nil {
+		return Template{}, err
+	}
+
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Template{}, err
+	}
+
+	_, err = r.Queries.DeleteTemplate(r.ctx, pgUUID)
+	if err != nil {
+		return Template{}, err
+	}
+
+	return template, nil
+}
+
+func (r *TemplateRepositoryPostgres) withTasks(templateDB db.Template) (Template, error) {
+	tasksDB, err := r.Queries.GetTemplateTasks(r.ctx, templateDB.ID)
+	if err != nil {
+		return Template{}, err
+	}
+
+	return TemplateDBToTemplateModel(templateDB, tasksDB)
+}
@@ -0,0 +1,56 @@
+package template
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrEmptyName = internal.NewValidationError("template and template task names must not be empty")
+
+type TemplateService struct {
+	repository TemplateRepository
+	logger     slog.Logger
+}
+
+func NewTemplateService(repository TemplateRepository) *TemplateService {
+	return &TemplateService{repository: repository, logger: *internal.NewLogger("TemplateService")}
+}
+
+// CreateTemplate validates and persists a template with the given task tree.
+func (ts *TemplateService) CreateTemplate(name string, tasks []TemplateTask) (Template, error) {
+	if strings.TrimSpace(name) == "" {
+		return Template{}, ErrEmptyName
+	}
+
+	emptyName := false
+	walkTemplateTasks(tasks, func(tt TemplateTask) {
+		emptyName = emptyName || strings.TrimSpace(tt.Name) == ""
+	})
+	if emptyName {
+		return Template{}, ErrEmptyName
+	}
+
+	template := NewTemplate(name, tasks)
+	err := ts.repository.Create(template)
+	if err != nil {
+		ts.logger.Error("failed to create template", slog.String("err", err.Error()))
+		return Template{}, err
+	}
+
+	return template, nil
+}
+
+func (ts *TemplateService) GetTemplate(id uuid.UUID) (Template, error) {
+	return ts.repository.Get(id)
+}
+
+func (ts *TemplateService) ListTemplates() ([]Template, error) {
+	return ts.repository.List()
+}
+
+func (ts *TemplateService) DeleteTemplate(id uuid.UUID) (Template, error) {
+	return ts.repository.Delete(id)
+}
@@ -0,0 +1,118 @@
+package template
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateServiceTestSuite struct {
+	suite.Suite
+	pgContainer *testhelpers.PostgresContainer
+	service     *TemplateService
+	ctx         context.Context
+}
+
+func (suite *TemplateServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.service = NewTemplateService(NewTemplateRepositoryPostgres(suite.ctx, pgPool))
+}
+
+func (suite *TemplateServiceTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTemplatesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+}
+
+func (suite *TemplateServiceTestSuite) TestCreateTemplate_Success() {
+	t := suite.T()
+
+	created, err := suite.service.CreateTemplate("Onboarding", sampleTemplate().Tasks)
+	require.NoError(t, err)
+
+	fetched, err := suite.service.GetTemplate(created.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Onboarding", fetched.Name)
+	require.Len(t, fetched.Tasks, 2)
+	assert.Equal(t, "Welcome {{ employee }}", fetched.Tasks[0].Name)
+	require.Len(t, fetched.Tasks[0].Subtasks, 2)
+	if assert.NotNil(t, fetched.Tasks[0].Subtasks[0].DueOffsetDays) {
+		assert.Equal(t, 3, *fetched.Tasks[0].Subtasks[0].DueOffsetDays)
+	}
+	assert.Equal(t, []string{"employee", "team"}, fetched.Variables())
+}
+
+func (suite *TemplateServiceTestSuite) TestCreateTemplate_EmptyName() {
+	t := suite.T()
+
+	_, err := suite.service.CreateTemplate("  ", nil)
+	assert.ErrorIs(t, err, ErrEmptyName)
+
+	_, err = suite.service.CreateTemplate("Onboarding", []TemplateTask{{Name: ""}})
+	assert.ErrorIs(t, err, ErrEmptyName)
+}
+
+func (suite *TemplateServiceTestSuite) TestCreateTemplate_AlreadyExists() {
+	t := suite.T()
+
+	_, err := suite.service.CreateTemplate("Onboarding", nil)
+	require.NoError(t, err)
+
+	_, err = suite.service.CreateTemplate("Onboarding", nil)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+}
+
+func (suite *TemplateServiceTestSuite) TestListTemplates() {
+	t := suite.T()
+
+	_, err := suite.service.CreateTemplate("Sprint", nil)
+	require.NoError(t, err)
+	_, err = suite.service.CreateTemplate("Onboarding", nil)
+	require.NoError(t, err)
+
+	templates, err := suite.service.ListTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "Onboarding", templates[0].Name)
+	assert.Equal(t, "Sprint", templates[1].Name)
+}
+
+func (suite *TemplateServiceTestSuite) TestDeleteTemplate() {
+	t := suite.T()
+
+	created, err := suite.service.CreateTemplate("Onboarding", sampleTemplate().Tasks)
+	require.NoError(t, err)
+
+	_, err = suite.service.DeleteTemplate(created.ID)
+	require.NoError(t, err)
+
+	_, err = suite.service.GetTemplate(created.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	_, err = suite.service.DeleteTemplate(uuid.New())
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func TestTemplateService(t *testing.T) {
+	suite.Run(t, new(TemplateServiceTestSuite))
+}
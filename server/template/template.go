@@ -0,0 +1,182 @@
+package template
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Matches placeholders such as "{{client}}" or "{{ client }}" in template and task names.
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// A Template is a reusable tree of tasks. Task names may contain "{{variable}}" placeholders,
+// which are substituted when the template is instantiated.
+type Template struct {
+	CreatedAt time.Time
+	Name      string         // Name of the template
+	Tasks     []TemplateTask // Root tasks of the template, each with its own subtasks
+	ID        uuid.UUID      // ID of the template
+}
+
+// A TemplateTask is a task blueprint inside a template.
+type TemplateTask struct {
+	// The ID of the parent template task, nil for root tasks
+	ParentID *uuid.UUID
+	// Number of days between the instantiation and the due date of the task. A nil offset means
+	// the instantiated task has no due date.
+	DueOffsetDays *int
+	// The name of the task, possibly containing placeholders
+	Name string
+	// Subtasks of this template task
+	Subtasks []TemplateTask
+	// A unique identifier for the template task
+	ID uuid.UUID
+	// The position of the task relative to its siblings
+	Order int
+}
+
+// NewTemplate returns a new instance of a template with the given task tree. IDs, parent IDs and
+// orders of the tasks are (re)assigned, so callers only need to fill in names, offsets and
+// subtasks.
+func NewTemplate(name string, tasks []TemplateTask) Template {
+	return Template{
+		ID:        uuid.New(),
+		Name:      name,
+		Tasks:     prepareTemplateTasks(tasks, nil),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func prepareTemplateTasks(tasks []TemplateTask, parentID *uuid.UUID) []TemplateTask {
+	prepared := []TemplateTask{}
+	for i, t := range tasks {
+		t.ID = uuid.New()
+		t.ParentID = parentID
+		t.Order = i
+		t.Subtasks = prepareTemplateTasks(t.Subtasks, &t.ID)
+		prepared = append(prepared, t)
+	}
+
+	return prepared
+}
+
+// Variables returns the sorted names of every placeholder used in the template's tasks.
+func (t Template) Variables() []string {
+	variables := []string{}
+	walkTemplateTasks(t.Tasks, func(tt TemplateTask) {
+		variables = append(variables, Placeholders(tt.Name)...)
+	})
+
+	slices.Sort(variables)
+	return slices.Compact(variables)
+}
+
+// Render returns a copy of the template in which every placeholder has been replaced by its
+// value. It fails if a variable used by the template is missing from vars.
+func (t Template) Render(vars map[string]string) (Template, error) {
+	missing := []string{}
+	for _, v := range t.Variables() {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return Template{}, NewMissingVariablesError(missing)
+	}
+
+	rendered := t
+	rendered.Tasks = renderTemplateTasks(t.Tasks, vars)
+
+	return rendered, nil
+}
+
+func renderTemplateTasks(tasks []TemplateTask, vars map[string]string) []TemplateTask {
+	rendered := []TemplateTask{}
+	for _, t := range tasks {
+		t.Name = substitute(t.Name, vars)
+		t.Subtasks = renderTemplateTasks(t.Subtasks, vars)
+		rendered = append(rendered, t)
+	}
+
+	return rendered
+}
+
+// RenderName substitutes the placeholders of a single name, e.g. the name of the project created
+// from a template.
+func RenderName(name string, vars map[string]string) (string, error) {
+	missing := []string{}
+	for _, v := range Placeholders(name) {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return "", NewMissingVariablesError(missing)
+	}
+
+	return substitute(name, vars), nil
+}
+
+// Placeholders returns the names of the placeholders found in s, in order of appearance.
+func Placeholders(s string) []string {
+	placeholders := []string{}
+	for _, match := range placeholderRegexp.FindAllStringSubmatch(s, -1) {
+		placeholders = append(placeholders, match[1])
+	}
+
+	return placeholders
+}
+
+func substitute(s string, vars map[string]string) string {
+	return placeholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		return vars[name]
+	})
+}
+
+// DueDate computes the due date of a task instantiated at start. It returns nil if the template
+// task has no due offset.
+func (tt TemplateTask) DueDate(start time.Time) *time.Time {
+	if tt.DueOffsetDays == nil {
+		return nil
+	}
+
+	dueAt := start.AddDate(0, 0, *tt.DueOffsetDays)
+	return &dueAt
+}
+
+func walkTemplateTasks(tasks []TemplateTask, fn func(TemplateTask)) {
+	for _, t := range tasks {
+		fn(t)
+		walkTemplateTasks(t.Subtasks, fn)
+	}
+}
+
+// MissingVariablesError is returned when a template is rendered without a value for every one of
+// its placeholders.
+type MissingVariablesError struct {
+	Variables []string
+}
+
+func NewMissingVariablesError(variables []string) MissingVariablesError {
+	slices.Sort(variables)
+	return MissingVariablesError{Variables: slices.Compact(variables)}
+}
+
+func (e MissingVariablesError) Error() string {
+	return fmt.Sprintf("missing values for template variables: %s", strings.Join(e.Variables, ", "))
+}
+
+// Will not log tasks in order to save log space.
+func (t Template) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ID", t.ID.String()),
+		slog.String("Name", t.Name),
+		slog.Time("CreatedAt", t.CreatedAt),
+	)
+}
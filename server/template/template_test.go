@@ -0,0 +1,116 @@
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleTemplate() Template {
+	threeDays := 3
+	return NewTemplate("Onboarding", []TemplateTask{
+		{
+			Name: "Welcome {{ employee }}",
+			Subtasks: []TemplateTask{
+				{Name: "Order laptop for {{employee}}", DueOffsetDays: &threeDays},
+				{Name: "Add to {{team}} channel"},
+			},
+		},
+		{Name: "Schedule 1:1"},
+	})
+}
+
+func TestNewTemplate_AssignsTreeStructure(t *testing.T) {
+	tmpl := sampleTemplate()
+
+	require.Len(t, tmpl.Tasks, 2)
+	assert.Nil(t, tmpl.Tasks[0].ParentID)
+	assert.Equal(t, 0, tmpl.Tasks[0].Order)
+	assert.Equal(t, 1, tmpl.Tasks[1].Order)
+
+	require.Len(t, tmpl.Tasks[0].Subtasks, 2)
+	for i, st := range tmpl.Tasks[0].Subtasks {
+		require.NotNil(t, st.ParentID)
+		assert.Equal(t, tmpl.Tasks[0].ID, *st.ParentID)
+		assert.Equal(t, i, st.Order)
+	}
+}
+
+func TestVariables(t *testing.T) {
+	assert.Equal(t, []string{"employee", "team"}, sampleTemplate().Variables())
+}
+
+func TestRender_Success(t *testing.T) {
+	tmpl := sampleTemplate()
+
+	rendered, err := tmpl.Render(map[string]string{"employee": "Alice", "team": "Platform"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Welcome Alice", rendered.Tasks[0].Name)
+	assert.Equal(t, "Order laptop for Alice", rendered.Tasks[0].Subtasks[0].Name)
+	assert.Equal(t, "Add to Platform channel", rendered.Tasks[0].Subtasks[1].Name)
+	assert.Equal(t, "Schedule 1:1", rendered.Tasks[1].Name)
+
+	// The original template must be left untouched
+	assert.Equal(t, "Welcome {{ employee }}", tmpl.Tasks[0].Name)
+}
+
+func TestRender_MissingVariables(t *testing.T) {
+	_, err := sampleTemplate().Render(map[string]string{"employee": "Alice"})
+
+	var missingErr MissingVariablesError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []string{"team"}, missingErr.Variables)
+}
+
+func TestRenderName(t *testing.T) {
+	name, err := RenderName("Onboarding {{employee}}", map[string]string{"employee": "Bob"})
+	require.NoError(t, err)
+	assert.Equal(t, "Onboarding Bob", name)
+
+	_, err = RenderName("Onboarding {{employee}}", nil)
+	assert.Error(t, err)
+}
+
+func TestDueDate(t *testing.T) {
+	start := time.Date(2024, time.December, 30, 9, 0, 0, 0, time.UTC)
+	tmpl := sampleTemplate()
+
+	dueAt := tmpl.Tasks[0].Subtasks[0].DueDate(start)
+	require.NotNil(t, dueAt)
+	assert.Equal(t, time.Date(2025, time.January, 2, 9, 0, 0, 0, time.UTC), *dueAt)
+
+	assert.Nil(t, tmpl.Tasks[1].DueDate(start))
+}
+
+func TestProjectTasksDBToTemplateTasks_RoundsDueOffsets(t *testing.T) {
+	start := time.Date(2024, time.December, 30, 9, 0, 0, 0, time.UTC)
+	dueIn := func(order int32, d time.Duration) db.Task {
+		return db.Task{
+			ID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Name:  d.String(),
+			Order: order,
+			DueAt: pgtype.Timestamptz{Time: start.Add(d), Valid: true},
+		}
+	}
+
+	// E.g. a day shortened by daylight saving time, or a task due in the evening
+	tasks, err := ProjectTasksDBToTemplateTasks([]db.Task{
+		dueIn(0, 47*time.Hour),
+		dueIn(1, 36*time.Hour-time.Minute),
+		dueIn(2, -23*time.Hour),
+	}, start)
+	require.NoError(t, err)
+
+	offsets := []int{}
+	for _, task := range tasks {
+		require.NotNil(t, task.DueOffsetDays)
+		offsets = append(offsets, *task.DueOffsetDays)
+	}
+	assert.Equal(t, []int{2, 1, -1}, offsets)
+}
@@ -75,3 +75,133 @@ func CleanupProjectsTable(ctx context.Context, t *testing.T, connectionString st
 	}
 	rows.Close()
 }
+
+// Connect to the database and run `DELETE FROM templates`. Template tasks are deleted in cascade.
+func CleanupTemplatesTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up templates table")
+	cleanupTemplates := "DELETE FROM templates"
+	rows, err := conn.Query(ctx, cleanupTemplates)
+	if err != nil {
+		t.Fatalf("failed to clean up templates table: %s", err)
+	}
+	rows.Close()
+}
+
+// Connect to the database and run `DELETE FROM activity_events`
+func CleanupActivityTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up activity_events table")
+	cleanupActivity := "DELETE FROM activity_events"
+	rows, err := conn.Query(ctx, cleanupActivity)
+	if err != nil {
+		t.Fatalf("failed to clean up activity_events table: %s", err)
+	}
+	rows.Close()
+}
+
+func CleanupIdempotencyKeysTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up idempotency_keys table")
+	cleanupKeys := "DELETE FROM idempotency_keys"
+	rows, err := conn.Query(ctx, cleanupKeys)
+	if err != nil {
+		t.Fatalf("failed to clean up idempotency_keys table: %s", err)
+	}
+	rows.Close()
+}
+
+func CleanupTimeEntriesTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up time_entries table")
+	cleanupTimeEntries := "DELETE FROM time_entries"
+	rows, err := conn.Query(ctx, cleanupTimeEntries)
+	if err != nil {
+		t.Fatalf("failed to clean up time_entries table: %s", err)
+	}
+	rows.Close()
+}
+
+func CleanupIterationsTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up iterations table")
+	cleanupIterations := "DELETE FROM iterations"
+	rows, err := conn.Query(ctx, cleanupIterations)
+	if err != nil {
+		t.Fatalf("failed to clean up iterations table: %s", err)
+	}
+	rows.Close()
+}
+
+func CleanupWorkspacesTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up workspaces table")
+	cleanupWorkspaces := "DELETE FROM workspaces"
+	rows, err := conn.Query(ctx, cleanupWorkspaces)
+	if err != nil {
+		t.Fatalf("failed to clean up workspaces table: %s", err)
+	}
+	rows.Close()
+}
+
+func CleanupUsersTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up users table")
+	cleanupUsers := "DELETE FROM users"
+	rows, err := conn.Query(ctx, cleanupUsers)
+	if err != nil {
+		t.Fatalf("failed to clean up users table: %s", err)
+	}
+	rows.Close()
+}
+
+func CleanupDeniedRefreshTokensTable(ctx context.Context, t *testing.T, connectionString string) {
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		t.Fatalf("unable to connect to the database: %s", err)
+	}
+	defer conn.Close(ctx)
+
+	t.Log("cleaning up denied_refresh_tokens table")
+	cleanupDeniedRefreshTokens := "DELETE FROM denied_refresh_tokens"
+	rows, err := conn.Query(ctx, cleanupDeniedRefreshTokens)
+	if err != nil {
+		t.Fatalf("failed to clean up denied_refresh_tokens table: %s", err)
+	}
+	rows.Close()
+}
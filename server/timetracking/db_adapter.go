@@ -0,0 +1,49 @@
+package timetracking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// Transforms a time entry as seen by the db package to a time entry as seen by the timetracking
+// package
+func TimeEntryDBToTimeEntryModel(entryDB db.TimeEntry) (TimeEntry, error) {
+	entryID, err := internal.EncodeUUID(entryDB.ID.Bytes)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	taskID, err := internal.EncodeUUID(entryDB.TaskID.Bytes)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	var userID *uuid.UUID = nil
+	if entryDB.UserID.Valid {
+		uID, err := internal.EncodeUUID(entryDB.UserID.Bytes)
+		if err != nil {
+			return TimeEntry{}, err
+		}
+
+		userID = &uID
+	}
+
+	var endedAt *time.Time = nil
+	if entryDB.EndedAt.Valid {
+		endedAt = &entryDB.EndedAt.Time
+	}
+
+	return TimeEntry{
+		ID:        entryID,
+		TaskID:    taskID,
+		UserID:    userID,
+		Actor:     entryDB.Actor,
+		StartedAt: entryDB.StartedAt.Time,
+		EndedAt:   endedAt,
+		Note:      entryDB.Note,
+		CreatedAt: entryDB.CreatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package timetracking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A TimeEntry is time spent on a task, either tracked with a timer or entered by hand.
+type TimeEntry struct {
+	ID uuid.UUID
+	// The task the time was spent on
+	TaskID uuid.UUID
+	// The user who spent the time, nil for entries made before users existed. Each user has at
+	// most one running timer
+	UserID *uuid.UUID
+	// The name of the user who spent the time
+	Actor string
+	// When the work started
+	StartedAt time.Time
+	// When the work ended. It is nil while the timer is running
+	EndedAt *time.Time
+	// What was done
+	Note      string
+	CreatedAt time.Time
+}
+
+// NewTimeEntry returns a new entry for the time a user spent on a task from startedAt to endedAt,
+// or a running timer started at startedAt if endedAt is nil.
+func NewTimeEntry(taskID uuid.UUID, userID *uuid.UUID, actor string, startedAt time.Time, endedAt *time.Time, note string) TimeEntry {
+	return TimeEntry{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Actor:     actor,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Note:      note,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// IsRunning tells whether the entry is a timer that was not stopped yet.
+func (e TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Duration is the time spent, counted until now for running timers.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.EndedAt == nil {
+		return now.Sub(e.StartedAt)
+	}
+
+	return e.EndedAt.Sub(e.StartedAt)
+}
+
+// Totals is the time tracked on a task.
+type Totals struct {
+	// Time tracked on the task itself
+	Own time.Duration
+	// Time tracked on the task and all of its subtasks
+	Total time.Duration
+}
+
+// A TimesheetRow is the time a user spent on a task during the period of a timesheet.
+type TimesheetRow struct {
+	TaskID   uuid.UUID
+	TaskName string
+	// The user who spent the time, nil for entries made before users existed
+	UserID *uuid.UUID
+	// The current name of the user, or the actor of the entries of no user
+	Actor    string
+	Duration time.Duration
+}
+
+// A Timesheet is the time spent on the tasks of a project during a period.
+type Timesheet struct {
+	ProjectID uuid.UUID
+	From      time.Time
+	To        time.Time
+	// One row for each task and user, sorted by task name
+	Rows  []TimesheetRow
+	Total time.Duration
+}
@@ -0,0 +1,25 @@
+package timetracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntryDuration(t *testing.T) {
+	startedAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	now := startedAt.Add(2 * time.Hour)
+
+	endedAt := startedAt.Add(45 * time.Minute)
+	userID := uuid.New()
+	entry := NewTimeEntry(uuid.New(), &userID, "alice", startedAt, &endedAt, "")
+	assert.False(t, entry.IsRunning())
+	assert.Equal(t, 45*time.Minute, entry.Duration(now))
+
+	// Running timers count until now
+	timer := NewTimeEntry(uuid.New(), &userID, "alice", startedAt, nil, "")
+	assert.True(t, timer.IsRunning())
+	assert.Equal(t, 2*time.Hour, timer.Duration(now))
+}
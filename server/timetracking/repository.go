@@ -0,0 +1,35 @@
+package timetracking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeEntryRepository interface {
+	// Store a new time entry. Fails with internal.ErrAlreadyExists if the entry is a running
+	// timer and its user already has one
+	Create(entry TimeEntry) (TimeEntry, error)
+
+	// Retrieve a time entry
+	Get(id uuid.UUID) (TimeEntry, error)
+
+	// Retrieve the running timer of a user
+	GetRunning(userID uuid.UUID) (TimeEntry, error)
+
+	// Stop the running timer of a user
+	Stop(userID uuid.UUID, endedAt time.Time) (TimeEntry, error)
+
+	// List the time entries of a task, most recent first
+	ListByTask(taskID uuid.UUID) ([]TimeEntry, error)
+
+	// Delete a time entry
+	Delete(id uuid.UUID) error
+
+	// Sum the time tracked on a task and on its subtasks
+	GetTotals(taskID uuid.UUID) (Totals, error)
+
+	// Sum the time tracked by each user on each task of a project from "from" (inclusive) to
+	// "to" (exclusive)
+	GetTimesheet(projectID uuid.UUID, from time.Time, to time.Time) ([]TimesheetRow, error)
+}
@@ -0,0 +1,244 @@
+package timetracking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrPgDuplicate = "23505"
+
+type TimeEntryRepositoryPostgres struct {
+	Queries *db.Queries
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewTimeEntryRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *TimeEntryRepositoryPostgres {
+	return &TimeEntryRepositoryPostgres{
+		Queries: db.New(pool),
+		ctx:     ctx,
+		logger:  *internal.NewLogger("TimeEntryRepositoryPostgres"),
+	}
+}
+
+func (r *TimeEntryRepositoryPostgres) Create(entry TimeEntry) (TimeEntry, error) {
+	pgID, err := internal.ScanUUID(entry.ID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	pgTaskID, err := internal.ScanUUID(entry.TaskID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	pgUserID := pgtype.UUID{}
+	if entry.UserID != nil {
+		pgUserID, err = internal.ScanUUID(*entry.UserID)
+		if err != nil {
+			return TimeEntry{}, err
+		}
+	}
+
+	pgEndedAt := pgtype.Timestamptz{}
+	if entry.EndedAt != nil {
+		pgEndedAt = pgtype.Timestamptz{Time: *entry.EndedAt, Valid: true}
+	}
+
+	entryDB, err := r.Queries.CreateTimeEntry(r.ctx, db.CreateTimeEntryParams{
+		ID:        pgID,
+		TaskID:    pgTaskID,
+		UserID:    pgUserID,
+		Actor:     entry.Actor,
+		StartedAt: pgtype.Timestamptz{Time: entry.StartedAt, Valid: true},
+		EndedAt:   pgEndedAt,
+		Note:      entry.Note,
+		CreatedAt: pgtype.Timestamptz{Time: entry.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == ErrPgDuplicate {
+			return TimeEntry{}, internal.NewAlreadyExistsError(fmt.Sprintf("Running timer of user %s", entry.UserID))
+		}
+
+		r.logger.Error("failed to create time entry", slog.String("err", err.Error()))
+		return TimeEntry{}, err
+	}
+
+	return TimeEntryDBToTimeEntryModel(entryDB)
+}
+
+func (r *TimeEntryRepositoryPostgres) Get(id uuid.UUID) (TimeEntry, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	entryDB, err := r.Queries.GetTimeEntry(r.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TimeEntry{}, internal.NewNotFoundError(fmt.Sprintf("Time entry with id %s", id))
+		}
+
+		return TimeEntry{}, err
+	}
+
+	return TimeEntryDBToTimeEntryModel(entryDB)
+}
+
+func (r *TimeEntryRepositoryPostgres) GetRunning(userID uuid.UUID) (TimeEntry, error) {
+	pgUserID, err := internal.ScanUUID(userID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	entryDB, err := r.Queries.GetRunningTimeEntry(r.ctx, pgUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TimeEntry{}, internal.NewNotFoundError(fmt.Sprintf("Running timer of user %s", userID))
+		}
+
+		return TimeEntry{}, err
+	}
+
+	return TimeEntryDBToTimeEntryModel(entryDB)
+}
+
+func (r *TimeEntryRepositoryPostgres) Stop(userID uuid.UUID, endedAt time.Time) (TimeEntry, error) {
+	pgUserID, err := internal.ScanUUID(userID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	entryDB, err := r.Queries.StopTimeEntry(r.ctx, db.StopTimeEntryParams{
+		UserID:  pgUserID,
+		EndedAt: pgtype.Timestamptz{Time: endedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TimeEntry{}, internal.NewNotFoundError(fmt.Sprintf("Running timer of user %s", userID))
+		}
+
+		return TimeEntry{}, err
+	}
+
+	return TimeEntryDBToTimeEntryModel(entryDB)
+}
+
+func (r *TimeEntryRepositoryPostgres) ListByTask(taskID uuid.UUID) ([]TimeEntry, error) {
+	pgTaskID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entriesDB, err := r.Queries.ListTaskTimeEntries(r.ctx, pgTaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []TimeEntry{}
+	for _, entryDB := range entriesDB {
+		entry, err := TimeEntryDBToTimeEntryModel(entryDB)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (r *TimeEntryRepositoryPostgres) Delete(id uuid.UUID) error {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.Queries.DeleteTimeEntry(r.ctx, pgID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return internal.NewNotFoundError(fmt.Sprintf("Time entry with id %s", id))
+	}
+
+	return nil
+}
+
+func (r *TimeEntryRepositoryPostgres) GetTotals(taskID uuid.UUID) (Totals, error) {
+	pgTaskID, err := internal.ScanUUID(taskID)
+	if err != nil {
+		return Totals{}, err
+	}
+
+	row, err := r.Queries.GetTaskTimeTotals(r.ctx, pgTaskID)
+	if err != nil {
+		return Totals{}, err
+	}
+
+	return Totals{
+		Own:   secondsToDuration(row.OwnSeconds),
+		Total: secondsToDuration(row.TotalSeconds),
+	}, nil
+}
+
+func (r *TimeEntryRepositoryPostgres) GetTimesheet(projectID uuid.UUID, from time.Time, to time.Time) ([]TimesheetRow, error) {
+	pgProjectID, err := internal.ScanUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsDB, err := r.Queries.GetProjectTimesheet(r.ctx, db.GetProjectTimesheetParams{
+		PeriodFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		PeriodTo:   pgtype.Timestamptz{Time: to, Valid: true},
+		ProjectID:  pgProjectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []TimesheetRow{}
+	for _, rowDB := range rowsDB {
+		taskID, err := internal.EncodeUUID(rowDB.TaskID.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var userID *uuid.UUID = nil
+		if rowDB.UserID.Valid {
+			uID, err := internal.EncodeUUID(rowDB.UserID.Bytes)
+			if err != nil {
+				return nil, err
+			}
+
+			userID = &uID
+		}
+
+		rows = append(rows, TimesheetRow{
+			TaskID:   taskID,
+			TaskName: rowDB.TaskName,
+			UserID:   userID,
+			Actor:    rowDB.Actor,
+			Duration: secondsToDuration(rowDB.Seconds),
+		})
+	}
+
+	return rows, nil
+}
+
+// secondsToDuration converts the number of seconds computed by the database, rounded to the
+// microsecond it stores times with.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Microsecond)
+}
@@ -0,0 +1,208 @@
+package timetracking
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+)
+
+// MaxNoteLength is the maximum number of characters in the note of a time entry.
+const MaxNoteLength = 1000
+
+var (
+	ErrMissingUser  = internal.NewValidationError("timers belong to a user, who must be authenticated")
+	ErrTimerRunning = internal.NewConflictError("a timer is already running, it must be stopped before starting another one")
+)
+
+// TimeTrackingService tracks the time spent on tasks, with timers or with entries made by hand.
+// Each user runs at most one timer at a time.
+type TimeTrackingService struct {
+	repository        TimeEntryRepository
+	taskRepository    task.TaskRepository
+	projectRepository project.ProjectRepository
+	logger            slog.Logger
+}
+
+func NewTimeTrackingService(repository TimeEntryRepository, taskRepository task.TaskRepository, projectRepository project.ProjectRepository) *TimeTrackingService {
+	return &TimeTrackingService{
+		repository:        repository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		logger:            *internal.NewLogger("TimeTrackingService"),
+	}
+}
+
+// StartTimer starts tracking the time the user of origin spends on a task. It fails with
+// ErrTimerRunning if the user already has a running timer.
+func (s *TimeTrackingService) StartTimer(taskID uuid.UUID, origin activity.Origin, note string) (TimeEntry, error) {
+	if origin.UserID == nil {
+		return TimeEntry{}, ErrMissingUser
+	}
+
+	if fieldErr := validateNote(note); fieldErr != nil {
+		return TimeEntry{}, internal.FieldErrors{*fieldErr}
+	}
+
+	err := s.ensureTaskIsActive(taskID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	entry, err := s.repository.Create(NewTimeEntry(taskID, origin.UserID, origin.Actor, time.Now().UTC(), nil, note))
+	if errors.Is(err, internal.ErrAlreadyExists) {
+		return TimeEntry{}, ErrTimerRunning
+	}
+
+	return entry, err
+}
+
+// StopTimer stops the running timer of the user of origin.
+func (s *TimeTrackingService) StopTimer(origin activity.Origin) (TimeEntry, error) {
+	if origin.UserID == nil {
+		return TimeEntry{}, ErrMissingUser
+	}
+
+	return s.repository.Stop(*origin.UserID, time.Now().UTC())
+}
+
+// GetRunningTimer returns the running timer of the user of origin.
+func (s *TimeTrackingService) GetRunningTimer(origin activity.Origin) (TimeEntry, error) {
+	if origin.UserID == nil {
+		return TimeEntry{}, ErrMissingUser
+	}
+
+	return s.repository.GetRunning(*origin.UserID)
+}
+
+// AddTimeEntry records by hand the time the user of origin spent on a task. Entries of anonymous
+// clients are attributed to activity.AnonymousActor.
+func (s *TimeTrackingService) AddTimeEntry(taskID uuid.UUID, origin activity.Origin, startedAt time.Time, endedAt time.Time, note string) (TimeEntry, error) {
+	fieldErrs := internal.FieldErrors{}
+	if endedAt.Before(startedAt) {
+		fieldErrs = append(fieldErrs, internal.FieldError{Field: "endedAt", Message: "must not be before startedAt"})
+	}
+	if fieldErr := validateNote(note); fieldErr != nil {
+		fieldErrs = append(fieldErrs, *fieldErr)
+	}
+	if err := fieldErrs.Err(); err != nil {
+		return TimeEntry{}, err
+	}
+
+	err := s.ensureTaskIsActive(taskID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	actor := origin.Actor
+	if actor == "" {
+		actor = activity.AnonymousActor
+	}
+
+	return s.repository.Create(NewTimeEntry(taskID, origin.UserID, actor, startedAt, &endedAt, note))
+}
+
+// ListTimeEntries lists the time entries of a task, most recent first.
+func (s *TimeTrackingService) ListTimeEntries(taskID uuid.UUID) ([]TimeEntry, error) {
+	_, err := s.taskRepository.Get(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListByTask(taskID)
+}
+
+// DeleteTimeEntry deletes a time entry, e.g. one entered by mistake, and returns it. Running timers
+// can be deleted too, to discard them.
+func (s *TimeTrackingService) DeleteTimeEntry(id uuid.UUID) (TimeEntry, error) {
+	entry, err := s.repository.Get(id)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	err = s.ensureTaskIsActive(entry.TaskID)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	err = s.repository.Delete(id)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// GetTotals sums the time tracked on a task, and on the task along with all of its subtasks.
+// Running timers count until now.
+func (s *TimeTrackingService) GetTotals(taskID uuid.UUID) (Totals, error) {
+	_, err := s.taskRepository.Get(taskID)
+	if err != nil {
+		return Totals{}, err
+	}
+
+	return s.repository.GetTotals(taskID)
+}
+
+// GetTimesheet sums the time spent by each user on each task of a project from "from"
+// (inclusive) to "to" (exclusive). Entries overlapping the period only count for their part in
+// it, and running timers count until now.
+func (s *TimeTrackingService) GetTimesheet(projectID uuid.UUID, from time.Time, to time.Time) (Timesheet, error) {
+	if !from.Before(to) {
+		return Timesheet{}, internal.FieldErrors{{Field: "to", Message: "must be after from"}}
+	}
+
+	_, err := s.projectRepository.Get(projectID)
+	if err != nil {
+		return Timesheet{}, err
+	}
+
+	rows, err := s.repository.GetTimesheet(projectID, from, to)
+	if err != nil {
+		return Timesheet{}, err
+	}
+
+	timesheet := Timesheet{ProjectID: projectID, From: from, To: to, Rows: rows}
+	for _, row := range rows {
+		timesheet.Total += row.Duration
+	}
+
+	return timesheet, nil
+}
+
+// ensureTaskIsActive checks that a task exists and that its project is not archived, since the
+// tasks of archived projects are frozen.
+func (s *TimeTrackingService) ensureTaskIsActive(taskID uuid.UUID) error {
+	t, err := s.taskRepository.Get(taskID)
+	if err != nil {
+		return err
+	}
+
+	proj, err := s.projectRepository.Get(t.ProjectID)
+	if err != nil {
+		return err
+	}
+	if proj.IsArchived() {
+		return fmt.Errorf("Cannot track time on the tasks of project %s: %w", t.ProjectID, project.ErrProjectArchived)
+	}
+
+	return nil
+}
+
+func validateNote(note string) *internal.FieldError {
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return &internal.FieldError{
+			Field:   "note",
+			Message: fmt.Sprintf("must not be longer than %d characters", MaxNoteLength),
+		}
+	}
+
+	return nil
+}
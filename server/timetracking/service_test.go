@@ -0,0 +1,215 @@
+package timetracking
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/activity"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeTrackingServiceTestSuite struct {
+	suite.Suite
+	ctx                 context.Context
+	pgContainer         *testhelpers.PostgresContainer
+	timeTrackingService *TimeTrackingService
+	taskService         *task.TaskService
+	projectRepository   project.ProjectRepository
+	projectID           uuid.UUID
+}
+
+func (suite *TimeTrackingServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewTimeEntryRepositoryPostgres(suite.ctx, pgPool)
+	taskRepository := task.NewTaskRepositoryPostgres(suite.ctx, pgPool)
+	suite.projectRepository = project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.taskService = task.NewTaskService(taskRepository, suite.projectRepository)
+	suite.timeTrackingService = NewTimeTrackingService(repository, taskRepository, suite.projectRepository)
+}
+
+// Setup database before each test
+func (suite *TimeTrackingServiceTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTimeEntriesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+
+	testProject := project.NewProject("Test project")
+	require.NoError(t, suite.projectRepository.Create(testProject))
+	suite.projectID = testProject.ID
+}
+
+func (suite *TimeTrackingServiceTestSuite) TearDownSuite() {
+	if err := suite.pgContainer.Terminate(suite.ctx); err != nil {
+		log.Fatalf("error terminating postgres container: %s", err)
+	}
+}
+
+func (suite *TimeTrackingServiceTestSuite) TestStartAndStopTimer() {
+	t := suite.T()
+
+	taskModel, err := suite.taskService.CreateTask("Task", suite.projectID, nil)
+	require.NoError(t, err)
+	otherTask, err := suite.taskService.CreateTask("Other task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	alice := userOrigin("alice")
+	timer, err := suite.timeTrackingService.StartTimer(taskModel.ID, alice, "Reading")
+	require.NoError(t, err)
+	assert.True(t, timer.IsRunning())
+
+	_, err = suite.timeTrackingService.StartTimer(otherTask.ID, alice, "")
+	assert.ErrorIs(t, err, ErrTimerRunning)
+
+	_, err = suite.timeTrackingService.StartTimer(otherTask.ID, activity.Origin{Actor: "alice"}, "")
+	assert.ErrorIs(t, err, ErrMissingUser)
+
+	// Timers belong to users, not to names
+	otherAlice := userOrigin("alice")
+	otherTimer, err := suite.timeTrackingService.StartTimer(otherTask.ID, otherAlice, "")
+	require.NoError(t, err)
+	assert.Equal(t, otherAlice.UserID, otherTimer.UserID)
+
+	running, err := suite.timeTrackingService.GetRunningTimer(alice)
+	require.NoError(t, err)
+	assert.Equal(t, timer.ID, running.ID)
+
+	stopped, err := suite.timeTrackingService.StopTimer(alice)
+	require.NoError(t, err)
+	assert.Equal(t, timer.ID, stopped.ID)
+	assert.False(t, stopped.IsRunning())
+
+	_, err = suite.timeTrackingService.StopTimer(alice)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	// Once stopped, another timer can be started
+	_, err = suite.timeTrackingService.StartTimer(otherTask.ID, alice, "")
+	assert.NoError(t, err)
+
+	running, err = suite.timeTrackingService.GetRunningTimer(otherAlice)
+	require.NoError(t, err)
+	assert.Equal(t, otherTimer.ID, running.ID)
+}
+
+func (suite *TimeTrackingServiceTestSuite) TestAddTimeEntry() {
+	t := suite.T()
+
+	taskModel, err := suite.taskService.CreateTask("Task", suite.projectID, nil)
+	require.NoError(t, err)
+
+	startedAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	entry, err := suite.timeTrackingService.AddTimeEntry(taskModel.ID, activity.Origin{}, startedAt, startedAt.Add(time.Hour), "")
+	require.NoError(t, err)
+	assert.Equal(t, activity.AnonymousActor, entry.Actor)
+	assert.Nil(t, entry.UserID)
+	assert.Equal(t, time.Hour, entry.Duration(time.Now()))
+
+	alice := userOrigin("alice")
+	_, err = suite.timeTrackingService.AddTimeEntry(taskModel.ID, alice, startedAt, startedAt.Add(-time.Hour), "")
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.timeTrackingService.AddTimeEntry(uuid.New(), alice, startedAt, startedAt.Add(time.Hour), "")
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	// The tasks of archived projects are frozen
+	_, err = suite.projectRepository.Archive(suite.projectID, time.Now().UTC())
+	require.NoError(t, err)
+	_, err = suite.timeTrackingService.AddTimeEntry(taskModel.ID, alice, startedAt, startedAt.Add(time.Hour), "")
+	assert.ErrorIs(t, err, project.ErrProjectArchived)
+}
+
+func (suite *TimeTrackingServiceTestSuite) TestGetTotals() {
+	t := suite.T()
+
+	root, err := suite.taskService.CreateTask("Root", suite.projectID, nil)
+	require.NoError(t, err)
+	child, err := suite.taskService.CreateTask("Child", suite.projectID, &root.ID)
+	require.NoError(t, err)
+	grandchild, err := suite.taskService.CreateTask("Grandchild", suite.projectID, &child.ID)
+	require.NoError(t, err)
+
+	startedAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	for taskID, duration := range map[uuid.UUID]time.Duration{
+		root.ID:       time.Hour,
+		child.ID:      30 * time.Minute,
+		grandchild.ID: 15 * time.Minute,
+	} {
+		_, err := suite.timeTrackingService.AddTimeEntry(taskID, userOrigin("alice"), startedAt, startedAt.Add(duration), "")
+		require.NoError(t, err)
+	}
+
+	totals, err := suite.timeTrackingService.GetTotals(root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Totals{Own: time.Hour, Total: time.Hour + 45*time.Minute}, totals)
+
+	totals, err = suite.timeTrackingService.GetTotals(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Totals{Own: 30 * time.Minute, Total: 45 * time.Minute}, totals)
+}
+
+func (suite *TimeTrackingServiceTestSuite) TestGetTimesheet() {
+	t := suite.T()
+
+	review, err := suite.taskService.CreateTask("Review", suite.projectID, nil)
+	require.NoError(t, err)
+	analysis, err := suite.taskService.CreateTask("Analysis", suite.projectID, nil)
+	require.NoError(t, err)
+
+	day := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	alice, bob := userOrigin("alice"), userOrigin("bob")
+	add := func(taskID uuid.UUID, origin activity.Origin, from time.Duration, to time.Duration) {
+		_, err := suite.timeTrackingService.AddTimeEntry(taskID, origin, day.Add(from), day.Add(to), "")
+		require.NoError(t, err)
+	}
+	add(review.ID, alice, 9*time.Hour, 10*time.Hour)
+	add(review.ID, alice, 11*time.Hour, 12*time.Hour)
+	add(analysis.ID, bob, 23*time.Hour, 25*time.Hour) // Half of it is in the next day
+	add(analysis.ID, bob, -2*time.Hour, -time.Hour)   // The day before
+
+	timesheet, err := suite.timeTrackingService.GetTimesheet(suite.projectID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []TimesheetRow{
+		{TaskID: analysis.ID, TaskName: "Analysis", UserID: bob.UserID, Actor: "bob", Duration: time.Hour},
+		{TaskID: review.ID, TaskName: "Review", UserID: alice.UserID, Actor: "alice", Duration: 2 * time.Hour},
+	}, timesheet.Rows)
+	assert.Equal(t, 3*time.Hour, timesheet.Total)
+
+	_, err = suite.timeTrackingService.GetTimesheet(suite.projectID, day, day)
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	_, err = suite.timeTrackingService.GetTimesheet(uuid.New(), day, day.AddDate(0, 0, 1))
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+// userOrigin returns the origin of the changes made by a new user with the given name.
+func userOrigin(name string) activity.Origin {
+	userID := uuid.New()
+	return activity.Origin{Actor: name, UserID: &userID}
+}
+
+func TestTimeTrackingServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeTrackingServiceTestSuite))
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"time"
+
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// Transforms a user as seen by the db package to a user as seen by the user package
+func UserDBToUserModel(userDB db.User) (User, error) {
+	userID, err := internal.EncodeUUID(userDB.ID.Bytes)
+	if err != nil {
+		return User{}, err
+	}
+
+	return User{
+		ID:        userID,
+		Name:      userDB.Name,
+		CreatedAt: userDB.CreatedAt.Time,
+	}, nil
+}
+
+func APITokenDBToAPITokenModel(tokenDB db.ApiToken) (APIToken, error) {
+	tokenID, err := internal.EncodeUUID(tokenDB.ID.Bytes)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	userID, err := internal.EncodeUUID(tokenDB.UserID.Bytes)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	var lastUsedAt *time.Time = nil
+	if tokenDB.LastUsedAt.Valid {
+		lastUsedAt = &tokenDB.LastUsedAt.Time
+	}
+
+	var revokedAt *time.Time = nil
+	if tokenDB.RevokedAt.Valid {
+		revokedAt = &tokenDB.RevokedAt.Time
+	}
+
+	return APIToken{
+		ID:         tokenID,
+		UserID:     userID,
+		Name:       tokenDB.Name,
+		Hash:       tokenDB.TokenHash,
+		CreatedAt:  tokenDB.CreatedAt.Time,
+		LastUsedAt: lastUsedAt,
+		RevokedAt:  revokedAt,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserRepository interface {
+	Create(user User) error
+	Get(id uuid.UUID) (User, error)
+	// List every user, by name
+	List() ([]User, error)
+	CreateToken(token APIToken) error
+	// List the tokens of a user, revoked or not, newest first
+	ListTokens(userID uuid.UUID) ([]APIToken, error)
+	// Revoke a token of a user. Revoking a revoked token does nothing.
+	RevokeToken(userID uuid.UUID, tokenID uuid.UUID) (APIToken, error)
+	// Get a token, revoked or not
+	GetToken(id uuid.UUID) (APIToken, error)
+	// Find the token with the given hash, if it is not revoked, and record that it was used at
+	// usedAt
+	UseToken(hash []byte, usedAt time.Time) (APIToken, error)
+}
@@ -0,0 +1,208 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+)
+
+type UserRepositoryPostgres struct {
+	Queries *db.Queries
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewUserRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *UserRepositoryPostgres {
+	return &UserRepositoryPostgres{
+		Queries: db.New(pool),
+		ctx:     ctx,
+		logger:  *internal.NewLogger("UserRepositoryPostgres"),
+	}
+}
+
+func (r *UserRepositoryPostgres) Create(user User) error {
+	pgID, err := internal.ScanUUID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = r.Queries.CreateUser(r.ctx, db.CreateUserParams{
+		ID:        pgID,
+		Name:      user.Name,
+		CreatedAt: pgtype.Timestamptz{Time: user.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to create user", slog.Any("user", user), slog.String("err", err.Error()))
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("User \"%s\"", user.Name))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryPostgres) Get(id uuid.UUID) (User, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return User{}, err
+	}
+
+	userDB, err := r.Queries.GetUser(r.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, internal.NewNotFoundError(fmt.Sprintf("User with id %s", id))
+		}
+
+		return User{}, err
+	}
+
+	return UserDBToUserModel(userDB)
+}
+
+func (r *UserRepositoryPostgres) List() ([]User, error) {
+	usersDB, err := r.Queries.ListUsers(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []User{}
+	for _, userDB := range usersDB {
+		user, err := UserDBToUserModel(userDB)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepositoryPostgres) CreateToken(token APIToken) error {
+	pgID, err := internal.ScanUUID(token.ID)
+	if err != nil {
+		return err
+	}
+
+	pgUserID, err := internal.ScanUUID(token.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = r.Queries.CreateAPIToken(r.ctx, db.CreateAPITokenParams{
+		ID:        pgID,
+		UserID:    pgUserID,
+		Name:      token.Name,
+		TokenHash: token.Hash,
+		CreatedAt: pgtype.Timestamptz{Time: token.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to create API token", slog.Any("token", token), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryPostgres) ListTokens(userID uuid.UUID) ([]APIToken, error) {
+	pgUserID, err := internal.ScanUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokensDB, err := r.Queries.ListUserAPITokens(r.ctx, pgUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []APIToken{}
+	for _, tokenDB := range tokensDB {
+		token, err := APITokenDBToAPITokenModel(tokenDB)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (r *UserRepositoryPostgres) RevokeToken(userID uuid.UUID, tokenID uuid.UUID) (APIToken, error) {
+	pgUserID, err := internal.ScanUUID(userID)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	pgTokenID, err := internal.ScanUUID(tokenID)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	tokenDB, err := r.Queries.RevokeAPIToken(r.ctx, db.RevokeAPITokenParams{
+		ID:     pgTokenID,
+		UserID: pgUserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return APIToken{}, internal.NewNotFoundError(fmt.Sprintf("API token with id %s", tokenID))
+		}
+
+		return APIToken{}, err
+	}
+
+	return APITokenDBToAPITokenModel(tokenDB)
+}
+
+func (r *UserRepositoryPostgres) GetToken(id uuid.UUID) (APIToken, error) {
+	pgUUID, err := internal.ScanUUID(id)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	tokenDB, err := r.Queries.GetAPIToken(r.ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return APIToken{}, internal.NewNotFoundError(fmt.Sprintf("API token with id %s", id))
+		}
+
+		return APIToken{}, err
+	}
+
+	return APITokenDBToAPITokenModel(tokenDB)
+}
+
+func (r *UserRepositoryPostgres) UseToken(hash []byte, usedAt time.Time) (APIToken, error) {
+	tokenDB, err := r.Queries.UseAPIToken(r.ctx, db.UseAPITokenParams{
+		TokenHash:  hash,
+		LastUsedAt: pgtype.Timestamptz{Time: usedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return APIToken{}, internal.NewNotFoundError("API token")
+		}
+
+		return APIToken{}, err
+	}
+
+	return APITokenDBToAPITokenModel(tokenDB)
+}
+
+// isUniqueViolation tells if the query failed because another user has the same name.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == project.ErrPgDuplicate
+}
@@ -0,0 +1,153 @@
+package user
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+var ErrInvalidToken = internal.NewError(internal.ErrUnauthenticated, "invalid or revoked API token")
+
+// UserService manages the users and their API tokens, and authenticates the requests made with
+// the tokens.
+type UserService struct {
+	repository UserRepository
+	logger     slog.Logger
+	limits     internal.Limits
+}
+
+type UserServiceOption func(*UserService)
+
+// WithLimits sets the limits the names of users and tokens are validated against, instead of
+// internal.DefaultLimits.
+func WithLimits(limits internal.Limits) UserServiceOption {
+	return func(s *UserService) {
+		s.limits = limits
+	}
+}
+
+func NewUserService(repository UserRepository, opts ...UserServiceOption) *UserService {
+	s := &UserService{
+		repository: repository,
+		logger:     *internal.NewLogger("UserService"),
+		limits:     internal.DefaultLimits,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// CreateUser creates a user without tokens. User names are unique, and the whitespace around them
+// is trimmed.
+func (s *UserService) CreateUser(name string) (User, error) {
+	name, err := s.validateName(name)
+	if err != nil {
+		return User{}, err
+	}
+
+	user := NewUser(name)
+	err = s.repository.Create(user)
+	if err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) GetUser(id uuid.UUID) (User, error) {
+	return s.repository.Get(id)
+}
+
+// ListUsers lists every user, by name.
+func (s *UserService) ListUsers() ([]User, error) {
+	return s.repository.List()
+}
+
+// CreateToken creates an API token for a user, and returns it along with its secret. The secret
+// cannot be recovered afterwards, only its hash is stored.
+func (s *UserService) CreateToken(userID uuid.UUID, name string) (APIToken, string, error) {
+	name, err := s.validateName(name)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	_, err = s.repository.Get(userID)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	token, secret, err := NewAPIToken(userID, name)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	err = s.repository.CreateToken(token)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	return token, secret, nil
+}
+
+// ListTokens lists the tokens of a user, the revoked ones included, newest first.
+func (s *UserService) ListTokens(userID uuid.UUID) ([]APIToken, error) {
+	return s.repository.ListTokens(userID)
+}
+
+// RevokeToken revokes a token of a user, which can no longer be used to authenticate. The tokens
+// of other users are not found.
+func (s *UserService) RevokeToken(userID uuid.UUID, tokenID uuid.UUID) (APIToken, error) {
+	token, err := s.repository.RevokeToken(userID, tokenID)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	s.logger.Info("revoked API token", slog.Any("token", token))
+	return token, nil
+}
+
+// Authenticate finds the user of the given token secret. Fails with ErrInvalidToken if no token
+// has the secret or if the token was revoked.
+func (s *UserService) Authenticate(secret string) (User, error) {
+	user, _, err := s.AuthenticateToken(secret)
+	return user, err
+}
+
+// AuthenticateToken is like Authenticate, but also returns the token the user was authenticated
+// with.
+func (s *UserService) AuthenticateToken(secret string) (User, APIToken, error) {
+	if !strings.HasPrefix(secret, TokenPrefix) {
+		return User{}, APIToken{}, ErrInvalidToken
+	}
+
+	token, err := s.repository.UseToken(HashSecret(secret), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, internal.ErrNotFound) {
+			return User{}, APIToken{}, ErrInvalidToken
+		}
+
+		return User{}, APIToken{}, err
+	}
+
+	user, err := s.repository.Get(token.UserID)
+	if err != nil {
+		return User{}, APIToken{}, err
+	}
+
+	return user, token, nil
+}
+
+func (s *UserService) validateName(name string) (string, error) {
+	name = internal.NormalizeName(name)
+	if fieldErr := internal.ValidateName("name", name, s.limits.MaxNameLength); fieldErr != nil {
+		return "", internal.FieldErrors{*fieldErr}
+	}
+
+	return name, nil
+}
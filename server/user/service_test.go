@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *testhelpers.PostgresContainer
+	userService *UserService
+}
+
+func (suite *UserServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.userService = NewUserService(NewUserRepositoryPostgres(suite.ctx, pgPool))
+}
+
+// Setup database before each test
+func (suite *UserServiceTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupUsersTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+}
+
+func (suite *UserServiceTestSuite) TestCreateUser() {
+	t := suite.T()
+
+	user, err := suite.userService.CreateUser("  alice ")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+
+	_, err = suite.userService.CreateUser("alice")
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	_, err = suite.userService.CreateUser(" ")
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	users, err := suite.userService.ListUsers()
+	require.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, user.ID, users[0].ID)
+	}
+}
+
+func (suite *UserServiceTestSuite) TestTokens() {
+	t := suite.T()
+
+	alice, err := suite.userService.CreateUser("alice")
+	require.NoError(t, err)
+	bob, err := suite.userService.CreateUser("bob")
+	require.NoError(t, err)
+
+	token, secret, err := suite.userService.CreateToken(alice.ID, "laptop")
+	require.NoError(t, err)
+	assert.Contains(t, secret, TokenPrefix)
+	assert.Equal(t, HashSecret(secret), token.Hash)
+	assert.Nil(t, token.LastUsedAt)
+
+	_, _, err = suite.userService.CreateToken(uuid.New(), "laptop")
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	authenticated, err := suite.userService.Authenticate(secret)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, authenticated.ID)
+
+	_, err = suite.userService.Authenticate(secret + "x")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.ErrorIs(t, err, internal.ErrUnauthenticated)
+	_, err = suite.userService.Authenticate("")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	tokens, err := suite.userService.ListTokens(alice.ID)
+	require.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, token.ID, tokens[0].ID)
+		assert.NotNil(t, tokens[0].LastUsedAt, "the token was used to authenticate")
+	}
+
+	// Users only revoke their own tokens
+	_, err = suite.userService.RevokeToken(bob.ID, token.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	revoked, err := suite.userService.RevokeToken(alice.ID, token.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked.IsRevoked())
+
+	_, err = suite.userService.Authenticate(secret)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Revoked tokens are still listed
+	tokens, err = suite.userService.ListTokens(alice.ID)
+	require.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.True(t, tokens[0].IsRevoked())
+	}
+}
+
+func TestUserService(t *testing.T) {
+	suite.Run(t, new(UserServiceTestSuite))
+}
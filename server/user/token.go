@@ -0,0 +1,68 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Prefix of the secrets of API tokens, which makes them easy to recognise, e.g. by secret
+// scanners.
+const TokenPrefix = "tdt_"
+
+// An APIToken is a personal token a user authenticates with. Only the hash of its secret is kept:
+// the secret is given once, when the token is created.
+type APIToken struct {
+	CreatedAt time.Time
+	// When the token was last used to authenticate, nil if it never was
+	LastUsedAt *time.Time
+	// Revoked tokens are kept, so that users can tell which of their tokens were used, but they no
+	// longer authenticate anyone
+	RevokedAt *time.Time
+	Name      string
+	// SHA-256 hash of the secret. It must not be disclosed either.
+	Hash   []byte
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+// NewAPIToken returns a token of a user and its secret. The token is not stored.
+func NewAPIToken(userID uuid.UUID, name string) (APIToken, string, error) {
+	// 256 bits of entropy: the secrets cannot be guessed, so a fast hash is enough to store them
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	secret := TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return APIToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Hash:      HashSecret(secret),
+		CreatedAt: time.Now().UTC(),
+	}, secret, nil
+}
+
+// HashSecret hashes the secret of a token, the way it is stored.
+func HashSecret(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+func (t APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t APIToken) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ID", t.ID.String()),
+		slog.String("UserID", t.UserID.String()),
+		slog.String("Name", t.Name),
+	)
+}
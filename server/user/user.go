@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A User is an account clients authenticate as, with one of its API tokens. The changes made by
+// the requests of a user are recorded under their ID.
+type User struct {
+	CreatedAt time.Time
+	Name      string
+	ID        uuid.UUID
+}
+
+func NewUser(name string) User {
+	return User{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func (u User) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ID", u.ID.String()),
+		slog.String("Name", u.Name),
+	)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the user the request is made by.
+func NewContext(ctx context.Context, u User) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the user a request is made by, if the request was authenticated.
+func FromContext(ctx context.Context) (User, bool) {
+	u, ok := ctx.Value(contextKey{}).(User)
+	return u, ok
+}
@@ -0,0 +1,20 @@
+package workspace
+
+import (
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+)
+
+// Transforms a workspace as seen by the db package to a workspace as seen by the workspace package
+func WorkspaceDBToWorkspaceModel(workspaceDB db.Workspace) (Workspace, error) {
+	workspaceID, err := internal.EncodeUUID(workspaceDB.ID.Bytes)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	return Workspace{
+		ID:        workspaceID,
+		Name:      workspaceDB.Name,
+		CreatedAt: workspaceDB.CreatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package workspace
+
+import "github.com/google/uuid"
+
+type WorkspaceRepository interface {
+	Create(workspace Workspace) error
+	Get(id uuid.UUID) (Workspace, error)
+	// List every workspace, by name
+	List() ([]Workspace, error)
+	Rename(id uuid.UUID, newName string) (Workspace, error)
+	// Delete a workspace along with the projects of its trash, in one transaction. Fails with
+	// ErrWorkspaceNotEmpty if it has other projects
+	Delete(id uuid.UUID) (Workspace, error)
+}
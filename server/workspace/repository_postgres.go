@@ -0,0 +1,172 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/db"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+)
+
+// This code indicates that a foreign key constraint was violated by the query
+const errPgForeignKey = "23503"
+
+type WorkspaceRepositoryPostgres struct {
+	Queries *db.Queries
+	db      *pgxpool.Pool
+	ctx     context.Context
+	logger  slog.Logger
+}
+
+func NewWorkspaceRepositoryPostgres(ctx context.Context, pool *pgxpool.Pool) *WorkspaceRepositoryPostgres {
+	return &WorkspaceRepositoryPostgres{
+		Queries: db.New(pool),
+		db:      pool,
+		ctx:     ctx,
+		logger:  *internal.NewLogger("WorkspaceRepositoryPostgres"),
+	}
+}
+
+func (r *WorkspaceRepositoryPostgres) Create(workspace Workspace) error {
+	pgID, err := internal.ScanUUID(workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	err = r.Queries.CreateWorkspace(r.ctx, db.CreateWorkspaceParams{
+		ID:        pgID,
+		Name:      workspace.Name,
+		CreatedAt: pgtype.Timestamptz{Time: workspace.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.Error("failed to create workspace", slog.Any("workspace", workspace), slog.String("err", err.Error()))
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Workspace \"%s\"", workspace.Name))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkspaceRepositoryPostgres) Get(id uuid.UUID) (Workspace, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	workspaceDB, err := r.Queries.GetWorkspace(r.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Workspace{}, internal.NewNotFoundError(fmt.Sprintf("Workspace with id %s", id))
+		}
+
+		return Workspace{}, err
+	}
+
+	return WorkspaceDBToWorkspaceModel(workspaceDB)
+}
+
+func (r *WorkspaceRepositoryPostgres) List() ([]Workspace, error) {
+	workspacesDB, err := r.Queries.ListWorkspaces(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := []Workspace{}
+	for _, workspaceDB := range workspacesDB {
+		workspace, err := WorkspaceDBToWorkspaceModel(workspaceDB)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepositoryPostgres) Rename(id uuid.UUID, newName string) (Workspace, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	workspaceDB, err := r.Queries.RenameWorkspace(r.ctx, db.RenameWorkspaceParams{
+		ID:   pgID,
+		Name: newName,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Workspace{}, internal.NewNotFoundError(fmt.Sprintf("Workspace with id %s", id))
+		}
+		if isUniqueViolation(err) {
+			err = internal.NewAlreadyExistsError(fmt.Sprintf("Workspace \"%s\"", newName))
+		}
+
+		return Workspace{}, err
+	}
+
+	return WorkspaceDBToWorkspaceModel(workspaceDB)
+}
+
+func (r *WorkspaceRepositoryPostgres) Delete(id uuid.UUID) (Workspace, error) {
+	pgID, err := internal.ScanUUID(id)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	tx, err := r.db.Begin(r.ctx)
+	if err != nil {
+		return Workspace{}, err
+	}
+	defer tx.Rollback(r.ctx)
+
+	qtx := r.Queries.WithTx(tx)
+	err = qtx.PurgeWorkspaceTrash(r.ctx, pgID)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	// The foreign key of the projects keeps a project put in the workspace in the meantime from
+	// being deleted along with it
+	workspaceDB, err := qtx.DeleteWorkspace(r.ctx, pgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Workspace{}, internal.NewNotFoundError(fmt.Sprintf("Workspace with id %s", id))
+		}
+		if isForeignKeyViolation(err) {
+			return Workspace{}, fmt.Errorf("Cannot delete workspace %s: %w", id, ErrWorkspaceNotEmpty)
+		}
+
+		return Workspace{}, err
+	}
+
+	err = tx.Commit(r.ctx)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	return WorkspaceDBToWorkspaceModel(workspaceDB)
+}
+
+// isForeignKeyViolation tells if the query failed because projects still reference the workspace.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == errPgForeignKey
+}
+
+// isUniqueViolation tells if the query failed because another workspace has the same name.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == project.ErrPgDuplicate
+}
@@ -0,0 +1,158 @@
+package workspace
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+)
+
+var ErrWorkspaceNotEmpty = internal.NewConflictError("workspace has projects")
+
+// WorkspaceService manages the workspaces projects are grouped in, and sums up their projects.
+// Projects are put in workspaces by the project service, see project.ProjectService.CreateProjectIn
+// and project.ProjectService.MoveProjectToWorkspace.
+type WorkspaceService struct {
+	repository        WorkspaceRepository
+	projectRepository project.ProjectRepository
+	logger            slog.Logger
+	limits            internal.Limits
+}
+
+type WorkspaceServiceOption func(*WorkspaceService)
+
+// WithLimits sets the limits the names of workspaces are validated against, instead of
+// internal.DefaultLimits.
+func WithLimits(limits internal.Limits) WorkspaceServiceOption {
+	return func(s *WorkspaceService) {
+		s.limits = limits
+	}
+}
+
+func NewWorkspaceService(repository WorkspaceRepository, projectRepository project.ProjectRepository, opts ...WorkspaceServiceOption) *WorkspaceService {
+	s := &WorkspaceService{
+		repository:        repository,
+		projectRepository: projectRepository,
+		logger:            *internal.NewLogger("WorkspaceService"),
+		limits:            internal.DefaultLimits,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// CreateWorkspace creates an empty workspace. Workspace names are unique, and the whitespace
+// around them is trimmed.
+func (s *WorkspaceService) CreateWorkspace(name string) (Workspace, error) {
+	name, err := s.validateName(name)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	workspace := NewWorkspace(name)
+	err = s.repository.Create(workspace)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	return workspace, nil
+}
+
+func (s *WorkspaceService) GetWorkspace(id uuid.UUID) (Workspace, error) {
+	return s.repository.Get(id)
+}
+
+// ListWorkspaces lists every workspace, by name.
+func (s *WorkspaceService) ListWorkspaces() ([]Workspace, error) {
+	return s.repository.List()
+}
+
+// RenameWorkspace gives a workspace a new name. The whitespace around the name is trimmed.
+func (s *WorkspaceService) RenameWorkspace(id uuid.UUID, newName string) (Workspace, error) {
+	newName, err := s.validateName(newName)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	workspace, err := s.repository.Get(id)
+	if err != nil {
+		return Workspace{}, err
+	}
+	if workspace.Name == newName {
+		return workspace, nil
+	}
+
+	return s.repository.Rename(id, newName)
+}
+
+// DeleteWorkspace deletes a workspace without projects. Its projects must be moved elsewhere or
+// deleted first; the ones in the trash are deleted along with it.
+func (s *WorkspaceService) DeleteWorkspace(id uuid.UUID) (Workspace, error) {
+	_, err := s.repository.Get(id)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	projects, err := s.projectRepository.ListInWorkspace(id)
+	if err != nil {
+		return Workspace{}, err
+	}
+	if len(projects) > 0 {
+		return Workspace{}, fmt.Errorf("Cannot delete workspace %s with %d projects: %w", id, len(projects), ErrWorkspaceNotEmpty)
+	}
+
+	return s.repository.Delete(id)
+}
+
+// GetWorkspaceStats counts the projects of a workspace, archived or not, and sums up the task
+// summaries of all of them.
+func (s *WorkspaceService) GetWorkspaceStats(id uuid.UUID) (Stats, error) {
+	_, err := s.repository.Get(id)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	projects, err := s.projectRepository.ListInWorkspace(id)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	ids := make([]uuid.UUID, len(projects))
+	for i, pr := range projects {
+		ids[i] = pr.ID
+	}
+	summaries, err := s.projectRepository.GetTaskSummaries(ids)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{Projects: len(projects)}
+	for _, pr := range projects {
+		if pr.IsArchived() {
+			stats.ArchivedProjects++
+		}
+
+		summary := summaries[pr.ID]
+		stats.Tasks.Total += summary.Total
+		stats.Tasks.Pending += summary.Pending
+		stats.Tasks.Completed += summary.Completed
+		stats.Tasks.Root += summary.Root
+		stats.Tasks.Estimate += summary.Estimate
+		stats.Tasks.RemainingWork += summary.RemainingWork
+	}
+
+	return stats, nil
+}
+
+func (s *WorkspaceService) validateName(name string) (string, error) {
+	name = internal.NormalizeName(name)
+	if fieldErr := internal.ValidateName("name", name, s.limits.MaxNameLength); fieldErr != nil {
+		return "", internal.FieldErrors{*fieldErr}
+	}
+
+	return name, nil
+}
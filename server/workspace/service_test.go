@@ -0,0 +1,216 @@
+package workspace
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/murasakiwano/todoctian/server/internal"
+	"github.com/murasakiwano/todoctian/server/project"
+	"github.com/murasakiwano/todoctian/server/task"
+	"github.com/murasakiwano/todoctian/server/template"
+	"github.com/murasakiwano/todoctian/server/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspaceServiceTestSuite struct {
+	suite.Suite
+	ctx              context.Context
+	pgContainer      *testhelpers.PostgresContainer
+	workspaceService *WorkspaceService
+	projectService   *project.ProjectService
+	taskService      *task.TaskService
+}
+
+func (suite *WorkspaceServiceTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	pgContainer, err := testhelpers.CreatePostgresContainer(suite.ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	suite.pgContainer = pgContainer
+	pgPool, err := pgxpool.New(suite.ctx, suite.pgContainer.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repository := NewWorkspaceRepositoryPostgres(suite.ctx, pgPool)
+	projectRepository := project.NewProjectRepositoryPostgres(suite.ctx, pgPool)
+	templateRepository := template.NewTemplateRepositoryPostgres(suite.ctx, pgPool)
+
+	suite.workspaceService = NewWorkspaceService(repository, projectRepository)
+	suite.projectService = project.NewProjectService(projectRepository, templateRepository)
+	suite.taskService = task.NewTaskService(task.NewTaskRepositoryPostgres(suite.ctx, pgPool), projectRepository)
+}
+
+// Setup database before each test
+func (suite *WorkspaceServiceTestSuite) SetupTest() {
+	t := suite.T()
+	t.Log("cleaning up database before test...")
+	testhelpers.CleanupTasksTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupProjectsTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+	testhelpers.CleanupWorkspacesTable(suite.ctx, t, suite.pgContainer.ConnectionString)
+}
+
+func (suite *WorkspaceServiceTestSuite) TestCreateWorkspace() {
+	t := suite.T()
+
+	workspace, err := suite.workspaceService.CreateWorkspace("  Platform ")
+	require.NoError(t, err)
+	assert.Equal(t, "Platform", workspace.Name)
+
+	_, err = suite.workspaceService.CreateWorkspace("Platform")
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	_, err = suite.workspaceService.CreateWorkspace(" ")
+	assert.ErrorIs(t, err, internal.ErrValidation)
+
+	renamed, err := suite.workspaceService.RenameWorkspace(workspace.ID, "Infrastructure")
+	require.NoError(t, err)
+	assert.Equal(t, "Infrastructure", renamed.Name)
+
+	workspaces, err := suite.workspaceService.ListWorkspaces()
+	require.NoError(t, err)
+	if assert.Len(t, workspaces, 1) {
+		assert.Equal(t, "Infrastructure", workspaces[0].Name)
+	}
+
+	_, err = suite.workspaceService.GetWorkspace(uuid.New())
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *WorkspaceServiceTestSuite) TestProjectNamesAreScopedPerWorkspace() {
+	t := suite.T()
+
+	platform, err := suite.workspaceService.CreateWorkspace("Platform")
+	require.NoError(t, err)
+	mobile, err := suite.workspaceService.CreateWorkspace("Mobile")
+	require.NoError(t, err)
+
+	for _, workspaceID := range []*uuid.UUID{&platform.ID, &mobile.ID, nil} {
+		project, err := suite.projectService.CreateProjectIn("Backlog", workspaceID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, workspaceID, project.WorkspaceID)
+	}
+
+	_, err = suite.projectService.CreateProjectIn("Backlog", &platform.ID, nil)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	missingWorkspaceID := uuid.New()
+	_, err = suite.projectService.CreateProjectIn("Roadmap", &missingWorkspaceID, nil)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *WorkspaceServiceTestSuite) TestMoveProjectToWorkspace() {
+	t := suite.T()
+
+	platform, err := suite.workspaceService.CreateWorkspace("Platform")
+	require.NoError(t, err)
+	mobile, err := suite.workspaceService.CreateWorkspace("Mobile")
+	require.NoError(t, err)
+
+	parent, err := suite.projectService.CreateProjectIn("Release", &platform.ID, nil)
+	require.NoError(t, err)
+	// Sub-projects go in the workspace of their parent, and only there
+	child, err := suite.projectService.CreateProjectIn("Backlog", nil, &parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &platform.ID, child.WorkspaceID)
+	_, err = suite.projectService.CreateProjectIn("Docs", &mobile.ID, &parent.ID)
+	assert.ErrorIs(t, err, project.ErrParentInOtherWorkspace)
+
+	// No project moves if one of them takes a name of the workspace
+	taken, err := suite.projectService.CreateProjectIn("Backlog", &mobile.ID, nil)
+	require.NoError(t, err)
+	_, err = suite.projectService.MoveProjectToWorkspace(parent.ID, &mobile.ID)
+	assert.ErrorIs(t, err, internal.ErrAlreadyExists)
+
+	_, err = suite.projectService.RenameProject(taken.ID, "Old backlog")
+	require.NoError(t, err)
+	moved, err := suite.projectService.MoveProjectToWorkspace(parent.ID, &mobile.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &mobile.ID, moved.WorkspaceID)
+
+	child, err = suite.projectService.GetProject(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &mobile.ID, child.WorkspaceID)
+	assert.Equal(t, &parent.ID, child.ParentProjectID)
+
+	// A sub-project moved on its own leaves its parent behind
+	child, err = suite.projectService.MoveProjectToWorkspace(child.ID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, child.WorkspaceID)
+	assert.Nil(t, child.ParentProjectID)
+
+	_, err = suite.projectService.SetParentProject(child.ID, &parent.ID)
+	assert.ErrorIs(t, err, project.ErrParentInOtherWorkspace)
+}
+
+func (suite *WorkspaceServiceTestSuite) TestDeleteWorkspace() {
+	t := suite.T()
+
+	workspace, err := suite.workspaceService.CreateWorkspace("Platform")
+	require.NoError(t, err)
+	proj, err := suite.projectService.CreateProjectIn("Backlog", &workspace.ID, nil)
+	require.NoError(t, err)
+
+	_, err = suite.workspaceService.DeleteWorkspace(workspace.ID)
+	assert.ErrorIs(t, err, ErrWorkspaceNotEmpty)
+
+	// A project put in the workspace after it was checked is not deleted with it either
+	_, err = suite.workspaceService.repository.Delete(workspace.ID)
+	assert.ErrorIs(t, err, ErrWorkspaceNotEmpty)
+	_, err = suite.projectService.GetProject(proj.ID)
+	require.NoError(t, err)
+
+	// Projects in the trash are deleted along with the workspace
+	_, err = suite.projectService.DeleteProject(proj.ID)
+	require.NoError(t, err)
+	_, err = suite.workspaceService.DeleteWorkspace(workspace.ID)
+	require.NoError(t, err)
+
+	_, err = suite.projectService.RestoreProject(proj.ID)
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+}
+
+func (suite *WorkspaceServiceTestSuite) TestGetWorkspaceStats() {
+	t := suite.T()
+
+	workspace, err := suite.workspaceService.CreateWorkspace("Platform")
+	require.NoError(t, err)
+	backlog, err := suite.projectService.CreateProjectIn("Backlog", &workspace.ID, nil)
+	require.NoError(t, err)
+	roadmap, err := suite.projectService.CreateProjectIn("Roadmap", &workspace.ID, nil)
+	require.NoError(t, err)
+	// Projects of other workspaces are not counted
+	_, err = suite.projectService.CreateProject("Elsewhere")
+	require.NoError(t, err)
+
+	for _, projectID := range []uuid.UUID{backlog.ID, backlog.ID, roadmap.ID} {
+		_, err := suite.taskService.CreateTask("Task", projectID, nil)
+		require.NoError(t, err)
+	}
+	done, err := suite.taskService.CreateTask("Done", roadmap.ID, nil)
+	require.NoError(t, err)
+	require.NoError(t, suite.taskService.UpdateTaskStatus(done.ID, task.TaskStatusCompleted.String()))
+
+	_, err = suite.projectService.ArchiveProject(roadmap.ID)
+	require.NoError(t, err)
+
+	stats, err := suite.workspaceService.GetWorkspaceStats(workspace.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Projects)
+	assert.Equal(t, 1, stats.ArchivedProjects)
+	assert.Equal(t, 4, stats.Tasks.Total)
+	assert.Equal(t, 3, stats.Tasks.Pending)
+	assert.Equal(t, 1, stats.Tasks.Completed)
+	assert.Equal(t, 25, stats.Tasks.PercentDone())
+}
+
+func TestWorkspaceService(t *testing.T) {
+	suite.Run(t, new(WorkspaceServiceTestSuite))
+}
@@ -0,0 +1,41 @@
+package workspace
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murasakiwano/todoctian/server/project"
+)
+
+// A Workspace groups the projects of a team or an organisation. Project names are unique within a
+// workspace, so that different workspaces may have projects with the same name, e.g. "Backlog".
+type Workspace struct {
+	CreatedAt time.Time
+	Name      string
+	ID        uuid.UUID
+}
+
+// Stats sums up the projects of a workspace and their tasks.
+type Stats struct {
+	// Number of projects in the workspace, including the archived ones
+	Projects         int
+	ArchivedProjects int
+	// Counts of the tasks of all the projects of the workspace, see project.TaskSummary
+	Tasks project.TaskSummary
+}
+
+func NewWorkspace(name string) Workspace {
+	return Workspace{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func (w Workspace) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ID", w.ID.String()),
+		slog.String("Name", w.Name),
+	)
+}